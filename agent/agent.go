@@ -139,6 +139,7 @@ type Agent struct {
 	api               *http.Server
 	assetGetter       asset.Getter
 	backendSelector   BackendSelector
+	backpressure      chan time.Duration
 	config            *Config
 	connected         bool
 	connectedMu       sync.RWMutex
@@ -184,6 +185,7 @@ func NewAgentContext(ctx context.Context, config *Config) (*Agent, error) {
 	}
 	agent := &Agent{
 		backendSelector: &RandomBackendSelector{Backends: config.BackendURLs},
+		backpressure:    make(chan time.Duration, 1),
 		connected:       false,
 		config:          config,
 		executor:        command.NewExecutor(),
@@ -201,6 +203,7 @@ func NewAgentContext(ctx context.Context, config *Config) (*Agent, error) {
 
 	agent.statsdServer = NewStatsdServer(agent)
 	agent.handler.AddHandler(transport.MessageTypeEntityConfig, agent.handleEntityConfig)
+	agent.handler.AddHandler(transport.MessageTypeBackpressure, agent.handleBackpressure)
 
 	// We don't check for errors here and let the agent get created regardless
 	// of system info status.
@@ -545,7 +548,14 @@ func (a *Agent) sendLoop(ctx context.Context, cancel context.CancelFunc, conn tr
 		logger.WithError(err).Error("error sending message over websocket")
 		return err
 	}
+	// While the backend applies backpressure, queued messages are held back
+	// until resume fires, but keepalives are still sent
+	var resume <-chan time.Time
 	for {
+		sendq := a.sendq
+		if resume != nil {
+			sendq = nil
+		}
 		select {
 		case <-ctx.Done():
 			if err := conn.Close(); err != nil {
@@ -553,7 +563,12 @@ func (a *Agent) sendLoop(ctx context.Context, cancel context.CancelFunc, conn tr
 				return err
 			}
 			return nil
-		case msg := <-a.sendq:
+		case delay := <-a.backpressure:
+			logger.WithField("delay", delay).Warn("backend is applying backpressure, slowing down event submissions")
+			resume = time.After(delay)
+		case <-resume:
+			resume = nil
+		case msg := <-sendq:
 			if err := conn.Send(msg); err != nil {
 				messagesDropped.WithLabelValues().Inc()
 				logger.WithError(err).Error("error sending message over websocket")
@@ -0,0 +1,24 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// handleBackpressure makes the send loop hold back queued messages for the
+// delay requested by the backend. Since the send queue is bounded, this in
+// turn slows down the submission of check results and metrics.
+func (a *Agent) handleBackpressure(ctx context.Context, payload []byte) error {
+	ms, err := strconv.ParseInt(string(payload), 10, 64)
+	if err != nil || ms < 0 {
+		return fmt.Errorf("invalid backpressure delay %q", payload)
+	}
+	select {
+	case a.backpressure <- time.Duration(ms) * time.Millisecond:
+	default:
+		// A delay is already pending
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package agent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sensu/sensu-go/testing/mocktransport"
+	"github.com/sensu/sensu-go/transport"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandleBackpressure(t *testing.T) {
+	cfg, cleanup := FixtureConfig()
+	defer cleanup()
+	a, err := NewAgent(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.handleBackpressure(context.Background(), []byte("bad")); err == nil {
+		t.Error("expected an error for an invalid delay")
+	}
+
+	sent := make(chan *transport.Message, 10)
+	conn := new(mocktransport.MockTransport)
+	conn.On("Send", mock.Anything).Run(func(args mock.Arguments) {
+		sent <- args.Get(0).(*transport.Message)
+	}).Return(nil)
+	conn.On("Close").Return(nil)
+
+	mockTime.Start()
+	defer mockTime.Stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error)
+	go func() {
+		done <- a.sendLoop(ctx, cancel, conn)
+	}()
+	if msg := <-sent; msg.Type != transport.MessageTypeKeepalive {
+		t.Fatalf("expected a keepalive, got %q", msg.Type)
+	}
+
+	// The agent's clock runs 50 times faster than the real one
+	if err := a.handleBackpressure(context.Background(), []byte("10000")); err != nil {
+		t.Fatal(err)
+	}
+	// Let the send loop pick up the delay before queueing an event
+	for len(a.backpressure) > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	a.sendq <- transport.NewMessage(transport.MessageTypeEvent, []byte("{}"))
+	held := time.After(50 * time.Millisecond)
+	for held != nil {
+		select {
+		case msg := <-sent:
+			// Keepalives are not held back
+			if msg.Type != transport.MessageTypeKeepalive {
+				t.Fatalf("expected the %q message to be held back", msg.Type)
+			}
+		case <-held:
+			held = nil
+		}
+	}
+	for msg := range sent {
+		if msg.Type == transport.MessageTypeEvent {
+			break
+		}
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
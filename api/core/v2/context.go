@@ -44,6 +44,10 @@ const (
 
 	// PipelineWorkflowKey contains the key name to retrieve the pipeline workflow from context
 	PipelineWorkflowKey
+
+	// CorrelationIDKey contains the key name to retrieve the correlation ID of
+	// the event being handled from context
+	CorrelationIDKey
 )
 
 // ContextNamespace returns the namespace injected in the context
@@ -70,6 +74,14 @@ func ContextPipelineWorkflow(ctx context.Context) string {
 	return ""
 }
 
+// ContextCorrelationID returns the correlation ID injected in the context
+func ContextCorrelationID(ctx context.Context) string {
+	if value := ctx.Value(CorrelationIDKey); value != nil {
+		return value.(string)
+	}
+	return ""
+}
+
 // PageSizeFromContext returns the page size stored in the given context, if
 // any. Returns 0 if none is found, typically meaning "unlimited" page size.
 func PageSizeFromContext(ctx context.Context) int {
@@ -0,0 +1,12 @@
+package v2
+
+// DeleteMatchingResult is the outcome of deleting the resources of a
+// namespace that match a label selector.
+type DeleteMatchingResult struct {
+	// Deleted is the number of resources that were deleted.
+	Deleted int `json:"deleted"`
+
+	// Errors contains the error that prevented the deletion of a resource, for
+	// each resource that could not be deleted, keyed by resource name.
+	Errors map[string]string `json:"errors,omitempty"`
+}
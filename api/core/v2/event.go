@@ -361,6 +361,19 @@ func (e *Event) RBACName() string {
 	return "events"
 }
 
+// CorrelationID returns the correlation ID of the event, as set by the
+// CorrelationIDAnnotation annotation. Events without the annotation are
+// correlated by their ID, if they have one.
+func (e *Event) CorrelationID() string {
+	if id := e.ObjectMeta.Annotations[CorrelationIDAnnotation]; id != "" {
+		return id
+	}
+	if len(e.ID) > 0 {
+		return e.GetUUID().String()
+	}
+	return ""
+}
+
 // GetUUID parses a UUID from the ID bytes. It does not check errors, assuming
 // that the event has already passed validation.
 func (e *Event) GetUUID() uuid.UUID {
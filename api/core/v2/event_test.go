@@ -77,6 +77,19 @@ func TestEventHasMetrics(t *testing.T) {
 	}
 }
 
+func TestEventCorrelationID(t *testing.T) {
+	id := uuid.New()
+	event := FixtureEvent("entity", "check")
+	event.ID = nil
+	assert.Equal(t, "", event.CorrelationID())
+
+	event.ID = id[:]
+	assert.Equal(t, id.String(), event.CorrelationID())
+
+	event.ObjectMeta.Annotations = map[string]string{CorrelationIDAnnotation: "abc"}
+	assert.Equal(t, "abc", event.CorrelationID())
+}
+
 func TestEventIsIncident(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -58,8 +58,8 @@ func (f *EventFilter) Validate() error {
 		return fmt.Errorf("action '%s' is not valid", f.Action)
 	}
 
-	if len(f.Expressions) == 0 {
-		return errors.New("filter must have one or more expressions")
+	if len(f.Expressions) == 0 && len(f.EntityLabelSelector) == 0 {
+		return errors.New("filter must have one or more expressions or an entity label selector")
 	}
 
 	if err := js.ParseExpressions(f.Expressions); err != nil {
@@ -86,6 +86,8 @@ func (f *EventFilter) Update(from *EventFilter, fields ...string) error {
 			f.Expressions = append(f.Expressions[0:0], from.Expressions...)
 		case "RuntimeAssets":
 			f.RuntimeAssets = append(f.RuntimeAssets[0:0], from.RuntimeAssets...)
+		case "EntityLabelSelector":
+			f.EntityLabelSelector = from.EntityLabelSelector
 		default:
 			return fmt.Errorf("unsupported field: %q", f)
 		}
@@ -93,6 +95,18 @@ func (f *EventFilter) Update(from *EventFilter, fields ...string) error {
 	return nil
 }
 
+// MatchesEntityLabels returns true if the given entity labels contain all the
+// labels of the entity label selector of the filter, with the same values. A
+// filter without an entity label selector matches any labels.
+func (f *EventFilter) MatchesEntityLabels(labels map[string]string) bool {
+	for key, value := range f.EntityLabelSelector {
+		if v, ok := labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
 // NewEventFilter creates a new EventFilter.
 func NewEventFilter(meta ObjectMeta) *EventFilter {
 	return &EventFilter{ObjectMeta: meta}
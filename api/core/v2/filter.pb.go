@@ -40,10 +40,14 @@ type EventFilter struct {
 	When *TimeWindowWhen `protobuf:"bytes,6,opt,name=when,proto3" json:"when,omitempty"`
 	// Runtime assets are Sensu assets that contain javascript libraries. They
 	// are evaluated within the execution context.
-	RuntimeAssets        []string `protobuf:"bytes,8,rep,name=runtime_assets,json=runtimeAssets,proto3" json:"runtime_assets"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	RuntimeAssets []string `protobuf:"bytes,8,rep,name=runtime_assets,json=runtimeAssets,proto3" json:"runtime_assets"`
+	// EntityLabelSelector is a set of labels that the entity of the event must
+	// have, with the same values, to match this filter. It is evaluated before
+	// the expressions.
+	EntityLabelSelector  map[string]string `protobuf:"bytes,9,rep,name=entity_label_selector,json=entityLabelSelector,proto3" json:"entity_label_selector,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *EventFilter) Reset()         { *m = EventFilter{} }
@@ -81,6 +85,7 @@ var xxx_messageInfo_EventFilter proto.InternalMessageInfo
 
 func init() {
 	proto.RegisterType((*EventFilter)(nil), "sensu.core.v2.EventFilter")
+	proto.RegisterMapType((map[string]string)(nil), "sensu.core.v2.EventFilter.EntityLabelSelectorEntry")
 }
 
 func init() {
@@ -88,31 +93,37 @@ func init() {
 }
 
 var fileDescriptor_b7dc9b35dab378f8 = []byte{
-	// 375 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x50, 0xc1, 0x4e, 0xea, 0x40,
-	0x14, 0x65, 0xe0, 0x85, 0x40, 0x09, 0xef, 0x25, 0xb3, 0x78, 0xe9, 0x23, 0x79, 0xd3, 0xe6, 0xad,
-	0x58, 0x3c, 0xa7, 0xb6, 0x98, 0x18, 0x5d, 0x29, 0x89, 0xee, 0x8c, 0x49, 0xa3, 0x21, 0x71, 0x63,
-	0xda, 0x32, 0x94, 0x31, 0x76, 0xa6, 0xe9, 0x4c, 0x8b, 0xfe, 0x81, 0x9f, 0xe0, 0x92, 0x9d, 0x7c,
-	0x82, 0x9f, 0xc0, 0x92, 0x2f, 0x68, 0xb4, 0xee, 0xf8, 0x02, 0x97, 0x86, 0x01, 0x0d, 0xb2, 0x62,
-	0x33, 0x99, 0x73, 0xef, 0x39, 0xe7, 0xde, 0x7b, 0x34, 0x27, 0xa4, 0x72, 0x98, 0xfa, 0x38, 0xe0,
-	0x91, 0x25, 0x08, 0x13, 0xe9, 0xf2, 0xdd, 0x09, 0xb9, 0xe5, 0xc5, 0xd4, 0x0a, 0x78, 0x42, 0xac,
-	0xcc, 0xb1, 0x06, 0xf4, 0x56, 0x92, 0x04, 0xc7, 0x09, 0x97, 0x1c, 0x36, 0x15, 0x05, 0x2f, 0x7a,
-	0x38, 0x73, 0x5a, 0x7b, 0x6b, 0x16, 0x21, 0x0f, 0xb9, 0xa5, 0x58, 0x7e, 0x3a, 0x38, 0xca, 0x6c,
-	0xdc, 0xc1, 0xb6, 0x2a, 0xaa, 0x9a, 0xfa, 0x2d, 0x4d, 0x5a, 0xfb, 0xdb, 0x0d, 0x96, 0x34, 0x22,
-	0xd7, 0x23, 0xca, 0xfa, 0x7c, 0xb4, 0x12, 0xee, 0x6e, 0x27, 0x8c, 0x88, 0xf4, 0x96, 0x8a, 0x7f,
-	0x4f, 0x65, 0xad, 0x71, 0x92, 0x11, 0x26, 0x4f, 0xd5, 0x15, 0xf0, 0x52, 0xab, 0x2d, 0xba, 0x7d,
-	0x4f, 0x7a, 0x3a, 0x30, 0x41, 0xbb, 0xe1, 0xfc, 0xc1, 0xdf, 0x4e, 0xc2, 0xe7, 0xfe, 0x0d, 0x09,
-	0xe4, 0x19, 0x91, 0x5e, 0x17, 0x4d, 0x73, 0xa3, 0x34, 0xcb, 0x0d, 0x30, 0xcf, 0x0d, 0xf8, 0x29,
-	0xfb, 0xcf, 0x23, 0x2a, 0x49, 0x14, 0xcb, 0x7b, 0xf7, 0xcb, 0x0a, 0xfe, 0xd6, 0xaa, 0x5e, 0x20,
-	0x29, 0x67, 0x7a, 0xd9, 0x04, 0xed, 0xba, 0xbb, 0x42, 0xd0, 0xd6, 0x1a, 0xe4, 0x2e, 0x4e, 0x88,
-	0x10, 0x94, 0x33, 0xa1, 0x57, 0xcc, 0x4a, 0xbb, 0xde, 0xfd, 0x35, 0xcf, 0x8d, 0xf5, 0xb2, 0xbb,
-	0x0e, 0xa0, 0xad, 0xfd, 0x18, 0x0d, 0x09, 0xd3, 0xab, 0x6a, 0xbb, 0xbf, 0x1b, 0xdb, 0x5d, 0xd0,
-	0x88, 0xf4, 0x54, 0x24, 0xbd, 0x21, 0x61, 0xae, 0xa2, 0xc2, 0x03, 0xed, 0x67, 0x92, 0x32, 0x15,
-	0x97, 0x27, 0x04, 0x91, 0x42, 0xaf, 0xa9, 0x41, 0x70, 0x9e, 0x1b, 0x1b, 0x1d, 0xb7, 0xb9, 0xc2,
-	0xc7, 0x0a, 0x1e, 0xd6, 0x1e, 0xc6, 0x46, 0x69, 0x32, 0x36, 0x40, 0xd7, 0x7c, 0x7f, 0x45, 0x60,
-	0x52, 0x20, 0xf0, 0x5c, 0x20, 0x30, 0x2d, 0x10, 0x98, 0x15, 0x08, 0xbc, 0x14, 0x08, 0x3c, 0xbe,
-	0xa1, 0xd2, 0x55, 0x39, 0x73, 0xfc, 0xaa, 0x8a, 0xb4, 0xf3, 0x11, 0x00, 0x00, 0xff, 0xff, 0x5e,
-	0x6b, 0x9d, 0x30, 0x38, 0x02, 0x00, 0x00,
+	// 466 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x52, 0xbd, 0x8e, 0xd3, 0x40,
+	0x10, 0xce, 0xc6, 0x10, 0x25, 0x1b, 0x1d, 0xa0, 0xe5, 0x47, 0x26, 0x12, 0xb6, 0x05, 0x4d, 0x0a,
+	0xb0, 0x89, 0x83, 0x04, 0x5c, 0x05, 0x91, 0x72, 0x15, 0x08, 0xc9, 0x80, 0x4e, 0xa2, 0x89, 0xd6,
+	0xbe, 0xb9, 0x64, 0xc1, 0xde, 0x8d, 0xbc, 0x6b, 0x87, 0xbc, 0x01, 0x05, 0x0f, 0x40, 0x79, 0xe5,
+	0x3d, 0x02, 0x8f, 0x70, 0xe5, 0x3d, 0x81, 0x05, 0xa6, 0xcb, 0x13, 0x50, 0x21, 0x94, 0x8d, 0x41,
+	0x26, 0x80, 0x94, 0xc6, 0x9a, 0x6f, 0x66, 0xbe, 0x6f, 0x66, 0x3e, 0x2f, 0xf6, 0xa7, 0x4c, 0xcd,
+	0xb2, 0xd0, 0x8d, 0x44, 0xe2, 0x49, 0xe0, 0x32, 0xdb, 0x7c, 0xef, 0x4d, 0x85, 0x47, 0xe7, 0xcc,
+	0x8b, 0x44, 0x0a, 0x5e, 0xee, 0x7b, 0xc7, 0x2c, 0x56, 0x90, 0xba, 0xf3, 0x54, 0x28, 0x41, 0xf6,
+	0x74, 0x8b, 0xbb, 0xae, 0xb9, 0xb9, 0xdf, 0x7b, 0x50, 0x93, 0x98, 0x8a, 0xa9, 0xf0, 0x74, 0x57,
+	0x98, 0x1d, 0x3f, 0xc9, 0x07, 0xee, 0xd0, 0x1d, 0xe8, 0xa4, 0xce, 0xe9, 0x68, 0x23, 0xd2, 0x7b,
+	0xb8, 0xdb, 0x60, 0xc5, 0x12, 0x98, 0x2c, 0x18, 0x3f, 0x12, 0x8b, 0x8a, 0x78, 0x7f, 0x37, 0x62,
+	0x02, 0x8a, 0x6e, 0x18, 0xb7, 0x7f, 0x18, 0xb8, 0x3b, 0xce, 0x81, 0xab, 0x03, 0x7d, 0x05, 0x79,
+	0x8d, 0xdb, 0xeb, 0xea, 0x11, 0x55, 0xd4, 0x44, 0x0e, 0xea, 0x77, 0xfd, 0x9b, 0xee, 0x1f, 0x27,
+	0xb9, 0x2f, 0xc2, 0xb7, 0x10, 0xa9, 0xe7, 0xa0, 0xe8, 0xc8, 0x3a, 0x2b, 0xec, 0xc6, 0x79, 0x61,
+	0xa3, 0x55, 0x61, 0x93, 0x5f, 0xb4, 0xbb, 0x22, 0x61, 0x0a, 0x92, 0xb9, 0x5a, 0x06, 0xbf, 0xa5,
+	0xc8, 0x0d, 0xdc, 0xa2, 0x91, 0x62, 0x82, 0x9b, 0x4d, 0x07, 0xf5, 0x3b, 0x41, 0x85, 0xc8, 0x00,
+	0x77, 0xe1, 0xfd, 0x3c, 0x05, 0x29, 0x99, 0xe0, 0xd2, 0x34, 0x1c, 0xa3, 0xdf, 0x19, 0x5d, 0x5e,
+	0x15, 0x76, 0x3d, 0x1d, 0xd4, 0x01, 0x19, 0xe0, 0x0b, 0x8b, 0x19, 0x70, 0xb3, 0xa5, 0xb7, 0xbb,
+	0xb5, 0xb5, 0xdd, 0x2b, 0x96, 0xc0, 0xa1, 0xb6, 0xe4, 0x70, 0x06, 0x3c, 0xd0, 0xad, 0xe4, 0x31,
+	0xbe, 0x94, 0x66, 0x5c, 0xdb, 0x45, 0xa5, 0x04, 0x25, 0xcd, 0xb6, 0x1e, 0x44, 0x56, 0x85, 0xbd,
+	0x55, 0x09, 0xf6, 0x2a, 0xfc, 0x54, 0x43, 0xf2, 0x11, 0xe1, 0xeb, 0xc0, 0x15, 0x53, 0xcb, 0x49,
+	0x4c, 0x43, 0x88, 0x27, 0x12, 0x62, 0x88, 0x94, 0x48, 0xcd, 0x8e, 0x63, 0xf4, 0xbb, 0xfe, 0x70,
+	0x6b, 0x7e, 0xcd, 0x4b, 0x77, 0xac, 0x79, 0xcf, 0xd6, 0xb4, 0x97, 0x15, 0x6b, 0xcc, 0x55, 0xba,
+	0x1c, 0xdd, 0x59, 0x15, 0xb6, 0xfd, 0x4f, 0xd5, 0x9a, 0x79, 0x57, 0xe1, 0x6f, 0x7a, 0xef, 0x00,
+	0x9b, 0xff, 0x53, 0x25, 0x57, 0xb0, 0xf1, 0x0e, 0x96, 0xfa, 0xaf, 0x75, 0x82, 0x75, 0x48, 0xae,
+	0xe1, 0x8b, 0x39, 0x8d, 0x33, 0xa8, 0x4c, 0xdf, 0x80, 0xfd, 0xe6, 0x23, 0xb4, 0xdf, 0xfe, 0x70,
+	0x62, 0x37, 0x4e, 0x4f, 0x6c, 0x34, 0x72, 0xbe, 0x7f, 0xb5, 0xd0, 0x69, 0x69, 0xa1, 0xcf, 0xa5,
+	0x85, 0xce, 0x4a, 0x0b, 0x9d, 0x97, 0x16, 0xfa, 0x52, 0x5a, 0xe8, 0xd3, 0x37, 0xab, 0xf1, 0xa6,
+	0x99, 0xfb, 0x61, 0x4b, 0xbf, 0x94, 0xe1, 0xcf, 0x01, 0x00, 0x3a, 0x6a, 0xd6, 0x3d, 0x0f, 0x03,
+	0x00, 0x00,
 }
 
 func (this *EventFilter) Equal(that interface{}) bool {
@@ -159,6 +170,14 @@ func (this *EventFilter) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if len(this.EntityLabelSelector) != len(that1.EntityLabelSelector) {
+		return false
+	}
+	for i := range this.EntityLabelSelector {
+		if this.EntityLabelSelector[i] != that1.EntityLabelSelector[i] {
+			return false
+		}
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -172,6 +191,7 @@ type EventFilterFace interface {
 	GetExpressions() []string
 	GetWhen() *TimeWindowWhen
 	GetRuntimeAssets() []string
+	GetEntityLabelSelector() map[string]string
 }
 
 func (this *EventFilter) Proto() github_com_golang_protobuf_proto.Message {
@@ -202,6 +222,10 @@ func (this *EventFilter) GetRuntimeAssets() []string {
 	return this.RuntimeAssets
 }
 
+func (this *EventFilter) GetEntityLabelSelector() map[string]string {
+	return this.EntityLabelSelector
+}
+
 func NewEventFilterFromFace(that EventFilterFace) *EventFilter {
 	this := &EventFilter{}
 	this.ObjectMeta = that.GetObjectMeta()
@@ -209,6 +233,7 @@ func NewEventFilterFromFace(that EventFilterFace) *EventFilter {
 	this.Expressions = that.GetExpressions()
 	this.When = that.GetWhen()
 	this.RuntimeAssets = that.GetRuntimeAssets()
+	this.EntityLabelSelector = that.GetEntityLabelSelector()
 	return this
 }
 
@@ -236,6 +261,25 @@ func (m *EventFilter) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.EntityLabelSelector) > 0 {
+		for k := range m.EntityLabelSelector {
+			v := m.EntityLabelSelector[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintFilter(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintFilter(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintFilter(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
 	if len(m.RuntimeAssets) > 0 {
 		for iNdEx := len(m.RuntimeAssets) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.RuntimeAssets[iNdEx])
@@ -315,8 +359,15 @@ func NewPopulatedEventFilter(r randyFilter, easy bool) *EventFilter {
 	for i := 0; i < v3; i++ {
 		this.RuntimeAssets[i] = string(randStringFilter(r))
 	}
+	if r.Intn(5) != 0 {
+		v4 := r.Intn(10)
+		this.EntityLabelSelector = make(map[string]string)
+		for i := 0; i < v4; i++ {
+			this.EntityLabelSelector[randStringFilter(r)] = randStringFilter(r)
+		}
+	}
 	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedFilter(r, 9)
+		this.XXX_unrecognized = randUnrecognizedFilter(r, 10)
 	}
 	return this
 }
@@ -340,9 +391,9 @@ func randUTF8RuneFilter(r randyFilter) rune {
 	return rune(ru + 61)
 }
 func randStringFilter(r randyFilter) string {
-	v4 := r.Intn(100)
-	tmps := make([]rune, v4)
-	for i := 0; i < v4; i++ {
+	v5 := r.Intn(100)
+	tmps := make([]rune, v5)
+	for i := 0; i < v5; i++ {
 		tmps[i] = randUTF8RuneFilter(r)
 	}
 	return string(tmps)
@@ -364,11 +415,11 @@ func randFieldFilter(dAtA []byte, r randyFilter, fieldNumber int, wire int) []by
 	switch wire {
 	case 0:
 		dAtA = encodeVarintPopulateFilter(dAtA, uint64(key))
-		v5 := r.Int63()
+		v6 := r.Int63()
 		if r.Intn(2) == 0 {
-			v5 *= -1
+			v6 *= -1
 		}
-		dAtA = encodeVarintPopulateFilter(dAtA, uint64(v5))
+		dAtA = encodeVarintPopulateFilter(dAtA, uint64(v6))
 	case 1:
 		dAtA = encodeVarintPopulateFilter(dAtA, uint64(key))
 		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
@@ -421,6 +472,14 @@ func (m *EventFilter) Size() (n int) {
 			n += 1 + l + sovFilter(uint64(l))
 		}
 	}
+	if len(m.EntityLabelSelector) > 0 {
+		for k, v := range m.EntityLabelSelector {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovFilter(uint64(len(k))) + 1 + len(v) + sovFilter(uint64(len(v)))
+			n += mapEntrySize + 1 + sovFilter(uint64(mapEntrySize))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -627,6 +686,133 @@ func (m *EventFilter) Unmarshal(dAtA []byte) error {
 			}
 			m.RuntimeAssets = append(m.RuntimeAssets, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EntityLabelSelector", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFilter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFilter
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFilter
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EntityLabelSelector == nil {
+				m.EntityLabelSelector = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowFilter
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowFilter
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthFilter
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthFilter
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowFilter
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthFilter
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthFilter
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipFilter(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthFilter
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.EntityLabelSelector[mapkey] = mapvalue
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFilter(dAtA[iNdEx:])
@@ -44,6 +44,22 @@ func TestEventFilterValidate(t *testing.T) {
 
 	// Valid filter
 	assert.NoError(t, f.Validate())
+
+	// Valid filter with only an entity label selector
+	f.Expressions = nil
+	f.EntityLabelSelector = map[string]string{"region": "us-west-2"}
+	assert.NoError(t, f.Validate())
+}
+
+func TestEventFilterMatchesEntityLabels(t *testing.T) {
+	f := FixtureEventFilter("filter")
+	assert.True(t, f.MatchesEntityLabels(nil))
+
+	f.EntityLabelSelector = map[string]string{"region": "us-west-2", "env": "prod"}
+	assert.True(t, f.MatchesEntityLabels(map[string]string{"region": "us-west-2", "env": "prod", "team": "ops"}))
+	assert.False(t, f.MatchesEntityLabels(map[string]string{"region": "us-west-2", "env": "dev"}))
+	assert.False(t, f.MatchesEntityLabels(map[string]string{"region": "us-west-2"}))
+	assert.False(t, f.MatchesEntityLabels(nil))
 }
 
 func TestEventFilterFields(t *testing.T) {
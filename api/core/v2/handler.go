@@ -110,6 +110,39 @@ func NewHandler(meta ObjectMeta) *Handler {
 	return &Handler{ObjectMeta: meta}
 }
 
+// HandlerTestResult is the result of running a handler against a synthetic
+// event.
+type HandlerTestResult struct {
+	// Output is the combined output of a pipe handler command. It is empty
+	// for socket handlers.
+	Output string `json:"output"`
+
+	// Status is the exit status of a pipe handler command.
+	Status int `json:"status"`
+}
+
+// HandlerStatus is the error state of a handler, as recorded by the backend
+// running its invocations.
+type HandlerStatus struct {
+	// Handler is the name of the handler.
+	Handler string `json:"handler"`
+
+	// Namespace is the namespace of the handler.
+	Namespace string `json:"namespace"`
+
+	// LastError is the error message of the last failed invocation of the
+	// handler. It is empty if the handler never failed.
+	LastError string `json:"last_error,omitempty"`
+
+	// LastErrorTimestamp is the time of the last failed invocation of the
+	// handler, as a Unix timestamp.
+	LastErrorTimestamp int64 `json:"last_error_timestamp,omitempty"`
+
+	// ConsecutiveFailures is the number of invocations of the handler that
+	// failed since the last successful one.
+	ConsecutiveFailures int64 `json:"consecutive_failures"`
+}
+
 //
 // Sorting
 
@@ -0,0 +1,158 @@
+package v2
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"time"
+
+	stringsutil "github.com/sensu/sensu-go/api/core/v2/internal/stringutil"
+)
+
+const (
+	// MaintenanceWindowsResource is the name of this resource type
+	MaintenanceWindowsResource = "maintenancewindows"
+
+	// MaintenanceWindowDaily is the recurrence of maintenance windows that
+	// occur every day
+	MaintenanceWindowDaily = "daily"
+
+	// MaintenanceWindowWeekly is the recurrence of maintenance windows that
+	// occur every week
+	MaintenanceWindowWeekly = "weekly"
+)
+
+// GetObjectMeta returns the object metadata for the resource.
+func (m *MaintenanceWindow) GetObjectMeta() ObjectMeta {
+	return m.ObjectMeta
+}
+
+// SetObjectMeta sets the object metadata for the resource.
+func (m *MaintenanceWindow) SetObjectMeta(meta ObjectMeta) {
+	m.ObjectMeta = meta
+}
+
+// SetNamespace sets the namespace of the resource. Maintenance windows are
+// global to the cluster, so it does nothing.
+func (m *MaintenanceWindow) SetNamespace(namespace string) {
+}
+
+// StorePrefix returns the path prefix to this resource in the store.
+func (m *MaintenanceWindow) StorePrefix() string {
+	return MaintenanceWindowsResource
+}
+
+// RBACName describes the name of the resource for RBAC purposes.
+func (m *MaintenanceWindow) RBACName() string {
+	return MaintenanceWindowsResource
+}
+
+// URIPath gives the path component of a maintenance window URI.
+func (m *MaintenanceWindow) URIPath() string {
+	return path.Join(URLPrefix, MaintenanceWindowsResource, url.PathEscape(m.Name))
+}
+
+// Validate checks if a maintenance window passes validation rules.
+func (m *MaintenanceWindow) Validate() error {
+	if err := ValidateName(m.Name); err != nil {
+		return errors.New("name " + err.Error())
+	}
+	if m.Namespace != "" {
+		return errors.New("maintenance windows cannot have a namespace")
+	}
+	if m.Begin <= 0 {
+		return errors.New("begin must be set")
+	}
+	if m.Duration <= 0 {
+		return errors.New("duration must be greater than 0")
+	}
+	period, err := m.period()
+	if err != nil {
+		return err
+	}
+	if period > 0 && time.Duration(m.Duration)*time.Second > period {
+		return fmt.Errorf("duration cannot exceed the %s recurrence", m.Recurrence)
+	}
+	if _, err := time.LoadLocation(m.Timezone); err != nil {
+		return fmt.Errorf("invalid timezone: %s", err)
+	}
+	return nil
+}
+
+// period returns the time between two occurrences of the maintenance window,
+// or zero if it only occurs once.
+func (m *MaintenanceWindow) period() (time.Duration, error) {
+	switch m.Recurrence {
+	case "":
+		return 0, nil
+	case MaintenanceWindowDaily:
+		return 24 * time.Hour, nil
+	case MaintenanceWindowWeekly:
+		return 7 * 24 * time.Hour, nil
+	}
+	return 0, fmt.Errorf("invalid recurrence %q, expected %q or %q", m.Recurrence, MaintenanceWindowDaily, MaintenanceWindowWeekly)
+}
+
+// IsActive returns true if t falls within an occurrence of the maintenance
+// window. Occurrences begin at the same local time as the first one, in the
+// timezone of the window.
+func (m *MaintenanceWindow) IsActive(t time.Time) bool {
+	duration := time.Duration(m.Duration) * time.Second
+	first := time.Unix(m.Begin, 0)
+	if t.Before(first) {
+		return false
+	}
+	period, err := m.period()
+	if err != nil {
+		return false
+	}
+	if period == 0 {
+		return t.Before(first.Add(duration))
+	}
+	loc, err := time.LoadLocation(m.Timezone)
+	if err != nil {
+		return false
+	}
+
+	// Look for an occurrence that began on the day of t, or on one of the
+	// previous days of the period, since occurrences last up to a period
+	first = first.In(loc)
+	t = t.In(loc)
+	days := int(period / (24 * time.Hour))
+	for i := 0; i <= days; i++ {
+		day := t.AddDate(0, 0, -i)
+		begin := time.Date(day.Year(), day.Month(), day.Day(), first.Hour(), first.Minute(), first.Second(), 0, loc)
+		if begin.Before(first) || begin.After(t) {
+			continue
+		}
+		if m.Recurrence == MaintenanceWindowWeekly && begin.Weekday() != first.Weekday() {
+			continue
+		}
+		if t.Before(begin.Add(duration)) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaintenanceWindowFields returns a set of fields that represent that resource.
+func MaintenanceWindowFields(r Resource) map[string]string {
+	resource := r.(*MaintenanceWindow)
+	fields := map[string]string{
+		"maintenance_window.name":       resource.ObjectMeta.Name,
+		"maintenance_window.recurrence": resource.Recurrence,
+	}
+	stringsutil.MergeMapWithPrefix(fields, resource.ObjectMeta.Labels, "maintenance_window.labels.")
+	return fields
+}
+
+// FixtureMaintenanceWindow returns a testing fixture for a MaintenanceWindow
+// that occurs once, for an hour from begin.
+func FixtureMaintenanceWindow(name string, begin int64) *MaintenanceWindow {
+	return &MaintenanceWindow{
+		ObjectMeta: NewObjectMeta(name, ""),
+		Begin:      begin,
+		Duration:   3600,
+	}
+}
@@ -0,0 +1,653 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: github.com/sensu/sensu-go/api/core/v2/maintenance_window.proto
+
+package v2
+
+import (
+	bytes "bytes"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/golang/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// MaintenanceWindow is a period of time, global to the cluster, during which
+// the handlers of events are not executed, unless they are annotated to run
+// during maintenance.
+type MaintenanceWindow struct {
+	// Metadata contains the name, labels and annotations of the maintenance
+	// window.
+	ObjectMeta `protobuf:"bytes,1,opt,name=Metadata,proto3,embedded=Metadata" json:"metadata,omitempty"`
+	// Begin is the time, in seconds since the Unix epoch, at which the first
+	// occurrence of the maintenance window begins.
+	Begin int64 `protobuf:"varint,2,opt,name=begin,proto3" json:"begin"`
+	// Duration is the duration of each occurrence of the maintenance window, in
+	// seconds.
+	Duration int64 `protobuf:"varint,3,opt,name=duration,proto3" json:"duration"`
+	// Recurrence is how often the maintenance window recurs after its first
+	// occurrence: either "daily" or "weekly". It only occurs once when empty.
+	Recurrence string `protobuf:"bytes,4,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	// Timezone is the IANA name of the time zone in which the maintenance window
+	// recurs, as in "America/Vancouver", so that each occurrence begins at the
+	// same local time across daylight saving time changes. It defaults to UTC.
+	Timezone             string   `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MaintenanceWindow) Reset()         { *m = MaintenanceWindow{} }
+func (m *MaintenanceWindow) String() string { return proto.CompactTextString(m) }
+func (*MaintenanceWindow) ProtoMessage()    {}
+func (*MaintenanceWindow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9e675f1cf7c10371, []int{0}
+}
+func (m *MaintenanceWindow) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MaintenanceWindow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MaintenanceWindow.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MaintenanceWindow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MaintenanceWindow.Merge(m, src)
+}
+func (m *MaintenanceWindow) XXX_Size() int {
+	return m.Size()
+}
+func (m *MaintenanceWindow) XXX_DiscardUnknown() {
+	xxx_messageInfo_MaintenanceWindow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MaintenanceWindow proto.InternalMessageInfo
+
+func (m *MaintenanceWindow) GetBegin() int64 {
+	if m != nil {
+		return m.Begin
+	}
+	return 0
+}
+
+func (m *MaintenanceWindow) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *MaintenanceWindow) GetRecurrence() string {
+	if m != nil {
+		return m.Recurrence
+	}
+	return ""
+}
+
+func (m *MaintenanceWindow) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*MaintenanceWindow)(nil), "sensu.core.v2.MaintenanceWindow")
+}
+
+func init() {
+	proto.RegisterFile("github.com/sensu/sensu-go/api/core/v2/maintenance_window.proto", fileDescriptor_9e675f1cf7c10371)
+}
+
+var fileDescriptor_9e675f1cf7c10371 = []byte{
+	// 343 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x90, 0xb1, 0x4a, 0xfb, 0x40,
+	0x18, 0xc0, 0x7b, 0xed, 0xbf, 0x7f, 0xda, 0x53, 0x07, 0x83, 0x48, 0xec, 0x70, 0x09, 0x4e, 0x19,
+	0xf4, 0x62, 0x53, 0x07, 0x27, 0x91, 0xec, 0x45, 0x28, 0x88, 0xe0, 0x22, 0x97, 0xf4, 0x33, 0x9e,
+	0x90, 0xbb, 0x92, 0x5e, 0x52, 0xf4, 0x49, 0xdc, 0x5d, 0x7c, 0x04, 0x1f, 0xa1, 0x63, 0x9f, 0x20,
+	0x68, 0xdc, 0xf2, 0x04, 0x8e, 0xd2, 0x8b, 0x6d, 0xe3, 0xe6, 0x92, 0x7c, 0xfc, 0xf8, 0x7d, 0x1f,
+	0x3f, 0x0e, 0x9f, 0x47, 0x5c, 0xdd, 0xa7, 0x01, 0x0d, 0x65, 0xec, 0x4e, 0x41, 0x4c, 0xd3, 0xea,
+	0x7b, 0x1c, 0x49, 0x97, 0x4d, 0xb8, 0x1b, 0xca, 0x04, 0xdc, 0xcc, 0x73, 0x63, 0xc6, 0x85, 0x02,
+	0xc1, 0x44, 0x08, 0xb7, 0x33, 0x2e, 0xc6, 0x72, 0x46, 0x27, 0x89, 0x54, 0xd2, 0xd8, 0xd1, 0x3a,
+	0x5d, 0x7a, 0x34, 0xf3, 0x7a, 0xa7, 0xb5, 0x73, 0x91, 0x8c, 0xa4, 0xab, 0xad, 0x20, 0xbd, 0xbb,
+	0xc8, 0xfa, 0x74, 0x40, 0xfb, 0x1a, 0x6a, 0xa6, 0xa7, 0xea, 0x48, 0xef, 0xe4, 0x8f, 0x11, 0xa0,
+	0x58, 0xb5, 0x71, 0xf8, 0xd2, 0xc4, 0xbb, 0xc3, 0x4d, 0xd3, 0xb5, 0x4e, 0x32, 0xae, 0x70, 0x67,
+	0x08, 0x8a, 0x8d, 0x99, 0x62, 0x26, 0xb2, 0x91, 0xb3, 0xe5, 0x1d, 0xd0, 0x5f, 0x7d, 0xf4, 0x32,
+	0x78, 0x80, 0x50, 0x2d, 0x25, 0x9f, 0xcc, 0x73, 0xab, 0xb1, 0xc8, 0x2d, 0x54, 0xe6, 0x96, 0x11,
+	0xff, 0xac, 0x1d, 0xc9, 0x98, 0x2b, 0x88, 0x27, 0xea, 0x71, 0xb4, 0x3e, 0x65, 0x58, 0xb8, 0x1d,
+	0x40, 0xc4, 0x85, 0xd9, 0xb4, 0x91, 0xd3, 0xf2, 0xbb, 0x65, 0x6e, 0x55, 0x60, 0x54, 0xfd, 0x0c,
+	0x07, 0x77, 0xc6, 0x69, 0xc2, 0x14, 0x97, 0xc2, 0x6c, 0x69, 0x67, 0xbb, 0xcc, 0xad, 0x35, 0x1b,
+	0xad, 0x27, 0xe3, 0x0c, 0xe3, 0x04, 0xc2, 0x34, 0x49, 0x40, 0x84, 0x60, 0xfe, 0xb3, 0x91, 0xd3,
+	0xf5, 0xcd, 0x32, 0xb7, 0xf6, 0x36, 0xb4, 0x96, 0x50, 0x73, 0x0d, 0x0f, 0x77, 0x14, 0x8f, 0xe1,
+	0x49, 0x0a, 0x30, 0xdb, 0x7a, 0x6f, 0x7f, 0x19, 0xbe, 0x62, 0xf5, 0xf0, 0x15, 0xf3, 0xed, 0xaf,
+	0x0f, 0x82, 0x5e, 0x0b, 0x82, 0xde, 0x0a, 0x82, 0xe6, 0x05, 0x41, 0x8b, 0x82, 0xa0, 0xf7, 0x82,
+	0xa0, 0xe7, 0x4f, 0xd2, 0xb8, 0x69, 0x66, 0x5e, 0xf0, 0x5f, 0x3f, 0xe7, 0xe0, 0x7b, 0x00, 0xcd,
+	0xc9, 0x4f, 0x58, 0x07, 0x02, 0x00, 0x00,
+}
+
+func (this *MaintenanceWindow) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*MaintenanceWindow)
+	if !ok {
+		that2, ok := that.(MaintenanceWindow)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.ObjectMeta.Equal(&that1.ObjectMeta) {
+		return false
+	}
+	if this.Begin != that1.Begin {
+		return false
+	}
+	if this.Duration != that1.Duration {
+		return false
+	}
+	if this.Recurrence != that1.Recurrence {
+		return false
+	}
+	if this.Timezone != that1.Timezone {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (m *MaintenanceWindow) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MaintenanceWindow) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MaintenanceWindow) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Timezone) > 0 {
+		i -= len(m.Timezone)
+		copy(dAtA[i:], m.Timezone)
+		i = encodeVarintMaintenanceWindow(dAtA, i, uint64(len(m.Timezone)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Recurrence) > 0 {
+		i -= len(m.Recurrence)
+		copy(dAtA[i:], m.Recurrence)
+		i = encodeVarintMaintenanceWindow(dAtA, i, uint64(len(m.Recurrence)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Duration != 0 {
+		i = encodeVarintMaintenanceWindow(dAtA, i, uint64(m.Duration))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Begin != 0 {
+		i = encodeVarintMaintenanceWindow(dAtA, i, uint64(m.Begin))
+		i--
+		dAtA[i] = 0x10
+	}
+	{
+		size, err := m.ObjectMeta.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintMaintenanceWindow(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintMaintenanceWindow(dAtA []byte, offset int, v uint64) int {
+	offset -= sovMaintenanceWindow(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func NewPopulatedMaintenanceWindow(r randyMaintenanceWindow, easy bool) *MaintenanceWindow {
+	this := &MaintenanceWindow{}
+	v1 := NewPopulatedObjectMeta(r, easy)
+	this.ObjectMeta = *v1
+	this.Begin = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Begin *= -1
+	}
+	this.Duration = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Duration *= -1
+	}
+	this.Recurrence = string(randStringMaintenanceWindow(r))
+	this.Timezone = string(randStringMaintenanceWindow(r))
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedMaintenanceWindow(r, 6)
+	}
+	return this
+}
+
+type randyMaintenanceWindow interface {
+	Float32() float32
+	Float64() float64
+	Int63() int64
+	Int31() int32
+	Uint32() uint32
+	Intn(n int) int
+}
+
+func randUTF8RuneMaintenanceWindow(r randyMaintenanceWindow) rune {
+	ru := r.Intn(62)
+	if ru < 10 {
+		return rune(ru + 48)
+	} else if ru < 36 {
+		return rune(ru + 55)
+	}
+	return rune(ru + 61)
+}
+func randStringMaintenanceWindow(r randyMaintenanceWindow) string {
+	v2 := r.Intn(100)
+	tmps := make([]rune, v2)
+	for i := 0; i < v2; i++ {
+		tmps[i] = randUTF8RuneMaintenanceWindow(r)
+	}
+	return string(tmps)
+}
+func randUnrecognizedMaintenanceWindow(r randyMaintenanceWindow, maxFieldNumber int) (dAtA []byte) {
+	l := r.Intn(5)
+	for i := 0; i < l; i++ {
+		wire := r.Intn(4)
+		if wire == 3 {
+			wire = 5
+		}
+		fieldNumber := maxFieldNumber + r.Intn(100)
+		dAtA = randFieldMaintenanceWindow(dAtA, r, fieldNumber, wire)
+	}
+	return dAtA
+}
+func randFieldMaintenanceWindow(dAtA []byte, r randyMaintenanceWindow, fieldNumber int, wire int) []byte {
+	key := uint32(fieldNumber)<<3 | uint32(wire)
+	switch wire {
+	case 0:
+		dAtA = encodeVarintPopulateMaintenanceWindow(dAtA, uint64(key))
+		v3 := r.Int63()
+		if r.Intn(2) == 0 {
+			v3 *= -1
+		}
+		dAtA = encodeVarintPopulateMaintenanceWindow(dAtA, uint64(v3))
+	case 1:
+		dAtA = encodeVarintPopulateMaintenanceWindow(dAtA, uint64(key))
+		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
+	case 2:
+		dAtA = encodeVarintPopulateMaintenanceWindow(dAtA, uint64(key))
+		ll := r.Intn(100)
+		dAtA = encodeVarintPopulateMaintenanceWindow(dAtA, uint64(ll))
+		for j := 0; j < ll; j++ {
+			dAtA = append(dAtA, byte(r.Intn(256)))
+		}
+	default:
+		dAtA = encodeVarintPopulateMaintenanceWindow(dAtA, uint64(key))
+		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
+	}
+	return dAtA
+}
+func encodeVarintPopulateMaintenanceWindow(dAtA []byte, v uint64) []byte {
+	for v >= 1<<7 {
+		dAtA = append(dAtA, uint8(uint64(v)&0x7f|0x80))
+		v >>= 7
+	}
+	dAtA = append(dAtA, uint8(v))
+	return dAtA
+}
+func (m *MaintenanceWindow) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.ObjectMeta.Size()
+	n += 1 + l + sovMaintenanceWindow(uint64(l))
+	if m.Begin != 0 {
+		n += 1 + sovMaintenanceWindow(uint64(m.Begin))
+	}
+	if m.Duration != 0 {
+		n += 1 + sovMaintenanceWindow(uint64(m.Duration))
+	}
+	l = len(m.Recurrence)
+	if l > 0 {
+		n += 1 + l + sovMaintenanceWindow(uint64(l))
+	}
+	l = len(m.Timezone)
+	if l > 0 {
+		n += 1 + l + sovMaintenanceWindow(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovMaintenanceWindow(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozMaintenanceWindow(x uint64) (n int) {
+	return sovMaintenanceWindow(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *MaintenanceWindow) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMaintenanceWindow
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MaintenanceWindow: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MaintenanceWindow: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMaintenanceWindow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMaintenanceWindow
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMaintenanceWindow
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Begin", wireType)
+			}
+			m.Begin = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMaintenanceWindow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Begin |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Duration", wireType)
+			}
+			m.Duration = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMaintenanceWindow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Duration |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Recurrence", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMaintenanceWindow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMaintenanceWindow
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMaintenanceWindow
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Recurrence = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timezone", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMaintenanceWindow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMaintenanceWindow
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMaintenanceWindow
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Timezone = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMaintenanceWindow(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthMaintenanceWindow
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipMaintenanceWindow(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowMaintenanceWindow
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowMaintenanceWindow
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowMaintenanceWindow
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthMaintenanceWindow
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupMaintenanceWindow
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthMaintenanceWindow
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthMaintenanceWindow        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowMaintenanceWindow          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupMaintenanceWindow = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,154 @@
+package v2
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMaintenanceWindowValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		window  func(*MaintenanceWindow)
+		wantErr bool
+	}{
+		{
+			name: "valid",
+		},
+		{
+			name:    "missing name",
+			window:  func(m *MaintenanceWindow) { m.Name = "" },
+			wantErr: true,
+		},
+		{
+			name:    "namespaced",
+			window:  func(m *MaintenanceWindow) { m.Namespace = "default" },
+			wantErr: true,
+		},
+		{
+			name:    "missing begin",
+			window:  func(m *MaintenanceWindow) { m.Begin = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "missing duration",
+			window:  func(m *MaintenanceWindow) { m.Duration = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "invalid recurrence",
+			window:  func(m *MaintenanceWindow) { m.Recurrence = "monthly" },
+			wantErr: true,
+		},
+		{
+			name: "duration longer than recurrence",
+			window: func(m *MaintenanceWindow) {
+				m.Recurrence = MaintenanceWindowDaily
+				m.Duration = 25 * 3600
+			},
+			wantErr: true,
+		},
+		{
+			name:    "invalid timezone",
+			window:  func(m *MaintenanceWindow) { m.Timezone = "Mars/Olympus_Mons" },
+			wantErr: true,
+		},
+		{
+			name: "weekly in timezone",
+			window: func(m *MaintenanceWindow) {
+				m.Recurrence = MaintenanceWindowWeekly
+				m.Timezone = "America/Montreal"
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := FixtureMaintenanceWindow("window", 1)
+			if tt.window != nil {
+				tt.window(m)
+			}
+			if err := m.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMaintenanceWindowIsActive(t *testing.T) {
+	montreal, err := time.LoadLocation("America/Montreal")
+	if err != nil {
+		t.Skip(err)
+	}
+	// Sunday, 2:00 AM in Montreal
+	begin := time.Date(2020, time.March, 1, 2, 0, 0, 0, montreal)
+
+	tests := []struct {
+		name       string
+		recurrence string
+		duration   int64
+		at         time.Time
+		want       bool
+	}{
+		{
+			name: "before the first occurrence",
+			at:   begin.Add(-time.Minute),
+		},
+		{
+			name: "during a single occurrence",
+			at:   begin.Add(30 * time.Minute),
+			want: true,
+		},
+		{
+			name: "after a single occurrence",
+			at:   begin.Add(time.Hour),
+		},
+		{
+			name:       "during a later daily occurrence",
+			recurrence: MaintenanceWindowDaily,
+			at:         time.Date(2020, time.March, 4, 2, 30, 0, 0, montreal),
+			want:       true,
+		},
+		{
+			name:       "between daily occurrences",
+			recurrence: MaintenanceWindowDaily,
+			at:         time.Date(2020, time.March, 4, 4, 0, 0, 0, montreal),
+		},
+		{
+			name:       "daily occurrence spanning midnight",
+			recurrence: MaintenanceWindowDaily,
+			duration:   23 * 3600,
+			at:         time.Date(2020, time.March, 5, 0, 30, 0, 0, montreal),
+			want:       true,
+		},
+		{
+			name:       "during a weekly occurrence after daylight saving time",
+			recurrence: MaintenanceWindowWeekly,
+			at:         time.Date(2020, time.March, 15, 2, 30, 0, 0, montreal),
+			want:       true,
+		},
+		{
+			name:       "another day of the week",
+			recurrence: MaintenanceWindowWeekly,
+			at:         time.Date(2020, time.March, 16, 2, 30, 0, 0, montreal),
+		},
+		{
+			name:       "weekly occurrence spanning days",
+			recurrence: MaintenanceWindowWeekly,
+			duration:   3 * 24 * 3600,
+			at:         time.Date(2020, time.March, 10, 1, 0, 0, 0, montreal),
+			want:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := FixtureMaintenanceWindow("window", begin.Unix())
+			m.Recurrence = tt.recurrence
+			m.Timezone = "America/Montreal"
+			if tt.duration > 0 {
+				m.Duration = tt.duration
+			}
+			if got := m.IsActive(tt.at); got != tt.want {
+				t.Errorf("IsActive(%s) = %v, want %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}
@@ -4,6 +4,39 @@ const (
 	// ManagedByLabel is used to identify which client was used to create/update a
 	// resource
 	ManagedByLabel = "sensu.io/managed_by"
+
+	// ImmutableAnnotation is used to prevent a resource from being modified. A
+	// resource is immutable when the annotation is set to "true".
+	ImmutableAnnotation = "sensu.io/immutable"
+
+	// CorrelationIDAnnotation carries the correlation ID of an event, which
+	// follows the event through the pipelines that handle it.
+	CorrelationIDAnnotation = "sensu.io/correlation_id"
+
+	// RunWhenSilencedAnnotation marks a handler that still runs for silenced
+	// events when pipelined is configured to skip them. A handler is marked
+	// when the annotation is set to "true".
+	RunWhenSilencedAnnotation = "sensu.io/run_when_silenced"
+
+	// RunDuringMaintenanceAnnotation marks a handler that still runs for
+	// events that occur during an active maintenance window. A handler is
+	// marked when the annotation is set to "true".
+	RunDuringMaintenanceAnnotation = "sensu.io/run_during_maintenance"
+
+	// AsyncHandlerAnnotation marks a handler that pipelined invokes in the
+	// background, so that slow handlers don't hold up the handling of other
+	// events. A handler is marked when the annotation is set to "true".
+	AsyncHandlerAnnotation = "sensu.io/async"
+
+	// RequiresApprovalAnnotation marks a resource whose updates must be
+	// approved by a second user before they are applied. A resource is marked
+	// when the annotation is set to "true".
+	RequiresApprovalAnnotation = "sensu.io/requires_approval"
+
+	// UpdatedAtAnnotation holds the time a resource was last touched, in the
+	// RFC 3339 format. Touching a resource changes its ETag and notifies its
+	// watchers without otherwise modifying it.
+	UpdatedAtAnnotation = "sensu.io/updated_at"
 )
 
 type Comparison int
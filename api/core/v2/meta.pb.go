@@ -45,7 +45,14 @@ type ObjectMeta struct {
 	// More info: http://kubernetes.io/docs/user-guide/annotations
 	Annotations map[string]string `protobuf:"bytes,4,rep,name=annotations,proto3" json:"annotations,omitempty" yaml: "annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// CreatedBy indicates which user created the resource.
-	CreatedBy            string   `protobuf:"bytes,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty" yaml: "created_by,omitempty"`
+	CreatedBy string `protobuf:"bytes,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty" yaml: "created_by,omitempty"`
+	// Finalizers are the names of the external controllers that must clean up
+	// after the resource before it is deleted. A resource with finalizers is
+	// only marked for deletion, and is deleted once they are all removed.
+	Finalizers []string `protobuf:"bytes,6,rep,name=finalizers,proto3" json:"finalizers,omitempty" yaml:"finalizers,omitempty"`
+	// DeletedAt is the time, in seconds since the Unix epoch, at which the
+	// deletion of the resource was requested, while it still had finalizers.
+	DeletedAt            int64    `protobuf:"varint,7,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty" yaml:"deleted_at,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -119,6 +126,20 @@ func (m *ObjectMeta) GetCreatedBy() string {
 	return ""
 }
 
+func (m *ObjectMeta) GetFinalizers() []string {
+	if m != nil {
+		return m.Finalizers
+	}
+	return nil
+}
+
+func (m *ObjectMeta) GetDeletedAt() int64 {
+	if m != nil {
+		return m.DeletedAt
+	}
+	return 0
+}
+
 // TypeMeta is information that can be used to resolve a data type
 type TypeMeta struct {
 	// Type is the type name of the data type
@@ -189,38 +210,42 @@ func init() {
 }
 
 var fileDescriptor_ebda82d5ea369e05 = []byte{
-	// 494 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0x41, 0x8b, 0xd3, 0x40,
-	0x14, 0x76, 0xda, 0xec, 0x62, 0x5f, 0x51, 0x4a, 0x5c, 0x25, 0xd6, 0x35, 0x29, 0x03, 0xc2, 0x22,
-	0x75, 0xb2, 0xed, 0xca, 0xb2, 0xf6, 0x20, 0xbb, 0x05, 0x0f, 0x82, 0xe2, 0x12, 0x16, 0x05, 0x2f,
-	0xcb, 0x24, 0x8e, 0x35, 0xda, 0x64, 0x42, 0x32, 0x0d, 0xe4, 0x1f, 0xf8, 0x03, 0x3c, 0xf8, 0x13,
-	0xfc, 0x29, 0x1e, 0xfd, 0x05, 0x41, 0xeb, 0x2d, 0x47, 0x41, 0xf0, 0x28, 0x99, 0x04, 0x33, 0x29,
-	0xeb, 0x61, 0x2f, 0xed, 0xbc, 0xef, 0x7b, 0xef, 0xfb, 0xe6, 0xbd, 0x79, 0x81, 0xfd, 0x85, 0x2f,
-	0xde, 0xad, 0x5c, 0xe2, 0xf1, 0xc0, 0x4e, 0x58, 0x98, 0xac, 0xaa, 0xdf, 0x07, 0x0b, 0x6e, 0xd3,
-	0xc8, 0xb7, 0x3d, 0x1e, 0x33, 0x3b, 0x9d, 0xda, 0x01, 0x13, 0x94, 0x44, 0x31, 0x17, 0x5c, 0xbf,
-	0x26, 0x13, 0x48, 0xc9, 0x90, 0x74, 0x3a, 0x7c, 0xa8, 0x08, 0x2c, 0xf8, 0x82, 0xdb, 0x32, 0xcb,
-	0x5d, 0xbd, 0x3d, 0x4e, 0x27, 0xe4, 0x80, 0x4c, 0x24, 0x28, 0x31, 0x79, 0xaa, 0x44, 0xf0, 0x6f,
-	0x0d, 0xe0, 0x85, 0xfb, 0x9e, 0x79, 0xe2, 0x39, 0x13, 0x54, 0x3f, 0x06, 0x2d, 0xa4, 0x01, 0x33,
-	0xd0, 0x08, 0xed, 0xf5, 0xe6, 0xe3, 0x22, 0xb7, 0xae, 0x97, 0xf1, 0x98, 0x07, 0xbe, 0x60, 0x41,
-	0x24, 0xb2, 0x5f, 0xb9, 0x75, 0x2b, 0xa3, 0xc1, 0x72, 0x36, 0xc2, 0x6d, 0x02, 0x3b, 0xb2, 0x52,
-	0x3f, 0x83, 0x5e, 0xf9, 0x9f, 0x44, 0xd4, 0x63, 0x46, 0x47, 0xca, 0x1c, 0x16, 0xb9, 0x75, 0xe3,
-	0x1f, 0xd8, 0xd2, 0xba, 0xa3, 0x68, 0x6d, 0xb0, 0xd8, 0x69, 0x84, 0xf4, 0x08, 0xb6, 0x97, 0xd4,
-	0x65, 0xcb, 0xc4, 0xe8, 0x8e, 0xba, 0x7b, 0xfd, 0xe9, 0x3d, 0xd2, 0x6a, 0x9e, 0x34, 0x2d, 0x90,
-	0x67, 0x32, 0xef, 0x49, 0x28, 0xe2, 0x6c, 0x3e, 0x29, 0x72, 0x6b, 0x50, 0x15, 0xb6, 0x6c, 0x6f,
-	0xd7, 0xb6, 0xe3, 0x4d, 0x0e, 0x3b, 0xb5, 0x8f, 0xfe, 0x11, 0x41, 0x9f, 0x86, 0x21, 0x17, 0x54,
-	0xf8, 0x3c, 0x4c, 0x0c, 0x4d, 0xfa, 0xde, 0xff, 0xbf, 0xef, 0x49, 0x93, 0x5c, 0x99, 0xcf, 0x8a,
-	0xdc, 0xba, 0xa9, 0x48, 0xb4, 0x6e, 0x70, 0xb7, 0xbe, 0xc1, 0x85, 0x3c, 0x76, 0x54, 0x6b, 0xfd,
-	0x15, 0x80, 0x17, 0x33, 0x2a, 0xd8, 0x9b, 0x73, 0x37, 0x33, 0xb6, 0xe4, 0x4c, 0x8f, 0x8a, 0xdc,
-	0xda, 0x69, 0xd0, 0x96, 0xf6, 0x6e, 0xad, 0x7d, 0x11, 0x8d, 0x9d, 0x5e, 0x0d, 0xcf, 0xb3, 0xe1,
-	0x23, 0xe8, 0x2b, 0xd3, 0xd2, 0x07, 0xd0, 0xfd, 0xc0, 0xb2, 0xea, 0xed, 0x9d, 0xf2, 0xa8, 0xef,
-	0xc0, 0x56, 0x4a, 0x97, 0xab, 0xfa, 0x21, 0x9d, 0x2a, 0x98, 0x75, 0x8e, 0xd0, 0xf0, 0x31, 0x0c,
-	0x36, 0x1b, 0xbe, 0x4c, 0x3d, 0xfe, 0x84, 0xe0, 0xea, 0x59, 0x16, 0x31, 0xb9, 0x75, 0x87, 0xa0,
-	0x95, 0xe7, 0x7a, 0xeb, 0x70, 0x91, 0x5b, 0x9a, 0xc8, 0x22, 0xa6, 0xec, 0x5a, 0x19, 0xb6, 0x76,
-	0xad, 0xcc, 0xd7, 0x4f, 0x01, 0x4e, 0x4e, 0x9f, 0xbe, 0x64, 0x71, 0xe2, 0xf3, 0xb0, 0x5e, 0xb6,
-	0xfd, 0x22, 0xb7, 0xfa, 0x34, 0xf2, 0xcf, 0xd3, 0x0a, 0x56, 0x67, 0xdd, 0xa0, 0xaa, 0x96, 0xa2,
-	0x31, 0xdf, 0xfd, 0xf3, 0xc3, 0x44, 0x5f, 0xd6, 0x26, 0xfa, 0xba, 0x36, 0xd1, 0xb7, 0xb5, 0x89,
-	0xbe, 0xaf, 0x4d, 0xf4, 0xf9, 0xa7, 0x79, 0xe5, 0x75, 0x27, 0x9d, 0xba, 0xdb, 0xf2, 0x9b, 0x39,
-	0xf8, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x4d, 0x97, 0x8d, 0x24, 0xac, 0x03, 0x00, 0x00,
+	// 549 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0x4f, 0x8b, 0xd3, 0x4e,
+	0x1c, 0xc6, 0x7f, 0xd3, 0x76, 0xfb, 0xb3, 0x53, 0x94, 0x32, 0xae, 0x12, 0xeb, 0x9a, 0x94, 0x01,
+	0xa1, 0x48, 0x4d, 0xb6, 0x5d, 0x59, 0xd7, 0x1e, 0x64, 0x5b, 0xf0, 0x20, 0x28, 0x2e, 0x61, 0x59,
+	0xc1, 0xcb, 0x32, 0xc9, 0xce, 0xd6, 0x68, 0x92, 0x09, 0xc9, 0x34, 0x10, 0x5f, 0x81, 0x2f, 0xc0,
+	0x83, 0xaf, 0x40, 0x7c, 0x29, 0x1e, 0x7d, 0x05, 0x41, 0xeb, 0x2d, 0x47, 0x4f, 0x1e, 0x65, 0x26,
+	0xc1, 0x24, 0x25, 0x1e, 0xbc, 0xb4, 0x33, 0xcf, 0xf3, 0x9d, 0xcf, 0x93, 0xf9, 0xf3, 0x85, 0xfb,
+	0x2b, 0x87, 0xbf, 0x5e, 0x5b, 0xba, 0xcd, 0x3c, 0x23, 0xa2, 0x7e, 0xb4, 0xce, 0x7f, 0xef, 0xaf,
+	0x98, 0x41, 0x02, 0xc7, 0xb0, 0x59, 0x48, 0x8d, 0x78, 0x66, 0x78, 0x94, 0x13, 0x3d, 0x08, 0x19,
+	0x67, 0xe8, 0xaa, 0x2c, 0xd0, 0x85, 0xa3, 0xc7, 0xb3, 0xe1, 0x83, 0x0a, 0x60, 0xc5, 0x56, 0xcc,
+	0x90, 0x55, 0xd6, 0xfa, 0xf2, 0x38, 0x9e, 0xea, 0x07, 0xfa, 0x54, 0x8a, 0x52, 0x93, 0xa3, 0x1c,
+	0x82, 0x3f, 0x75, 0x21, 0x7c, 0x61, 0xbd, 0xa1, 0x36, 0x7f, 0x4e, 0x39, 0x41, 0xc7, 0xb0, 0xe3,
+	0x13, 0x8f, 0x2a, 0x60, 0x04, 0xc6, 0xbd, 0xe5, 0x24, 0x4b, 0xb5, 0x6b, 0x62, 0x3e, 0x61, 0x9e,
+	0xc3, 0xa9, 0x17, 0xf0, 0xe4, 0x67, 0xaa, 0xdd, 0x4c, 0x88, 0xe7, 0xce, 0x47, 0xb8, 0x6e, 0x60,
+	0x53, 0xae, 0x44, 0xa7, 0xb0, 0x27, 0xfe, 0xa3, 0x80, 0xd8, 0x54, 0x69, 0x49, 0xcc, 0x61, 0x96,
+	0x6a, 0xd7, 0xff, 0x88, 0x35, 0xd6, 0xed, 0x0a, 0x6b, 0xcb, 0xc5, 0x66, 0x09, 0x42, 0x01, 0xec,
+	0xba, 0xc4, 0xa2, 0x6e, 0xa4, 0xb4, 0x47, 0xed, 0x71, 0x7f, 0x76, 0x57, 0xaf, 0x6d, 0x5e, 0x2f,
+	0xb7, 0xa0, 0x3f, 0x93, 0x75, 0x4f, 0x7c, 0x1e, 0x26, 0xcb, 0x69, 0x96, 0x6a, 0x83, 0x7c, 0x61,
+	0x2d, 0xf6, 0x56, 0x11, 0x3b, 0xd9, 0xf6, 0xb0, 0x59, 0xe4, 0xa0, 0xf7, 0x00, 0xf6, 0x89, 0xef,
+	0x33, 0x4e, 0xb8, 0xc3, 0xfc, 0x48, 0xe9, 0xc8, 0xdc, 0x7b, 0x7f, 0xcf, 0x5d, 0x94, 0xc5, 0x79,
+	0xf8, 0x3c, 0x4b, 0xb5, 0x1b, 0x15, 0x44, 0xed, 0x0b, 0xee, 0x14, 0x5f, 0xd0, 0xe8, 0x63, 0xb3,
+	0x1a, 0x8d, 0x5e, 0x42, 0x68, 0x87, 0x94, 0x70, 0x7a, 0x71, 0x6e, 0x25, 0xca, 0x8e, 0x3c, 0xd3,
+	0xa3, 0x2c, 0xd5, 0x76, 0x4b, 0xb5, 0xc6, 0xde, 0x2b, 0xd8, 0x4d, 0x36, 0x36, 0x7b, 0x85, 0xbc,
+	0x4c, 0x04, 0xf8, 0xd2, 0xf1, 0x89, 0xeb, 0xbc, 0xa3, 0x61, 0xa4, 0x74, 0x47, 0xed, 0x71, 0x6f,
+	0xf9, 0x50, 0x80, 0x4b, 0xb5, 0xe1, 0xb6, 0x70, 0x93, 0x8b, 0xcd, 0x0a, 0x0a, 0x9d, 0x41, 0x78,
+	0x41, 0x5d, 0x2a, 0xc2, 0x09, 0x57, 0xfe, 0x1f, 0x81, 0x71, 0x3b, 0x07, 0x97, 0x6a, 0x13, 0xb8,
+	0xc9, 0xc5, 0x66, 0xaf, 0x90, 0x17, 0x7c, 0xf8, 0x08, 0xf6, 0x2b, 0xd7, 0x8b, 0x06, 0xb0, 0xfd,
+	0x96, 0x26, 0xf9, 0x63, 0x35, 0xc5, 0x10, 0xed, 0xc2, 0x9d, 0x98, 0xb8, 0xeb, 0xe2, 0xe5, 0x99,
+	0xf9, 0x64, 0xde, 0x3a, 0x02, 0xc3, 0xc7, 0x70, 0xb0, 0x7d, 0x43, 0xff, 0xb2, 0x1e, 0x7f, 0x00,
+	0xf0, 0xca, 0x69, 0x12, 0x50, 0xd9, 0x26, 0x87, 0xb0, 0x23, 0xc6, 0x45, 0x9b, 0xe0, 0x2c, 0xd5,
+	0x3a, 0x3c, 0x09, 0x68, 0xa5, 0x39, 0xc4, 0xb4, 0xd6, 0x1c, 0xa2, 0x1e, 0x9d, 0x40, 0xb8, 0x38,
+	0x79, 0x7a, 0x46, 0xc3, 0xc8, 0x61, 0x7e, 0xd1, 0x1d, 0xfb, 0x59, 0xaa, 0xf5, 0x49, 0xe0, 0x9c,
+	0xc7, 0xb9, 0x5c, 0x7d, 0x1c, 0xa5, 0x5a, 0x3b, 0xe9, 0x92, 0xb1, 0xdc, 0xfb, 0xf5, 0x5d, 0x05,
+	0x9f, 0x37, 0x2a, 0xf8, 0xb2, 0x51, 0xc1, 0xd7, 0x8d, 0x0a, 0xbe, 0x6d, 0x54, 0xf0, 0xf1, 0x87,
+	0xfa, 0xdf, 0xab, 0x56, 0x3c, 0xb3, 0xba, 0xb2, 0xc9, 0x0f, 0x7e, 0x0f, 0x00, 0xc2, 0x1a, 0x50,
+	0x03, 0x5d, 0x04, 0x00, 0x00,
 }
 
 func (this *ObjectMeta) Equal(that interface{}) bool {
@@ -267,6 +292,17 @@ func (this *ObjectMeta) Equal(that interface{}) bool {
 	if this.CreatedBy != that1.CreatedBy {
 		return false
 	}
+	if len(this.Finalizers) != len(that1.Finalizers) {
+		return false
+	}
+	for i := range this.Finalizers {
+		if this.Finalizers[i] != that1.Finalizers[i] {
+			return false
+		}
+	}
+	if this.DeletedAt != that1.DeletedAt {
+		return false
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -326,6 +362,20 @@ func (m *ObjectMeta) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.DeletedAt != 0 {
+		i = encodeVarintMeta(dAtA, i, uint64(m.DeletedAt))
+		i--
+		dAtA[i] = 0x38
+	}
+	if len(m.Finalizers) > 0 {
+		for iNdEx := len(m.Finalizers) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Finalizers[iNdEx])
+			copy(dAtA[i:], m.Finalizers[iNdEx])
+			i = encodeVarintMeta(dAtA, i, uint64(len(m.Finalizers[iNdEx])))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
 	if len(m.CreatedBy) > 0 {
 		i -= len(m.CreatedBy)
 		copy(dAtA[i:], m.CreatedBy)
@@ -459,8 +509,17 @@ func NewPopulatedObjectMeta(r randyMeta, easy bool) *ObjectMeta {
 		}
 	}
 	this.CreatedBy = string(randStringMeta(r))
+	v3 := r.Intn(10)
+	this.Finalizers = make([]string, v3)
+	for i := 0; i < v3; i++ {
+		this.Finalizers[i] = string(randStringMeta(r))
+	}
+	this.DeletedAt = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.DeletedAt *= -1
+	}
 	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedMeta(r, 6)
+		this.XXX_unrecognized = randUnrecognizedMeta(r, 8)
 	}
 	return this
 }
@@ -494,9 +553,9 @@ func randUTF8RuneMeta(r randyMeta) rune {
 	return rune(ru + 61)
 }
 func randStringMeta(r randyMeta) string {
-	v3 := r.Intn(100)
-	tmps := make([]rune, v3)
-	for i := 0; i < v3; i++ {
+	v4 := r.Intn(100)
+	tmps := make([]rune, v4)
+	for i := 0; i < v4; i++ {
 		tmps[i] = randUTF8RuneMeta(r)
 	}
 	return string(tmps)
@@ -518,11 +577,11 @@ func randFieldMeta(dAtA []byte, r randyMeta, fieldNumber int, wire int) []byte {
 	switch wire {
 	case 0:
 		dAtA = encodeVarintPopulateMeta(dAtA, uint64(key))
-		v4 := r.Int63()
+		v5 := r.Int63()
 		if r.Intn(2) == 0 {
-			v4 *= -1
+			v5 *= -1
 		}
-		dAtA = encodeVarintPopulateMeta(dAtA, uint64(v4))
+		dAtA = encodeVarintPopulateMeta(dAtA, uint64(v5))
 	case 1:
 		dAtA = encodeVarintPopulateMeta(dAtA, uint64(key))
 		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
@@ -581,6 +640,15 @@ func (m *ObjectMeta) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovMeta(uint64(l))
 	}
+	if len(m.Finalizers) > 0 {
+		for _, s := range m.Finalizers {
+			l = len(s)
+			n += 1 + l + sovMeta(uint64(l))
+		}
+	}
+	if m.DeletedAt != 0 {
+		n += 1 + sovMeta(uint64(m.DeletedAt))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -992,6 +1060,57 @@ func (m *ObjectMeta) Unmarshal(dAtA []byte) error {
 			}
 			m.CreatedBy = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Finalizers", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMeta
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMeta
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMeta
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Finalizers = append(m.Finalizers, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeletedAt", wireType)
+			}
+			m.DeletedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMeta
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DeletedAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMeta(dAtA[iNdEx:])
@@ -1,6 +1,7 @@
 package v2
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 	"path"
@@ -31,9 +32,33 @@ func (n *Namespace) Validate() error {
 		return fmt.Errorf("namespace name %s", err)
 	}
 
+	if err := n.EventRetention.Validate(); err != nil {
+		return fmt.Errorf("namespace event retention %s", err)
+	}
+
+	return nil
+}
+
+// Validate returns an error if the event retention policy does not pass
+// validation tests. A nil policy is valid.
+func (p *EventRetentionPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.MaxAge < 0 {
+		return errors.New("max_age must not be negative")
+	}
+	if p.MaxCount < 0 {
+		return errors.New("max_count must not be negative")
+	}
 	return nil
 }
 
+// Enabled returns true if the policy bounds the events kept in any way.
+func (p *EventRetentionPolicy) Enabled() bool {
+	return p != nil && (p.MaxAge > 0 || p.MaxCount > 0)
+}
+
 // FixtureNamespace returns a mocked namespace
 func FixtureNamespace(name string) *Namespace {
 	return &Namespace{
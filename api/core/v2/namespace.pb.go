@@ -27,10 +27,13 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 // Namespace represents a virtual cluster
 type Namespace struct {
 	// Name is the unique identifier for a namespace.
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// EventRetention is the retention policy of the events of the namespace.
+	// Events are kept forever when it is nil.
+	EventRetention       *EventRetentionPolicy `protobuf:"bytes,2,opt,name=event_retention,json=eventRetention,proto3" json:"event_retention,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *Namespace) Reset()         { *m = Namespace{} }
@@ -73,8 +76,78 @@ func (m *Namespace) GetName() string {
 	return ""
 }
 
+func (m *Namespace) GetEventRetention() *EventRetentionPolicy {
+	if m != nil {
+		return m.EventRetention
+	}
+	return nil
+}
+
+// EventRetentionPolicy bounds the events kept in a namespace. The events that
+// fall outside of its bounds are deleted by the backend. Keepalive events are
+// left out of the policy.
+type EventRetentionPolicy struct {
+	// MaxAge is the maximum age of the events, in seconds, according to their
+	// timestamp. Zero means no maximum.
+	MaxAge int64 `protobuf:"varint,1,opt,name=max_age,json=maxAge,proto3" json:"max_age"`
+	// MaxCount is the maximum number of events, of which the most recent are
+	// kept. Zero means no maximum.
+	MaxCount             int64    `protobuf:"varint,2,opt,name=max_count,json=maxCount,proto3" json:"max_count"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EventRetentionPolicy) Reset()         { *m = EventRetentionPolicy{} }
+func (m *EventRetentionPolicy) String() string { return proto.CompactTextString(m) }
+func (*EventRetentionPolicy) ProtoMessage()    {}
+func (*EventRetentionPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0a0fa14fb06c2a7b, []int{1}
+}
+func (m *EventRetentionPolicy) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EventRetentionPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EventRetentionPolicy.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *EventRetentionPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventRetentionPolicy.Merge(m, src)
+}
+func (m *EventRetentionPolicy) XXX_Size() int {
+	return m.Size()
+}
+func (m *EventRetentionPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventRetentionPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventRetentionPolicy proto.InternalMessageInfo
+
+func (m *EventRetentionPolicy) GetMaxAge() int64 {
+	if m != nil {
+		return m.MaxAge
+	}
+	return 0
+}
+
+func (m *EventRetentionPolicy) GetMaxCount() int64 {
+	if m != nil {
+		return m.MaxCount
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Namespace)(nil), "sensu.core.v2.Namespace")
+	proto.RegisterType((*EventRetentionPolicy)(nil), "sensu.core.v2.EventRetentionPolicy")
 }
 
 func init() {
@@ -82,18 +155,25 @@ func init() {
 }
 
 var fileDescriptor_0a0fa14fb06c2a7b = []byte{
-	// 176 bytes of a gzipped FileDescriptorProto
+	// 285 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x32, 0x4d, 0xcf, 0x2c, 0xc9,
 	0x28, 0x4d, 0xd2, 0x4b, 0xce, 0xcf, 0xd5, 0x2f, 0x4e, 0xcd, 0x2b, 0x2e, 0x85, 0x90, 0xba, 0xe9,
 	0xf9, 0xfa, 0x89, 0x05, 0x99, 0xfa, 0xc9, 0xf9, 0x45, 0xa9, 0xfa, 0x65, 0x46, 0xfa, 0x79, 0x89,
 	0xb9, 0xa9, 0xc5, 0x05, 0x89, 0xc9, 0xa9, 0x7a, 0x05, 0x45, 0xf9, 0x25, 0xf9, 0x42, 0xbc, 0x60,
 	0x55, 0x7a, 0x20, 0x69, 0xbd, 0x32, 0x23, 0x29, 0x13, 0x24, 0x53, 0xd2, 0xf3, 0xd3, 0xf3, 0xf5,
 	0xc1, 0xaa, 0x92, 0x4a, 0xd3, 0x1c, 0xca, 0x0c, 0xf5, 0x8c, 0xf5, 0x0c, 0xc1, 0x82, 0x60, 0x31,
-	0x30, 0x0b, 0x62, 0x88, 0x92, 0x3c, 0x17, 0xa7, 0x1f, 0xcc, 0x5c, 0x21, 0x21, 0x2e, 0x16, 0x90,
-	0x25, 0x12, 0x8c, 0x0a, 0x8c, 0x1a, 0x9c, 0x41, 0x60, 0xb6, 0x93, 0xc2, 0x8f, 0x87, 0x72, 0x8c,
-	0x2b, 0x1e, 0xc9, 0x31, 0xee, 0x78, 0x24, 0xc7, 0x78, 0xe2, 0x91, 0x1c, 0xe3, 0x85, 0x47, 0x72,
-	0x8c, 0x0f, 0x1e, 0xc9, 0x31, 0xce, 0x78, 0x2c, 0xc7, 0x10, 0xc5, 0x54, 0x66, 0x94, 0xc4, 0x06,
-	0x36, 0xc9, 0x18, 0x10, 0x00, 0x00, 0xff, 0xff, 0xc1, 0xe7, 0x76, 0x13, 0xc7, 0x00, 0x00, 0x00,
+	0x30, 0x0b, 0x62, 0x88, 0x52, 0x2e, 0x17, 0xa7, 0x1f, 0xcc, 0x5c, 0x21, 0x21, 0x2e, 0x16, 0x90,
+	0x25, 0x12, 0x8c, 0x0a, 0x8c, 0x1a, 0x9c, 0x41, 0x60, 0xb6, 0x90, 0x0f, 0x17, 0x7f, 0x6a, 0x59,
+	0x6a, 0x5e, 0x49, 0x7c, 0x51, 0x6a, 0x49, 0x6a, 0x5e, 0x49, 0x66, 0x7e, 0x9e, 0x04, 0x93, 0x02,
+	0xa3, 0x06, 0xb7, 0x91, 0xb2, 0x1e, 0x8a, 0xfd, 0x7a, 0xae, 0x20, 0x55, 0x41, 0x30, 0x45, 0x01,
+	0xf9, 0x39, 0x99, 0xc9, 0x95, 0x41, 0x7c, 0xa9, 0x28, 0xa2, 0x4a, 0x19, 0x5c, 0x22, 0xd8, 0xd4,
+	0x09, 0xa9, 0x70, 0xb1, 0xe7, 0x26, 0x56, 0xc4, 0x27, 0xa6, 0x43, 0x2c, 0x67, 0x76, 0xe2, 0x7e,
+	0x75, 0x4f, 0x1e, 0x26, 0x14, 0xc4, 0x96, 0x9b, 0x58, 0xe1, 0x98, 0x9e, 0x2a, 0xa4, 0xc5, 0xc5,
+	0x09, 0x12, 0x4a, 0xce, 0x2f, 0xcd, 0x2b, 0x01, 0xbb, 0x82, 0xd9, 0x89, 0xf7, 0xd5, 0x3d, 0x79,
+	0x84, 0x60, 0x10, 0x47, 0x6e, 0x62, 0x85, 0x33, 0x88, 0xe5, 0xa4, 0xf0, 0xe3, 0xa1, 0x1c, 0xe3,
+	0x8a, 0x47, 0x72, 0x8c, 0x3b, 0x1e, 0xc9, 0x31, 0x9e, 0x78, 0x24, 0xc7, 0x78, 0xe1, 0x91, 0x1c,
+	0xe3, 0x83, 0x47, 0x72, 0x8c, 0x33, 0x1e, 0xcb, 0x31, 0x44, 0x31, 0x95, 0x19, 0x25, 0xb1, 0x81,
+	0x43, 0xc0, 0x18, 0x30, 0x00, 0x4e, 0x8a, 0x87, 0x80, 0x7f, 0x01, 0x00, 0x00,
 }
 
 func (this *Namespace) Equal(that interface{}) bool {
@@ -118,6 +198,39 @@ func (this *Namespace) Equal(that interface{}) bool {
 	if this.Name != that1.Name {
 		return false
 	}
+	if !this.EventRetention.Equal(that1.EventRetention) {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *EventRetentionPolicy) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*EventRetentionPolicy)
+	if !ok {
+		that2, ok := that.(EventRetentionPolicy)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.MaxAge != that1.MaxAge {
+		return false
+	}
+	if this.MaxCount != that1.MaxCount {
+		return false
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -147,6 +260,18 @@ func (m *Namespace) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.EventRetention != nil {
+		{
+			size, err := m.EventRetention.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNamespace(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
 	if len(m.Name) > 0 {
 		i -= len(m.Name)
 		copy(dAtA[i:], m.Name)
@@ -157,6 +282,43 @@ func (m *Namespace) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *EventRetentionPolicy) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EventRetentionPolicy) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EventRetentionPolicy) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.MaxCount != 0 {
+		i = encodeVarintNamespace(dAtA, i, uint64(m.MaxCount))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.MaxAge != 0 {
+		i = encodeVarintNamespace(dAtA, i, uint64(m.MaxAge))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintNamespace(dAtA []byte, offset int, v uint64) int {
 	offset -= sovNamespace(v)
 	base := offset
@@ -171,8 +333,27 @@ func encodeVarintNamespace(dAtA []byte, offset int, v uint64) int {
 func NewPopulatedNamespace(r randyNamespace, easy bool) *Namespace {
 	this := &Namespace{}
 	this.Name = string(randStringNamespace(r))
+	if r.Intn(5) != 0 {
+		this.EventRetention = NewPopulatedEventRetentionPolicy(r, easy)
+	}
 	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedNamespace(r, 2)
+		this.XXX_unrecognized = randUnrecognizedNamespace(r, 3)
+	}
+	return this
+}
+
+func NewPopulatedEventRetentionPolicy(r randyNamespace, easy bool) *EventRetentionPolicy {
+	this := &EventRetentionPolicy{}
+	this.MaxAge = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.MaxAge *= -1
+	}
+	this.MaxCount = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.MaxCount *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedNamespace(r, 3)
 	}
 	return this
 }
@@ -259,6 +440,28 @@ func (m *Namespace) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovNamespace(uint64(l))
 	}
+	if m.EventRetention != nil {
+		l = m.EventRetention.Size()
+		n += 1 + l + sovNamespace(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *EventRetentionPolicy) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MaxAge != 0 {
+		n += 1 + sovNamespace(uint64(m.MaxAge))
+	}
+	if m.MaxCount != 0 {
+		n += 1 + sovNamespace(uint64(m.MaxCount))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -332,6 +535,131 @@ func (m *Namespace) Unmarshal(dAtA []byte) error {
 			}
 			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventRetention", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EventRetention == nil {
+				m.EventRetention = &EventRetentionPolicy{}
+			}
+			if err := m.EventRetention.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNamespace(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNamespace
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EventRetentionPolicy) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNamespace
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EventRetentionPolicy: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EventRetentionPolicy: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxAge", wireType)
+			}
+			m.MaxAge = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxAge |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxCount", wireType)
+			}
+			m.MaxCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNamespace(dAtA[iNdEx:])
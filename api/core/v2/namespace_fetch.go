@@ -0,0 +1,22 @@
+package v2
+
+// NamespaceFetchResult is the result of fetching several namespaces by name at
+// once.
+type NamespaceFetchResult struct {
+	// Namespaces are the namespaces that were found
+	Namespaces []*Namespace `json:"namespaces"`
+
+	// Missing are the names of the namespaces that were not found
+	Missing []string `json:"missing"`
+}
+
+// NamespaceWithCounts is a namespace along with the number of resources of
+// each type it contains.
+type NamespaceWithCounts struct {
+	// Namespace is the namespace
+	Namespace *Namespace `json:"namespace"`
+
+	// Counts are the numbers of resources of the namespace, keyed by resource
+	// type, e.g. "checks"
+	Counts map[string]int64 `json:"counts"`
+}
@@ -28,3 +28,38 @@ func TestNamespaceFields(t *testing.T) {
 		})
 	}
 }
+
+func TestNamespaceValidateEventRetention(t *testing.T) {
+	tests := []struct {
+		name      string
+		retention *EventRetentionPolicy
+		wantErr   bool
+	}{
+		{
+			name: "no policy",
+		},
+		{
+			name:      "max age and count",
+			retention: &EventRetentionPolicy{MaxAge: 3600, MaxCount: 100},
+		},
+		{
+			name:      "negative max age",
+			retention: &EventRetentionPolicy{MaxAge: -1},
+			wantErr:   true,
+		},
+		{
+			name:      "negative max count",
+			retention: &EventRetentionPolicy{MaxCount: -1},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ns := FixtureNamespace("default")
+			ns.EventRetention = tt.retention
+			if err := ns.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
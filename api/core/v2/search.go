@@ -0,0 +1,13 @@
+package v2
+
+// SearchResult is a resource whose name matched a search query.
+type SearchResult struct {
+	// Type is the type of the resource, as in "checks"
+	Type string `json:"type"`
+
+	// Name is the name of the resource
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the resource
+	Namespace string `json:"namespace"`
+}
@@ -106,6 +106,85 @@ func (s *Silenced) Matches(check, subscription string) bool {
 	return true
 }
 
+// OverlappingSilences returns the entries that silence some of the same
+// events as the given entry, during some of the same time. Two entries
+// overlap when they belong to the same namespace, when their subscriptions
+// and checks can match the same event, and when their silencing windows
+// intersect. The given entry itself is never returned.
+func OverlappingSilences(entry *Silenced, entries []*Silenced) []*Silenced {
+	var result []*Silenced
+	for _, other := range entries {
+		if other == nil || other == entry {
+			continue
+		}
+		if other.Namespace != entry.Namespace {
+			continue
+		}
+		if !silencedFieldsOverlap(entry.Subscription, other.Subscription) {
+			continue
+		}
+		if !silencedFieldsOverlap(entry.Check, other.Check) {
+			continue
+		}
+		begin, end := entry.silencedWindow()
+		otherBegin, otherEnd := other.silencedWindow()
+		if (end > 0 && end <= otherBegin) || (otherEnd > 0 && otherEnd <= begin) {
+			continue
+		}
+		result = append(result, other)
+	}
+	return result
+}
+
+// silencedFieldsOverlap returns true if a and b, a subscription or a check of
+// silenced entries, can match the same value.
+func silencedFieldsOverlap(a, b string) bool {
+	if a == "" || a == "*" || b == "" || b == "*" {
+		return true
+	}
+	return a == b
+}
+
+// silencedWindow returns the unix timestamps between which the entry
+// silences events. The end is 0 if the entry does not expire.
+func (s *Silenced) silencedWindow() (begin, end int64) {
+	begin = s.Begin
+	if s.ExpireAt > 0 {
+		end = s.ExpireAt
+	} else if s.Expire > 0 {
+		start := begin
+		if start == 0 {
+			start = time.Now().Unix()
+		}
+		end = start + s.Expire
+	}
+	return begin, end
+}
+
+// SilencedBatchResult is the outcome of creating one of the entries of a
+// batch of silenced entries.
+type SilencedBatchResult struct {
+	// Name is the name of the silenced entry
+	Name string `json:"name"`
+
+	// Created is true if the silenced entry was created
+	Created bool `json:"created"`
+
+	// Overlaps contains the names of the existing entries, and of the other
+	// entries of the batch, that overlap with the silenced entry
+	Overlaps []string `json:"overlaps,omitempty"`
+
+	// Error describes why the silenced entry was not created
+	Error string `json:"error,omitempty"`
+}
+
+// SilencedDeleteResult is the outcome of deleting the silenced entries that
+// match a subscription and a check.
+type SilencedDeleteResult struct {
+	// Deleted is the number of silenced entries that were deleted
+	Deleted int `json:"deleted"`
+}
+
 // NewSilenced creates a new Silenced entry.
 func NewSilenced(meta ObjectMeta) *Silenced {
 	return &Silenced{ObjectMeta: meta}
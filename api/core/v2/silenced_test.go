@@ -201,3 +201,35 @@ func TestSilencedFields(t *testing.T) {
 		})
 	}
 }
+
+func TestOverlappingSilences(t *testing.T) {
+	entry := FixtureSilenced("linux:check-cpu")
+	entry.Begin = 100
+	entry.ExpireAt = 200
+
+	subscriptionWide := FixtureSilenced("linux:*")
+	subscriptionWide.Check = ""
+
+	otherNamespace := FixtureSilenced("linux:check-cpu")
+	otherNamespace.Namespace = "acme"
+
+	before := FixtureSilenced("*:check-cpu")
+	before.Subscription = ""
+	before.Begin = 0
+	before.ExpireAt = 100
+
+	after := FixtureSilenced("linux:check-cpu")
+	after.Begin = 200
+
+	during := FixtureSilenced("*:check-cpu")
+	during.Begin = 150
+	during.Expire = 100
+
+	otherCheck := FixtureSilenced("linux:check-mem")
+	otherSubscription := FixtureSilenced("windows:check-cpu")
+
+	entries := []*Silenced{entry, subscriptionWide, otherNamespace, before, after, during, otherCheck, otherSubscription}
+	got := OverlappingSilences(entry, entries)
+	want := []*Silenced{subscriptionWide, during}
+	assert.Equal(t, want, got)
+}
@@ -61,6 +61,8 @@ var typeMap = map[string]interface{}{
 	"hook_list":              &HookList{},
 	"KeepaliveRecord":        &KeepaliveRecord{},
 	"keepalive_record":       &KeepaliveRecord{},
+	"MaintenanceWindow":      &MaintenanceWindow{},
+	"maintenance_window":     &MaintenanceWindow{},
 	"MetricPoint":            &MetricPoint{},
 	"metric_point":           &MetricPoint{},
 	"MetricTag":              &MetricTag{},
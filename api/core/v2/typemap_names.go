@@ -0,0 +1,14 @@
+package v2
+
+import "sort"
+
+// TypeNames returns the names of the types that ResolveResource knows about,
+// in both their Go and snake_case forms.
+func TypeNames() []string {
+	names := make([]string, 0, len(typeMap))
+	for name := range typeMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
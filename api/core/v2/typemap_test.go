@@ -448,6 +448,23 @@ func TestResolveKeepaliveRecord(t *testing.T) {
 	}
 }
 
+func TestResolveMaintenanceWindow(t *testing.T) {
+	var value interface{} = new(MaintenanceWindow)
+	if _, ok := value.(Resource); ok {
+		if _, err := ResolveResource("MaintenanceWindow"); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	_, err := ResolveResource("MaintenanceWindow")
+	if err == nil {
+		t.Fatal("expected non-nil error")
+	}
+	if got, want := err.Error(), `"MaintenanceWindow" is not a Resource`; got != want {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
 func TestResolveMetricPoint(t *testing.T) {
 	var value interface{} = new(MetricPoint)
 	if _, ok := value.(Resource); ok {
@@ -71,6 +71,9 @@ func init() {
 	if err := prometheus.Register(sessionErrorCounter); err != nil {
 		metrics.LogError(logger, sessionErrorCounterName, err)
 	}
+	if err := prometheus.Register(backpressureCounter); err != nil {
+		metrics.LogError(logger, backpressureCounterName, err)
+	}
 	if err := prometheus.Register(eventBytesSummary); err != nil {
 		metrics.LogError(logger, EventBytesSummaryName, err)
 	}
@@ -114,6 +117,7 @@ type Config struct {
 	RingPool            *ringv2.RingPool
 	WriteTimeout        int
 	Client              *clientv3.Client
+	Storev2             storev2.Interface
 	EtcdClientTLSConfig *tls.Config
 	Watcher             <-chan store.WatchEventEntityConfig
 }
@@ -144,6 +148,10 @@ func New(c Config, opts ...Option) (*Agentd, error) {
 		etcdClientTLSConfig: c.EtcdClientTLSConfig,
 	}
 
+	if c.Storev2 != nil {
+		a.storev2 = c.Storev2
+	}
+
 	// prepare server TLS config
 	tlsServerConfig, err := c.TLS.ToServerTLSConfig()
 	if err != nil {
@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -39,6 +41,17 @@ const (
 	// Name of the websocket errors metric
 	websocketErrorCounterName = "sensu_go_websocket_errors"
 
+	// Name of the backpressure signals counter metric
+	backpressureCounterName = "sensu_go_agentd_backpressure_signals"
+
+	// BackpressureDelay is the time agents are asked to wait before sending
+	// their next event, when eventd can't keep up with them.
+	BackpressureDelay = time.Second
+
+	// pendingEventsSize is the number of events a session holds on to while
+	// eventd has no room for them.
+	pendingEventsSize = 100
+
 	// EventBytesSummaryName is the name of the prometheus summary vec used to
 	// track event sizes (in bytes).
 	EventBytesSummaryName = "sensu_go_agentd_event_bytes"
@@ -72,6 +85,12 @@ var (
 		},
 		[]string{"error"},
 	)
+	backpressureCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: backpressureCounterName,
+			Help: "The total number of backpressure signals sent to agents",
+		},
+	)
 )
 
 // A Session is a server-side connection between a Sensu backend server and
@@ -88,6 +107,8 @@ type Session struct {
 	wg               *sync.WaitGroup
 	stopWG           sync.WaitGroup
 	checkChannel     chan interface{}
+	pendingEvents    chan *corev2.Event
+	pending          int32
 	bus              messaging.MessageBus
 	ringPool         *ringv2.RingPool
 	ctx              context.Context
@@ -165,6 +186,7 @@ func NewSession(ctx context.Context, cfg SessionConfig) (*Session, error) {
 		cfg:              cfg,
 		wg:               &sync.WaitGroup{},
 		checkChannel:     make(chan interface{}, 100),
+		pendingEvents:    make(chan *corev2.Event, pendingEventsSize),
 		store:            cfg.Store,
 		storev2:          cfg.Storev2,
 		bus:              cfg.Bus,
@@ -404,15 +426,17 @@ func (s *Session) sender() {
 // Start a Session.
 // 1. Start sender
 // 2. Start receiver
-// 3. Start goroutine that waits for context cancellation, and shuts down service.
+// 3. Start publisher
+// 4. Start goroutine that waits for context cancellation, and shuts down service.
 func (s *Session) Start() (err error) {
 	defer close(s.entityConfig.subscriptions)
 	sessionCounter.WithLabelValues(s.cfg.Namespace).Inc()
 	s.wg = &sync.WaitGroup{}
-	s.wg.Add(2)
+	s.wg.Add(3)
 	s.stopWG.Add(1)
 	go s.sender()
 	go s.receiver()
+	go s.publisher()
 	go func() {
 		<-s.ctx.Done()
 		s.stop()
@@ -616,7 +640,65 @@ func (s *Session) handleEvent(_ context.Context, payload []byte) error {
 		eventBytesSummary.WithLabelValues(metrics.EventTypeLabelMetrics).Observe(float64(len(payload)))
 	}
 
-	return s.bus.Publish(messaging.TopicEventRaw, event)
+	return s.publishEvent(event)
+}
+
+// publishEvent publishes the event to eventd. If eventd has no room for it,
+// the agent is asked to slow down, and the event is queued for the publisher,
+// so that the session keeps reading keepalives from the agent in the
+// meantime. Events are dropped once the queue is full.
+func (s *Session) publishEvent(event *corev2.Event) error {
+	// Events that are already queued go first
+	if atomic.LoadInt32(&s.pending) == 0 {
+		err := s.bus.Publish(messaging.TopicEventRaw, event)
+		if err != messaging.ErrTopicFull {
+			return err
+		}
+	}
+
+	backpressureCounter.Inc()
+	delay := strconv.FormatInt(int64(BackpressureDelay/time.Millisecond), 10)
+	if err := s.conn.Send(transport.NewMessage(transport.MessageTypeBackpressure, []byte(delay))); err != nil {
+		logger.WithFields(logrus.Fields{
+			"addr":  s.cfg.AgentAddr,
+			"agent": s.cfg.AgentName,
+		}).WithError(err).Warn("could not send backpressure signal to agent")
+	}
+
+	atomic.AddInt32(&s.pending, 1)
+	select {
+	case s.pendingEvents <- event:
+		return nil
+	default:
+		atomic.AddInt32(&s.pending, -1)
+		return messaging.ErrTopicFull
+	}
+}
+
+// publisher publishes the events queued by publishEvent, waiting for eventd
+// to have room for them.
+func (s *Session) publisher() {
+	defer func() {
+		s.cancel()
+		s.wg.Done()
+		logger.Info("shutting down agent session: stopping publisher")
+	}()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case event := <-s.pendingEvents:
+			err := messaging.PublishWait(s.ctx, s.bus, messaging.TopicEventRaw, event)
+			atomic.AddInt32(&s.pending, -1)
+			if s.ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				logger.WithError(err).Error("error publishing event")
+			}
+		}
+	}
 }
 
 // subscribe adds a subscription to the session for every check subscriptions
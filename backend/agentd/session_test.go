@@ -808,3 +808,66 @@ func TestSession_receiver(t *testing.T) {
 		})
 	}
 }
+
+type eventSubscriber chan interface{}
+
+func (e eventSubscriber) Receiver() chan<- interface{} {
+	return e
+}
+
+func TestSessionPublishEventBackpressure(t *testing.T) {
+	bus, err := messaging.NewWizardBus(messaging.WizardBusConfig{
+		OverflowPolicies: map[string]messaging.OverflowPolicy{
+			messaging.TopicEventRaw: messaging.OverflowReject,
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, bus.Start())
+	defer func() {
+		_ = bus.Stop()
+	}()
+
+	events := make(eventSubscriber, 1)
+	_, err = bus.Subscribe(messaging.TopicEventRaw, "eventd", events)
+	require.NoError(t, err)
+
+	conn := new(mocktransport.MockTransport)
+	conn.On("Send", mock.MatchedBy(func(msg *transport.Message) bool {
+		return msg.Type == transport.MessageTypeBackpressure && string(msg.Payload) == "1000"
+	})).Return(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	session := &Session{
+		bus:           bus,
+		conn:          conn,
+		ctx:           ctx,
+		cancel:        cancel,
+		wg:            &sync.WaitGroup{},
+		pendingEvents: make(chan *corev2.Event, 1),
+	}
+
+	require.NoError(t, session.publishEvent(corev2.FixtureEvent("entity", "first")))
+
+	// eventd has no room for the second event, so the agent is asked to slow
+	// down, and the event is queued without blocking the session
+	require.NoError(t, session.publishEvent(corev2.FixtureEvent("entity", "second")))
+
+	// The third event is dropped, the queue being full
+	assert.Equal(t, messaging.ErrTopicFull, session.publishEvent(corev2.FixtureEvent("entity", "third")))
+	conn.AssertNumberOfCalls(t, "Send", 2)
+
+	// The queued event is published once the first one has been consumed
+	session.wg.Add(1)
+	go session.publisher()
+	first := (<-events).(*corev2.Event)
+	assert.Equal(t, "first", first.Check.Name)
+	second := (<-events).(*corev2.Event)
+	assert.Equal(t, "second", second.Check.Name)
+
+	// The publisher gives up when the session ends
+	events <- first
+	require.NoError(t, session.publishEvent(corev2.FixtureEvent("entity", "fourth")))
+	cancel()
+	session.wg.Wait()
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+	"github.com/sensu/sensu-go/backend/authorization"
+	"github.com/sensu/sensu-go/backend/store"
+	storev2 "github.com/sensu/sensu-go/backend/store/v2"
+)
+
+// deleteMatchingConcurrency is the maximum number of resources that
+// DeleteMatching deletes concurrently.
+const deleteMatchingConcurrency = 8
+
+// ParseLabelSelector parses a label selector made of comma-separated
+// key=value requirements, which resources must all fulfill to be selected.
+func ParseLabelSelector(selector string) (map[string]string, error) {
+	labels := map[string]string{}
+	for _, requirement := range strings.Split(selector, ",") {
+		requirement = strings.TrimSpace(requirement)
+		if requirement == "" {
+			continue
+		}
+		kv := strings.SplitN(strings.Replace(requirement, "==", "=", 1), "=", 2)
+		key := strings.TrimSpace(kv[0])
+		if len(kv) != 2 || key == "" {
+			return nil, fmt.Errorf("invalid label selector requirement %q, expected key=value", requirement)
+		}
+		labels[key] = strings.TrimSpace(kv[1])
+	}
+	if len(labels) == 0 {
+		return nil, errors.New("empty label selector")
+	}
+	return labels, nil
+}
+
+// DeleteMatching deletes the resources of the namespace whose labels match all
+// of the given labels. Deletions are authorized individually, and a failure to
+// delete a resource does not prevent the deletion of the others; it is
+// recorded in the result instead. Resources are guarded like they are when
+// deleted one by one: those with finalizers are only marked for deletion, and
+// those that require approval are not deleted.
+func (g *GenericClient) DeleteMatching(ctx context.Context, labels map[string]string) (*corev2.DeleteMatchingResult, error) {
+	if err := g.validateConfig(); err != nil {
+		return nil, err
+	}
+	if len(labels) == 0 {
+		return nil, errors.New("empty label selector")
+	}
+
+	values, err := g.listValues(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &corev2.DeleteMatchingResult{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, deleteMatchingConcurrency)
+	for _, value := range values {
+		if !matchLabels(value.GetObjectMeta().Labels, labels) {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(value corev2.Resource) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			name := value.GetObjectMeta().Name
+			err := g.deleteMatched(ctx, value)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if result.Errors == nil {
+					result.Errors = make(map[string]string)
+				}
+				result.Errors[name] = err.Error()
+				return
+			}
+			result.Deleted++
+		}(value)
+	}
+	wg.Wait()
+
+	return result, nil
+}
+
+// deleteMatched deletes a resource selected by DeleteMatching, if authorized.
+// The resource is only deleted, or marked for deletion, if it was not modified
+// since it was listed.
+func (g *GenericClient) deleteMatched(ctx context.Context, value corev2.Resource) error {
+	meta := value.GetObjectMeta()
+	attrs := &authorization.Attributes{
+		APIGroup:     g.APIGroup,
+		APIVersion:   g.APIVersion,
+		Resource:     g.Kind.RBACName(),
+		Namespace:    corev2.ContextNamespace(ctx),
+		Verb:         "delete",
+		ResourceName: meta.Name,
+	}
+	if err := authorize(ctx, g.Auth, attrs); err != nil {
+		return err
+	}
+	// The deletion of these resources must be approved one by one
+	if meta.Annotations[corev2.RequiresApprovalAnnotation] == "true" {
+		return fmt.Errorf("the deletion of %s requires approval, delete it individually", meta.Name)
+	}
+
+	if proxy, ok := value.(*corev3.V2ResourceProxy); ok {
+		etag, err := store.ETag(proxy.Resource)
+		if err != nil {
+			return err
+		}
+		req := storev2.ResourceRequest{
+			Namespace: corev2.ContextNamespace(ctx),
+			Name:      meta.Name,
+			StoreName: g.Kind.StorePrefix(),
+			Context:   store.ContextWithIfMatch(ctx, etag),
+		}
+		return g.StoreV2.Delete(req)
+	}
+
+	etag, err := store.ETag(value)
+	if err != nil {
+		return err
+	}
+	if len(meta.Finalizers) > 0 {
+		// Resources with finalizers are deleted by the reaper once their
+		// finalizers are removed
+		if meta.DeletedAt != 0 {
+			return nil
+		}
+		meta.DeletedAt = time.Now().Unix()
+		value.SetObjectMeta(meta)
+		return g.Store.CreateOrUpdateResource(store.ContextWithIfMatch(ctx, etag), value)
+	}
+	resource := reflect.New(reflect.TypeOf(g.Kind).Elem()).Interface().(corev2.Resource)
+	return g.Store.DeleteResourceIfMatch(ctx, resource, meta.Name, etag)
+}
+
+// listValues returns the resources of the namespace, if authorized.
+func (g *GenericClient) listValues(ctx context.Context) ([]corev2.Resource, error) {
+	var values []corev2.Resource
+	pred := &store.SelectionPredicate{}
+	if _, ok := g.Kind.(*corev3.V2ResourceProxy); ok {
+		if err := g.List(ctx, &values, pred); err != nil {
+			return nil, err
+		}
+		return values, nil
+	}
+
+	// The store lists resources into a slice of their concrete type
+	ptr := reflect.New(reflect.SliceOf(reflect.TypeOf(g.Kind)))
+	if err := g.List(ctx, ptr.Interface(), pred); err != nil {
+		return nil, err
+	}
+	for i := 0; i < ptr.Elem().Len(); i++ {
+		if value, ok := ptr.Elem().Index(i).Interface().(corev2.Resource); ok {
+			values = append(values, value)
+		}
+	}
+	return values, nil
+}
+
+func matchLabels(labels, selector map[string]string) bool {
+	for key, value := range selector {
+		if v, ok := labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/authorization"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseLabelSelector(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     map[string]string
+		wantErr  bool
+	}{
+		{
+			name:     "single requirement",
+			selector: "region=us-west-2",
+			want:     map[string]string{"region": "us-west-2"},
+		},
+		{
+			name:     "multiple requirements",
+			selector: "region == us-west-2, tier=web",
+			want:     map[string]string{"region": "us-west-2", "tier": "web"},
+		},
+		{
+			name:     "empty selector",
+			selector: " , ",
+			wantErr:  true,
+		},
+		{
+			name:     "missing value",
+			selector: "region",
+			wantErr:  true,
+		},
+		{
+			name:     "missing key",
+			selector: "=us-west-2",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLabelSelector(tt.selector)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLabelSelector() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLabelSelector() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenericClient_DeleteMatching(t *testing.T) {
+	labeledAsset := func(name, region string) *corev2.Asset {
+		asset := corev2.FixtureAsset(name)
+		asset.Labels = map[string]string{"region": region}
+		return asset
+	}
+
+	store := &mockstore.MockStore{}
+	store.On("ListResources", mock.Anything, (&corev2.Asset{}).StorePrefix(), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		arg := args.Get(2).(*[]*corev2.Asset)
+		*arg = []*corev2.Asset{
+			labeledAsset("a", "us-west-2"),
+			labeledAsset("b", "us-west-2"),
+			labeledAsset("c", "eu-west-1"),
+		}
+	}).Return(nil)
+	store.On("DeleteResourceIfMatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	// tom may list assets and delete asset a, but not asset b
+	key := func(verb, name string) authorization.AttributesKey {
+		return authorization.AttributesKey{
+			APIGroup:     "core",
+			APIVersion:   "v2",
+			Namespace:    "default",
+			Resource:     "assets",
+			ResourceName: name,
+			UserName:     "tom",
+			Verb:         verb,
+		}
+	}
+	auth := &mockAuth{
+		attrs: map[authorization.AttributesKey]bool{
+			key("list", ""):    true,
+			key("delete", "a"): true,
+			key("delete", "b"): false,
+		},
+	}
+
+	client := defaultTestClient(store, auth)
+	ctx := contextWithUser(defaultContext(), "tom", nil)
+	result, err := client.DeleteMatching(ctx, map[string]string{"region": "us-west-2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := result.Deleted, 1; got != want {
+		t.Errorf("bad deleted count: got %d, want %d", got, want)
+	}
+	if _, ok := result.Errors["b"]; !ok || len(result.Errors) != 1 {
+		t.Errorf("expected a single error for asset b, got %v", result.Errors)
+	}
+	store.AssertNumberOfCalls(t, "DeleteResourceIfMatch", 1)
+
+	if _, err := defaultTestClient(store, badAuth()).DeleteMatching(ctx, map[string]string{"region": "us-west-2"}); err == nil {
+		t.Error("expected an error when listing is not authorized")
+	}
+}
+
+func TestGenericClient_DeleteMatchingGuarded(t *testing.T) {
+	finalized := corev2.FixtureAsset("finalized")
+	finalized.Labels = map[string]string{"region": "us-west-2"}
+	finalized.Finalizers = []string{"example.com/cleanup"}
+	gated := corev2.FixtureAsset("gated")
+	gated.Labels = map[string]string{"region": "us-west-2"}
+	gated.Annotations = map[string]string{corev2.RequiresApprovalAnnotation: "true"}
+	finalizedETag, err := store.ETag(finalized)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &mockstore.MockStore{}
+	s.On("ListResources", mock.Anything, (&corev2.Asset{}).StorePrefix(), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		arg := args.Get(2).(*[]*corev2.Asset)
+		*arg = []*corev2.Asset{finalized, gated}
+	}).Return(nil)
+	var marked *corev2.Asset
+	s.On("CreateOrUpdateResource", mock.MatchedBy(func(ctx context.Context) bool {
+		return store.IfMatchFromContext(ctx) == finalizedETag
+	}), mock.Anything).Run(func(args mock.Arguments) {
+		marked = args.Get(1).(*corev2.Asset)
+	}).Return(nil)
+
+	client := defaultTestClient(s, &mockAuth{attrs: map[authorization.AttributesKey]bool{
+		{APIGroup: "core", APIVersion: "v2", Namespace: "default", Resource: "assets", UserName: "tom", Verb: "list"}:                              true,
+		{APIGroup: "core", APIVersion: "v2", Namespace: "default", Resource: "assets", ResourceName: "finalized", UserName: "tom", Verb: "delete"}: true,
+		{APIGroup: "core", APIVersion: "v2", Namespace: "default", Resource: "assets", ResourceName: "gated", UserName: "tom", Verb: "delete"}:     true,
+	}})
+	ctx := contextWithUser(defaultContext(), "tom", nil)
+	result, err := client.DeleteMatching(ctx, map[string]string{"region": "us-west-2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The finalized asset is marked for deletion rather than deleted
+	if got, want := result.Deleted, 1; got != want {
+		t.Errorf("bad deleted count: got %d, want %d", got, want)
+	}
+	s.AssertNumberOfCalls(t, "CreateOrUpdateResource", 1)
+	if marked == nil || marked.Name != "finalized" || marked.DeletedAt == 0 {
+		t.Errorf("expected the finalized asset to be marked for deletion, got %v", marked)
+	}
+
+	// The deletion of the gated asset requires approval
+	if _, ok := result.Errors["gated"]; !ok || len(result.Errors) != 1 {
+		t.Errorf("expected a single error for asset gated, got %v", result.Errors)
+	}
+	s.AssertNotCalled(t, "DeleteResource", mock.Anything, mock.Anything, mock.Anything)
+	s.AssertNotCalled(t, "DeleteResourceIfMatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -58,7 +58,7 @@ func (e *EventClient) UpdateEvent(ctx context.Context, event *corev2.Event) erro
 		event.Entity.CreatedBy = claims.StandardClaims.Subject
 	}
 	// Update the event through eventd
-	return e.bus.Publish(messaging.TopicEventRaw, event)
+	return messaging.PublishWait(ctx, e.bus, messaging.TopicEventRaw, event)
 }
 
 // FetchEvent gets an event, if authorized.
@@ -177,6 +177,94 @@ func (a *NamespaceClient) ListNamespaces(ctx context.Context, pred *store.Select
 	return namespaces, nil
 }
 
+// countedResource is a type of resource counted by ListNamespacesWithCounts.
+type countedResource struct {
+	// name is the RBAC name of the resource type, which also keys its count
+	name string
+
+	// prefix is the store prefix of the resources
+	prefix string
+}
+
+// namespaceCountedResources are the resource types counted by
+// ListNamespacesWithCounts.
+var namespaceCountedResources = []countedResource{
+	v2CountedResource(&corev2.Asset{}),
+	v2CountedResource(&corev2.CheckConfig{}),
+	v3CountedResource(&corev3.EntityConfig{}),
+	v2CountedResource(&corev2.Event{}),
+	v2CountedResource(&corev2.EventFilter{}),
+	v2CountedResource(&corev2.Handler{}),
+	v2CountedResource(&corev2.HookConfig{}),
+	v2CountedResource(&corev2.Mutator{}),
+	v2CountedResource(&corev2.Pipeline{}),
+	v2CountedResource(&corev2.Role{}),
+	v2CountedResource(&corev2.RoleBinding{}),
+	v2CountedResource(&corev2.Silenced{}),
+}
+
+func v2CountedResource(r corev2.Resource) countedResource {
+	return countedResource{name: r.RBACName(), prefix: r.StorePrefix()}
+}
+
+func v3CountedResource(r corev3.Resource) countedResource {
+	return countedResource{name: r.RBACName(), prefix: r.StoreName()}
+}
+
+// ListNamespacesWithCounts lists the namespaces authorized by the supplied
+// credentials, like ListNamespaces, along with the number of resources of each
+// type they contain. Only the resources of the listed namespaces are counted,
+// and only the types that the credentials are authorized to list in a
+// namespace are reported.
+func (a *NamespaceClient) ListNamespacesWithCounts(ctx context.Context, pred *store.SelectionPredicate) ([]corev2.NamespaceWithCounts, error) {
+	namespaces, err := a.ListNamespaces(ctx, pred)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]corev2.NamespaceWithCounts, 0, len(namespaces))
+	if len(namespaces) == 0 {
+		return result, nil
+	}
+
+	prefixes := make([]string, len(namespaceCountedResources))
+	for i, resource := range namespaceCountedResources {
+		prefixes[i] = resource.prefix
+	}
+	names := make([]string, len(namespaces))
+	for i, namespace := range namespaces {
+		names[i] = namespace.Name
+	}
+	counts, err := a.namespaceStore.CountNamespaceResources(ctx, names, prefixes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, namespace := range namespaces {
+		namespaceCounts := map[string]int64{}
+		for _, resource := range namespaceCountedResources {
+			attrs := &authorization.Attributes{
+				APIGroup:   a.client.APIGroup,
+				APIVersion: a.client.APIVersion,
+				Resource:   resource.name,
+				Namespace:  namespace.Name,
+				Verb:       "list",
+			}
+			if err := authorize(ctx, a.auth, attrs); err == authorization.ErrUnauthorized {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			namespaceCounts[resource.name] = counts[namespace.Name][resource.prefix]
+		}
+		result = append(result, corev2.NamespaceWithCounts{
+			Namespace: namespace,
+			Counts:    namespaceCounts,
+		})
+	}
+
+	return result, nil
+}
+
 // FetchNamespace fetches a namespace resource from the backend, if authorized.
 func (a *NamespaceClient) FetchNamespace(ctx context.Context, name string) (*corev2.Namespace, error) {
 	var namespace corev2.Namespace
@@ -191,6 +279,63 @@ func (a *NamespaceClient) FetchNamespace(ctx context.Context, name string) (*cor
 		return nil, err
 	}
 
+	authorized, err := a.namespaceGetAuthorized(ctx, visitor, name)
+	if err != nil {
+		return nil, err
+	}
+	if !authorized {
+		return nil, authorization.ErrUnauthorized
+	}
+
+	return &namespace, nil
+}
+
+// FetchNamespaces fetches the namespace resources with the given names from
+// the backend, in a single store read, keyed by name. Namespaces that do not
+// exist, or that the credentials are not authorized to get, are omitted, so
+// that the result does not disclose the existence of unauthorized namespaces.
+func (a *NamespaceClient) FetchNamespaces(ctx context.Context, names []string) (map[string]*corev2.Namespace, error) {
+	namespaces, err := a.namespaceStore.GetNamespaces(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*corev2.Namespace, len(namespaces))
+	visitor, ok := a.auth.(ruleVisitor)
+	for _, namespace := range namespaces {
+		var authorized bool
+		if ok {
+			authorized, err = a.namespaceGetAuthorized(ctx, visitor, namespace.Name)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			attrs := &authorization.Attributes{
+				APIGroup:     a.client.APIGroup,
+				APIVersion:   a.client.APIVersion,
+				Resource:     a.client.Kind.RBACName(),
+				Namespace:    corev2.ContextNamespace(ctx),
+				Verb:         "get",
+				ResourceName: namespace.Name,
+			}
+			err = authorize(ctx, a.auth, attrs)
+			if err != nil && err != authorization.ErrUnauthorized {
+				return nil, err
+			}
+			authorized = err == nil
+		}
+		if authorized {
+			result[namespace.Name] = namespace
+		}
+	}
+
+	return result, nil
+}
+
+// namespaceGetAuthorized returns true if the rules visited for the credentials
+// grant access to the namespace with the given name, either explicitly or
+// implicitly through access to the resources it contains.
+func (a *NamespaceClient) namespaceGetAuthorized(ctx context.Context, visitor ruleVisitor, name string) (bool, error) {
 	attrs := &authorization.Attributes{
 		APIGroup:     a.client.APIGroup,
 		APIVersion:   a.client.APIVersion,
@@ -200,7 +345,7 @@ func (a *NamespaceClient) FetchNamespace(ctx context.Context, name string) (*cor
 		Verb:         "get",
 	}
 	if err := addAuthUser(ctx, attrs); err != nil {
-		return nil, err
+		return false, err
 	}
 	logger = logger.WithFields(logrus.Fields{
 		"zz_request": map[string]string{
@@ -271,15 +416,12 @@ func (a *NamespaceClient) FetchNamespace(ctx context.Context, name string) (*cor
 	})
 
 	if funcErr != nil {
-		return nil, fmt.Errorf("error getting namespace: %s", funcErr)
+		return false, fmt.Errorf("error getting namespace: %s", funcErr)
 	}
-
 	if !authorized {
 		logger.Debug("unauthorized request")
-		return nil, authorization.ErrUnauthorized
 	}
-
-	return &namespace, nil
+	return authorized, nil
 }
 
 func (a *NamespaceClient) createRoleAndBinding(ctx context.Context, namespace string) error {
@@ -358,9 +500,16 @@ func (a *NamespaceClient) createResourceTemplates(ctx context.Context, namespace
 	return nil
 }
 
-// UpdateNamespace updates a namespace resource, if authorized.
-func (a *NamespaceClient) UpdateNamespace(ctx context.Context, namespace *corev2.Namespace) error {
-	if err := a.client.Update(ctx, namespace); err != nil {
+// UpdateNamespace updates a namespace resource, if authorized. When
+// conditions are provided, the update is only performed if the stored
+// namespace matches them, otherwise a *store.ErrPreconditionFailed is
+// returned.
+func (a *NamespaceClient) UpdateNamespace(ctx context.Context, namespace *corev2.Namespace, conditions *store.ETagCondition) error {
+	conditionalCtx, err := a.checkNamespaceConditions(ctx, namespace.Name, conditions)
+	if err != nil {
+		return err
+	}
+	if err := a.client.Update(conditionalCtx, namespace); err != nil {
 		return err
 	}
 	if err := a.createResourceTemplates(ctx, namespace.Name); err != nil {
@@ -369,8 +518,42 @@ func (a *NamespaceClient) UpdateNamespace(ctx context.Context, namespace *corev2
 	return a.createRoleAndBinding(ctx, namespace.Name)
 }
 
-// DeleteNamespace deletes a namespace.
-func (a *NamespaceClient) DeleteNamespace(ctx context.Context, name string) error {
+// checkNamespaceConditions verifies that the etag of the stored namespace
+// satisfies the given conditions. A namespace that does not exist yet can
+// never satisfy an If-Match condition. The returned context carries the etag
+// of the namespace that was checked, so that the store only writes the
+// namespace if it wasn't modified after it was checked.
+func (a *NamespaceClient) checkNamespaceConditions(ctx context.Context, name string, conditions *store.ETagCondition) (context.Context, error) {
+	if conditions == nil || (conditions.IfMatch == "" && conditions.IfNoneMatch == "") {
+		return ctx, nil
+	}
+	var stored corev2.Namespace
+	if err := a.client.Get(ctx, name, &stored); err != nil {
+		if _, ok := err.(*store.ErrNotFound); !ok {
+			return ctx, err
+		}
+		if conditions.IfMatch != "" {
+			return ctx, &store.ErrPreconditionFailed{Key: name}
+		}
+		return store.ContextWithIfNoneMatch(ctx, "*"), nil
+	}
+	etag, err := store.ETag(&stored)
+	if err != nil {
+		return ctx, err
+	}
+	if !store.CheckIfMatch(conditions.IfMatch, etag) {
+		return ctx, &store.ErrPreconditionFailed{Key: name}
+	}
+	if !store.CheckIfNoneMatch(conditions.IfNoneMatch, etag) {
+		return ctx, &store.ErrPreconditionFailed{Key: name}
+	}
+	return store.ContextWithIfMatch(ctx, etag), nil
+}
+
+// DeleteNamespace deletes a namespace, if authorized. When conditions are
+// provided, the namespace is only deleted if the stored namespace matches
+// them, otherwise a *store.ErrPreconditionFailed is returned.
+func (a *NamespaceClient) DeleteNamespace(ctx context.Context, name string, conditions *store.ETagCondition) error {
 	// Inject the namespace into the context so we can target the namespaced
 	// resources
 	namespacedCtx := context.WithValue(ctx, corev2.NamespaceKey, name)
@@ -382,6 +565,10 @@ func (a *NamespaceClient) DeleteNamespace(ctx context.Context, name string) erro
 	if err := authorize(ctx, a.auth, attrs); err != nil {
 		return err
 	}
+	conditionalCtx, err := a.checkNamespaceConditions(ctx, name, conditions)
+	if err != nil {
+		return err
+	}
 
 	// We don't use the generic client and store here because there is some
 	// special logic that applies to namespace deletion, namely the fact that we
@@ -392,7 +579,7 @@ func (a *NamespaceClient) DeleteNamespace(ctx context.Context, name string) erro
 	// Since we don't have a good abstraction for these types of custom logic in
 	// the generic client and store yet, we reach straight to the
 	// NamespaceStore, which already has this logic implemented.
-	if err := a.namespaceStore.DeleteNamespace(ctx, name); err != nil {
+	if err := a.namespaceStore.DeleteNamespace(conditionalCtx, name); err != nil {
 		return err
 	}
 
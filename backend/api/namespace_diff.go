@@ -0,0 +1,50 @@
+package api
+
+import (
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// DiffNamespaces computes what it takes for the target namespaces to match the
+// source namespaces, matching them by name. toCreate holds the source
+// namespaces missing from target, toUpdate the source namespaces that differ
+// from their target counterpart, and toDelete the target namespaces missing
+// from source. Namespaces have no metadata besides their name, so only their
+// settings are compared: protobuf bookkeeping, such as unrecognized fields, is
+// ignored. Source namespaces are returned in source order, and target ones in
+// target order.
+func DiffNamespaces(source, target []*corev2.Namespace) (toCreate, toUpdate, toDelete []*corev2.Namespace) {
+	targets := make(map[string]*corev2.Namespace, len(target))
+	for _, namespace := range target {
+		targets[namespace.Name] = namespace
+	}
+	sources := make(map[string]bool, len(source))
+	for _, namespace := range source {
+		sources[namespace.Name] = true
+		existing, ok := targets[namespace.Name]
+		if !ok {
+			toCreate = append(toCreate, namespace)
+			continue
+		}
+		if !namespacesEqual(namespace, existing) {
+			toUpdate = append(toUpdate, namespace)
+		}
+	}
+	for _, namespace := range target {
+		if !sources[namespace.Name] {
+			toDelete = append(toDelete, namespace)
+		}
+	}
+	return toCreate, toUpdate, toDelete
+}
+
+// namespacesEqual returns true if a and b have the same name and settings.
+func namespacesEqual(a, b *corev2.Namespace) bool {
+	x, y := *a, *b
+	x.XXX_unrecognized, y.XXX_unrecognized = nil, nil
+	if x.EventRetention != nil && y.EventRetention != nil {
+		xr, yr := *x.EventRetention, *y.EventRetention
+		xr.XXX_unrecognized, yr.XXX_unrecognized = nil, nil
+		x.EventRetention, y.EventRetention = &xr, &yr
+	}
+	return x.Equal(&y)
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+func TestDiffNamespaces(t *testing.T) {
+	withRetention := func(name string, maxAge int64) *corev2.Namespace {
+		namespace := corev2.FixtureNamespace(name)
+		namespace.EventRetention = &corev2.EventRetentionPolicy{MaxAge: maxAge}
+		return namespace
+	}
+	names := func(namespaces []*corev2.Namespace) []string {
+		var result []string
+		for _, namespace := range namespaces {
+			result = append(result, namespace.Name)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name       string
+		source     []*corev2.Namespace
+		target     []*corev2.Namespace
+		wantCreate []string
+		wantUpdate []string
+		wantDelete []string
+	}{
+		{
+			name: "empty lists",
+		},
+		{
+			name:       "empty target",
+			source:     []*corev2.Namespace{corev2.FixtureNamespace("b"), corev2.FixtureNamespace("a")},
+			wantCreate: []string{"b", "a"},
+		},
+		{
+			name:       "empty source",
+			target:     []*corev2.Namespace{corev2.FixtureNamespace("a"), corev2.FixtureNamespace("b")},
+			wantDelete: []string{"a", "b"},
+		},
+		{
+			name:   "identical lists",
+			source: []*corev2.Namespace{corev2.FixtureNamespace("a"), withRetention("b", 60)},
+			target: []*corev2.Namespace{withRetention("b", 60), corev2.FixtureNamespace("a")},
+		},
+		{
+			name:       "changed retention",
+			source:     []*corev2.Namespace{withRetention("a", 60), withRetention("b", 60), corev2.FixtureNamespace("c")},
+			target:     []*corev2.Namespace{withRetention("a", 120), corev2.FixtureNamespace("b"), withRetention("c", 60)},
+			wantUpdate: []string{"a", "b", "c"},
+		},
+		{
+			name: "unrecognized fields are ignored",
+			source: []*corev2.Namespace{
+				{Name: "a", XXX_unrecognized: []byte{1}},
+				{Name: "b", EventRetention: &corev2.EventRetentionPolicy{MaxAge: 60, XXX_unrecognized: []byte{1}}},
+			},
+			target: []*corev2.Namespace{corev2.FixtureNamespace("a"), withRetention("b", 60)},
+		},
+		{
+			name:       "every bucket",
+			source:     []*corev2.Namespace{corev2.FixtureNamespace("new"), withRetention("changed", 60), corev2.FixtureNamespace("same")},
+			target:     []*corev2.Namespace{corev2.FixtureNamespace("same"), corev2.FixtureNamespace("gone"), corev2.FixtureNamespace("changed")},
+			wantCreate: []string{"new"},
+			wantUpdate: []string{"changed"},
+			wantDelete: []string{"gone"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toCreate, toUpdate, toDelete := DiffNamespaces(tt.source, tt.target)
+			if got := names(toCreate); !reflect.DeepEqual(got, tt.wantCreate) {
+				t.Errorf("got namespaces to create %v, want %v", got, tt.wantCreate)
+			}
+			if got := names(toUpdate); !reflect.DeepEqual(got, tt.wantUpdate) {
+				t.Errorf("got namespaces to update %v, want %v", got, tt.wantUpdate)
+			}
+			if got := names(toDelete); !reflect.DeepEqual(got, tt.wantDelete) {
+				t.Errorf("got namespaces to delete %v, want %v", got, tt.wantDelete)
+			}
+		})
+	}
+
+	// Updates carry the source version of the namespace
+	source := withRetention("a", 60)
+	_, toUpdate, _ := DiffNamespaces([]*corev2.Namespace{source}, []*corev2.Namespace{withRetention("a", 120)})
+	if len(toUpdate) != 1 || toUpdate[0] != source {
+		t.Errorf("expected the source namespace to be updated, got %v", toUpdate)
+	}
+}
@@ -432,6 +432,128 @@ func TestFetchNamespace(t *testing.T) {
 	}
 }
 
+func TestFetchNamespaces(t *testing.T) {
+	clusterRoles := []*corev2.ClusterRole{
+		{
+			ObjectMeta: corev2.NewObjectMeta("cluster-admin", ""),
+			Rules: []corev2.Rule{
+				{
+					Verbs:     []string{corev2.VerbAll},
+					Resources: []string{corev2.ResourceAll},
+				},
+			},
+		},
+	}
+	roleBindings := []*corev2.RoleBinding{
+		{
+			Subjects: []corev2.Subject{
+				{
+					Type: corev2.GroupType,
+					Name: "ops",
+				},
+			},
+			RoleRef: corev2.RoleRef{
+				Type: "ClusterRole",
+				Name: "cluster-admin",
+			},
+			ObjectMeta: corev2.NewObjectMeta("ops", "dev"),
+		},
+	}
+
+	store := new(mockstore.MockStore)
+	store.On("ListClusterRoles", mock.Anything, mock.Anything).Return(clusterRoles, nil)
+	store.On("ListClusterRoleBindings", mock.Anything, mock.Anything).Return([]*corev2.ClusterRoleBinding{}, nil)
+	store.On("ListRoles", mock.Anything, mock.Anything).Return([]*corev2.Role{}, nil)
+	store.On("ListRoleBindings", mock.Anything, mock.Anything).Return(roleBindings, nil)
+	setupGetClusterRoleAndGetRole(store, clusterRoles, nil)
+
+	// The missing namespace is not returned by the store
+	names := []string{"dev", "missing", "prod"}
+	store.On("GetNamespaces", mock.Anything, names).Return([]*corev2.Namespace{
+		corev2.FixtureNamespace("dev"),
+		corev2.FixtureNamespace("prod"),
+	}, nil)
+
+	auth := &rbac.Authorizer{Store: store}
+	client := NewNamespaceClient(store, store, auth, new(mockstore.V2MockStore))
+
+	// The user only has access to the dev namespace
+	ctx := contextWithUser(defaultContext(), "foo", []string{"ops"})
+	got, err := client.FetchNamespaces(ctx, names)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]*corev2.Namespace{"dev": corev2.FixtureNamespace("dev")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NamespaceClient.FetchNamespaces() = %v, want %v", got, want)
+	}
+	store.AssertNumberOfCalls(t, "GetNamespaces", 1)
+}
+
+func TestListNamespacesWithCounts(t *testing.T) {
+	roles := []*corev2.Role{
+		{
+			ObjectMeta: corev2.NewObjectMeta("check-viewer", "dev"),
+			Rules: []corev2.Rule{
+				{
+					Verbs:     []string{"get", "list"},
+					Resources: []string{corev2.ChecksResource},
+				},
+			},
+		},
+	}
+	roleBindings := []*corev2.RoleBinding{
+		{
+			Subjects: []corev2.Subject{
+				{
+					Type: corev2.GroupType,
+					Name: "ops",
+				},
+			},
+			RoleRef: corev2.RoleRef{
+				Type: "Role",
+				Name: "check-viewer",
+			},
+			ObjectMeta: corev2.NewObjectMeta("ops", "dev"),
+		},
+	}
+
+	s := new(mockstore.MockStore)
+	s.On("ListClusterRoles", mock.Anything, mock.Anything).Return([]*corev2.ClusterRole{}, nil)
+	s.On("ListClusterRoleBindings", mock.Anything, mock.Anything).Return([]*corev2.ClusterRoleBinding{}, nil)
+	s.On("ListRoles", mock.Anything, mock.Anything).Return(roles, nil)
+	s.On("ListRoleBindings", mock.Anything, mock.Anything).Return(roleBindings, nil)
+	s.On("ListResources", mock.Anything, corev2.NamespacesResource, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		resources := args[2].(*[]*corev2.Namespace)
+		*resources = append(*resources, corev2.FixtureNamespace("dev"), corev2.FixtureNamespace("prod"))
+	}).Return(nil)
+	s.On("CountNamespaceResources", mock.Anything, []string{"dev"}, mock.Anything).Return(map[string]map[string]int64{
+		"dev":  {"checks": 3, "events": 12},
+		"prod": {"checks": 5},
+	}, nil)
+	setupGetClusterRoleAndGetRole(s, nil, roles)
+
+	auth := &rbac.Authorizer{Store: s}
+	client := NewNamespaceClient(s, s, auth, new(mockstore.V2MockStore))
+
+	// The user can only see the dev namespace, and only list its checks
+	ctx := contextWithUser(defaultContext(), "foo", []string{"ops"})
+	got, err := client.ListNamespacesWithCounts(ctx, &store.SelectionPredicate{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []corev2.NamespaceWithCounts{
+		{
+			Namespace: corev2.FixtureNamespace("dev"),
+			Counts:    map[string]int64{"checks": 3},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NamespaceClient.ListNamespacesWithCounts() = %v, want %v", got, want)
+	}
+	s.AssertNumberOfCalls(t, "CountNamespaceResources", 1)
+}
+
 func TestNamespaceList(t *testing.T) {
 	namespaces := []*corev2.Namespace{
 		corev2.FixtureNamespace("a"),
@@ -957,7 +1079,7 @@ func TestNamespaceCreateSideEffects(t *testing.T) {
 	}).Return(nil)
 	s.On("DeleteNamespace", mock.Anything, namespace.Name).Return(nil)
 
-	if err := client.DeleteNamespace(ctx, namespace.Name); err != nil {
+	if err := client.DeleteNamespace(ctx, namespace.Name, nil); err != nil {
 		t.Fatal(err)
 	}
 
@@ -1038,7 +1160,7 @@ func TestNamespaceUpdateSideEffects(t *testing.T) {
 	client := NewNamespaceClient(s, s, auth, s2)
 
 	namespace := &corev2.Namespace{Name: "test_namespace"}
-	if err := client.UpdateNamespace(ctx, namespace); err != nil {
+	if err := client.UpdateNamespace(ctx, namespace, nil); err != nil {
 		t.Fatal(err)
 	}
 
@@ -1090,7 +1212,7 @@ func TestNamespaceUpdateSideEffects(t *testing.T) {
 	}).Return(nil)
 	s.On("DeleteNamespace", mock.Anything, namespace.Name).Return(nil)
 
-	if err := client.DeleteNamespace(ctx, namespace.Name); err != nil {
+	if err := client.DeleteNamespace(ctx, namespace.Name, nil); err != nil {
 		t.Fatal(err)
 	}
 
@@ -1104,3 +1226,77 @@ func TestNamespaceUpdateSideEffects(t *testing.T) {
 	s2.AssertCalled(t, "List", mock.Anything, mock.Anything)
 	s2.AssertCalled(t, "CreateOrUpdate", mock.Anything, mock.Anything)
 }
+
+func TestNamespaceConditionalWrites(t *testing.T) {
+	clusterRoles := []*corev2.ClusterRole{
+		{
+			ObjectMeta: corev2.NewObjectMeta("cluster-admin", "cluster-admin"),
+			Rules: []corev2.Rule{
+				{
+					Verbs:     []string{corev2.VerbAll},
+					Resources: []string{corev2.ResourceAll},
+				},
+			},
+		},
+	}
+	clusterRoleBindings := []*corev2.ClusterRoleBinding{
+		{
+			Subjects: []corev2.Subject{
+				{
+					Type: corev2.GroupType,
+					Name: "cluster-admins",
+				},
+			},
+			RoleRef: corev2.RoleRef{
+				Type: "ClusterRole",
+				Name: "cluster-admin",
+			},
+			ObjectMeta: corev2.NewObjectMeta("cluster-admin", "cluster-admin"),
+		},
+	}
+	stored := &corev2.Namespace{Name: "test_namespace"}
+	etag, err := store.ETag(stored)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hasIfMatch := func(ctx context.Context) bool {
+		return store.IfMatchFromContext(ctx) == etag
+	}
+
+	s := new(mockstore.MockStore)
+	s.On("ListClusterRoles", mock.Anything, mock.Anything).Return(clusterRoles, nil)
+	s.On("ListClusterRoleBindings", mock.Anything, mock.Anything).Return(clusterRoleBindings, nil)
+	s.On("ListRoles", mock.Anything, mock.Anything).Return(([]*corev2.Role)(nil), nil)
+	s.On("ListRoleBindings", mock.Anything, mock.Anything).Return(([]*corev2.RoleBinding)(nil), nil)
+	s.On("GetResource", mock.Anything, stored.Name, mock.AnythingOfType("*v2.Namespace")).Run(func(args mock.Arguments) {
+		*args.Get(2).(*corev2.Namespace) = *stored
+	}).Return(nil)
+	// Only the namespace itself is written conditionally
+	s.On("CreateOrUpdateResource", mock.MatchedBy(hasIfMatch), mock.AnythingOfType("*v2.Namespace")).Return(nil)
+	s.On("CreateOrUpdateResource", mock.MatchedBy(func(ctx context.Context) bool { return !hasIfMatch(ctx) }), mock.Anything).Return(nil)
+	s.On("DeleteNamespace", mock.MatchedBy(hasIfMatch), stored.Name).Return(nil)
+	s.On("DeleteResource", mock.MatchedBy(func(ctx context.Context) bool { return !hasIfMatch(ctx) }), mock.Anything, pipelineRoleName).Return(nil)
+	setupGetClusterRoleAndGetRole(s, clusterRoles, nil)
+	s2 := new(mockstore.V2MockStore)
+	s2.On("List", mock.Anything, mock.Anything).Return(wrap.List{}, nil)
+
+	ctx := contextWithUser(context.Background(), "cluster-admin", []string{"cluster-admins"})
+	client := NewNamespaceClient(s, s, &rbac.Authorizer{Store: s}, s2)
+
+	conditions := &store.ETagCondition{IfMatch: etag}
+	if err := client.UpdateNamespace(ctx, &corev2.Namespace{Name: stored.Name}, conditions); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.DeleteNamespace(ctx, stored.Name, conditions); err != nil {
+		t.Fatal(err)
+	}
+	s.AssertCalled(t, "CreateOrUpdateResource", mock.MatchedBy(hasIfMatch), mock.AnythingOfType("*v2.Namespace"))
+	s.AssertCalled(t, "DeleteNamespace", mock.MatchedBy(hasIfMatch), stored.Name)
+
+	conditions.IfMatch = `"abc"`
+	if err := client.UpdateNamespace(ctx, &corev2.Namespace{Name: stored.Name}, conditions); err == nil {
+		t.Error("expected non-nil error")
+	} else if _, ok := err.(*store.ErrPreconditionFailed); !ok {
+		t.Errorf("wrong error: %s", err)
+	}
+}
@@ -0,0 +1,188 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/authorization"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+// searchPageSize is the number of resources of a type that are listed at once
+// while searching them, which bounds the memory used by a search.
+const searchPageSize = 100
+
+// searchableResources are the kinds of resources that can be searched, in the
+// order in which they are searched. Entities are listed with the entity
+// store, and the others with the resource store.
+var searchableResources = []corev2.Resource{
+	&corev2.CheckConfig{},
+	&corev2.Entity{},
+	&corev2.Handler{},
+	&corev2.Asset{},
+	&corev2.EventFilter{},
+	&corev2.Mutator{},
+	&corev2.HookConfig{},
+	&corev2.Silenced{},
+	&corev2.Pipeline{},
+}
+
+// SearchTypes returns the types of resources that can be searched, in the
+// order in which they are searched.
+func SearchTypes() []string {
+	types := make([]string, len(searchableResources))
+	for i, kind := range searchableResources {
+		types[i] = kind.RBACName()
+	}
+	return types
+}
+
+// SearchClient is an API client that searches resources by name, across
+// types.
+type SearchClient struct {
+	store       store.ResourceStore
+	entityStore store.EntityStore
+	auth        authorization.Authorizer
+}
+
+// NewSearchClient creates a new SearchClient, given a store and an authorizer.
+func NewSearchClient(store store.Store, auth authorization.Authorizer) *SearchClient {
+	return &SearchClient{store: store, entityStore: store, auth: auth}
+}
+
+// Search returns the resources of the namespace whose name contains the
+// query, regardless of case. Only the given types of resources are searched,
+// or all of the searchable types if none is given.
+//
+// Results are ordered by type, in the order of SearchTypes, and then by name.
+// At most pred.Limit results are returned, in which case pred.Continue is set
+// to the token that resumes the search. The types that are not requested
+// explicitly are skipped if listing them is not authorized.
+func (s *SearchClient) Search(ctx context.Context, query string, types []string, pred *store.SelectionPredicate) ([]corev2.SearchResult, error) {
+	kinds, err := searchKinds(types)
+	if err != nil {
+		return nil, err
+	}
+
+	var resumeType, resumeName string
+	if pred.Continue != "" {
+		parts := strings.SplitN(pred.Continue, "/", 2)
+		if len(parts) != 2 {
+			return nil, &store.ErrNotValid{Err: fmt.Errorf("invalid continue token %q", pred.Continue)}
+		}
+		resumeType, resumeName = parts[0], parts[1]
+	}
+	pred.Continue = ""
+
+	query = strings.ToLower(query)
+	results := []corev2.SearchResult{}
+	for _, kind := range kinds {
+		rbacName := kind.RBACName()
+		if resumeType != "" {
+			if rbacName != resumeType {
+				continue
+			}
+			resumeType = ""
+		} else {
+			resumeName = ""
+		}
+
+		attrs := &authorization.Attributes{
+			APIGroup:   "core",
+			APIVersion: "v2",
+			Resource:   rbacName,
+			Namespace:  corev2.ContextNamespace(ctx),
+			Verb:       "list",
+		}
+		if err := authorize(ctx, s.auth, attrs); err != nil {
+			if err == authorization.ErrUnauthorized && len(types) == 0 {
+				continue
+			}
+			return nil, err
+		}
+
+		listPred := &store.SelectionPredicate{Limit: searchPageSize}
+		for {
+			names, err := s.listNames(ctx, kind, listPred)
+			if err != nil {
+				return nil, err
+			}
+			for _, name := range names {
+				if name <= resumeName || !strings.Contains(strings.ToLower(name), query) {
+					continue
+				}
+				if pred.Limit > 0 && int64(len(results)) == pred.Limit {
+					last := results[len(results)-1]
+					pred.Continue = last.Type + "/" + last.Name
+					return results, nil
+				}
+				results = append(results, corev2.SearchResult{
+					Type:      rbacName,
+					Name:      name,
+					Namespace: corev2.ContextNamespace(ctx),
+				})
+			}
+			if listPred.Continue == "" {
+				break
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// listNames returns the names of a page of resources of the given kind, in
+// the namespace of the context.
+func (s *SearchClient) listNames(ctx context.Context, kind corev2.Resource, pred *store.SelectionPredicate) ([]string, error) {
+	var names []string
+	if _, ok := kind.(*corev2.Entity); ok {
+		entities, err := s.entityStore.GetEntities(ctx, pred)
+		if err != nil {
+			return nil, err
+		}
+		for _, entity := range entities {
+			names = append(names, entity.Name)
+		}
+		return names, nil
+	}
+
+	// The store lists resources into a slice of their concrete type
+	ptr := reflect.New(reflect.SliceOf(reflect.TypeOf(kind)))
+	if err := s.store.ListResources(ctx, kind.StorePrefix(), ptr.Interface(), pred); err != nil {
+		return nil, err
+	}
+	for i := 0; i < ptr.Elem().Len(); i++ {
+		if value, ok := ptr.Elem().Index(i).Interface().(corev2.Resource); ok {
+			names = append(names, value.GetObjectMeta().Name)
+		}
+	}
+	return names, nil
+}
+
+// searchKinds returns the searchable kinds of resources of the given types,
+// or all of them if no type is given.
+func searchKinds(types []string) ([]corev2.Resource, error) {
+	if len(types) == 0 {
+		return searchableResources, nil
+	}
+	requested := make(map[string]bool, len(types))
+	for _, t := range types {
+		requested[t] = true
+	}
+	var kinds []corev2.Resource
+	for _, kind := range searchableResources {
+		if requested[kind.RBACName()] {
+			kinds = append(kinds, kind)
+			delete(requested, kind.RBACName())
+		}
+	}
+	for _, t := range types {
+		if requested[t] {
+			return nil, &store.ErrNotValid{Err: fmt.Errorf("resources of type %q cannot be searched", t)}
+		}
+	}
+	return kinds, nil
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/authorization"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSearchClient_Search(t *testing.T) {
+	st := &mockstore.MockStore{}
+	st.On("ListResources", mock.Anything, (&corev2.CheckConfig{}).StorePrefix(), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		arg := args.Get(2).(*[]*corev2.CheckConfig)
+		*arg = []*corev2.CheckConfig{
+			corev2.FixtureCheckConfig("check-cpu"),
+			corev2.FixtureCheckConfig("check-disk"),
+			corev2.FixtureCheckConfig("system-CPU"),
+		}
+	}).Return(nil)
+	st.On("GetEntities", mock.Anything, mock.Anything).Return([]*corev2.Entity{
+		corev2.FixtureEntity("cpu-host"),
+		corev2.FixtureEntity("web"),
+	}, nil)
+
+	// tom may only list checks and entities
+	attrs := map[authorization.AttributesKey]bool{}
+	for _, resource := range SearchTypes() {
+		attrs[authorization.AttributesKey{
+			APIGroup:   "core",
+			APIVersion: "v2",
+			Namespace:  "default",
+			Resource:   resource,
+			UserName:   "tom",
+			Verb:       "list",
+		}] = resource == "checks" || resource == "entities"
+	}
+	client := NewSearchClient(st, &mockAuth{attrs: attrs})
+	ctx := contextWithUser(defaultContext(), "tom", nil)
+
+	result := func(resourceType, name string) corev2.SearchResult {
+		return corev2.SearchResult{Type: resourceType, Name: name, Namespace: "default"}
+	}
+
+	tests := []struct {
+		name         string
+		types        []string
+		pred         *store.SelectionPredicate
+		want         []corev2.SearchResult
+		wantContinue string
+		wantErr      bool
+	}{
+		{
+			name: "all authorized types",
+			pred: &store.SelectionPredicate{},
+			want: []corev2.SearchResult{
+				result("checks", "check-cpu"),
+				result("checks", "system-CPU"),
+				result("entities", "cpu-host"),
+			},
+		},
+		{
+			name:  "requested types",
+			types: []string{"entities"},
+			pred:  &store.SelectionPredicate{},
+			want:  []corev2.SearchResult{result("entities", "cpu-host")},
+		},
+		{
+			name:         "first page",
+			pred:         &store.SelectionPredicate{Limit: 2},
+			want:         []corev2.SearchResult{result("checks", "check-cpu"), result("checks", "system-CPU")},
+			wantContinue: "checks/system-CPU",
+		},
+		{
+			name: "last page",
+			pred: &store.SelectionPredicate{Limit: 2, Continue: "checks/system-CPU"},
+			want: []corev2.SearchResult{result("entities", "cpu-host")},
+		},
+		{
+			name:    "invalid continue token",
+			pred:    &store.SelectionPredicate{Continue: "checks"},
+			wantErr: true,
+		},
+		{
+			name:    "unauthorized requested type",
+			types:   []string{"handlers"},
+			pred:    &store.SelectionPredicate{},
+			wantErr: true,
+		},
+		{
+			name:    "unsearchable type",
+			types:   []string{"events"},
+			pred:    &store.SelectionPredicate{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.Search(ctx, "cpu", tt.types, tt.pred)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Search() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search() = %v, want %v", got, tt.want)
+			}
+			if tt.pred.Continue != tt.wantContinue {
+				t.Errorf("bad continue token: got %q, want %q", tt.pred.Continue, tt.wantContinue)
+			}
+		})
+	}
+}
@@ -75,6 +75,9 @@ type Error struct {
 	// Message is a developer / operator friendly message briefly describing what
 	// occurred.
 	Message string
+	// Retryable indicates that the action may succeed if it is attempted again,
+	// e.g. with a fresh ETag after a concurrent modification.
+	Retryable bool
 }
 
 // Error method implements error interface
@@ -87,6 +90,12 @@ func NewError(code ErrCode, err error) Error {
 	return Error{Code: code, Message: err.Error()}
 }
 
+// NewRetryableError returns a new Error given existing error and code, that
+// signals the action may be attempted again.
+func NewRetryableError(code ErrCode, err error) Error {
+	return Error{Code: code, Message: err.Error(), Retryable: true}
+}
+
 // NewErrorf returns a new Error given message and code.
 func NewErrorf(code ErrCode, s ...interface{}) Error {
 	var f string
@@ -89,7 +89,7 @@ func (a EventController) Delete(ctx context.Context, entity, check string) error
 	if result.HasCheck() && result.Check.Ttl > 0 {
 		// Disable check TTL for this event, and inform eventd
 		result.Check.Ttl = deletedEventSentinel
-		if err := a.bus.Publish(messaging.TopicEventRaw, result); err != nil {
+		if err := messaging.PublishWait(ctx, a.bus, messaging.TopicEventRaw, result); err != nil {
 			return NewError(InternalErr, err)
 		}
 	}
@@ -135,7 +135,7 @@ func (a EventController) CreateOrReplace(ctx context.Context, event *corev2.Even
 	}
 
 	// Publish to event pipeline
-	if err := a.bus.Publish(messaging.TopicEventRaw, event); err != nil {
+	if err := messaging.PublishWait(ctx, a.bus, messaging.TopicEventRaw, event); err != nil {
 		return NewError(InternalErr, err)
 	}
 
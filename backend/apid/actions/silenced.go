@@ -3,6 +3,9 @@ package actions
 import (
 	"context"
 	"errors"
+	"fmt"
+	"path"
+	"time"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/authentication/jwt"
@@ -46,7 +49,7 @@ func (c SilencedController) Create(ctx context.Context, entry *corev2.Silenced)
 	entry.Prepare(ctx)
 
 	// Validate the silenced entry
-	if err := entry.Validate(); err != nil {
+	if err := validateSilenced(entry); err != nil {
 		return NewError(InvalidArgument, err)
 	}
 
@@ -69,13 +72,26 @@ func (c SilencedController) Create(ctx context.Context, entry *corev2.Silenced)
 	return nil
 }
 
+// validateSilenced validates a prepared silenced entry, and rejects entries
+// that already expired, which would silence nothing. Entries without an
+// expiration time never expire, and are valid.
+func validateSilenced(entry *corev2.Silenced) error {
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+	if entry.ExpireAt > 0 && entry.ExpireAt <= time.Now().Unix() {
+		return fmt.Errorf("expire_at %s is in the past", time.Unix(entry.ExpireAt, 0).UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
 // CreateOrReplace creates or replaces a silenced entry.
 func (c SilencedController) CreateOrReplace(ctx context.Context, entry *corev2.Silenced) error {
 	// Prepare the silenced entry for storage
 	entry.Prepare(ctx)
 
 	// Validate the silenced entry
-	if err := entry.Validate(); err != nil {
+	if err := validateSilenced(entry); err != nil {
 		return NewError(InvalidArgument, err)
 	}
 
@@ -91,6 +107,69 @@ func (c SilencedController) CreateOrReplace(ctx context.Context, entry *corev2.S
 	return nil
 }
 
+// CreateBatch creates the given silenced entries, and reports for each of
+// them the entries it overlaps with, among the existing entries and the other
+// entries of the batch. Overlapping entries are created anyway, unless strict
+// is true. A failure to create one entry does not prevent the creation of the
+// others.
+func (c SilencedController) CreateBatch(ctx context.Context, entries []*corev2.Silenced, strict bool) ([]corev2.SilencedBatchResult, error) {
+	existing, err := c.Store.GetSilencedEntries(ctx)
+	if err != nil {
+		return nil, NewError(InternalErr, err)
+	}
+
+	var creator string
+	if claims := jwt.GetClaimsFromContext(ctx); claims != nil {
+		creator = claims.StandardClaims.Subject
+	}
+
+	results := make([]corev2.SilencedBatchResult, len(entries))
+	valid := make([]*corev2.Silenced, 0, len(entries))
+	for i, entry := range entries {
+		entry.Prepare(ctx)
+		results[i].Name = entry.Name
+		if err := validateSilenced(entry); err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		if creator != "" {
+			entry.CreatedBy = creator
+		}
+		valid = append(valid, entry)
+	}
+
+	candidates := append(existing, valid...)
+	for i, entry := range entries {
+		if results[i].Error != "" {
+			continue
+		}
+
+		for _, overlap := range corev2.OverlappingSilences(entry, candidates) {
+			results[i].Overlaps = append(results[i].Overlaps, overlap.Name)
+		}
+		if strict && len(results[i].Overlaps) > 0 {
+			results[i].Error = "the silenced entry overlaps with other entries"
+			continue
+		}
+
+		if e, err := c.Store.GetSilencedEntryByName(ctx, entry.Name); err != nil {
+			results[i].Error = err.Error()
+			continue
+		} else if e != nil {
+			results[i].Error = "the silenced entry already exists"
+			continue
+		}
+
+		if err := c.Store.UpdateSilencedEntry(ctx, entry); err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		results[i].Created = true
+	}
+
+	return results, nil
+}
+
 func (c SilencedController) Get(ctx context.Context, name string) (*corev2.Silenced, error) {
 	entry, err := c.Store.GetSilencedEntryByName(ctx, name)
 	if err != nil {
@@ -101,3 +180,180 @@ func (c SilencedController) Get(ctx context.Context, name string) (*corev2.Silen
 	}
 	return entry, nil
 }
+
+// DeleteMatching deletes the silenced entries that silence events of the
+// given subscription and check, and returns how many were deleted. An empty
+// subscription or check matches every entry, and entries are otherwise
+// matched like events are, so that wildcard entries are deleted along with
+// exact ones. At least one of subscription and check must be given.
+func (c SilencedController) DeleteMatching(ctx context.Context, sub, check string) (int, error) {
+	if sub == "" && check == "" {
+		return 0, NewErrorf(InvalidArgument, "a subscription or a check is required")
+	}
+
+	entries, err := c.Store.GetSilencedEntries(ctx)
+	if err != nil {
+		return 0, NewError(InternalErr, err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		entrySub, entryCheck := sub, check
+		if entrySub == "" {
+			entrySub = entry.Subscription
+		}
+		if entryCheck == "" {
+			entryCheck = entry.Check
+		}
+		if entry.Matches(entryCheck, entrySub) {
+			names = append(names, entry.Name)
+		}
+	}
+	if len(names) == 0 {
+		return 0, nil
+	}
+
+	if err := c.Store.DeleteSilencedEntryByName(ctx, names...); err != nil {
+		return 0, NewError(InternalErr, err)
+	}
+	return len(names), nil
+}
+
+const (
+	// SilencedCreated is the action of a silenced entry that was created.
+	SilencedCreated = "created"
+	// SilencedUpdated is the action of a silenced entry that was updated.
+	SilencedUpdated = "updated"
+	// SilencedDeleted is the action of a silenced entry that was deleted.
+	SilencedDeleted = "deleted"
+	// SilencedExpired is the action of a silenced entry that reached its
+	// expiration time.
+	SilencedExpired = "expired"
+)
+
+// SilencedWatchEvent is a notification that a silenced entry has changed.
+type SilencedWatchEvent struct {
+	Action   string           `json:"action"`
+	Silenced *corev2.Silenced `json:"silenced"`
+}
+
+// Watch returns a channel that emits an event every time a silenced entry
+// within the ctx's namespace is created, updated or deleted. An expired event
+// is also emitted when an entry reaches its expiration time, even though the
+// store is not modified at that moment. The channel is closed when the ctx is
+// cancelled or the store watcher terminates.
+func (c SilencedController) Watch(ctx context.Context) (<-chan SilencedWatchEvent, error) {
+	// Start watching before listing the existing entries, so that no change
+	// can be missed in between
+	watcher := c.Store.GetSilencedWatcher(ctx)
+	entries, err := c.Store.GetSilencedEntries(ctx)
+	if err != nil {
+		return nil, NewError(InternalErr, err)
+	}
+
+	ch := make(chan SilencedWatchEvent, 1)
+	go watchSilenced(ctx, watcher, entries, ch)
+
+	return ch, nil
+}
+
+// silencedExpiry tracks the expiration time of a silenced entry.
+type silencedExpiry struct {
+	entry *corev2.Silenced
+	timer *time.Timer
+}
+
+func watchSilenced(ctx context.Context, watcher <-chan store.WatchEventSilenced, entries []*corev2.Silenced, ch chan<- SilencedWatchEvent) {
+	defer close(ch)
+
+	expiries := make(map[string]silencedExpiry)
+	expiredCh := make(chan *corev2.Silenced)
+	// expired contains the entries that were reported as expired, and that
+	// are yet to be deleted from the store
+	expired := make(map[string]struct{})
+
+	defer func() {
+		for _, expiry := range expiries {
+			expiry.timer.Stop()
+		}
+	}()
+
+	unschedule := func(key string) {
+		if expiry, ok := expiries[key]; ok {
+			expiry.timer.Stop()
+			delete(expiries, key)
+		}
+	}
+	schedule := func(entry *corev2.Silenced) {
+		key := path.Join(entry.Namespace, entry.Name)
+		unschedule(key)
+		delete(expired, key)
+		if entry.ExpireAt <= 0 {
+			return
+		}
+		timer := time.AfterFunc(time.Until(time.Unix(entry.ExpireAt, 0)), func() {
+			select {
+			case expiredCh <- entry:
+			case <-ctx.Done():
+			}
+		})
+		expiries[key] = silencedExpiry{entry: entry, timer: timer}
+	}
+	send := func(action string, entry *corev2.Silenced) bool {
+		select {
+		case ch <- SilencedWatchEvent{Action: action, Silenced: entry}:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	for _, entry := range entries {
+		schedule(entry)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-watcher:
+			if !ok {
+				return
+			}
+			var action string
+			switch event.Action {
+			case store.WatchCreate:
+				schedule(event.Silenced)
+				action = SilencedCreated
+			case store.WatchUpdate:
+				schedule(event.Silenced)
+				action = SilencedUpdated
+			case store.WatchDelete:
+				key := path.Join(event.Silenced.Namespace, event.Silenced.Name)
+				unschedule(key)
+				if _, ok := expired[key]; ok {
+					// The deletion of an expired entry was already reported
+					delete(expired, key)
+					continue
+				}
+				action = SilencedDeleted
+			default:
+				continue
+			}
+			if !send(action, event.Silenced) {
+				return
+			}
+		case entry := <-expiredCh:
+			key := path.Join(entry.Namespace, entry.Name)
+			// Ignore timers that fired after the entry was rescheduled
+			if expiry, ok := expiries[key]; !ok || expiry.entry != entry {
+				continue
+			}
+			delete(expiries, key)
+			expired[key] = struct{}{}
+			if !send(SilencedExpired, entry) {
+				return
+			}
+		}
+	}
+}
@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	jwt "github.com/golang-jwt/jwt/v4"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	coreJWT "github.com/sensu/sensu-go/backend/authentication/jwt"
+	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/testing/mockstore"
 	"github.com/sensu/sensu-go/types"
 	"github.com/stretchr/testify/assert"
@@ -121,6 +123,9 @@ func TestSilencedCreateOrReplace(t *testing.T) {
 	badSilence := types.FixtureSilenced("*:silence1")
 	badSilence.Check = "!@#!#$@#^$%&$%&$&$%&%^*%&(%@###"
 
+	expiredSilence := types.FixtureSilenced("*:silence1")
+	expiredSilence.ExpireAt = time.Now().Add(-time.Minute).Unix()
+
 	testCases := []struct {
 		name            string
 		ctx             context.Context
@@ -152,6 +157,13 @@ func TestSilencedCreateOrReplace(t *testing.T) {
 			expectedErr:     true,
 			expectedErrCode: InternalErr,
 		},
+		{
+			name:            "Expired",
+			ctx:             defaultCtx,
+			argument:        expiredSilence,
+			expectedErr:     true,
+			expectedErrCode: InvalidArgument,
+		},
 		{
 			name:            "Validation Error",
 			ctx:             defaultCtx,
@@ -218,6 +230,11 @@ func TestSilencedCreate(t *testing.T) {
 	badSilence := types.FixtureSilenced("*:silence1")
 	badSilence.Check = "!@#!#$@#^$%&$%&$&$%&%^*%&(%@###"
 
+	expiredSilence := types.FixtureSilenced("*:silence1")
+	expiredSilence.ExpireAt = time.Now().Add(-time.Minute).Unix()
+	futureSilence := types.FixtureSilenced("*:silence1")
+	futureSilence.ExpireAt = time.Now().Add(time.Hour).Unix()
+
 	testCases := []struct {
 		name            string
 		ctx             context.Context
@@ -264,6 +281,21 @@ func TestSilencedCreate(t *testing.T) {
 			expectedErrCode: InternalErr,
 			expectedID:      "*:silence1",
 		},
+		{
+			name:        "Expires Later",
+			ctx:         defaultCtx,
+			argument:    futureSilence,
+			expectedErr: false,
+			expectedID:  "*:silence1",
+		},
+		{
+			name:            "Expired",
+			ctx:             defaultCtx,
+			argument:        expiredSilence,
+			expectedErr:     true,
+			expectedErrCode: InvalidArgument,
+			expectedID:      "*:silence1",
+		},
 		{
 			name:            "Validation Error",
 			ctx:             defaultCtx,
@@ -354,3 +386,215 @@ func TestSilencedCreatedBy(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "admin", silenced.CreatedBy)
 }
+
+func TestSilencedWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	expiring := types.FixtureSilenced("linux:expiring")
+	expiring.ExpireAt = time.Now().Add(-time.Second).Unix()
+	other := types.FixtureSilenced("linux:other")
+
+	watcher := make(chan store.WatchEventSilenced)
+	s := &mockstore.MockStore{}
+	s.On("GetSilencedWatcher", mock.Anything).Return((<-chan store.WatchEventSilenced)(watcher))
+	s.On("GetSilencedEntries", mock.Anything).Return([]*types.Silenced{expiring}, nil)
+
+	events, err := NewSilencedController(s).Watch(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	next := func() SilencedWatchEvent {
+		t.Helper()
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a silenced event")
+		}
+		return SilencedWatchEvent{}
+	}
+
+	// The expiration of an existing entry is reported without a store write
+	event := next()
+	assert.Equal(t, SilencedExpired, event.Action)
+	assert.Equal(t, expiring.Name, event.Silenced.Name)
+
+	watcher <- store.WatchEventSilenced{Action: store.WatchCreate, Silenced: other}
+	event = next()
+	assert.Equal(t, SilencedCreated, event.Action)
+	assert.Equal(t, other.Name, event.Silenced.Name)
+
+	// The deletion of the expired entry is not reported a second time
+	watcher <- store.WatchEventSilenced{Action: store.WatchDelete, Silenced: expiring}
+	watcher <- store.WatchEventSilenced{Action: store.WatchDelete, Silenced: other}
+	event = next()
+	assert.Equal(t, SilencedDeleted, event.Action)
+	assert.Equal(t, other.Name, event.Silenced.Name)
+
+	close(watcher)
+	if _, ok := <-events; ok {
+		t.Error("expected the events channel to be closed")
+	}
+}
+
+func TestSilencedWatchError(t *testing.T) {
+	s := &mockstore.MockStore{}
+	s.On("GetSilencedWatcher", mock.Anything).Return((<-chan store.WatchEventSilenced)(make(chan store.WatchEventSilenced)))
+	s.On("GetSilencedEntries", mock.Anything).Return([]*types.Silenced(nil), errors.New("error"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err := NewSilencedController(s).Watch(ctx)
+	assert.Error(t, err)
+}
+
+func TestSilencedCreateBatch(t *testing.T) {
+	existing := types.FixtureSilenced("linux:*")
+	existing.Check = ""
+
+	fixtures := func() []*types.Silenced {
+		return []*types.Silenced{
+			types.FixtureSilenced("linux:check-cpu"),
+			types.FixtureSilenced("windows:check-cpu"),
+			types.FixtureSilenced("windows:*"),
+			types.FixtureSilenced("mac:check-cpu"),
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		strict         bool
+		expectedResult []corev2.SilencedBatchResult
+	}{
+		{
+			name: "overlaps are reported",
+			expectedResult: []corev2.SilencedBatchResult{
+				{Name: "linux:check-cpu", Created: true, Overlaps: []string{"linux:*"}},
+				{Name: "windows:check-cpu", Created: true, Overlaps: []string{"windows:*"}},
+				{Name: "windows:*", Created: true, Overlaps: []string{"windows:check-cpu"}},
+				{Name: "mac:check-cpu", Created: true},
+			},
+		},
+		{
+			name:   "overlaps are rejected in strict mode",
+			strict: true,
+			expectedResult: []corev2.SilencedBatchResult{
+				{Name: "linux:check-cpu", Overlaps: []string{"linux:*"}, Error: "the silenced entry overlaps with other entries"},
+				{Name: "windows:check-cpu", Overlaps: []string{"windows:*"}, Error: "the silenced entry overlaps with other entries"},
+				{Name: "windows:*", Overlaps: []string{"windows:check-cpu"}, Error: "the silenced entry overlaps with other entries"},
+				{Name: "mac:check-cpu", Created: true},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := &mockstore.MockStore{}
+			store.On("GetSilencedEntries", mock.Anything).Return([]*types.Silenced{existing}, nil)
+			store.On("GetSilencedEntryByName", mock.Anything, mock.Anything).Return((*types.Silenced)(nil), nil)
+			store.On("UpdateSilencedEntry", mock.Anything, mock.Anything).Return(nil)
+
+			results, err := NewSilencedController(store).CreateBatch(context.Background(), fixtures(), tc.strict)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.expectedResult, results)
+		})
+	}
+}
+
+func TestSilencedCreateBatchInvalidAndExisting(t *testing.T) {
+	invalid := types.FixtureSilenced("*:silence1")
+	invalid.Check = "!@#"
+	duplicate := types.FixtureSilenced("*:silence2")
+
+	store := &mockstore.MockStore{}
+	store.On("GetSilencedEntries", mock.Anything).Return([]*types.Silenced{}, nil)
+	store.On("GetSilencedEntryByName", mock.Anything, "*:silence2").Return(types.FixtureSilenced("*:silence2"), nil)
+	store.On("UpdateSilencedEntry", mock.Anything, mock.Anything).Return(errors.New("error"))
+
+	results, err := NewSilencedController(store).CreateBatch(context.Background(), []*types.Silenced{invalid, duplicate}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, results, 2) {
+		assert.False(t, results[0].Created)
+		assert.NotEmpty(t, results[0].Error)
+		assert.False(t, results[1].Created)
+		assert.Equal(t, "the silenced entry already exists", results[1].Error)
+	}
+	store.AssertNotCalled(t, "UpdateSilencedEntry", mock.Anything, mock.Anything)
+}
+
+func TestSilencedDeleteMatching(t *testing.T) {
+	entries := []*types.Silenced{
+		types.FixtureSilenced("app:check-cpu"),
+		types.FixtureSilenced("app:check-mem"),
+		types.FixtureSilenced("db:check-cpu"),
+		types.FixtureSilenced("*:check-cpu"),
+	}
+
+	testCases := []struct {
+		name          string
+		sub           string
+		check         string
+		expectedNames []string
+	}{
+		{
+			name:          "by subscription",
+			sub:           "app",
+			expectedNames: []string{"app:check-cpu", "app:check-mem", "*:check-cpu"},
+		},
+		{
+			name:          "by check",
+			check:         "check-cpu",
+			expectedNames: []string{"app:check-cpu", "db:check-cpu", "*:check-cpu"},
+		},
+		{
+			name:          "by subscription and check",
+			sub:           "db",
+			check:         "check-cpu",
+			expectedNames: []string{"db:check-cpu", "*:check-cpu"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := &mockstore.MockStore{}
+			store.On("GetSilencedEntries", mock.Anything).Return(entries, nil)
+			store.On("DeleteSilencedEntryByName", mock.Anything, tc.expectedNames).Return(nil)
+
+			deleted, err := NewSilencedController(store).DeleteMatching(context.Background(), tc.sub, tc.check)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, len(tc.expectedNames), deleted)
+			store.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSilencedDeleteMatchingErrors(t *testing.T) {
+	store := &mockstore.MockStore{}
+	controller := NewSilencedController(store)
+
+	_, err := controller.DeleteMatching(context.Background(), "", "")
+	if assert.IsType(t, Error{}, err) {
+		assert.Equal(t, InvalidArgument, err.(Error).Code)
+	}
+
+	store.On("GetSilencedEntries", mock.Anything).Return([]*types.Silenced{}, nil).Once()
+	deleted, err := controller.DeleteMatching(context.Background(), "app", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+	store.AssertNotCalled(t, "DeleteSilencedEntryByName", mock.Anything, mock.Anything)
+
+	store.On("GetSilencedEntries", mock.Anything).Return(([]*types.Silenced)(nil), errors.New("error")).Once()
+	_, err = controller.DeleteMatching(context.Background(), "app", "")
+	if assert.IsType(t, Error{}, err) {
+		assert.Equal(t, InternalErr, err.(Error).Code)
+	}
+}
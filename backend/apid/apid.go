@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	clientv3 "go.etcd.io/etcd/client/v3"
 
@@ -59,6 +60,7 @@ type Config struct {
 	ListenAddress       string
 	RequestLimit        int64
 	WriteTimeout        time.Duration
+	MaxRequestTimeout   time.Duration
 	URL                 string
 	Bus                 messaging.MessageBus
 	Store               store.Store
@@ -72,6 +74,10 @@ type Config struct {
 	ClusterVersion      string
 	GraphQLService      *graphql.Service
 	HealthRouter        *routers.HealthRouter
+	HandlerTester       routers.HandlerTester
+	HandlerStatuses     routers.HandlerStatusGetter
+	LargeEncodes        routers.LargeEncodesLister
+	Purger              routers.Purger
 }
 
 // New creates a new APId.
@@ -168,6 +174,8 @@ func CoreSubrouter(router *mux.Router, cfg Config) *mux.Router {
 		middlewares.Authorization{Authorizer: &rbac.Authorizer{Store: cfg.Store}},
 		middlewares.LimitRequest{Limit: cfg.RequestLimit},
 		middlewares.Pagination{},
+		middlewares.RequestTimeout{Max: cfg.MaxRequestTimeout},
+		middlewares.ResourceChanges{Bus: cfg.Bus},
 	)
 	mountRouters(
 		subrouter,
@@ -175,19 +183,26 @@ func CoreSubrouter(router *mux.Router, cfg Config) *mux.Router {
 		routers.NewAPIKeysRouter(cfg.Store),
 		routers.NewChecksRouter(cfg.Store, cfg.QueueGetter),
 		routers.NewClusterRolesRouter(cfg.Store),
+		routers.NewDeleteMatchingRouter(cfg.Store, &rbac.Authorizer{Store: cfg.Store}),
 		routers.NewClusterRoleBindingsRouter(cfg.Store),
 		routers.NewClusterRouter(actions.NewClusterController(cfg.Cluster, cfg.Store)),
 		routers.NewEventFiltersRouter(cfg.Store),
-		routers.NewHandlersRouter(cfg.Store),
+		routers.NewHandlersRouter(cfg.Store, cfg.HandlerTester, cfg.HandlerStatuses),
 		routers.NewHooksRouter(cfg.Store),
+		routers.NewLargeEncodesRouter(cfg.LargeEncodes),
+		routers.NewMaintenanceWindowsRouter(cfg.Store),
 		routers.NewMutatorsRouter(cfg.Store),
-		routers.NewNamespacesRouter(cfg.Store, cfg.Store, &rbac.Authorizer{Store: cfg.Store}, cfg.Storev2),
+		routers.NewNamespacesRouter(cfg.Store, cfg.Store, cfg.Store, &rbac.Authorizer{Store: cfg.Store}, cfg.Storev2),
 		routers.NewPipelinesRouter(cfg.Store),
+		routers.NewPurgeRouter(cfg.Purger),
 		routers.NewRolesRouter(cfg.Store),
 		routers.NewRoleBindingsRouter(cfg.Store),
 		routers.NewSilencedRouter(cfg.Store),
+		routers.NewSearchRouter(cfg.Store, &rbac.Authorizer{Store: cfg.Store}),
+		routers.NewSecurityRouter(cfg.TLS, cfg.EtcdClientTLSConfig),
 		routers.NewTessenRouter(actions.NewTessenController(cfg.Store, cfg.Bus)),
 		routers.NewUsersRouter(cfg.Store),
+		routers.NewWrappersRouter(cfg.Storev2),
 	)
 
 	return subrouter
@@ -205,6 +220,7 @@ func EntityLimitedCoreSubrouter(router *mux.Router, cfg Config) *mux.Router {
 		middlewares.Authorization{Authorizer: &rbac.Authorizer{Store: cfg.Store}},
 		middlewares.LimitRequest{Limit: cfg.RequestLimit},
 		middlewares.Pagination{},
+		middlewares.ResourceChanges{Bus: cfg.Bus},
 	)
 	mountRouters(
 		subrouter,
@@ -266,7 +282,11 @@ func PublicSubrouter(router *mux.Router, cfg Config) *mux.Router {
 		routers.NewTessenMetricRouter(actions.NewTessenMetricController(cfg.Bus)),
 	)
 
-	subrouter.Handle("/metrics", promhttp.Handler())
+	// OpenMetrics is negotiated, so that scrapers asking for it get exemplars
+	subrouter.Handle("/metrics", promhttp.InstrumentMetricHandler(
+		prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{EnableOpenMetrics: true}),
+	))
 
 	return subrouter
 }
@@ -79,7 +79,7 @@ type NamespaceClient interface {
 	ListNamespaces(ctx context.Context, pred *store.SelectionPredicate) ([]*corev2.Namespace, error)
 	FetchNamespace(ctx context.Context, name string) (*corev2.Namespace, error)
 	CreateNamespace(ctx context.Context, namespace *corev2.Namespace) error
-	UpdateNamespace(ctx context.Context, namespace *corev2.Namespace) error
+	UpdateNamespace(ctx context.Context, namespace *corev2.Namespace, conditions *store.ETagCondition) error
 }
 
 type HookClient interface {
@@ -274,8 +274,8 @@ func (c *MockNamespaceClient) CreateNamespace(ctx context.Context, namespace *co
 	return c.Called(ctx, namespace).Error(0)
 }
 
-func (c *MockNamespaceClient) UpdateNamespace(ctx context.Context, namespace *corev2.Namespace) error {
-	return c.Called(ctx, namespace).Error(0)
+func (c *MockNamespaceClient) UpdateNamespace(ctx context.Context, namespace *corev2.Namespace, conditions *store.ETagCondition) error {
+	return c.Called(ctx, namespace, conditions).Error(0)
 }
 
 type MockUserClient struct {
@@ -0,0 +1,397 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/authentication/jwt"
+	"github.com/sensu/sensu-go/backend/authorization"
+	"github.com/sensu/sensu-go/backend/authorization/rbac"
+	"github.com/sensu/sensu-go/backend/messaging"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/backend/store/patch"
+	storev2 "github.com/sensu/sensu-go/backend/store/v2"
+)
+
+// requiresApproval returns whether the resource carries the requires approval
+// annotation.
+func requiresApproval(resource interface{}) bool {
+	meta := objectMeta(resource)
+	return meta != nil && meta.Annotations[corev2.RequiresApprovalAnnotation] == "true"
+}
+
+// pendingChanges returns the store of pending changes. Resources that require
+// approval can't be updated if the store does not hold pending changes.
+func (h Handlers) pendingChanges() (store.PendingChangeStore, error) {
+	changes, ok := h.Store.(store.PendingChangeStore)
+	if !ok {
+		return nil, actions.NewError(actions.InternalErr, errors.New("the store does not support changes that require approval"))
+	}
+	return changes, nil
+}
+
+// subject returns the JWT subject of the request context, or an empty string
+// if it has none.
+func subject(ctx context.Context) string {
+	if claims := jwt.GetClaimsFromContext(ctx); claims != nil {
+		return claims.StandardClaims.Subject
+	}
+	return ""
+}
+
+// resourcePrefix returns the store prefix of the resources handled by h, which
+// identifies their pending changes.
+func (h Handlers) resourcePrefix() (string, error) {
+	if h.Resource != nil {
+		return h.Resource.StorePrefix(), nil
+	} else if h.V3Resource != nil {
+		return h.V3Resource.StoreName(), nil
+	}
+	return "", actions.NewError(actions.InvalidArgument, errors.New("no resource available"))
+}
+
+// newPendingChange returns a pending change of the stored resource, requested
+// by the user of ctx. Anonymous requests can't propose changes, since any
+// other user could then approve them.
+func (h Handlers) newPendingChange(ctx context.Context, stored interface{}) (*store.PendingChange, error) {
+	requester := subject(ctx)
+	if requester == "" {
+		return nil, actions.NewError(actions.Unauthenticated, errors.New("changes that require approval must be requested by an authenticated user"))
+	}
+	prefix, err := h.resourcePrefix()
+	if err != nil {
+		return nil, err
+	}
+	etag, err := store.ETag(stored)
+	if err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	meta := objectMeta(stored)
+	return &store.PendingChange{
+		ID:        uuid.New().String(),
+		Namespace: meta.Namespace,
+		Resource:  prefix,
+		Name:      meta.Name,
+		Requester: requester,
+		ETag:      etag,
+		CreatedAt: time.Now().Unix(),
+	}, nil
+}
+
+// putPendingChange records the change, replacing the pending change of the
+// resource, if any, and returns it.
+func (h Handlers) putPendingChange(ctx context.Context, change *store.PendingChange) (*store.PendingChange, error) {
+	changes, err := h.pendingChanges()
+	if err != nil {
+		return nil, err
+	}
+	if err := changes.PutPendingChange(ctx, change); err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	return change, nil
+}
+
+// proposeChange records the update of the stored resource to the proposed
+// one as a pending change, to be applied once approved, and returns it. A
+// new proposal replaces the pending change of the resource, if any.
+func (h Handlers) proposeChange(ctx context.Context, stored, proposed interface{}) (*store.PendingChange, error) {
+	if v, ok := proposed.(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return nil, actions.NewError(actions.InvalidArgument, err)
+		}
+	}
+	change, err := h.newPendingChange(ctx, stored)
+	if err != nil {
+		return nil, err
+	}
+	change.Proposed, err = json.Marshal(proposed)
+	if err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	return h.putPendingChange(ctx, change)
+}
+
+// proposeDeletion records the deletion of the stored resource as a pending
+// change, like proposeChange.
+func (h Handlers) proposeDeletion(ctx context.Context, stored interface{}) (*store.PendingChange, error) {
+	change, err := h.newPendingChange(ctx, stored)
+	if err != nil {
+		return nil, err
+	}
+	change.Delete = true
+	return h.putPendingChange(ctx, change)
+}
+
+// proposePatch is the equivalent of proposeChange for patches, which are
+// applied to a copy of the stored resource. The conditions apply to the stored
+// resource.
+func (h Handlers) proposePatch(ctx context.Context, stored interface{}, patcher patch.Patcher, conditions *store.ETagCondition) (*store.PendingChange, error) {
+	etag, err := store.ETag(stored)
+	if err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	if conditions != nil {
+		if !store.CheckIfMatch(conditions.IfMatch, etag) || !store.CheckIfNoneMatch(conditions.IfNoneMatch, etag) {
+			return nil, actions.NewError(actions.PreconditionFailed, &store.ErrPreconditionFailed{Key: objectMeta(stored).Name})
+		}
+	}
+
+	original, err := json.Marshal(stored)
+	if err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	patched, err := patcher.Patch(original)
+	if err != nil {
+		return nil, immutablePatchError(err)
+	}
+	proposed := reflect.New(reflect.TypeOf(stored).Elem()).Interface()
+	if err := json.Unmarshal(patched, proposed); err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+
+	return h.proposeChange(ctx, stored, proposed)
+}
+
+// GetPendingChange returns the change of the resource that awaits approval.
+func (h Handlers) GetPendingChange(r *http.Request) (interface{}, error) {
+	prefix, err := h.resourcePrefix()
+	if err != nil {
+		return nil, err
+	}
+	name, err := url.PathUnescape(mux.Vars(r)["id"])
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	changes, err := h.pendingChanges()
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := changes.GetPendingChange(r.Context(), prefix, name)
+	if err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	if change == nil {
+		return nil, actions.NewErrorf(actions.NotFound, "the resource %s has no pending change", name)
+	}
+	return change, nil
+}
+
+// ApproveChange applies the pending change of the resource identified by the
+// change route variable. The approver must be a different user than the
+// requester of the change, and the change can only be approved if the resource
+// was not modified since it was requested.
+func (h Handlers) ApproveChange(r *http.Request) (interface{}, error) {
+	prefix, err := h.resourcePrefix()
+	if err != nil {
+		return nil, err
+	}
+	params := mux.Vars(r)
+	name, err := url.PathUnescape(params["id"])
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	id, err := url.PathUnescape(params["change"])
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	changes, err := h.pendingChanges()
+	if err != nil {
+		return nil, err
+	}
+	ctx := r.Context()
+
+	change, err := changes.GetPendingChange(ctx, prefix, name)
+	if err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	if change == nil || change.ID != id {
+		return nil, actions.NewErrorf(actions.NotFound, "the resource %s has no pending change %s", name, id)
+	}
+	approver := subject(ctx)
+	if approver == "" || change.Requester == "" || approver == change.Requester {
+		return nil, actions.NewErrorf(actions.InvalidArgument, "the change %s must be approved by another user than its requester", id)
+	}
+	if change.Delete {
+		if err := h.authorizeDeletion(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var result interface{}
+	if h.Resource != nil {
+		result, err = h.applyV2Change(r, change)
+	} else {
+		result, err = h.applyV3Change(r, change)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := changes.DeletePendingChange(ctx, change.Resource, change.Name, change.ID); err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+
+	return result, nil
+}
+
+// authorizeDeletion makes sure that the approver of a deletion is allowed to
+// delete the resource. Approvals are authorized as updates of the resource,
+// which don't imply the right to delete it.
+func (h Handlers) authorizeDeletion(ctx context.Context) error {
+	attrs := authorization.GetAttributes(ctx)
+	if attrs == nil {
+		// The request was not authorized by the API
+		return nil
+	}
+	rbacStore, ok := h.Store.(rbac.Store)
+	if !ok {
+		return actions.NewError(actions.InternalErr, errors.New("the store does not support the authorization of deletions"))
+	}
+	deletion := *attrs
+	deletion.Verb = "delete"
+	authorized, err := (&rbac.Authorizer{Store: rbacStore}).Authorize(ctx, &deletion)
+	if err != nil {
+		return actions.NewError(actions.InternalErr, err)
+	}
+	if !authorized {
+		return actions.NewErrorf(actions.PermissionDenied, "the deletion must be approved by a user allowed to delete %s", attrs.ResourceName)
+	}
+	return nil
+}
+
+// modifiedError returns the error for a change whose resource was modified
+// after the change was requested.
+func modifiedError(change *store.PendingChange) error {
+	return actions.NewError(actions.PreconditionFailed, fmt.Errorf("the resource %s was modified after the change %s was requested", change.Name, change.ID))
+}
+
+// applyV2Change applies the approved change, provided the stored resource
+// still has the ETag it had when the change was requested.
+func (h Handlers) applyV2Change(r *http.Request, change *store.PendingChange) (interface{}, error) {
+	ctx := r.Context()
+	stored := reflect.New(reflect.TypeOf(h.Resource).Elem()).Interface().(corev2.Resource)
+	if err := h.Store.GetResource(ctx, change.Name, stored); err != nil {
+		if _, ok := err.(*store.ErrNotFound); ok {
+			return nil, actions.NewError(actions.NotFound, err)
+		}
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	etag, err := store.ETag(stored)
+	if err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	if etag != change.ETag {
+		return nil, modifiedError(change)
+	}
+
+	if change.Delete {
+		// Resources with finalizers are only marked for deletion
+		if meta := stored.GetObjectMeta(); len(meta.Finalizers) > 0 {
+			return nil, h.markForDeletion(r, stored, etag)
+		}
+		resource := reflect.New(reflect.TypeOf(h.Resource).Elem()).Interface().(corev2.Resource)
+		if err := h.Store.DeleteResourceIfMatch(ctx, resource, change.Name, etag); err != nil {
+			switch err := err.(type) {
+			case *store.ErrNotFound:
+				return nil, actions.NewError(actions.NotFound, err)
+			case *store.ErrPreconditionFailed:
+				return nil, modifiedError(change)
+			default:
+				return nil, actions.NewError(actions.InternalErr, err)
+			}
+		}
+		publishDeletion(ctx, h.Resource, change.Name)
+		return nil, nil
+	}
+
+	resource := reflect.New(reflect.TypeOf(h.Resource).Elem()).Interface().(corev2.Resource)
+	if err := json.Unmarshal(change.Proposed, resource); err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	if err := h.Store.CreateOrUpdateResource(store.ContextWithIfMatch(ctx, etag), resource); err != nil {
+		switch err := err.(type) {
+		case *store.ErrNotValid:
+			return nil, actions.NewError(actions.InvalidArgument, err)
+		case *store.ErrPreconditionFailed:
+			return nil, modifiedError(change)
+		default:
+			return nil, actions.NewError(actions.InternalErr, err)
+		}
+	}
+	publishChange(ctx, resource, messaging.ResourceUpdated)
+
+	return resource, nil
+}
+
+// applyV3Change is the equivalent of applyV2Change for v3 resources.
+func (h Handlers) applyV3Change(r *http.Request, change *store.PendingChange) (interface{}, error) {
+	ctx := r.Context()
+	req := storev2.NewResourceRequest(ctx, store.NewNamespaceFromContext(ctx), change.Name, change.Resource)
+	w, err := h.StoreV2.Get(req)
+	if err != nil {
+		if _, ok := err.(*store.ErrNotFound); ok {
+			return nil, actions.NewError(actions.NotFound, err)
+		}
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	stored, err := w.Unwrap()
+	if err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	etag, err := store.ETag(stored)
+	if err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	if etag != change.ETag {
+		return nil, modifiedError(change)
+	}
+	req.Context = store.ContextWithIfMatch(req.Context, etag)
+
+	if change.Delete {
+		if err := h.StoreV2.Delete(req); err != nil {
+			switch err := err.(type) {
+			case *store.ErrNotFound:
+				return nil, actions.NewError(actions.NotFound, err)
+			case *store.ErrPreconditionFailed:
+				return nil, modifiedError(change)
+			default:
+				return nil, actions.NewError(actions.InternalErr, err)
+			}
+		}
+		publishDeletion(ctx, h.V3Resource, change.Name)
+		return nil, nil
+	}
+
+	resource := reflect.New(reflect.TypeOf(h.V3Resource).Elem()).Interface().(corev3.Resource)
+	if err := json.Unmarshal(change.Proposed, resource); err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	wrapper, err := storev2.WrapResource(resource)
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	if err := h.StoreV2.CreateOrUpdate(req, wrapper); err != nil {
+		switch err := err.(type) {
+		case *store.ErrNotValid:
+			return nil, actions.NewError(actions.InvalidArgument, err)
+		case *store.ErrPreconditionFailed:
+			return nil, modifiedError(change)
+		default:
+			return nil, actions.NewError(actions.InternalErr, err)
+		}
+	}
+	publishChange(ctx, resource, messaging.ResourceUpdated)
+
+	return resource, nil
+}
@@ -0,0 +1,469 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/authentication/jwt"
+	"github.com/sensu/sensu-go/backend/authorization"
+	"github.com/sensu/sensu-go/backend/store"
+	storev2 "github.com/sensu/sensu-go/backend/store/v2"
+	"github.com/sensu/sensu-go/testing/fixture"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/mock"
+)
+
+// pendingChangeStore is a mock store that keeps the pending change in memory.
+type pendingChangeStore struct {
+	*mockstore.MockStore
+	pending *store.PendingChange
+}
+
+func (s *pendingChangeStore) PutPendingChange(ctx context.Context, change *store.PendingChange) error {
+	s.pending = change
+	return nil
+}
+
+func (s *pendingChangeStore) GetPendingChange(ctx context.Context, resourcePrefix, name string) (*store.PendingChange, error) {
+	if s.pending == nil || s.pending.Resource != resourcePrefix || s.pending.Name != name {
+		return nil, nil
+	}
+	return s.pending, nil
+}
+
+func (s *pendingChangeStore) DeletePendingChange(ctx context.Context, resourcePrefix, name, id string) error {
+	if s.pending != nil && s.pending.ID == id {
+		s.pending = nil
+	}
+	return nil
+}
+
+// ifMatches returns a matcher of the contexts whose If-Match condition is the
+// etag of the stored resource, as it is when the store is called.
+func ifMatches(t *testing.T, stored interface{}) interface{} {
+	return mock.MatchedBy(func(ctx context.Context) bool {
+		etag, err := store.ETag(stored)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return store.IfMatchFromContext(ctx) == etag
+	})
+}
+
+// approvalStore mocks a store that holds a single resource, stored, and only
+// writes it conditionally.
+func approvalStore(t *testing.T, stored *fixture.Resource) *pendingChangeStore {
+	s := &mockstore.MockStore{}
+	s.On("GetResource", mock.Anything, "foo", mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(2).(*fixture.Resource) = *stored
+	}).Return(nil)
+	s.On("CreateOrUpdateResource", ifMatches(t, stored), mock.Anything).Run(func(args mock.Arguments) {
+		*stored = *args.Get(1).(*fixture.Resource)
+	}).Return(nil)
+	s.On("CreateOrUpdateResource", mock.Anything, mock.Anything).Return(&store.ErrPreconditionFailed{Key: "foo"})
+	return &pendingChangeStore{MockStore: s}
+}
+
+func userRequest(t *testing.T, username, method string, body []byte, vars map[string]string) *http.Request {
+	t.Helper()
+	claims, err := jwt.NewClaims(&corev2.User{Username: username})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.WithValue(context.Background(), corev2.ClaimsKey, claims)
+	r, err := http.NewRequestWithContext(ctx, method, "/", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return mux.SetURLVars(r, vars)
+}
+
+func errCode(err error) actions.ErrCode {
+	code, _ := actions.StatusFromError(err)
+	return code
+}
+
+func TestHandlers_ApproveChange(t *testing.T) {
+	stored := &fixture.Resource{Foo: "old", ObjectMeta: corev2.ObjectMeta{
+		Name:        "foo",
+		Namespace:   "default",
+		Annotations: map[string]string{corev2.RequiresApprovalAnnotation: "true"},
+	}}
+	s := approvalStore(t, stored)
+	h := Handlers{Resource: &fixture.Resource{}, Store: s}
+	vars := map[string]string{"id": "foo", "namespace": "default"}
+
+	// The update is recorded as a pending change rather than applied
+	update := *stored
+	update.Foo = "new"
+	result, err := h.CreateOrUpdateResource(userRequest(t, "alice", http.MethodPut, marshal(t, update), vars))
+	if err != nil {
+		t.Fatal(err)
+	}
+	change, ok := result.(*store.PendingChange)
+	if !ok {
+		t.Fatalf("expected a pending change, got %T", result)
+	}
+	if change.Requester != "alice" || change.Name != "foo" || change.Resource != "resource" {
+		t.Errorf("bad pending change: %+v", change)
+	}
+	s.AssertNotCalled(t, "CreateOrUpdateResource", mock.Anything, mock.Anything)
+
+	result, err = h.GetPendingChange(userRequest(t, "bob", http.MethodGet, nil, vars))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.(*store.PendingChange).ID != change.ID {
+		t.Errorf("got pending change %v, want %v", result, change)
+	}
+
+	approve := func(username, id string) (interface{}, error) {
+		approveVars := map[string]string{"id": "foo", "namespace": "default", "change": id}
+		return h.ApproveChange(userRequest(t, username, http.MethodPut, nil, approveVars))
+	}
+
+	// The requester can't approve their own change
+	if _, err := approve("alice", change.ID); errCode(err) != actions.InvalidArgument {
+		t.Errorf("expected an invalid argument error, got %v", err)
+	}
+	// Only the pending change can be approved
+	if _, err := approve("bob", "other"); errCode(err) != actions.NotFound {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+
+	result, err = approve("bob", change.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := result.(*fixture.Resource).Foo; got != "new" {
+		t.Errorf("got %q, want the change to be applied", got)
+	}
+	if stored.Foo != "new" {
+		t.Error("expected the change to be stored")
+	}
+	if s.pending != nil {
+		t.Error("expected the pending change to be deleted")
+	}
+}
+
+func TestHandlers_ApproveChangeOfModifiedResource(t *testing.T) {
+	stored := &fixture.Resource{Foo: "old", ObjectMeta: corev2.ObjectMeta{
+		Name:        "foo",
+		Namespace:   "default",
+		Annotations: map[string]string{corev2.RequiresApprovalAnnotation: "true"},
+	}}
+	s := approvalStore(t, stored)
+	h := Handlers{Resource: &fixture.Resource{}, Store: s}
+	vars := map[string]string{"id": "foo", "namespace": "default"}
+
+	// Patches are recorded as pending changes too
+	patchReq := userRequest(t, "alice", http.MethodPatch, []byte(`{"foo":"new"}`), vars)
+	patchReq.Header.Set("Content-Type", mergePatchContentType)
+	result, err := h.PatchResource(patchReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	change, ok := result.(*store.PendingChange)
+	if !ok {
+		t.Fatalf("expected a pending change, got %T", result)
+	}
+	if !bytes.Contains(change.Proposed, []byte(`"foo":"new"`)) {
+		t.Errorf("expected the patch to be part of the proposed resource, got %s", change.Proposed)
+	}
+	s.AssertNotCalled(t, "PatchResource", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// The resource is modified before the change is approved
+	stored.Foo = "other"
+	approveVars := map[string]string{"id": "foo", "namespace": "default", "change": change.ID}
+	_, err = h.ApproveChange(userRequest(t, "bob", http.MethodPut, nil, approveVars))
+	if errCode(err) != actions.PreconditionFailed {
+		t.Errorf("expected a precondition failure, got %v", err)
+	}
+	if stored.Foo != "other" {
+		t.Error("expected the change not to be applied")
+	}
+}
+
+func TestHandlers_ApproveChangeModifiedWhileApproved(t *testing.T) {
+	stored := &fixture.Resource{Foo: "old", ObjectMeta: corev2.ObjectMeta{
+		Name:        "foo",
+		Namespace:   "default",
+		Annotations: map[string]string{corev2.RequiresApprovalAnnotation: "true"},
+	}}
+	s := approvalStore(t, stored)
+	h := Handlers{Resource: &fixture.Resource{}, Store: s}
+	vars := map[string]string{"id": "foo", "namespace": "default"}
+
+	update := *stored
+	update.Foo = "new"
+	result, err := h.CreateOrUpdateResource(userRequest(t, "alice", http.MethodPut, marshal(t, update), vars))
+	if err != nil {
+		t.Fatal(err)
+	}
+	change := result.(*store.PendingChange)
+
+	// The resource is modified after the approval checked it
+	s.MockStore = &mockstore.MockStore{}
+	s.On("GetResource", mock.Anything, "foo", mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(2).(*fixture.Resource) = *stored
+		stored.Foo = "other"
+	}).Return(nil)
+	s.On("CreateOrUpdateResource", ifMatches(t, stored), mock.Anything).Return(nil)
+	s.On("CreateOrUpdateResource", mock.Anything, mock.Anything).Return(&store.ErrPreconditionFailed{Key: "foo"})
+
+	approveVars := map[string]string{"id": "foo", "namespace": "default", "change": change.ID}
+	_, err = h.ApproveChange(userRequest(t, "bob", http.MethodPut, nil, approveVars))
+	if errCode(err) != actions.PreconditionFailed {
+		t.Errorf("expected a precondition failure, got %v", err)
+	}
+	if s.pending == nil {
+		t.Error("expected the change to be left pending")
+	}
+}
+
+func TestHandlers_ApproveReplacedChange(t *testing.T) {
+	stored := &fixture.Resource{Foo: "old", ObjectMeta: corev2.ObjectMeta{
+		Name:        "foo",
+		Namespace:   "default",
+		Annotations: map[string]string{corev2.RequiresApprovalAnnotation: "true"},
+	}}
+	s := approvalStore(t, stored)
+	h := Handlers{Resource: &fixture.Resource{}, Store: s}
+	vars := map[string]string{"id": "foo", "namespace": "default"}
+
+	update := *stored
+	update.Foo = "new"
+	result, err := h.CreateOrUpdateResource(userRequest(t, "alice", http.MethodPut, marshal(t, update), vars))
+	if err != nil {
+		t.Fatal(err)
+	}
+	change := result.(*store.PendingChange)
+
+	// Another change replaces the approved one before it is deleted
+	replacing := *change
+	replacing.ID = "replacing"
+	s.MockStore = &mockstore.MockStore{}
+	s.On("GetResource", mock.Anything, "foo", mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(2).(*fixture.Resource) = *stored
+		s.pending = &replacing
+	}).Return(nil)
+	s.On("CreateOrUpdateResource", mock.Anything, mock.Anything).Return(nil)
+
+	approveVars := map[string]string{"id": "foo", "namespace": "default", "change": change.ID}
+	if _, err := h.ApproveChange(userRequest(t, "bob", http.MethodPut, nil, approveVars)); err != nil {
+		t.Fatal(err)
+	}
+	if s.pending == nil || s.pending.ID != "replacing" {
+		t.Errorf("expected the replacing change to be left pending, got %v", s.pending)
+	}
+}
+
+func TestHandlers_ApproveDeletion(t *testing.T) {
+	stored := &fixture.Resource{Foo: "old", ObjectMeta: corev2.ObjectMeta{
+		Name:        "foo",
+		Namespace:   "default",
+		Annotations: map[string]string{corev2.RequiresApprovalAnnotation: "true"},
+	}}
+	s := approvalStore(t, stored)
+	h := Handlers{Resource: &fixture.Resource{}, Store: s}
+	vars := map[string]string{"id": "foo", "namespace": "default"}
+
+	// The deletion is recorded as a pending change rather than applied
+	result, err := h.DeleteResource(userRequest(t, "alice", http.MethodDelete, nil, vars))
+	if err != nil {
+		t.Fatal(err)
+	}
+	change, ok := result.(*store.PendingChange)
+	if !ok {
+		t.Fatalf("expected a pending change, got %T", result)
+	}
+	if !change.Delete || change.Requester != "alice" {
+		t.Errorf("bad pending change: %+v", change)
+	}
+	s.AssertNotCalled(t, "DeleteResourceIfMatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	s.AssertNotCalled(t, "DeleteResource", mock.Anything, mock.Anything, mock.Anything)
+
+	s.On("DeleteResourceIfMatch", mock.Anything, mock.Anything, "foo", change.ETag).Return(nil)
+	approveVars := map[string]string{"id": "foo", "namespace": "default", "change": change.ID}
+	if _, err := h.ApproveChange(userRequest(t, "bob", http.MethodPut, nil, approveVars)); err != nil {
+		t.Fatal(err)
+	}
+	s.AssertCalled(t, "DeleteResourceIfMatch", mock.Anything, mock.Anything, "foo", change.ETag)
+	if s.pending != nil {
+		t.Error("expected the pending change to be deleted")
+	}
+}
+
+func TestHandlers_ProposeChangeAnonymously(t *testing.T) {
+	stored := &fixture.Resource{Foo: "old", ObjectMeta: corev2.ObjectMeta{
+		Name:        "foo",
+		Namespace:   "default",
+		Annotations: map[string]string{corev2.RequiresApprovalAnnotation: "true"},
+	}}
+	s := approvalStore(t, stored)
+	h := Handlers{Resource: &fixture.Resource{}, Store: s}
+
+	update := *stored
+	update.Foo = "new"
+	r, err := http.NewRequest(http.MethodPut, "/", bytes.NewReader(marshal(t, update)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r = mux.SetURLVars(r, map[string]string{"id": "foo", "namespace": "default"})
+	if _, err := h.CreateOrUpdateResource(r); errCode(err) != actions.Unauthenticated {
+		t.Errorf("expected an unauthenticated error, got %v", err)
+	}
+	if s.pending != nil {
+		t.Errorf("expected no pending change, got %v", s.pending)
+	}
+}
+
+func TestHandlers_ApproveV3Change(t *testing.T) {
+	meta := corev2.NewObjectMetaP("foo", "default")
+	meta.Annotations = map[string]string{corev2.RequiresApprovalAnnotation: "true"}
+	stored := &fixture.V3Resource{Metadata: meta}
+	s := &pendingChangeStore{MockStore: &mockstore.MockStore{}}
+	wrapper, err := storev2.WrapResource(stored)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v2 := &mockstore.V2MockStore{}
+	v2.On("Get", mock.Anything).Return(wrapper, nil)
+	h := Handlers{V3Resource: &fixture.V3Resource{}, Store: s, StoreV2: v2}
+	vars := map[string]string{"id": "foo", "namespace": "default"}
+
+	updateMeta := *meta
+	updateMeta.Labels = map[string]string{"new": "true"}
+	update := &fixture.V3Resource{Metadata: &updateMeta}
+	result, err := h.CreateOrUpdateV3Resource(userRequest(t, "alice", http.MethodPut, marshal(t, update), vars))
+	if err != nil {
+		t.Fatal(err)
+	}
+	change, ok := result.(*store.PendingChange)
+	if !ok {
+		t.Fatalf("expected a pending change, got %T", result)
+	}
+	if change.Resource != "v3resource" {
+		t.Errorf("bad pending change: %+v", change)
+	}
+	v2.AssertNotCalled(t, "CreateOrUpdate", mock.Anything, mock.Anything)
+
+	etag, err := store.ETag(stored)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v2.On("CreateOrUpdate", mock.MatchedBy(func(req storev2.ResourceRequest) bool {
+		return store.IfMatchFromContext(req.Context) == etag
+	}), mock.Anything).Return(nil)
+	approveVars := map[string]string{"id": "foo", "namespace": "default", "change": change.ID}
+	result, err = h.ApproveChange(userRequest(t, "bob", http.MethodPut, nil, approveVars))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := result.(*fixture.V3Resource).Metadata.Labels["new"]; got != "true" {
+		t.Errorf("got labels %v, want the change to be applied", result.(*fixture.V3Resource).Metadata.Labels)
+	}
+	if s.pending != nil {
+		t.Error("expected the pending change to be deleted")
+	}
+}
+
+func TestHandlers_ApproveDeletionWithoutDeletePermission(t *testing.T) {
+	stored := &fixture.Resource{Foo: "old", ObjectMeta: corev2.ObjectMeta{
+		Name:        "foo",
+		Namespace:   "default",
+		Annotations: map[string]string{corev2.RequiresApprovalAnnotation: "true"},
+	}}
+	s := approvalStore(t, stored)
+	h := Handlers{Resource: &fixture.Resource{}, Store: s}
+	vars := map[string]string{"id": "foo", "namespace": "default"}
+
+	result, err := h.DeleteResource(userRequest(t, "alice", http.MethodDelete, nil, vars))
+	if err != nil {
+		t.Fatal(err)
+	}
+	change := result.(*store.PendingChange)
+
+	// bob may update resources, which authorizes approvals, but not delete
+	// them
+	s.On("ListClusterRoleBindings", mock.Anything, mock.Anything).Return([]*corev2.ClusterRoleBinding{{
+		Subjects: []corev2.Subject{{Type: corev2.UserType, Name: "bob"}},
+		RoleRef:  corev2.RoleRef{Type: "ClusterRole", Name: "editor"},
+	}}, nil)
+	s.On("ListRoleBindings", mock.Anything, mock.Anything).Return([]*corev2.RoleBinding{}, nil)
+	s.On("GetClusterRole", mock.Anything, "editor").Return(&corev2.ClusterRole{
+		Rules: []corev2.Rule{{
+			Verbs:     []string{"get", "update"},
+			Resources: []string{corev2.ResourceAll},
+		}},
+	}, nil)
+	s.On("DeleteResourceIfMatch", mock.Anything, mock.Anything, "foo", change.ETag).Return(nil)
+
+	approveVars := map[string]string{"id": "foo", "namespace": "default", "change": change.ID}
+	r := userRequest(t, "bob", http.MethodPut, nil, approveVars)
+	r = r.WithContext(authorization.SetAttributes(r.Context(), &authorization.Attributes{
+		Namespace:    "default",
+		Resource:     "fixtures",
+		ResourceName: "foo",
+		User:         corev2.User{Username: "bob"},
+		Verb:         "update",
+	}))
+	if _, err := h.ApproveChange(r); errCode(err) != actions.PermissionDenied {
+		t.Errorf("expected a permission denied error, got %v", err)
+	}
+	s.AssertNotCalled(t, "DeleteResourceIfMatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	if s.pending == nil {
+		t.Error("expected the deletion to be left pending")
+	}
+}
+
+func TestHandlers_ApproveV3Deletion(t *testing.T) {
+	meta := corev2.NewObjectMetaP("foo", "default")
+	meta.Annotations = map[string]string{corev2.RequiresApprovalAnnotation: "true"}
+	stored := &fixture.V3Resource{Metadata: meta}
+	s := &pendingChangeStore{MockStore: &mockstore.MockStore{}}
+	wrapper, err := storev2.WrapResource(stored)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v2 := &mockstore.V2MockStore{}
+	v2.On("Get", mock.Anything).Return(wrapper, nil)
+	h := Handlers{V3Resource: &fixture.V3Resource{}, Store: s, StoreV2: v2}
+	vars := map[string]string{"id": "foo", "namespace": "default"}
+
+	// The deletion is recorded as a pending change rather than applied
+	result, err := h.DeleteV3Resource(userRequest(t, "alice", http.MethodDelete, nil, vars))
+	if err != nil {
+		t.Fatal(err)
+	}
+	change, ok := result.(*store.PendingChange)
+	if !ok {
+		t.Fatalf("expected a pending change, got %T", result)
+	}
+	if !change.Delete || change.Requester != "alice" {
+		t.Errorf("bad pending change: %+v", change)
+	}
+	v2.AssertNotCalled(t, "Delete", mock.Anything)
+
+	etag, err := store.ETag(stored)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ifMatch := mock.MatchedBy(func(req storev2.ResourceRequest) bool {
+		return store.IfMatchFromContext(req.Context) == etag
+	})
+	v2.On("Delete", ifMatch).Return(nil)
+	approveVars := map[string]string{"id": "foo", "namespace": "default", "change": change.ID}
+	if _, err := h.ApproveChange(userRequest(t, "bob", http.MethodPut, nil, approveVars)); err != nil {
+		t.Fatal(err)
+	}
+	v2.AssertCalled(t, "Delete", ifMatch)
+	if s.pending != nil {
+		t.Error("expected the pending change to be deleted")
+	}
+}
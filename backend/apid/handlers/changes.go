@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"context"
+	"reflect"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/messaging"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/types"
+	"github.com/sirupsen/logrus"
+)
+
+type changePublisherKey struct{}
+
+// ContextWithChangePublisher returns a copy of ctx with which the handlers
+// publish the changes of the resources they write to bus, on the
+// messaging.TopicResourceChange topic.
+func ContextWithChangePublisher(ctx context.Context, bus messaging.Publisher) context.Context {
+	return context.WithValue(ctx, changePublisherKey{}, bus)
+}
+
+// resourceChange returns the change of the given resource, without its ETag.
+func resourceChange(resource interface{}, operation string) messaging.ResourceChange {
+	var tm corev2.TypeMeta
+	if getter, ok := resource.(interface{ GetTypeMeta() corev2.TypeMeta }); ok {
+		tm = getter.GetTypeMeta()
+	} else {
+		typ := reflect.Indirect(reflect.ValueOf(resource)).Type()
+		tm = corev2.TypeMeta{Type: typ.Name(), APIVersion: types.ApiVersion(typ.PkgPath())}
+	}
+	change := messaging.ResourceChange{
+		APIVersion: tm.APIVersion,
+		Type:       tm.Type,
+		Operation:  operation,
+	}
+	if meta := objectMeta(resource); meta != nil {
+		change.Namespace = meta.Namespace
+		change.Name = meta.Name
+	}
+	return change
+}
+
+// publishChange publishes the change of a resource that was just written.
+func publishChange(ctx context.Context, resource interface{}, operation string) {
+	change := resourceChange(resource, operation)
+	etag, err := store.ETag(resource)
+	if err != nil {
+		logger.WithError(err).Warn("could not compute the etag of a changed resource")
+	}
+	change.ETag = etag
+	publish(ctx, change)
+}
+
+// publishDeletion publishes the deletion of the resource of the given name,
+// of the same type as resource, from the namespace of ctx.
+func publishDeletion(ctx context.Context, resource interface{}, name string) {
+	change := resourceChange(resource, messaging.ResourceDeleted)
+	change.Namespace = store.NewNamespaceFromContext(ctx)
+	change.Name = name
+	publish(ctx, change)
+}
+
+// publish is best-effort: the resource is already written, so the request
+// does not fail if the change can't be published.
+func publish(ctx context.Context, change messaging.ResourceChange) {
+	bus, ok := ctx.Value(changePublisherKey{}).(messaging.Publisher)
+	if !ok || bus == nil {
+		return
+	}
+	if err := bus.Publish(messaging.TopicResourceChange, change); err != nil {
+		logger.WithError(err).WithFields(logrus.Fields{
+			"type":      change.Type,
+			"namespace": change.Namespace,
+			"name":      change.Name,
+			"operation": change.Operation,
+		}).Warn("could not publish the change of a resource")
+	}
+}
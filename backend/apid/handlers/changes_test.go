@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/messaging"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/testing/fixture"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/mock"
+)
+
+// changePublisher records the changes published to the resource change topic.
+type changePublisher struct {
+	changes []messaging.ResourceChange
+	err     error
+}
+
+func (p *changePublisher) Publish(topic string, message interface{}) error {
+	if topic == messaging.TopicResourceChange {
+		p.changes = append(p.changes, message.(messaging.ResourceChange))
+	}
+	return p.err
+}
+
+func changeRequest(t *testing.T, bus messaging.Publisher, method string, body []byte) *http.Request {
+	t.Helper()
+	ctx := context.WithValue(context.Background(), corev2.NamespaceKey, "default")
+	ctx = ContextWithChangePublisher(ctx, bus)
+	r, err := http.NewRequestWithContext(ctx, method, "/", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return mux.SetURLVars(r, map[string]string{"id": "foo", "namespace": "default"})
+}
+
+func TestHandlers_PublishChanges(t *testing.T) {
+	resource := &fixture.Resource{ObjectMeta: corev2.ObjectMeta{Name: "foo", Namespace: "default"}}
+	etag, err := store.ETag(resource)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &mockstore.MockStore{}
+	s.On("CreateResource", mock.Anything, mock.Anything).Return(nil)
+	s.On("GetResource", mock.Anything, "foo", mock.Anything).Return(&store.ErrNotFound{})
+	s.On("DeleteResource", mock.Anything, "resource", "foo").Return(nil)
+	h := Handlers{Resource: &fixture.Resource{}, Store: s}
+	bus := &changePublisher{}
+
+	if _, err := h.CreateResource(changeRequest(t, bus, http.MethodPost, marshal(t, resource))); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.DeleteResource(changeRequest(t, bus, http.MethodDelete, nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []messaging.ResourceChange{
+		{APIVersion: "testing/fixture", Type: "Resource", Namespace: "default", Name: "foo", Operation: messaging.ResourceCreated, ETag: etag},
+		{APIVersion: "testing/fixture", Type: "Resource", Namespace: "default", Name: "foo", Operation: messaging.ResourceDeleted},
+	}
+	if len(bus.changes) != len(want) {
+		t.Fatalf("got changes %+v, want %+v", bus.changes, want)
+	}
+	for i := range want {
+		if bus.changes[i] != want[i] {
+			t.Errorf("got change %+v, want %+v", bus.changes[i], want[i])
+		}
+	}
+}
+
+func TestHandlers_PublishChangesIsBestEffort(t *testing.T) {
+	s := &mockstore.MockStore{}
+	s.On("GetResource", mock.Anything, "foo", mock.Anything).Return(&store.ErrNotFound{})
+	s.On("CreateOrUpdateResource", mock.Anything, mock.Anything).Return(nil)
+	h := Handlers{Resource: &fixture.Resource{}, Store: s}
+	bus := &changePublisher{err: errors.New("bus no longer running")}
+
+	resource := &fixture.Resource{ObjectMeta: corev2.ObjectMeta{Name: "foo", Namespace: "default"}}
+	if _, err := h.CreateOrUpdateResource(changeRequest(t, bus, http.MethodPut, marshal(t, resource))); err != nil {
+		t.Fatalf("expected the write to succeed despite the bus, got %v", err)
+	}
+	if len(bus.changes) != 1 || bus.changes[0].Operation != messaging.ResourceCreated {
+		t.Errorf("expected the creation to be published, got %+v", bus.changes)
+	}
+}
@@ -9,11 +9,13 @@ import (
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/authentication/jwt"
+	"github.com/sensu/sensu-go/backend/messaging"
 	"github.com/sensu/sensu-go/backend/store"
 )
 
 // CreateResource creates the resource given in the request body but only if it
-// does not already exist
+// does not already exist. With If-None-Match: *, an existing resource is
+// reported as a failed precondition rather than a conflict.
 func (h Handlers) CreateResource(r *http.Request) (interface{}, error) {
 	payload := reflect.New(reflect.TypeOf(h.Resource).Elem())
 	if err := json.NewDecoder(r.Body).Decode(payload.Interface()); err != nil {
@@ -35,16 +37,25 @@ func (h Handlers) CreateResource(r *http.Request) (interface{}, error) {
 		resource.SetObjectMeta(meta)
 	}
 
-	if err := h.Store.CreateResource(r.Context(), resource); err != nil {
+	ctx := r.Context()
+	if ifNoneMatch := r.Header.Get(ifNoneMatchHeader); ifNoneMatch != "" {
+		// Creates only honor If-None-Match: *, since any existing resource
+		// fails them
+		ctx = store.ContextWithIfNoneMatch(ctx, ifNoneMatch)
+	}
+	if err := h.Store.CreateResource(ctx, resource); err != nil {
 		switch err := err.(type) {
 		case *store.ErrAlreadyExists:
 			return nil, actions.NewErrorf(actions.AlreadyExistsErr)
+		case *store.ErrPreconditionFailed:
+			return nil, actions.NewError(actions.PreconditionFailed, err)
 		case *store.ErrNotValid:
 			return nil, actions.NewError(actions.InvalidArgument, err)
 		default:
 			return nil, actions.NewError(actions.InternalErr, err)
 		}
 	}
+	publishChange(ctx, resource, messaging.ResourceCreated)
 
 	return nil, nil
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/authentication/jwt"
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/testing/fixture"
@@ -20,11 +21,13 @@ import (
 func TestHandlers_CreateResource(t *testing.T) {
 	type storeFunc func(*mockstore.MockStore)
 	tests := []struct {
-		name      string
-		body      []byte
-		urlVars   map[string]string
-		storeFunc storeFunc
-		wantErr   bool
+		name        string
+		body        []byte
+		urlVars     map[string]string
+		ifNoneMatch string
+		storeFunc   storeFunc
+		wantErr     bool
+		wantCode    actions.ErrCode
 	}{
 		{
 			name:    "invalid request body",
@@ -47,7 +50,22 @@ func TestHandlers_CreateResource(t *testing.T) {
 				s.On("CreateResource", mock.Anything, mock.AnythingOfType("*fixture.Resource")).
 					Return(&store.ErrAlreadyExists{})
 			},
-			wantErr: true,
+			wantErr:  true,
+			wantCode: actions.AlreadyExistsErr,
+		},
+		{
+			name:        "store err, conditional create of an existing resource",
+			body:        marshal(t, fixture.Resource{ObjectMeta: corev2.ObjectMeta{}}),
+			ifNoneMatch: "*",
+			storeFunc: func(s *mockstore.MockStore) {
+				conditional := mock.MatchedBy(func(ctx context.Context) bool {
+					return store.IfNoneMatchFromContext(ctx) == "*"
+				})
+				s.On("CreateResource", conditional, mock.AnythingOfType("*fixture.Resource")).
+					Return(&store.ErrPreconditionFailed{})
+			},
+			wantErr:  true,
+			wantCode: actions.PreconditionFailed,
 		},
 		{
 			name: "store err, not valid",
@@ -90,12 +108,18 @@ func TestHandlers_CreateResource(t *testing.T) {
 
 			r, _ := http.NewRequest(http.MethodPost, "/", bytes.NewReader(tt.body))
 			r = mux.SetURLVars(r, tt.urlVars)
+			if tt.ifNoneMatch != "" {
+				r.Header.Set(ifNoneMatchHeader, tt.ifNoneMatch)
+			}
 
 			_, err := h.CreateResource(r)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Handlers.CreateResource() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantCode != 0 && err.(actions.Error).Code != tt.wantCode {
+				t.Errorf("Handlers.CreateResource() error code = %v, want %v", err.(actions.Error).Code, tt.wantCode)
+			}
 		})
 	}
 }
@@ -9,12 +9,14 @@ import (
 	corev3 "github.com/sensu/sensu-go/api/core/v3"
 	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/authentication/jwt"
+	"github.com/sensu/sensu-go/backend/messaging"
 	"github.com/sensu/sensu-go/backend/store"
 	storev2 "github.com/sensu/sensu-go/backend/store/v2"
 )
 
 // CreateV3Resource creates the resource given in the request body but only if it
-// does not already exist
+// does not already exist. With If-None-Match: *, an existing resource is
+// reported as a failed precondition rather than a conflict.
 func (h Handlers) CreateV3Resource(r *http.Request) (interface{}, error) {
 	payload := reflect.New(reflect.TypeOf(h.V3Resource).Elem())
 	if err := json.NewDecoder(r.Body).Decode(payload.Interface()); err != nil {
@@ -35,7 +37,11 @@ func (h Handlers) CreateV3Resource(r *http.Request) (interface{}, error) {
 		meta.CreatedBy = claims.StandardClaims.Subject
 	}
 
-	req := storev2.NewResourceRequestFromResource(r.Context(), resource)
+	ctx := r.Context()
+	if ifNoneMatch := r.Header.Get(ifNoneMatchHeader); ifNoneMatch != "" {
+		ctx = store.ContextWithIfNoneMatch(ctx, ifNoneMatch)
+	}
+	req := storev2.NewResourceRequestFromResource(ctx, resource)
 	wrapper, err := storev2.WrapResource(resource)
 	if err != nil {
 		return nil, actions.NewError(actions.InvalidArgument, err)
@@ -45,12 +51,15 @@ func (h Handlers) CreateV3Resource(r *http.Request) (interface{}, error) {
 		switch err := err.(type) {
 		case *store.ErrAlreadyExists:
 			return nil, actions.NewErrorf(actions.AlreadyExistsErr)
+		case *store.ErrPreconditionFailed:
+			return nil, actions.NewError(actions.PreconditionFailed, err)
 		case *store.ErrNotValid:
 			return nil, actions.NewError(actions.InvalidArgument, err)
 		default:
 			return nil, actions.NewError(actions.InternalErr, err)
 		}
 	}
+	publishChange(ctx, resource, messaging.ResourceCreated)
 
 	return nil, nil
 }
@@ -9,8 +9,10 @@ import (
 
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/authentication/jwt"
 	"github.com/sensu/sensu-go/backend/store"
+	storev2 "github.com/sensu/sensu-go/backend/store/v2"
 	"github.com/sensu/sensu-go/testing/fixture"
 	"github.com/sensu/sensu-go/testing/mockstore"
 	"github.com/stretchr/testify/assert"
@@ -19,11 +21,13 @@ import (
 
 func TestHandlers_CreateV3Resource(t *testing.T) {
 	tests := []struct {
-		name      string
-		body      []byte
-		urlVars   map[string]string
-		storeFunc func(s *mockstore.V2MockStore)
-		wantErr   bool
+		name        string
+		body        []byte
+		urlVars     map[string]string
+		ifNoneMatch string
+		storeFunc   func(s *mockstore.V2MockStore)
+		wantErr     bool
+		wantCode    actions.ErrCode
 	}{
 		{
 			name:    "invalid request body",
@@ -46,7 +50,22 @@ func TestHandlers_CreateV3Resource(t *testing.T) {
 				s.On("CreateIfNotExists", mock.Anything, mock.Anything).
 					Return(&store.ErrAlreadyExists{})
 			},
-			wantErr: true,
+			wantErr:  true,
+			wantCode: actions.AlreadyExistsErr,
+		},
+		{
+			name:        "store err, conditional create of an existing resource",
+			body:        marshal(t, fixture.V3Resource{Metadata: &corev2.ObjectMeta{}}),
+			ifNoneMatch: "*",
+			storeFunc: func(s *mockstore.V2MockStore) {
+				conditional := mock.MatchedBy(func(req storev2.ResourceRequest) bool {
+					return store.IfNoneMatchFromContext(req.Context) == "*"
+				})
+				s.On("CreateIfNotExists", conditional, mock.Anything).
+					Return(&store.ErrPreconditionFailed{})
+			},
+			wantErr:  true,
+			wantCode: actions.PreconditionFailed,
 		},
 		{
 			name: "store err, not valid",
@@ -88,6 +107,9 @@ func TestHandlers_CreateV3Resource(t *testing.T) {
 			}
 
 			r, _ := http.NewRequest(http.MethodPost, "/", bytes.NewReader(tt.body))
+			if tt.ifNoneMatch != "" {
+				r.Header.Set(ifNoneMatchHeader, tt.ifNoneMatch)
+			}
 			r = mux.SetURLVars(r, tt.urlVars)
 
 			_, err := h.CreateV3Resource(r)
@@ -95,6 +117,9 @@ func TestHandlers_CreateV3Resource(t *testing.T) {
 				t.Errorf("Handlers.CreateV3Resource() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantCode != 0 && err.(actions.Error).Code != tt.wantCode {
+				t.Errorf("Handlers.CreateV3Resource() error code = %v, want %v", err.(actions.Error).Code, tt.wantCode)
+			}
 		})
 	}
 }
@@ -3,13 +3,20 @@ package handlers
 import (
 	"net/http"
 	"net/url"
+	"reflect"
+	"time"
 
 	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/messaging"
 	"github.com/sensu/sensu-go/backend/store"
 )
 
-// DeleteResource deletes the resources identified in the request path
+// DeleteResource deletes the resources identified in the request path.
+// Resources with finalizers are only marked for deletion, and are deleted by
+// the reaper once their finalizers are removed. Deletions of resources that
+// require approval are recorded as pending changes instead.
 func (h Handlers) DeleteResource(r *http.Request) (interface{}, error) {
 	params := mux.Vars(r)
 	name, err := url.PathUnescape(params["id"])
@@ -17,14 +24,65 @@ func (h Handlers) DeleteResource(r *http.Request) (interface{}, error) {
 		return nil, actions.NewError(actions.InvalidArgument, err)
 	}
 
-	if err := h.Store.DeleteResource(r.Context(), h.Resource.StorePrefix(), name); err != nil {
+	stored := reflect.New(reflect.TypeOf(h.Resource).Elem()).Interface().(corev2.Resource)
+	err = h.Store.GetResource(r.Context(), name, stored)
+	if err == nil {
+		// Deletions of resources that require approval are only applied
+		// once approved by another user
+		if requiresApproval(stored) {
+			return h.proposeDeletion(r.Context(), stored)
+		}
+		// Only write the resource that was checked for finalizers, so that
+		// the finalizers added or removed concurrently are not ignored
+		var etag string
+		etag, err = store.ETag(stored)
+		if err != nil {
+			return nil, actions.NewError(actions.InternalErr, err)
+		}
+		if meta := stored.GetObjectMeta(); len(meta.Finalizers) > 0 {
+			return nil, h.markForDeletion(r, stored, etag)
+		}
+		resource := reflect.New(reflect.TypeOf(h.Resource).Elem()).Interface().(corev2.Resource)
+		err = h.Store.DeleteResourceIfMatch(r.Context(), resource, name, etag)
+	} else if _, ok := err.(*store.ErrNotFound); ok {
+		err = h.Store.DeleteResource(r.Context(), h.Resource.StorePrefix(), name)
+	}
+	if err != nil {
 		switch err := err.(type) {
 		case *store.ErrNotFound:
 			return nil, actions.NewErrorf(actions.NotFound)
+		case *store.ErrPreconditionFailed:
+			// The resource was modified after it was checked
+			return nil, actions.NewRetryableError(actions.PreconditionFailed, err)
 		default:
 			return nil, actions.NewError(actions.InternalErr, err)
 		}
 	}
+	publishDeletion(r.Context(), h.Resource, name)
 
 	return nil, nil
 }
+
+// markForDeletion records the time at which the deletion of the resource was
+// first requested, unless it already is marked for deletion. The resource is
+// only updated if its stored version still has the given etag.
+func (h Handlers) markForDeletion(r *http.Request, resource corev2.Resource, etag string) error {
+	meta := resource.GetObjectMeta()
+	if meta.DeletedAt != 0 {
+		return nil
+	}
+	meta.DeletedAt = time.Now().Unix()
+	resource.SetObjectMeta(meta)
+	ctx := store.ContextWithIfMatch(r.Context(), etag)
+	if err := h.Store.CreateOrUpdateResource(ctx, resource); err != nil {
+		switch err := err.(type) {
+		case *store.ErrPreconditionFailed:
+			// The resource was modified after it was checked
+			return actions.NewRetryableError(actions.PreconditionFailed, err)
+		default:
+			return actions.NewError(actions.InternalErr, err)
+		}
+	}
+	publishChange(r.Context(), resource, messaging.ResourceUpdated)
+	return nil
+}
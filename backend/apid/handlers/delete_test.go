@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 	"testing"
 
@@ -12,12 +13,24 @@ import (
 )
 
 func TestHandlers_DeleteResource(t *testing.T) {
+	etag, err := store.ETag(&fixture.Resource{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hasIfMatch := func(ctx context.Context) bool {
+		return store.IfMatchFromContext(ctx) != ""
+	}
+
 	type storeFunc func(*mockstore.MockStore)
 	tests := []struct {
 		name      string
 		urlVars   map[string]string
 		storeFunc storeFunc
 		wantErr   bool
+
+		// wantCreateOrUpdate is true if the resource is stored again rather
+		// than deleted
+		wantCreateOrUpdate bool
 	}{
 		{
 			name:    "invalid URL parameter",
@@ -28,6 +41,8 @@ func TestHandlers_DeleteResource(t *testing.T) {
 			name:    "store ErrNotFound",
 			urlVars: map[string]string{"id": "foo"},
 			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetResource", mock.Anything, "foo", mock.Anything).
+					Return(&store.ErrNotFound{})
 				s.On("DeleteResource", mock.Anything, "resource", "foo").
 					Return(&store.ErrNotFound{})
 			},
@@ -37,18 +52,86 @@ func TestHandlers_DeleteResource(t *testing.T) {
 			name:    "store ErrInternal",
 			urlVars: map[string]string{"id": "foo"},
 			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetResource", mock.Anything, "foo", mock.Anything).
+					Return(&store.ErrNotFound{})
 				s.On("DeleteResource", mock.Anything, "resource", "foo").
 					Return(&store.ErrInternal{})
 			},
 			wantErr: true,
 		},
+		{
+			name:    "store error while getting the resource",
+			urlVars: map[string]string{"id": "foo"},
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetResource", mock.Anything, "foo", mock.Anything).
+					Return(&store.ErrInternal{})
+			},
+			wantErr: true,
+		},
+		{
+			name:    "resource with finalizers is marked for deletion",
+			urlVars: map[string]string{"id": "foo"},
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetResource", mock.Anything, "foo", mock.Anything).
+					Run(func(args mock.Arguments) {
+						resource := args.Get(2).(*fixture.Resource)
+						resource.Name = "foo"
+						resource.Finalizers = []string{"cleanup"}
+					}).Return(nil)
+				s.On("CreateOrUpdateResource", mock.MatchedBy(hasIfMatch), mock.MatchedBy(func(r *fixture.Resource) bool {
+					return r.DeletedAt > 0
+				})).Return(nil)
+			},
+			wantCreateOrUpdate: true,
+		},
+		{
+			name:    "finalizer removed while marking for deletion",
+			urlVars: map[string]string{"id": "foo"},
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetResource", mock.Anything, "foo", mock.Anything).
+					Run(func(args mock.Arguments) {
+						resource := args.Get(2).(*fixture.Resource)
+						resource.Name = "foo"
+						resource.Finalizers = []string{"cleanup"}
+					}).Return(nil)
+				s.On("CreateOrUpdateResource", mock.MatchedBy(hasIfMatch), mock.Anything).
+					Return(&store.ErrPreconditionFailed{})
+			},
+			wantErr: true,
+		},
+		{
+			name:    "resource already marked for deletion",
+			urlVars: map[string]string{"id": "foo"},
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetResource", mock.Anything, "foo", mock.Anything).
+					Run(func(args mock.Arguments) {
+						resource := args.Get(2).(*fixture.Resource)
+						resource.Name = "foo"
+						resource.Finalizers = []string{"cleanup"}
+						resource.DeletedAt = 1
+					}).Return(nil)
+			},
+		},
 		{
 			name:    "successful delete",
 			urlVars: map[string]string{"id": "foo"},
 			storeFunc: func(s *mockstore.MockStore) {
-				s.On("DeleteResource", mock.Anything, "resource", "foo").
+				s.On("GetResource", mock.Anything, "foo", mock.Anything).
 					Return(nil)
+				s.On("DeleteResourceIfMatch", mock.Anything, mock.AnythingOfType("*fixture.Resource"), "foo", etag).
+					Return(nil)
+			},
+		},
+		{
+			name:    "finalizer added while deleting",
+			urlVars: map[string]string{"id": "foo"},
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetResource", mock.Anything, "foo", mock.Anything).
+					Return(nil)
+				s.On("DeleteResourceIfMatch", mock.Anything, mock.AnythingOfType("*fixture.Resource"), "foo", etag).
+					Return(&store.ErrPreconditionFailed{})
 			},
+			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
@@ -71,6 +154,12 @@ func TestHandlers_DeleteResource(t *testing.T) {
 				t.Errorf("Handlers.DeleteResource() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantCreateOrUpdate {
+				store.AssertCalled(t, "CreateOrUpdateResource", mock.Anything, mock.Anything)
+			}
+			if tt.storeFunc != nil && !tt.wantErr && !tt.wantCreateOrUpdate {
+				store.AssertNotCalled(t, "CreateOrUpdateResource", mock.Anything, mock.Anything)
+			}
 		})
 	}
 }
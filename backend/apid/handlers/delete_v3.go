@@ -11,6 +11,9 @@ import (
 	storev2 "github.com/sensu/sensu-go/backend/store/v2"
 )
 
+// DeleteV3Resource deletes the resource identified in the request path.
+// Deletions of resources that require approval are recorded as pending
+// changes instead.
 func (h Handlers) DeleteV3Resource(r *http.Request) (interface{}, error) {
 	params := mux.Vars(r)
 	name, err := url.PathUnescape(params["id"])
@@ -23,15 +26,39 @@ func (h Handlers) DeleteV3Resource(r *http.Request) (interface{}, error) {
 	storeName := h.V3Resource.StoreName()
 
 	req := storev2.NewResourceRequest(ctx, namespace, name, storeName)
+	if w, err := h.StoreV2.Get(req); err == nil {
+		stored, err := w.Unwrap()
+		if err != nil {
+			return nil, actions.NewError(actions.InternalErr, err)
+		}
+		// Deletions of resources that require approval are only applied
+		// once approved by another user
+		if requiresApproval(stored) {
+			return h.proposeDeletion(ctx, stored)
+		}
+		// Only delete the resource that was checked
+		etag, err := store.ETag(stored)
+		if err != nil {
+			return nil, actions.NewError(actions.InternalErr, err)
+		}
+		req.Context = store.ContextWithIfMatch(req.Context, etag)
+	} else if _, ok := err.(*store.ErrNotFound); !ok {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+
 	if err := h.StoreV2.Delete(req); err != nil {
 		switch err := err.(type) {
 		case *store.ErrNotFound:
 			return nil, actions.NewErrorf(actions.NotFound)
 		case *store.ErrNotValid:
 			return nil, actions.NewError(actions.InvalidArgument, err)
+		case *store.ErrPreconditionFailed:
+			// The resource was modified after it was checked
+			return nil, actions.NewRetryableError(actions.PreconditionFailed, err)
 		default:
 			return nil, actions.NewError(actions.InternalErr, err)
 		}
 	}
+	publishDeletion(ctx, h.V3Resource, name)
 	return nil, nil
 }
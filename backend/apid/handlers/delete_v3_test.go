@@ -6,7 +6,9 @@ import (
 
 	"github.com/gorilla/mux"
 
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/store"
+	storev2 "github.com/sensu/sensu-go/backend/store/v2"
 	"github.com/sensu/sensu-go/testing/fixture"
 	"github.com/sensu/sensu-go/testing/mockstore"
 	"github.com/stretchr/testify/mock"
@@ -29,6 +31,8 @@ func TestHandlers_DeleteV3Resource(t *testing.T) {
 			name:    "store ErrNotFound",
 			urlVars: map[string]string{"id": "foo"},
 			storeFunc: func(s *mockstore.V2MockStore) {
+				s.On("Get", mock.Anything).
+					Return(nil, &store.ErrNotFound{})
 				s.On("Delete", mock.Anything).
 					Return(&store.ErrNotFound{})
 			},
@@ -38,15 +42,30 @@ func TestHandlers_DeleteV3Resource(t *testing.T) {
 			name:    "store ErrInternal",
 			urlVars: map[string]string{"id": "foo"},
 			storeFunc: func(s *mockstore.V2MockStore) {
+				s.On("Get", mock.Anything).
+					Return(nil, &store.ErrNotFound{})
 				s.On("Delete", mock.Anything).
 					Return(&store.ErrInternal{})
 			},
 			wantErr: true,
 		},
+		{
+			name:    "resource modified while deleted",
+			urlVars: map[string]string{"id": "foo"},
+			storeFunc: func(s *mockstore.V2MockStore) {
+				wrapper, _ := storev2.WrapResource(&fixture.V3Resource{Metadata: corev2.NewObjectMetaP("foo", "default")})
+				s.On("Get", mock.Anything).Return(wrapper, nil)
+				s.On("Delete", mock.Anything).
+					Return(&store.ErrPreconditionFailed{Key: "foo"})
+			},
+			wantErr: true,
+		},
 		{
 			name:    "successful delete",
 			urlVars: map[string]string{"id": "foo"},
 			storeFunc: func(s *mockstore.V2MockStore) {
+				s.On("Get", mock.Anything).
+					Return(nil, &store.ErrNotFound{})
 				s.On("Delete", mock.Anything).
 					Return(nil)
 			},
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/store"
+	storev2 "github.com/sensu/sensu-go/backend/store/v2"
+)
+
+// ResourceVersion is a retained version of a resource.
+type ResourceVersion struct {
+	// WrittenAt is the time at which the version was written, in seconds since
+	// the epoch.
+	WrittenAt int64 `json:"written_at"`
+
+	// WrittenBy is the user that wrote the version, as recorded in the
+	// created_by metadata of the resource.
+	WrittenBy string `json:"written_by,omitempty"`
+
+	// Resource is the resource, as it was written at that time.
+	Resource corev3.Resource `json:"resource"`
+}
+
+// GetV3ResourceHistory returns the retained versions of a core/v3 resource,
+// newest first.
+func (h Handlers) GetV3ResourceHistory(r *http.Request) (interface{}, error) {
+	params := mux.Vars(r)
+	name, err := url.PathUnescape(params["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := r.Context()
+	namespace := store.NewNamespaceFromContext(ctx)
+	storeName := h.V3Resource.StoreName()
+
+	req := storev2.NewResourceRequest(ctx, namespace, name, storeName)
+	versions, err := h.StoreV2.History(req)
+	if err != nil {
+		switch err := err.(type) {
+		case *store.ErrNotFound:
+			return nil, actions.NewErrorf(actions.NotFound)
+		case *store.ErrNotValid:
+			return nil, actions.NewError(actions.InvalidArgument, err)
+		default:
+			return nil, actions.NewError(actions.InternalErr, err)
+		}
+	}
+
+	result := make([]ResourceVersion, 0, len(versions))
+	for _, version := range versions {
+		resource, err := version.Wrapper.Unwrap()
+		if err != nil {
+			return nil, actions.NewError(actions.InternalErr, err)
+		}
+		rv := ResourceVersion{
+			WrittenAt: version.WrittenAt.Unix(),
+			Resource:  resource,
+		}
+		if meta := resource.GetMetadata(); meta != nil {
+			rv.WrittenBy = meta.CreatedBy
+		}
+		result = append(result, rv)
+	}
+
+	return result, nil
+}
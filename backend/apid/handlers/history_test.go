@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	storev2 "github.com/sensu/sensu-go/backend/store/v2"
+	"github.com/sensu/sensu-go/testing/fixture"
+	"github.com/sensu/sensu-go/testing/mockstore"
+)
+
+func TestHandlers_GetV3ResourceHistory(t *testing.T) {
+	meta := corev2.NewObjectMeta("bar", "default")
+	meta.CreatedBy = "admin"
+	barResource := &fixture.V3Resource{Metadata: &meta}
+	wrapper, _ := storev2.WrapResource(barResource)
+	writtenAt := time.Unix(1600000000, 0)
+	tests := []struct {
+		name      string
+		urlVars   map[string]string
+		storeFunc func(*mockstore.V2MockStore)
+		want      interface{}
+		wantErr   bool
+	}{
+		{
+			name:    "invalid URL parameter",
+			urlVars: map[string]string{"id": "%"},
+			wantErr: true,
+		},
+		{
+			name:    "store ErrNotFound",
+			urlVars: map[string]string{"id": "bar"},
+			storeFunc: func(s *mockstore.V2MockStore) {
+				s.On("History", mock.Anything).
+					Return(nil, &store.ErrNotFound{})
+			},
+			wantErr: true,
+		},
+		{
+			name:    "store ErrInternal",
+			urlVars: map[string]string{"id": "bar"},
+			storeFunc: func(s *mockstore.V2MockStore) {
+				s.On("History", mock.Anything).
+					Return(nil, &store.ErrInternal{})
+			},
+			wantErr: true,
+		},
+		{
+			name:    "successful history",
+			urlVars: map[string]string{"id": "bar"},
+			storeFunc: func(s *mockstore.V2MockStore) {
+				s.On("History", mock.Anything).
+					Return([]storev2.Version{{WrittenAt: writtenAt, Wrapper: wrapper}}, nil)
+			},
+			want: []ResourceVersion{
+				{
+					WrittenAt: writtenAt.Unix(),
+					WrittenBy: "admin",
+					Resource:  barResource,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockstore.V2MockStore{}
+			if tt.storeFunc != nil {
+				tt.storeFunc(store)
+			}
+
+			h := Handlers{
+				V3Resource: &fixture.V3Resource{},
+				StoreV2:    store,
+			}
+
+			r, _ := http.NewRequest(http.MethodGet, "/", nil)
+			r = mux.SetURLVars(r, tt.urlVars)
+
+			got, err := h.GetV3ResourceHistory(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Handlers.GetV3ResourceHistory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Handlers.GetV3ResourceHistory() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
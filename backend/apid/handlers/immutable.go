@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store/patch"
+)
+
+// objectMeta returns the metadata of a core/v2 or core/v3 resource, or nil if
+// the resource has none.
+func objectMeta(resource interface{}) *corev2.ObjectMeta {
+	switch r := resource.(type) {
+	case interface{ GetMetadata() *corev2.ObjectMeta }:
+		return r.GetMetadata()
+	case interface{ GetObjectMeta() corev2.ObjectMeta }:
+		meta := r.GetObjectMeta()
+		return &meta
+	}
+	return nil
+}
+
+// isImmutable returns whether the resource carries the immutable annotation.
+func isImmutable(resource interface{}) bool {
+	meta := objectMeta(resource)
+	return meta != nil && meta.Annotations[corev2.ImmutableAnnotation] == "true"
+}
+
+// checkImmutable returns an error if the stored resource is immutable and the
+// updated resource differs from it by anything else than the immutable
+// annotation, so that the annotation must be removed before the resource can
+// be modified.
+func checkImmutable(stored, updated interface{}) error {
+	if !isImmutable(stored) {
+		return nil
+	}
+
+	a, err := mutableFields(stored)
+	if err != nil {
+		return err
+	}
+	b, err := mutableFields(updated)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(a, b) {
+		meta := objectMeta(stored)
+		return fmt.Errorf(
+			"the resource %s is immutable, the %s annotation must be removed before it can be modified",
+			meta.Name,
+			corev2.ImmutableAnnotation,
+		)
+	}
+
+	return nil
+}
+
+// checkImmutablePatch is the equivalent of checkImmutable for patches, which
+// are applied to a copy of the stored resource.
+func checkImmutablePatch(stored interface{}, patcher patch.Patcher) error {
+	if !isImmutable(stored) {
+		return nil
+	}
+
+	original, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+	patched, err := patcher.Patch(original)
+	if err != nil {
+		return err
+	}
+	updated := reflect.New(reflect.TypeOf(stored).Elem()).Interface()
+	if err := json.Unmarshal(patched, updated); err != nil {
+		return err
+	}
+
+	return checkImmutable(stored, updated)
+}
+
+// mutableFields returns the JSON encoding of a copy of the resource, without
+// the immutable annotation nor the fields that are set by the server.
+func mutableFields(resource interface{}) ([]byte, error) {
+	b, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+	c := reflect.New(reflect.TypeOf(resource).Elem()).Interface()
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, err
+	}
+
+	switch r := c.(type) {
+	case interface{ GetMetadata() *corev2.ObjectMeta }:
+		if meta := r.GetMetadata(); meta != nil {
+			stripMeta(meta)
+		}
+	case interface {
+		GetObjectMeta() corev2.ObjectMeta
+		SetObjectMeta(corev2.ObjectMeta)
+	}:
+		meta := r.GetObjectMeta()
+		stripMeta(&meta)
+		r.SetObjectMeta(meta)
+	}
+
+	return json.Marshal(c)
+}
+
+func stripMeta(meta *corev2.ObjectMeta) {
+	meta.CreatedBy = ""
+	delete(meta.Annotations, corev2.ImmutableAnnotation)
+	if len(meta.Annotations) == 0 {
+		meta.Annotations = nil
+	}
+	if len(meta.Labels) == 0 {
+		meta.Labels = nil
+	}
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store/patch"
+	"github.com/sensu/sensu-go/testing/fixture"
+)
+
+func TestCheckImmutable(t *testing.T) {
+	immutable := func(foo string) *fixture.Resource {
+		r := &fixture.Resource{Foo: foo, ObjectMeta: corev2.NewObjectMeta("foo", "default")}
+		r.Annotations[corev2.ImmutableAnnotation] = "true"
+		return r
+	}
+	mutable := func(foo string) *fixture.Resource {
+		return &fixture.Resource{Foo: foo, ObjectMeta: corev2.NewObjectMeta("foo", "default")}
+	}
+	createdBy := immutable("bar")
+	createdBy.CreatedBy = "admin"
+
+	tests := []struct {
+		name    string
+		stored  *fixture.Resource
+		updated *fixture.Resource
+		wantErr bool
+	}{
+		{
+			name:    "mutable resource",
+			stored:  mutable("bar"),
+			updated: mutable("baz"),
+		},
+		{
+			name:    "immutable resource modified",
+			stored:  immutable("bar"),
+			updated: immutable("baz"),
+			wantErr: true,
+		},
+		{
+			name:    "immutable resource modified along with its immutability",
+			stored:  immutable("bar"),
+			updated: mutable("baz"),
+			wantErr: true,
+		},
+		{
+			name:    "immutability removed",
+			stored:  immutable("bar"),
+			updated: mutable("bar"),
+		},
+		{
+			name:    "immutable resource unchanged",
+			stored:  immutable("bar"),
+			updated: createdBy,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkImmutable(tt.stored, tt.updated); (err != nil) != tt.wantErr {
+				t.Errorf("checkImmutable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckImmutablePatch(t *testing.T) {
+	stored := &fixture.V3Resource{Metadata: corev2.NewObjectMetaP("foo", "default")}
+	stored.Metadata.Labels["env"] = "prod"
+	stored.Metadata.Annotations[corev2.ImmutableAnnotation] = "true"
+
+	tests := []struct {
+		name    string
+		patch   string
+		wantErr bool
+	}{
+		{
+			name:    "label modified",
+			patch:   `{"Metadata":{"labels":{"env":"dev"}}}`,
+			wantErr: true,
+		},
+		{
+			name:  "immutability removed",
+			patch: `{"Metadata":{"annotations":{"sensu.io/immutable":null}}}`,
+		},
+		{
+			name:  "immutability disabled",
+			patch: `{"Metadata":{"annotations":{"sensu.io/immutable":"false"}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patcher := &patch.Merge{MergePatch: []byte(tt.patch)}
+			if err := checkImmutablePatch(stored, patcher); (err != nil) != tt.wantErr {
+				t.Errorf("checkImmutablePatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
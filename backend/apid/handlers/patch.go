@@ -6,15 +6,19 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"mime"
 	"net/http"
 	"net/url"
+	"path"
 	"reflect"
 	"strings"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	corev3 "github.com/sensu/sensu-go/api/core/v3"
 	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/messaging"
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/backend/store/patch"
 	storev2 "github.com/sensu/sensu-go/backend/store/v2"
@@ -25,12 +29,29 @@ const (
 	mergePatchContentType = "application/merge-patch+json"
 	jsonPatchContentType  = "application/json-patch+json"
 
+	// pointerPatchContentType is used for patches that replace values
+	// referenced by JSON Pointers, see patch.Pointer
+	pointerPatchContentType = "application/vnd.sensu.pointer-patch+json"
+
+	// protoPatchContentType is used for patches whose body is the protobuf
+	// encoding of the resource, of which only the fields listed by the
+	// update_mask query parameter are applied, see patch.FieldMask
+	protoPatchContentType = "application/merge-patch+proto"
+
+	// jsonContentType is accepted as a merge patch, for clients that do not
+	// send the merge patch content type. It is deprecated, see PatchWarning.
+	jsonContentType = "application/json"
+
 	ifMatchHeader     = "If-Match"
 	ifNoneMatchHeader = "If-None-Match"
+
+	// updateMaskQueryParam holds the comma-separated field mask of protobuf
+	// patches
+	updateMaskQueryParam = "update_mask"
 )
 
 // acceptedContentTypes contains the list of content types we accept
-var acceptedContentTypes = []string{mergePatchContentType}
+var acceptedContentTypes = []string{mergePatchContentType, jsonPatchContentType, pointerPatchContentType, protoPatchContentType}
 
 // PatchResource patches a given resource, using the request body as the patch
 func (h Handlers) PatchResource(r *http.Request) (interface{}, error) {
@@ -47,14 +68,21 @@ func (h Handlers) PatchResource(r *http.Request) (interface{}, error) {
 
 	// Determine the requested PATCH operation based on the Content-Type header
 	// and initialize a patcher
-	switch contentType := r.Header.Get("Content-Type"); contentType {
-	case mergePatchContentType, "": // Use merge patch as fallback value
+	contentType, err := patchContentType(r)
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	switch contentType {
+	case mergePatchContentType, jsonContentType, "": // Use merge patch as fallback value
 		patcher = &patch.Merge{MergePatch: body}
+	case pointerPatchContentType:
+		patcher = &patch.Pointer{PointerPatch: body}
 	case jsonPatchContentType:
-		return nil, actions.NewError(
-			actions.InvalidArgument,
-			fmt.Errorf("JSON Patch is not supported yet. Allowed values: %s", strings.Join(acceptedContentTypes, ", ")),
-		)
+		patcher = &patch.JSON{JSONPatch: body}
+	case protoPatchContentType:
+		if patcher, err = h.fieldMaskPatch(r, body); err != nil {
+			return nil, actions.NewError(actions.InvalidArgument, err)
+		}
 	default:
 		return nil, actions.NewError(
 			actions.InvalidArgument,
@@ -80,10 +108,33 @@ func (h Handlers) PatchResource(r *http.Request) (interface{}, error) {
 	}
 
 	// Validate that the patch does not alter the namespace nor the name
-	if err := validatePatch(body, params); err != nil {
+	validate := validatePatch
+	switch p := patcher.(type) {
+	case *patch.Pointer:
+		validate = validatePointerPatch
+	case *patch.JSON:
+		validate = validateJSONPatch
+	case *patch.FieldMask:
+		validate = func(_ []byte, vars map[string]string) error {
+			return validateFieldMaskPatch(p, vars)
+		}
+	}
+	if err := validate(body, params); err != nil {
 		return nil, actions.NewError(actions.InvalidArgument, err)
 	}
 
+	// Validate that the fields set by a merge patch exist on the resource,
+	// with compatible types, before the store applies it
+	if merge, ok := patcher.(*patch.Merge); ok {
+		var target interface{} = h.Resource
+		if target == nil {
+			target = h.V3Resource
+		}
+		if err := merge.ValidateSchema(target); err != nil {
+			return nil, actions.NewError(actions.InvalidArgument, err)
+		}
+	}
+
 	if h.Resource != nil {
 		return h.patchV2Resource(r.Context(), body, name, patcher, conditions)
 	} else if h.V3Resource != nil {
@@ -93,9 +144,68 @@ func (h Handlers) PatchResource(r *http.Request) (interface{}, error) {
 	return nil, actions.NewError(actions.InvalidArgument, errors.New("no resource available"))
 }
 
+// PatchWarning returns the value of the Warning header to send in response to
+// the given PATCH request, or an empty string if the request warrants none.
+func PatchWarning(r *http.Request) string {
+	if contentType, _ := patchContentType(r); contentType == jsonContentType {
+		return fmt.Sprintf(
+			`299 - "the %s Content-Type is deprecated for PATCH requests, use %s instead"`,
+			jsonContentType,
+			mergePatchContentType,
+		)
+	}
+	return ""
+}
+
+// patchContentType returns the media type of the Content-Type header of a
+// PATCH request, without its parameters, such as its charset.
+func patchContentType(r *http.Request) (string, error) {
+	header := r.Header.Get("Content-Type")
+	if header == "" {
+		return "", nil
+	}
+	contentType, _, err := mime.ParseMediaType(header)
+	if err != nil {
+		return "", fmt.Errorf("invalid Content-Type header %q: %s", header, err)
+	}
+	return contentType, nil
+}
+
+// fieldMaskPatch returns the field mask patch of a protobuf PATCH request,
+// whose body is decoded into a resource of the type handled by h.
+func (h Handlers) fieldMaskPatch(r *http.Request, body []byte) (*patch.FieldMask, error) {
+	var paths []string
+	for _, path := range strings.Split(r.URL.Query().Get(updateMaskQueryParam), ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("the %s query parameter is required for %s patches", updateMaskQueryParam, protoPatchContentType)
+	}
+
+	var t reflect.Type
+	if h.Resource != nil {
+		t = reflect.TypeOf(h.Resource).Elem()
+	} else if h.V3Resource != nil {
+		t = reflect.TypeOf(h.V3Resource).Elem()
+	} else {
+		return nil, errors.New("no resource available")
+	}
+	message, ok := reflect.New(t).Interface().(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("%s resources cannot be patched with %s", t, protoPatchContentType)
+	}
+	if err := proto.Unmarshal(body, message); err != nil {
+		return nil, fmt.Errorf("could not decode the protobuf patch: %s", err)
+	}
+
+	return &patch.FieldMask{Paths: paths, Message: message}, nil
+}
+
 func (h Handlers) patchV2Resource(ctx context.Context, body []byte, name string, patcher patch.Patcher, conditions *store.ETagCondition) (interface{}, error) {
 	payload := reflect.New(reflect.TypeOf(h.Resource).Elem())
-	if err := json.Unmarshal(body, payload.Interface()); err != nil {
+	if err := decodePatchPayload(body, patcher, payload.Interface()); err != nil {
 		return nil, actions.NewError(actions.InvalidArgument, err)
 	}
 	resource, ok := payload.Interface().(corev2.Resource)
@@ -103,25 +213,53 @@ func (h Handlers) patchV2Resource(ctx context.Context, body []byte, name string,
 		return nil, actions.NewErrorf(actions.InvalidArgument)
 	}
 
-	if err := h.Store.PatchResource(ctx, resource, name, patcher, conditions); err != nil {
+	// Immutable resources can only be patched to remove their immutability
+	stored := reflect.New(reflect.TypeOf(h.Resource).Elem()).Interface().(corev2.Resource)
+	if err := h.Store.GetResource(ctx, name, stored); err != nil {
+		switch err := err.(type) {
+		case *store.ErrNotFound:
+			return nil, actions.NewError(actions.NotFound, err)
+		default:
+			return nil, actions.NewError(actions.InternalErr, err)
+		}
+	}
+	if err := checkImmutablePatch(stored, patcher); err != nil {
+		return nil, immutablePatchError(err)
+	}
+	// Patches of resources that require approval are only applied once
+	// approved by another user
+	if requiresApproval(stored) {
+		return h.proposePatch(ctx, stored, patcher, conditions)
+	}
+	checked, err := checkedConditions(name, stored, conditions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.Store.PatchResource(ctx, resource, name, patcher, checked); err != nil {
 		switch err := err.(type) {
 		case *store.ErrNotFound:
 			return nil, actions.NewError(actions.NotFound, err)
 		case *store.ErrNotValid:
 			return nil, actions.NewError(actions.InvalidArgument, err)
 		case *store.ErrPreconditionFailed:
+			return nil, preconditionFailed(err, conditions)
+		case *patch.TestFailedError:
 			return nil, actions.NewError(actions.PreconditionFailed, err)
+		case *patch.InvalidPointerError, *patch.InvalidFieldMaskError:
+			return nil, actions.NewError(actions.InvalidArgument, err)
 		default:
 			return nil, actions.NewError(actions.InternalErr, err)
 		}
 	}
+	publishChange(ctx, resource, messaging.ResourcePatched)
 
 	return resource, nil
 }
 
 func (h Handlers) patchV3Resource(ctx context.Context, body []byte, name, namespace string, patcher patch.Patcher, conditions *store.ETagCondition) (interface{}, error) {
 	payload := reflect.New(reflect.TypeOf(h.V3Resource).Elem())
-	if err := json.Unmarshal(body, payload.Interface()); err != nil {
+	if err := decodePatchPayload(body, patcher, payload.Interface()); err != nil {
 		return nil, actions.NewError(actions.InvalidArgument, err)
 	}
 	resource, ok := payload.Interface().(corev3.Resource)
@@ -135,14 +273,55 @@ func (h Handlers) patchV3Resource(ctx context.Context, body []byte, name, namesp
 		return nil, actions.NewError(actions.InvalidArgument, err)
 	}
 
-	if err := h.StoreV2.Patch(req, w, patcher, conditions); err != nil {
+	// Immutable resources can only be patched to remove their immutability
+	storedWrapper, err := h.StoreV2.Get(req)
+	if err != nil {
+		switch err := err.(type) {
+		case *store.ErrNotFound:
+			return nil, actions.NewError(actions.NotFound, err)
+		default:
+			return nil, actions.NewError(actions.InternalErr, err)
+		}
+	}
+	stored, err := storedWrapper.Unwrap()
+	if err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	if err := checkImmutablePatch(stored, patcher); err != nil {
+		return nil, immutablePatchError(err)
+	}
+	// Patches of resources that require approval are only applied once
+	// approved by another user
+	if requiresApproval(stored) {
+		return h.proposePatch(ctx, stored, patcher, conditions)
+	}
+	checked, err := checkedConditions(name, stored, conditions)
+	if err != nil {
+		return nil, err
+	}
+
+	// Patches that only touch labels and annotations are merged atomically by
+	// the store, so that concurrent patches of distinct keys don't clobber
+	// each other
+	var patchErr error
+	if labels, annotations, ok := metadataOnlyPatch(body, patcher); ok {
+		patchErr = h.StoreV2.MergeLabels(req, w, labels, annotations, checked)
+	} else {
+		patchErr = h.StoreV2.Patch(req, w, patcher, checked)
+	}
+
+	if err := patchErr; err != nil {
 		switch err := err.(type) {
 		case *store.ErrNotFound:
 			return nil, actions.NewError(actions.NotFound, err)
 		case *store.ErrNotValid:
 			return nil, actions.NewError(actions.InvalidArgument, err)
 		case *store.ErrPreconditionFailed:
+			return nil, preconditionFailed(err, conditions)
+		case *patch.TestFailedError:
 			return nil, actions.NewError(actions.PreconditionFailed, err)
+		case *patch.InvalidPointerError, *patch.InvalidFieldMaskError:
+			return nil, actions.NewError(actions.InvalidArgument, err)
 		default:
 			return nil, actions.NewError(actions.InternalErr, err)
 		}
@@ -153,10 +332,112 @@ func (h Handlers) patchV3Resource(ctx context.Context, body []byte, name, namesp
 	if err != nil {
 		return nil, actions.NewError(actions.InternalErr, err)
 	}
+	publishChange(ctx, resource, messaging.ResourcePatched)
 
 	return resource, nil
 }
 
+// decodePatchPayload decodes the body of a patch into v, so that fields of the
+// wrong type are rejected before reaching the store. JSON Patches are lists of
+// operations rather than partial resources, so they are left undecoded.
+func decodePatchPayload(body []byte, patcher patch.Patcher, v interface{}) error {
+	switch patcher.(type) {
+	case *patch.JSON:
+		return nil
+	case *patch.FieldMask:
+		return proto.Unmarshal(body, v.(proto.Message))
+	}
+	return json.Unmarshal(body, v)
+}
+
+// checkedConditions returns the conditions of a patch of the stored resource
+// that was checked, so that the patch fails if the resource is modified after
+// it was checked. The If-Match condition of the client is evaluated against
+// the checked resource instead.
+func checkedConditions(name string, stored interface{}, conditions *store.ETagCondition) (*store.ETagCondition, error) {
+	etag, err := store.ETag(stored)
+	if err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	checked := &store.ETagCondition{IfMatch: etag}
+	if conditions != nil {
+		if !store.CheckIfMatch(conditions.IfMatch, etag) {
+			return nil, preconditionFailed(&store.ErrPreconditionFailed{Key: name}, conditions)
+		}
+		checked.IfNoneMatch = conditions.IfNoneMatch
+	}
+	return checked, nil
+}
+
+// preconditionFailed returns the error for a patch that failed a precondition.
+// The patch is retryable when the resource was modified after the client or
+// the store read it, since it may apply cleanly to the new version with a
+// fresh ETag. A failed If-None-Match is a genuine conflict instead, because
+// the resource is in the very state the client wanted to avoid.
+func preconditionFailed(err error, conditions *store.ETagCondition) error {
+	if conditions != nil && conditions.IfNoneMatch != "" {
+		return actions.NewError(actions.PreconditionFailed, err)
+	}
+	return actions.NewRetryableError(actions.PreconditionFailed, err)
+}
+
+// immutablePatchError returns the error for a patch that could not be checked
+// against an immutable resource. Failed test operations of JSON Patches are
+// preconditions, like the If-Match header, rather than invalid patches.
+func immutablePatchError(err error) error {
+	if _, ok := err.(*patch.TestFailedError); ok {
+		return actions.NewError(actions.PreconditionFailed, err)
+	}
+	return actions.NewError(actions.InvalidArgument, err)
+}
+
+// metadataOnlyPatch returns the labels and annotations of the given merge patch
+// if the patch touches nothing but the labels and annotations of a resource.
+func metadataOnlyPatch(data []byte, patcher patch.Patcher) (labels, annotations map[string]*string, ok bool) {
+	if _, isMerge := patcher.(*patch.Merge); !isMerge {
+		return nil, nil, false
+	}
+
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, nil, false
+	}
+	if len(body) != 1 || body["metadata"] == nil {
+		return nil, nil, false
+	}
+
+	var metadata map[string]json.RawMessage
+	if err := json.Unmarshal(body["metadata"], &metadata); err != nil {
+		return nil, nil, false
+	}
+	for field, value := range metadata {
+		switch field {
+		case "labels":
+			if err := json.Unmarshal(value, &labels); err != nil {
+				return nil, nil, false
+			}
+		case "annotations":
+			if err := json.Unmarshal(value, &annotations); err != nil {
+				return nil, nil, false
+			}
+		case "name", "namespace":
+			// Already validated against the URI by validatePatch
+		default:
+			return nil, nil, false
+		}
+	}
+
+	// A null labels or annotations object would clear the whole map
+	if labels == nil && annotations == nil {
+		return nil, nil, false
+	}
+	if (metadata["labels"] != nil && labels == nil) || (metadata["annotations"] != nil && annotations == nil) {
+		return nil, nil, false
+	}
+
+	return labels, annotations, true
+}
+
 func validatePatch(data []byte, vars map[string]string) error {
 	type body struct {
 		Metadata *corev2.ObjectMeta `json:"metadata"`
@@ -198,3 +479,122 @@ func validatePatch(data []byte, vars map[string]string) error {
 
 	return nil
 }
+
+// validateFieldMaskPatch is the equivalent of validatePatch for field mask
+// patches. Masked fields are set even to their zero value, so a masked name or
+// namespace must be the one of the URI.
+func validateFieldMaskPatch(mask *patch.FieldMask, vars map[string]string) error {
+	meta := &corev2.ObjectMeta{}
+	switch m := mask.Message.(type) {
+	case interface{ GetMetadata() *corev2.ObjectMeta }:
+		if m.GetMetadata() != nil {
+			meta = m.GetMetadata()
+		}
+	case interface{ GetObjectMeta() corev2.ObjectMeta }:
+		objectMeta := m.GetObjectMeta()
+		meta = &objectMeta
+	}
+
+	name, err := url.PathUnescape(vars["id"])
+	if err != nil {
+		return err
+	}
+	namespace, err := url.PathUnescape(vars["namespace"])
+	if err != nil {
+		return err
+	}
+
+	for _, path := range mask.Paths {
+		if path == "metadata" || path == "metadata.name" {
+			if meta.Name != name {
+				return fmt.Errorf("the name of the resource (%s) does not match the name in the URI (%s)", meta.Name, name)
+			}
+		}
+		if path == "metadata" || path == "metadata.namespace" {
+			if meta.Namespace != namespace {
+				return fmt.Errorf("the namespace of the resource (%s) does not match the namespace in the URI (%s)", meta.Namespace, namespace)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validatePointerPatch is the equivalent of validatePatch for pointer patches.
+// The pointers that reference the metadata of the resource are validated as if
+// they were part of a merge patch.
+func validatePointerPatch(data []byte, vars map[string]string) error {
+	var changes map[string]json.RawMessage
+	if err := json.Unmarshal(data, &changes); err != nil {
+		return err
+	}
+
+	for pointer, value := range changes {
+		var body string
+		switch pointer {
+		case "/metadata":
+			body = fmt.Sprintf(`{"metadata":%s}`, value)
+		case "/metadata/name", "/metadata/namespace":
+			body = fmt.Sprintf(`{"metadata":{%q:%s}}`, path.Base(pointer), value)
+		default:
+			continue
+		}
+		if err := validatePatch([]byte(body), vars); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateJSONPatch is the equivalent of validatePatch for JSON Patches. The
+// values that operations add to the metadata of the resource are validated as
+// if they were part of a merge patch, and its name and namespace can't be
+// removed nor moved.
+func validateJSONPatch(data []byte, vars map[string]string) error {
+	var operations []struct {
+		Op    string          `json:"op"`
+		Path  string          `json:"path"`
+		From  string          `json:"from"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &operations); err != nil {
+		return err
+	}
+
+	for _, op := range operations {
+		switch op.Op {
+		case "add", "replace":
+			changes, err := json.Marshal(map[string]json.RawMessage{op.Path: op.Value})
+			if err != nil {
+				return err
+			}
+			if err := validatePointerPatch(changes, vars); err != nil {
+				return err
+			}
+		case "remove", "copy":
+			if err := validateJSONPatchPointer(op.Op, op.Path); err != nil {
+				return err
+			}
+		case "move":
+			if err := validateJSONPatchPointer(op.Op, op.Path); err != nil {
+				return err
+			}
+			if err := validateJSONPatchPointer(op.Op, op.From); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateJSONPatchPointer makes sure that an operation whose value is not
+// known upfront leaves the name and namespace of the resource alone.
+func validateJSONPatchPointer(op, pointer string) error {
+	switch pointer {
+	case "/metadata", "/metadata/name", "/metadata/namespace":
+		return fmt.Errorf("the %s operation cannot alter %s", op, pointer)
+	}
+	return nil
+}
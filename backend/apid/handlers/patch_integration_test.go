@@ -9,9 +9,11 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	corev3 "github.com/sensu/sensu-go/api/core/v3"
+	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/etcd"
 	"github.com/sensu/sensu-go/backend/seeds"
 	"github.com/sensu/sensu-go/backend/store"
@@ -56,6 +58,34 @@ func patchRequest(target, namespace, id, body string) *http.Request {
 	return mux.SetURLVars(r, vars)
 }
 
+func pointerPatchRequest(target, namespace, id, body string) *http.Request {
+	r := patchRequest(target, namespace, id, body)
+	r.Header.Set("Content-Type", pointerPatchContentType)
+	return r
+}
+
+func jsonPatchRequest(target, namespace, id, body string) *http.Request {
+	r := patchRequest(target, namespace, id, body)
+	r.Header.Set("Content-Type", jsonContentType)
+	return r
+}
+
+func rfc6902PatchRequest(target, namespace, id, body string) *http.Request {
+	r := patchRequest(target, namespace, id, body)
+	r.Header.Set("Content-Type", jsonPatchContentType)
+	return r
+}
+
+func protoPatchRequest(target, namespace, id string, message proto.Message) *http.Request {
+	body, err := proto.Marshal(message)
+	if err != nil {
+		panic(err)
+	}
+	r := patchRequest(target, namespace, id, string(body))
+	r.Header.Set("Content-Type", protoPatchContentType)
+	return r
+}
+
 func TestHandlers_PatchResource(t *testing.T) {
 	type fields struct {
 		Resource   corev2.Resource
@@ -69,11 +99,12 @@ func TestHandlers_PatchResource(t *testing.T) {
 		fields    fields
 		args      args
 		storeInit func(*testing.T, *etcdstore.Store, *etcdstorev2.Store)
-		want      interface{}
-		wantErr   bool
+		want        interface{}
+		wantErr     bool
+		wantErrCode actions.ErrCode
 	}{
 		{
-			name: "succeeds & ignores non-existent field for a V2 resource",
+			name: "errors when a merge patch sets a non-existent field for a V2 resource",
 			fields: fields{
 				Resource: &corev2.CheckConfig{},
 			},
@@ -87,9 +118,8 @@ func TestHandlers_PatchResource(t *testing.T) {
 					t.Fatal(err)
 				}
 			},
-			want: func() interface{} {
-				return corev2.FixtureCheckConfig("testcheck")
-			}(),
+			wantErr:     true,
+			wantErrCode: actions.InvalidArgument,
 		},
 		{
 			name: "errors when field has invalid type for a V2 resource",
@@ -130,7 +160,66 @@ func TestHandlers_PatchResource(t *testing.T) {
 			}(),
 		},
 		{
-			name: "succeeds & ignores non-existent field for a V3 resource",
+			name: "succeeds when a merge patch is sent as application/json for a V2 resource",
+			fields: fields{
+				Resource: &corev2.CheckConfig{},
+			},
+			args: args{
+				r: jsonPatchRequest("/", "default", "testcheck", `{"subscriptions": ["windows"]}`),
+			},
+			storeInit: func(t *testing.T, s1 *etcdstore.Store, s2 *etcdstorev2.Store) {
+				ctx := store.NamespaceContext(context.Background(), "default")
+				check := corev2.FixtureCheckConfig("testcheck")
+				if err := s1.UpdateCheckConfig(ctx, check); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: func() interface{} {
+				check := corev2.FixtureCheckConfig("testcheck")
+				check.Subscriptions = []string{"windows"}
+				return check
+			}(),
+		},
+		{
+			name: "succeeds when a pointer patch replaces an array element for a V2 resource",
+			fields: fields{
+				Resource: &corev2.CheckConfig{},
+			},
+			args: args{
+				r: pointerPatchRequest("/", "default", "testcheck", `{"/subscriptions/0": "windows"}`),
+			},
+			storeInit: func(t *testing.T, s1 *etcdstore.Store, s2 *etcdstorev2.Store) {
+				ctx := store.NamespaceContext(context.Background(), "default")
+				check := corev2.FixtureCheckConfig("testcheck")
+				if err := s1.UpdateCheckConfig(ctx, check); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: func() interface{} {
+				check := corev2.FixtureCheckConfig("testcheck")
+				check.Subscriptions = []string{"windows"}
+				return check
+			}(),
+		},
+		{
+			name: "errors when a pointer patch index is out of range for a V2 resource",
+			fields: fields{
+				Resource: &corev2.CheckConfig{},
+			},
+			args: args{
+				r: pointerPatchRequest("/", "default", "testcheck", `{"/subscriptions/1": "windows"}`),
+			},
+			storeInit: func(t *testing.T, s1 *etcdstore.Store, s2 *etcdstorev2.Store) {
+				ctx := store.NamespaceContext(context.Background(), "default")
+				check := corev2.FixtureCheckConfig("testcheck")
+				if err := s1.UpdateCheckConfig(ctx, check); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr: true,
+		},
+		{
+			name: "errors when a merge patch sets a non-existent field for a V3 resource",
 			fields: fields{
 				V3Resource: &corev3.EntityConfig{},
 			},
@@ -149,9 +238,8 @@ func TestHandlers_PatchResource(t *testing.T) {
 					t.Fatal(err)
 				}
 			},
-			want: func() interface{} {
-				return corev3.FixtureEntityConfig("testentity")
-			}(),
+			wantErr:     true,
+			wantErrCode: actions.InvalidArgument,
 		},
 		{
 			name: "errors when field has invalid type for a V3 resource",
@@ -201,6 +289,162 @@ func TestHandlers_PatchResource(t *testing.T) {
 				return entity
 			}(),
 		},
+		{
+			name: "succeeds when a field mask patch updates and clears masked fields of a V2 resource",
+			fields: fields{
+				Resource: &corev2.CheckConfig{},
+			},
+			args: args{
+				r: func() *http.Request {
+					check := corev2.FixtureCheckConfig("testcheck")
+					check.Interval = 30
+					check.Publish = false
+					check.Command = "not masked"
+					return protoPatchRequest("/?update_mask=interval,publish", "default", "testcheck", check)
+				}(),
+			},
+			storeInit: func(t *testing.T, s1 *etcdstore.Store, s2 *etcdstorev2.Store) {
+				ctx := store.NamespaceContext(context.Background(), "default")
+				check := corev2.FixtureCheckConfig("testcheck")
+				if err := s1.UpdateCheckConfig(ctx, check); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: func() interface{} {
+				check := corev2.FixtureCheckConfig("testcheck")
+				check.Interval = 30
+				check.Publish = false
+				return check
+			}(),
+		},
+		{
+			name: "succeeds when a field mask patch updates a V3 resource",
+			fields: fields{
+				V3Resource: &corev3.EntityConfig{},
+			},
+			args: args{
+				r: func() *http.Request {
+					entity := corev3.FixtureEntityConfig("testentity")
+					entity.Subscriptions = []string{"windows"}
+					return protoPatchRequest("/?update_mask=subscriptions", "default", "testentity", entity)
+				}(),
+			},
+			storeInit: func(t *testing.T, s1 *etcdstore.Store, s2 *etcdstorev2.Store) {
+				ctx := store.NamespaceContext(context.Background(), "default")
+				entity := corev3.FixtureEntityConfig("testentity")
+				req := storev2.NewResourceRequestFromResource(ctx, entity)
+				wrapper, err := storev2.WrapResource(entity)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := s2.CreateOrUpdate(req, wrapper); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: func() interface{} {
+				entity := corev3.FixtureEntityConfig("testentity")
+				entity.Subscriptions = []string{"windows", "entity:testentity"}
+				return entity
+			}(),
+		},
+		{
+			name: "errors when a field mask patch masks a name that does not match the URI",
+			fields: fields{
+				Resource: &corev2.CheckConfig{},
+			},
+			args: args{
+				r: protoPatchRequest("/?update_mask=metadata.name", "default", "testcheck", corev2.FixtureCheckConfig("other")),
+			},
+			storeInit: func(t *testing.T, s1 *etcdstore.Store, s2 *etcdstorev2.Store) {
+				ctx := store.NamespaceContext(context.Background(), "default")
+				check := corev2.FixtureCheckConfig("testcheck")
+				if err := s1.UpdateCheckConfig(ctx, check); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr:     true,
+			wantErrCode: actions.InvalidArgument,
+		},
+		{
+			name: "errors when a field mask patch references an unknown field",
+			fields: fields{
+				Resource: &corev2.CheckConfig{},
+			},
+			args: args{
+				r: protoPatchRequest("/?update_mask=foo", "default", "testcheck", corev2.FixtureCheckConfig("testcheck")),
+			},
+			storeInit: func(t *testing.T, s1 *etcdstore.Store, s2 *etcdstorev2.Store) {
+				ctx := store.NamespaceContext(context.Background(), "default")
+				check := corev2.FixtureCheckConfig("testcheck")
+				if err := s1.UpdateCheckConfig(ctx, check); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr:     true,
+			wantErrCode: actions.InvalidArgument,
+		},
+		{
+			name: "succeeds when the test operation of a JSON Patch passes for a V2 resource",
+			fields: fields{
+				Resource: &corev2.CheckConfig{},
+			},
+			args: args{
+				r: rfc6902PatchRequest("/", "default", "testcheck", `[{"op":"test","path":"/interval","value":60},{"op":"replace","path":"/interval","value":30}]`),
+			},
+			storeInit: func(t *testing.T, s1 *etcdstore.Store, s2 *etcdstorev2.Store) {
+				ctx := store.NamespaceContext(context.Background(), "default")
+				check := corev2.FixtureCheckConfig("testcheck")
+				if err := s1.UpdateCheckConfig(ctx, check); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: func() interface{} {
+				check := corev2.FixtureCheckConfig("testcheck")
+				check.Interval = 30
+				return check
+			}(),
+		},
+		{
+			name: "fails the precondition when the test operation of a JSON Patch fails for a V2 resource",
+			fields: fields{
+				Resource: &corev2.CheckConfig{},
+			},
+			args: args{
+				r: rfc6902PatchRequest("/", "default", "testcheck", `[{"op":"test","path":"/interval","value":10},{"op":"replace","path":"/interval","value":30}]`),
+			},
+			storeInit: func(t *testing.T, s1 *etcdstore.Store, s2 *etcdstorev2.Store) {
+				ctx := store.NamespaceContext(context.Background(), "default")
+				check := corev2.FixtureCheckConfig("testcheck")
+				if err := s1.UpdateCheckConfig(ctx, check); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr:     true,
+			wantErrCode: actions.PreconditionFailed,
+		},
+		{
+			name: "fails the precondition when the test operation of a JSON Patch fails for a V3 resource",
+			fields: fields{
+				V3Resource: &corev3.EntityConfig{},
+			},
+			args: args{
+				r: rfc6902PatchRequest("/", "default", "testentity", `[{"op":"test","path":"/entity_class","value":"proxy"},{"op":"replace","path":"/entity_class","value":"agent"}]`),
+			},
+			storeInit: func(t *testing.T, s1 *etcdstore.Store, s2 *etcdstorev2.Store) {
+				ctx := store.NamespaceContext(context.Background(), "default")
+				entity := corev3.FixtureEntityConfig("testentity")
+				req := storev2.NewResourceRequestFromResource(ctx, entity)
+				wrapper, err := storev2.WrapResource(entity)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := s2.CreateOrUpdate(req, wrapper); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr:     true,
+			wantErrCode: actions.PreconditionFailed,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -219,6 +463,11 @@ func TestHandlers_PatchResource(t *testing.T) {
 					t.Errorf("Handlers.PatchResource() error = %v, wantErr %v", err, tt.wantErr)
 					return
 				}
+				if tt.wantErrCode != 0 {
+					if actionErr, ok := err.(actions.Error); !ok || actionErr.Code != tt.wantErrCode {
+						t.Errorf("Handlers.PatchResource() error = %v, want code %v", err, tt.wantErrCode)
+					}
+				}
 				if tt.want != nil {
 					wantComparable, ok := tt.want.(comparable)
 					if !ok {
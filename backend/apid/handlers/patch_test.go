@@ -1,6 +1,17 @@
 package handlers
 
-import "testing"
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/backend/store/patch"
+)
 
 func TestValidatePatch(t *testing.T) {
 	tests := []struct {
@@ -61,3 +72,296 @@ func TestValidatePatch(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateFieldMaskPatch(t *testing.T) {
+	vars := map[string]string{"namespace": "default", "id": "check1"}
+	tests := []struct {
+		name       string
+		paths      []string
+		message    interface{}
+		wantErrMsg string
+	}{
+		{
+			name:    "succeeds when the metadata is not masked",
+			paths:   []string{"interval"},
+			message: corev2.FixtureCheckConfig("other"),
+		},
+		{
+			name:    "succeeds when the masked metadata matches the URI",
+			paths:   []string{"metadata"},
+			message: corev2.FixtureCheckConfig("check1"),
+		},
+		{
+			name:       "errors when the masked name does not match the URI",
+			paths:      []string{"metadata.name"},
+			message:    corev2.FixtureCheckConfig("other"),
+			wantErrMsg: "the name of the resource (other) does not match the name in the URI (check1)",
+		},
+		{
+			name:       "errors when the masked name is cleared",
+			paths:      []string{"metadata"},
+			message:    &corev2.CheckConfig{},
+			wantErrMsg: "the name of the resource () does not match the name in the URI (check1)",
+		},
+		{
+			name:       "errors when the masked namespace of a V3 resource does not match the URI",
+			paths:      []string{"metadata.namespace"},
+			message:    &corev3.EntityConfig{Metadata: &corev2.ObjectMeta{Name: "check1", Namespace: "acme"}},
+			wantErrMsg: "the namespace of the resource (acme) does not match the namespace in the URI (default)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFieldMaskPatch(&patch.FieldMask{Paths: tt.paths, Message: tt.message}, vars)
+			if tt.wantErrMsg == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMsg {
+				t.Errorf("validateFieldMaskPatch() error = %v, want %q", err, tt.wantErrMsg)
+			}
+		})
+	}
+}
+
+func TestValidatePointerPatch(t *testing.T) {
+	vars := map[string]string{"id": "foo", "namespace": "default"}
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr bool
+	}{
+		{
+			name: "array element",
+			data: []byte(`{"/subscriptions/2":"linux"}`),
+		},
+		{
+			name: "same name",
+			data: []byte(`{"/metadata/name":"foo"}`),
+		},
+		{
+			name:    "renames the resource",
+			data:    []byte(`{"/metadata/name":"bar"}`),
+			wantErr: true,
+		},
+		{
+			name:    "moves the resource to another namespace",
+			data:    []byte(`{"/metadata/namespace":"dev"}`),
+			wantErr: true,
+		},
+		{
+			name:    "replaces the metadata",
+			data:    []byte(`{"/metadata":{"name":"bar"}}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validatePointerPatch(tt.data, vars); (err != nil) != tt.wantErr {
+				t.Errorf("validatePointerPatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMetadataOnlyPatch(t *testing.T) {
+	tests := []struct {
+		name            string
+		data            []byte
+		patcher         patch.Patcher
+		wantOK          bool
+		wantLabels      map[string]*string
+		wantAnnotations map[string]*string
+	}{
+		{
+			name:       "labels only",
+			data:       []byte(`{"metadata":{"labels":{"foo":"bar","baz":null}}}`),
+			patcher:    &patch.Merge{},
+			wantOK:     true,
+			wantLabels: map[string]*string{"foo": stringPtr("bar"), "baz": nil},
+		},
+		{
+			name:            "labels and annotations with name",
+			data:            []byte(`{"metadata":{"name":"foo","labels":{"a":"b"},"annotations":{"c":"d"}}}`),
+			patcher:         &patch.Merge{},
+			wantOK:          true,
+			wantLabels:      map[string]*string{"a": stringPtr("b")},
+			wantAnnotations: map[string]*string{"c": stringPtr("d")},
+		},
+		{
+			name:    "other top-level fields",
+			data:    []byte(`{"metadata":{"labels":{"a":"b"}},"subscriptions":["linux"]}`),
+			patcher: &patch.Merge{},
+		},
+		{
+			name:    "other metadata fields",
+			data:    []byte(`{"metadata":{"labels":{"a":"b"},"created_by":"me"}}`),
+			patcher: &patch.Merge{},
+		},
+		{
+			name:    "null labels",
+			data:    []byte(`{"metadata":{"labels":null}}`),
+			patcher: &patch.Merge{},
+		},
+		{
+			name:    "name only",
+			data:    []byte(`{"metadata":{"name":"foo"}}`),
+			patcher: &patch.Merge{},
+		},
+		{
+			name:    "not a merge patch",
+			data:    []byte(`{"metadata":{"labels":{"a":"b"}}}`),
+			patcher: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labels, annotations, ok := metadataOnlyPatch(tt.data, tt.patcher)
+			if ok != tt.wantOK {
+				t.Fatalf("metadataOnlyPatch() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !reflect.DeepEqual(labels, tt.wantLabels) {
+				t.Errorf("metadataOnlyPatch() labels = %v, want %v", labels, tt.wantLabels)
+			}
+			if !reflect.DeepEqual(annotations, tt.wantAnnotations) {
+				t.Errorf("metadataOnlyPatch() annotations = %v, want %v", annotations, tt.wantAnnotations)
+			}
+		})
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestValidateJSONPatch(t *testing.T) {
+	vars := map[string]string{"id": "foo", "namespace": "default"}
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr bool
+	}{
+		{
+			name: "test and replace",
+			data: []byte(`[{"op":"test","path":"/interval","value":10},{"op":"replace","path":"/interval","value":20}]`),
+		},
+		{
+			name: "same name",
+			data: []byte(`[{"op":"replace","path":"/metadata/name","value":"foo"}]`),
+		},
+		{
+			name:    "renames the resource",
+			data:    []byte(`[{"op":"replace","path":"/metadata/name","value":"bar"}]`),
+			wantErr: true,
+		},
+		{
+			name:    "adds another namespace",
+			data:    []byte(`[{"op":"add","path":"/metadata","value":{"namespace":"dev"}}]`),
+			wantErr: true,
+		},
+		{
+			name:    "removes the name",
+			data:    []byte(`[{"op":"remove","path":"/metadata/name"}]`),
+			wantErr: true,
+		},
+		{
+			name:    "moves the namespace",
+			data:    []byte(`[{"op":"move","from":"/metadata/namespace","path":"/command"}]`),
+			wantErr: true,
+		},
+		{
+			name: "copies the name",
+			data: []byte(`[{"op":"copy","from":"/metadata/name","path":"/command"}]`),
+		},
+		{
+			name:    "not a list of operations",
+			data:    []byte(`{"/metadata/name":"foo"}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateJSONPatch(tt.data, vars); (err != nil) != tt.wantErr {
+				t.Errorf("validateJSONPatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestImmutablePatchError(t *testing.T) {
+	err := immutablePatchError(&patch.TestFailedError{Path: "/interval", Err: errors.New("fail")})
+	if got, want := err.(actions.Error).Code, actions.PreconditionFailed; got != want {
+		t.Errorf("bad code for a failed test: got %v, want %v", got, want)
+	}
+	err = immutablePatchError(errors.New("fail"))
+	if got, want := err.(actions.Error).Code, actions.InvalidArgument; got != want {
+		t.Errorf("bad code: got %v, want %v", got, want)
+	}
+}
+
+func TestPreconditionFailed(t *testing.T) {
+	tests := []struct {
+		name          string
+		conditions    *store.ETagCondition
+		wantRetryable bool
+	}{
+		{
+			name:          "concurrent modification",
+			wantRetryable: true,
+		},
+		{
+			name:          "stale if-match",
+			conditions:    &store.ETagCondition{IfMatch: `"abc"`},
+			wantRetryable: true,
+		},
+		{
+			name:          "if-none-match",
+			conditions:    &store.ETagCondition{IfNoneMatch: `"abc"`},
+			wantRetryable: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := preconditionFailed(errors.New("fail"), tt.conditions)
+			actionErr, ok := err.(actions.Error)
+			if !ok {
+				t.Fatalf("expected actions.Error, got %T", err)
+			}
+			if got, want := actionErr.Code, actions.PreconditionFailed; got != want {
+				t.Errorf("bad code: got %v, want %v", got, want)
+			}
+			if got := actionErr.Retryable; got != tt.wantRetryable {
+				t.Errorf("bad retryable: got %v, want %v", got, tt.wantRetryable)
+			}
+		})
+	}
+}
+
+func TestPatchContentType(t *testing.T) {
+	tests := []struct {
+		header  string
+		want    string
+		wantErr bool
+	}{
+		{header: "", want: ""},
+		{header: mergePatchContentType, want: mergePatchContentType},
+		{header: "application/json; charset=utf-8", want: jsonContentType},
+		{header: "Application/Merge-Patch+JSON; charset=UTF-8", want: mergePatchContentType},
+		{header: "application/json;;", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			r, _ := http.NewRequest(http.MethodPatch, "/", nil)
+			r.Header.Set("Content-Type", tt.header)
+			got, err := patchContentType(r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("patchContentType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("patchContentType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
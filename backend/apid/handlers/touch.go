@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"time"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/backend/store/patch"
+)
+
+// TouchResource stamps a resource with the current time, in its
+// UpdatedAtAnnotation annotation, so that its ETag changes and its watchers
+// are notified without otherwise modifying it. The touch only applies if the
+// If-Match header, when present, matches the stored resource.
+func (h Handlers) TouchResource(r *http.Request) (interface{}, error) {
+	params := mux.Vars(r)
+	name, err := url.PathUnescape(params["id"])
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	namespace, err := url.PathUnescape(params["namespace"])
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				corev2.UpdatedAtAnnotation: time.Now().UTC().Format(time.RFC3339Nano),
+			},
+		},
+	})
+	if err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	patcher := &patch.Merge{MergePatch: body}
+	conditions := &store.ETagCondition{IfMatch: r.Header.Get(ifMatchHeader)}
+
+	if h.Resource != nil {
+		result, err := h.patchV2Resource(r.Context(), body, name, patcher, conditions)
+		if err != nil {
+			return nil, err
+		}
+		if change, ok := result.(*store.PendingChange); ok {
+			return change, nil
+		}
+		// The patch only holds the annotation, respond with the whole resource
+		resource := reflect.New(reflect.TypeOf(h.Resource).Elem()).Interface().(corev2.Resource)
+		if err := h.Store.GetResource(r.Context(), name, resource); err != nil {
+			return nil, actions.NewError(actions.InternalErr, err)
+		}
+		return resource, nil
+	} else if h.V3Resource != nil {
+		return h.patchV3Resource(r.Context(), body, name, namespace, patcher, conditions)
+	}
+
+	return nil, actions.NewError(actions.InvalidArgument, errors.New("no resource available"))
+}
@@ -0,0 +1,121 @@
+// +build integration
+
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/store"
+	etcdstore "github.com/sensu/sensu-go/backend/store/etcd"
+	storev2 "github.com/sensu/sensu-go/backend/store/v2"
+	etcdstorev2 "github.com/sensu/sensu-go/backend/store/v2/etcdstore"
+)
+
+func touchRequest(namespace, id, ifMatch string) *http.Request {
+	r := httptest.NewRequest(http.MethodPut, "/", nil)
+	if ifMatch != "" {
+		r.Header.Set(ifMatchHeader, ifMatch)
+	}
+	r = r.WithContext(store.NamespaceContext(r.Context(), namespace))
+	return mux.SetURLVars(r, map[string]string{"namespace": namespace, "id": id})
+}
+
+func TestHandlers_TouchResourceIntegration(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler func(*etcdstore.Store, *etcdstorev2.Store) Handlers
+		init    func(*testing.T, *etcdstore.Store, *etcdstorev2.Store) interface{}
+	}{
+		{
+			name: "v2 resource",
+			handler: func(s1 *etcdstore.Store, s2 *etcdstorev2.Store) Handlers {
+				return Handlers{Resource: &corev2.CheckConfig{}, Store: s1}
+			},
+			init: func(t *testing.T, s1 *etcdstore.Store, s2 *etcdstorev2.Store) interface{} {
+				ctx := store.NamespaceContext(context.Background(), "default")
+				check := corev2.FixtureCheckConfig("foo")
+				if err := s1.UpdateCheckConfig(ctx, check); err != nil {
+					t.Fatal(err)
+				}
+				return check
+			},
+		},
+		{
+			name: "v3 resource",
+			handler: func(s1 *etcdstore.Store, s2 *etcdstorev2.Store) Handlers {
+				return Handlers{V3Resource: &corev3.EntityConfig{}, StoreV2: s2}
+			},
+			init: func(t *testing.T, s1 *etcdstore.Store, s2 *etcdstorev2.Store) interface{} {
+				ctx := store.NamespaceContext(context.Background(), "default")
+				entity := corev3.FixtureEntityConfig("foo")
+				req := storev2.NewResourceRequestFromResource(ctx, entity)
+				wrapper, err := storev2.WrapResource(entity)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := s2.CreateOrUpdate(req, wrapper); err != nil {
+					t.Fatal(err)
+				}
+				return entity
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testWithEtcdStores(t, func(s1 *etcdstore.Store, s2 *etcdstorev2.Store) {
+				h := tt.handler(s1, s2)
+				original := tt.init(t, s1, s2)
+				etag, err := store.ETag(original)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				_, err = h.TouchResource(touchRequest("default", "foo", `"stale"`))
+				if err == nil || err.(actions.Error).Code != actions.PreconditionFailed {
+					t.Fatalf("expected a precondition failure, got %v", err)
+				}
+
+				touched, err := h.TouchResource(touchRequest("default", "foo", etag))
+				if err != nil {
+					t.Fatal(err)
+				}
+				touchedETag, err := store.ETag(touched)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if touchedETag == etag {
+					t.Error("expected the ETag to change")
+				}
+
+				var meta *corev2.ObjectMeta
+				switch r := touched.(type) {
+				case *corev2.CheckConfig:
+					meta = &r.ObjectMeta
+					if r.Command != original.(*corev2.CheckConfig).Command {
+						t.Error("expected the check to be otherwise unchanged")
+					}
+				case *corev3.EntityConfig:
+					meta = r.Metadata
+					if r.EntityClass != original.(*corev3.EntityConfig).EntityClass {
+						t.Error("expected the entity to be otherwise unchanged")
+					}
+				}
+				if meta.Annotations[corev2.UpdatedAtAnnotation] == "" {
+					t.Error("expected the resource to be stamped")
+				}
+
+				_, err = h.TouchResource(touchRequest("default", "missing", ""))
+				if err == nil || err.(actions.Error).Code != actions.NotFound {
+					t.Errorf("expected a not found error, got %v", err)
+				}
+			})
+		})
+	}
+}
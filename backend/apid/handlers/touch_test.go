@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/backend/store/patch"
+	"github.com/sensu/sensu-go/backend/store/v2/wrap"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/mock"
+)
+
+// touchPatch matches the merge patches that only set the updated at annotation
+func touchPatch(p patch.Patcher) bool {
+	merge, ok := p.(*patch.Merge)
+	if !ok {
+		return false
+	}
+	var body struct {
+		Metadata map[string]map[string]string `json:"metadata"`
+	}
+	if err := json.Unmarshal(merge.MergePatch, &body); err != nil {
+		return false
+	}
+	return len(body.Metadata) == 1 && len(body.Metadata["annotations"]) == 1 &&
+		body.Metadata["annotations"][corev2.UpdatedAtAnnotation] != ""
+}
+
+func TestHandlers_TouchResource(t *testing.T) {
+	etag, err := store.ETag(corev2.FixtureCheckConfig("foo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type storeFunc func(*mockstore.MockStore)
+	tests := []struct {
+		name      string
+		ifMatch   string
+		storeFunc storeFunc
+		wantCode  actions.ErrCode
+		wantErr   bool
+	}{
+		{
+			name:    "resource is touched",
+			ifMatch: etag,
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetResource", mock.Anything, "foo", mock.Anything).
+					Run(func(args mock.Arguments) {
+						check := args.Get(2).(*corev2.CheckConfig)
+						*check = *corev2.FixtureCheckConfig("foo")
+					}).Return(nil)
+				s.On("PatchResource", mock.Anything, mock.Anything, "foo", mock.MatchedBy(touchPatch), &store.ETagCondition{IfMatch: etag}).
+					Return(nil)
+			},
+		},
+		{
+			name:    "if-match does not match",
+			ifMatch: `"abc"`,
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetResource", mock.Anything, "foo", mock.Anything).Return(nil)
+			},
+			wantErr:  true,
+			wantCode: actions.PreconditionFailed,
+		},
+		{
+			name:    "resource is modified after it was checked",
+			ifMatch: etag,
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetResource", mock.Anything, "foo", mock.Anything).
+					Run(func(args mock.Arguments) {
+						check := args.Get(2).(*corev2.CheckConfig)
+						*check = *corev2.FixtureCheckConfig("foo")
+					}).Return(nil)
+				s.On("PatchResource", mock.Anything, mock.Anything, "foo", mock.Anything, &store.ETagCondition{IfMatch: etag}).
+					Return(&store.ErrPreconditionFailed{})
+			},
+			wantErr:  true,
+			wantCode: actions.PreconditionFailed,
+		},
+		{
+			name: "resource does not exist",
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetResource", mock.Anything, "foo", mock.Anything).Return(&store.ErrNotFound{})
+			},
+			wantErr:  true,
+			wantCode: actions.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &mockstore.MockStore{}
+			tt.storeFunc(s)
+			h := Handlers{
+				Resource: &corev2.CheckConfig{},
+				Store:    s,
+			}
+
+			r, _ := http.NewRequest(http.MethodPut, "/", nil)
+			r.Header.Set(ifMatchHeader, tt.ifMatch)
+			r = mux.SetURLVars(r, map[string]string{"id": "foo", "namespace": "default"})
+
+			got, err := h.TouchResource(r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Handlers.TouchResource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if code := err.(actions.Error).Code; code != tt.wantCode {
+					t.Errorf("bad error code: got %v, want %v", code, tt.wantCode)
+				}
+				return
+			}
+			if check := got.(*corev2.CheckConfig); check.Name != "foo" || check.Command == "" {
+				t.Errorf("expected the whole resource in response, got %v", check)
+			}
+			s.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandlers_TouchResourceInvalidName(t *testing.T) {
+	h := Handlers{
+		Resource: &corev2.CheckConfig{},
+		Store:    &mockstore.MockStore{},
+	}
+	r, _ := http.NewRequest(http.MethodPut, "/", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "foo%zz", "namespace": "default"})
+
+	_, err := h.TouchResource(r)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if code := err.(actions.Error).Code; code != actions.InvalidArgument {
+		t.Errorf("bad error code: got %v, want %v", code, actions.InvalidArgument)
+	}
+}
+
+func TestHandlers_TouchV3Resource(t *testing.T) {
+	entity := corev3.FixtureEntityConfig("foo")
+	stored, err := wrap.Resource(entity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	etag, err := store.ETag(entity)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &mockstore.V2MockStore{}
+	s.On("Get", mock.Anything).Return(stored, nil)
+	s.On("MergeLabels", mock.Anything, mock.Anything, map[string]*string(nil), mock.MatchedBy(func(annotations map[string]*string) bool {
+		return len(annotations) == 1 && annotations[corev2.UpdatedAtAnnotation] != nil
+	}), &store.ETagCondition{IfMatch: etag}).Run(func(args mock.Arguments) {
+		touched := corev3.FixtureEntityConfig("foo")
+		touched.Metadata.Annotations[corev2.UpdatedAtAnnotation] = *args.Get(3).(map[string]*string)[corev2.UpdatedAtAnnotation]
+		w, err := wrap.Resource(touched)
+		if err != nil {
+			t.Fatal(err)
+		}
+		*args.Get(1).(*wrap.Wrapper) = *w
+	}).Return(nil)
+
+	h := Handlers{
+		V3Resource: &corev3.EntityConfig{},
+		StoreV2:    s,
+	}
+	r, _ := http.NewRequest(http.MethodPut, "/", nil)
+	r.Header.Set(ifMatchHeader, etag)
+	r = mux.SetURLVars(r, map[string]string{"id": "foo", "namespace": "default"})
+
+	got, err := h.TouchResource(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	touched := got.(*corev3.EntityConfig)
+	if touched.Metadata.Annotations[corev2.UpdatedAtAnnotation] == "" {
+		t.Error("expected the resource to be touched")
+	}
+	if touched.EntityClass != entity.EntityClass {
+		t.Error("expected the rest of the resource to be unchanged")
+	}
+	s.AssertExpectations(t)
+}
@@ -9,6 +9,7 @@ import (
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/authentication/jwt"
+	"github.com/sensu/sensu-go/backend/messaging"
 	"github.com/sensu/sensu-go/backend/store"
 )
 
@@ -29,20 +30,63 @@ func (h Handlers) CreateOrUpdateResource(r *http.Request) (interface{}, error) {
 		return nil, actions.NewErrorf(actions.InvalidArgument)
 	}
 
+	// Immutable resources can only be updated to remove their immutability
+	ctx := r.Context()
+	stored := reflect.New(reflect.TypeOf(h.Resource).Elem()).Interface().(corev2.Resource)
+	found := false
+	if err := h.Store.GetResource(ctx, resource.GetObjectMeta().Name, stored); err == nil {
+		found = true
+		if err := checkImmutable(stored, resource); err != nil {
+			return nil, actions.NewError(actions.InvalidArgument, err)
+		}
+		// Only update the resource that was checked
+		etag, err := store.ETag(stored)
+		if err != nil {
+			return nil, actions.NewError(actions.InternalErr, err)
+		}
+		ctx = store.ContextWithIfMatch(ctx, etag)
+		// Updates don't cancel a pending deletion
+		if deletedAt := stored.GetObjectMeta().DeletedAt; deletedAt != 0 {
+			meta := resource.GetObjectMeta()
+			meta.DeletedAt = deletedAt
+			resource.SetObjectMeta(meta)
+		}
+	} else if _, ok := err.(*store.ErrNotFound); !ok {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+
 	meta := resource.GetObjectMeta()
-	if claims := jwt.GetClaimsFromContext(r.Context()); claims != nil {
+	if claims := jwt.GetClaimsFromContext(ctx); claims != nil {
 		meta.CreatedBy = claims.StandardClaims.Subject
 		resource.SetObjectMeta(meta)
 	}
 
-	if err := h.Store.CreateOrUpdateResource(r.Context(), resource); err != nil {
+	// Updates of resources that require approval are only applied once
+	// approved by another user
+	if found && requiresApproval(stored) {
+		return h.proposeChange(r.Context(), stored, resource)
+	}
+
+	if !found {
+		// Only create the resource if it still doesn't exist
+		ctx = store.ContextWithIfNoneMatch(ctx, "*")
+	}
+	if err := h.Store.CreateOrUpdateResource(ctx, resource); err != nil {
 		switch err := err.(type) {
 		case *store.ErrNotValid:
 			return nil, actions.NewError(actions.InvalidArgument, err)
+		case *store.ErrPreconditionFailed:
+			// The resource was modified after it was checked
+			return nil, actions.NewRetryableError(actions.PreconditionFailed, err)
 		default:
 			return nil, actions.NewError(actions.InternalErr, err)
 		}
 	}
+	if found {
+		publishChange(ctx, resource, messaging.ResourceUpdated)
+	} else {
+		publishChange(ctx, resource, messaging.ResourceCreated)
+	}
 
 	return nil, nil
 }
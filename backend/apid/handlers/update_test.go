@@ -44,6 +44,7 @@ func TestHandlers_UpdateResource(t *testing.T) {
 			name: "store err, not valid",
 			body: marshal(t, fixture.Resource{ObjectMeta: corev2.ObjectMeta{}}),
 			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetResource", mock.Anything, mock.Anything, mock.Anything).Return(&store.ErrNotFound{})
 				s.On("CreateOrUpdateResource", mock.Anything, mock.AnythingOfType("*fixture.Resource")).
 					Return(&store.ErrNotValid{Err: errors.New("error")})
 			},
@@ -53,16 +54,46 @@ func TestHandlers_UpdateResource(t *testing.T) {
 			name: "store err, default",
 			body: marshal(t, fixture.Resource{ObjectMeta: corev2.ObjectMeta{}}),
 			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetResource", mock.Anything, mock.Anything, mock.Anything).Return(&store.ErrNotFound{})
 				s.On("CreateOrUpdateResource", mock.Anything, mock.AnythingOfType("*fixture.Resource")).
 					Return(&store.ErrInternal{})
 			},
 			wantErr: true,
 		},
+		{
+			name: "immutable resource",
+			body: marshal(t, fixture.Resource{Foo: "bar", ObjectMeta: corev2.ObjectMeta{}}),
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetResource", mock.Anything, mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) {
+						stored := args.Get(2).(*fixture.Resource)
+						stored.Annotations = map[string]string{corev2.ImmutableAnnotation: "true"}
+					}).
+					Return(nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "resource modified after it was checked",
+			body: marshal(t, fixture.Resource{Foo: "bar", ObjectMeta: corev2.ObjectMeta{}}),
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetResource", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				etag, _ := store.ETag(&fixture.Resource{})
+				s.On("CreateOrUpdateResource", mock.MatchedBy(func(ctx context.Context) bool {
+					return store.IfMatchFromContext(ctx) == etag
+				}), mock.AnythingOfType("*fixture.Resource")).
+					Return(&store.ErrPreconditionFailed{})
+			},
+			wantErr: true,
+		},
 		{
 			name: "successful create",
 			body: marshal(t, fixture.Resource{ObjectMeta: corev2.ObjectMeta{}}),
 			storeFunc: func(s *mockstore.MockStore) {
-				s.On("CreateOrUpdateResource", mock.Anything, mock.AnythingOfType("*fixture.Resource")).
+				s.On("GetResource", mock.Anything, mock.Anything, mock.Anything).Return(&store.ErrNotFound{})
+				s.On("CreateOrUpdateResource", mock.MatchedBy(func(ctx context.Context) bool {
+					return store.IfNoneMatchFromContext(ctx) == "*"
+				}), mock.AnythingOfType("*fixture.Resource")).
 					Return(nil)
 			},
 		},
@@ -97,12 +128,14 @@ func TestCreatedByUpdate(t *testing.T) {
 	ctx := context.WithValue(context.Background(), corev2.ClaimsKey, claims)
 	body := marshal(t, fixture.Resource{ObjectMeta: corev2.ObjectMeta{}})
 
+	notFound := &store.ErrNotFound{}
 	store := &mockstore.MockStore{}
 	h := Handlers{
 		Resource: &fixture.Resource{},
 		Store:    store,
 	}
 
+	store.On("GetResource", mock.Anything, mock.Anything, mock.Anything).Return(notFound)
 	store.On("CreateOrUpdateResource", mock.Anything, mock.AnythingOfType("*fixture.Resource")).Return(nil)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPut, "/", bytes.NewReader(body))
@@ -9,6 +9,7 @@ import (
 	corev3 "github.com/sensu/sensu-go/api/core/v3"
 	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/authentication/jwt"
+	"github.com/sensu/sensu-go/backend/messaging"
 	"github.com/sensu/sensu-go/backend/store"
 	storev2 "github.com/sensu/sensu-go/backend/store/v2"
 )
@@ -33,23 +34,59 @@ func (h Handlers) CreateOrUpdateV3Resource(r *http.Request) (interface{}, error)
 	req := storev2.NewResourceRequestFromResource(r.Context(), resource)
 	meta := resource.GetMetadata()
 
+	// Immutable resources can only be updated to remove their immutability
+	operation := messaging.ResourceCreated
+	var stored corev3.Resource
+	if w, err := h.StoreV2.Get(req); err == nil {
+		operation = messaging.ResourceUpdated
+		stored, err = w.Unwrap()
+		if err != nil {
+			return nil, actions.NewError(actions.InternalErr, err)
+		}
+		if err := checkImmutable(stored, resource); err != nil {
+			return nil, actions.NewError(actions.InvalidArgument, err)
+		}
+		// Only update the resource that was checked
+		etag, err := store.ETag(stored)
+		if err != nil {
+			return nil, actions.NewError(actions.InternalErr, err)
+		}
+		req.Context = store.ContextWithIfMatch(req.Context, etag)
+	} else if _, ok := err.(*store.ErrNotFound); !ok {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+
 	if claims := jwt.GetClaimsFromContext(r.Context()); claims != nil {
 		meta.CreatedBy = claims.StandardClaims.Subject
 	}
 
+	// Updates of resources that require approval are only applied once
+	// approved by another user
+	if stored != nil && requiresApproval(stored) {
+		return h.proposeChange(r.Context(), stored, resource)
+	}
+
 	wrapper, err := storev2.WrapResource(resource)
 	if err != nil {
 		return nil, actions.NewError(actions.InvalidArgument, err)
 	}
 
+	if operation == messaging.ResourceCreated {
+		// Only create the resource if it still doesn't exist
+		req.Context = store.ContextWithIfNoneMatch(req.Context, "*")
+	}
 	if err := h.StoreV2.CreateOrUpdate(req, wrapper); err != nil {
 		switch err := err.(type) {
 		case *store.ErrNotValid:
 			return nil, actions.NewError(actions.InvalidArgument, err)
+		case *store.ErrPreconditionFailed:
+			// The resource was modified after it was checked
+			return nil, actions.NewRetryableError(actions.PreconditionFailed, err)
 		default:
 			return nil, actions.NewError(actions.InternalErr, err)
 		}
 	}
+	publishChange(r.Context(), resource, operation)
 
 	return nil, nil
 }
@@ -11,6 +11,7 @@ import (
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/authentication/jwt"
 	"github.com/sensu/sensu-go/backend/store"
+	storev2 "github.com/sensu/sensu-go/backend/store/v2"
 	"github.com/sensu/sensu-go/testing/fixture"
 	"github.com/sensu/sensu-go/testing/mockstore"
 	"github.com/stretchr/testify/assert"
@@ -41,6 +42,7 @@ func TestHandlers_UpdateResourceV3(t *testing.T) {
 			name: "store err, not valid",
 			body: marshal(t, fixture.V3Resource{Metadata: corev2.NewObjectMetaP("", "")}),
 			storeFunc: func(s *mockstore.V2MockStore) {
+				s.On("Get", mock.Anything).Return(nil, &store.ErrNotFound{})
 				s.On("CreateOrUpdate", mock.Anything, mock.Anything).
 					Return(&store.ErrNotValid{Err: errors.New("error")})
 			},
@@ -50,16 +52,35 @@ func TestHandlers_UpdateResourceV3(t *testing.T) {
 			name: "store err, default",
 			body: marshal(t, fixture.V3Resource{Metadata: corev2.NewObjectMetaP("", "")}),
 			storeFunc: func(s *mockstore.V2MockStore) {
+				s.On("Get", mock.Anything).Return(nil, &store.ErrNotFound{})
 				s.On("CreateOrUpdate", mock.Anything, mock.Anything).
 					Return(&store.ErrInternal{})
 			},
 			wantErr: true,
 		},
+		{
+			name: "resource modified after it was checked",
+			body: marshal(t, fixture.V3Resource{Metadata: corev2.NewObjectMetaP("", "")}),
+			storeFunc: func(s *mockstore.V2MockStore) {
+				wrapper, _ := storev2.WrapResource(&fixture.V3Resource{Metadata: corev2.NewObjectMetaP("", "")})
+				s.On("Get", mock.Anything).Return(wrapper, nil)
+				stored, _ := wrapper.Unwrap()
+				etag, _ := store.ETag(stored)
+				s.On("CreateOrUpdate", mock.MatchedBy(func(req storev2.ResourceRequest) bool {
+					return store.IfMatchFromContext(req.Context) == etag
+				}), mock.Anything).
+					Return(&store.ErrPreconditionFailed{})
+			},
+			wantErr: true,
+		},
 		{
 			name: "successful create",
 			body: marshal(t, fixture.V3Resource{Metadata: corev2.NewObjectMetaP("", "")}),
 			storeFunc: func(s *mockstore.V2MockStore) {
-				s.On("CreateOrUpdate", mock.Anything, mock.Anything).
+				s.On("Get", mock.Anything).Return(nil, &store.ErrNotFound{})
+				s.On("CreateOrUpdate", mock.MatchedBy(func(req storev2.ResourceRequest) bool {
+					return store.IfNoneMatchFromContext(req.Context) == "*"
+				}), mock.Anything).
 					Return(nil)
 			},
 		},
@@ -94,12 +115,14 @@ func TestCreatedByUpdateV3(t *testing.T) {
 	ctx := context.WithValue(context.Background(), corev2.ClaimsKey, claims)
 	body := marshal(t, fixture.V3Resource{Metadata: corev2.NewObjectMetaP("", "")})
 
+	notFound := &store.ErrNotFound{}
 	store := &mockstore.V2MockStore{}
 	h := Handlers{
 		V3Resource: &fixture.V3Resource{},
 		StoreV2:    store,
 	}
 
+	store.On("Get", mock.Anything).Return(nil, notFound)
 	store.On("CreateOrUpdate", mock.Anything, mock.Anything).Return(nil)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPut, "/", bytes.NewReader(body))
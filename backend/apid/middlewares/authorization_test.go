@@ -508,6 +508,14 @@ func TestAuthorization(t *testing.T) {
 			attributesMiddleware: AuthorizationAttributes{},
 			expectedCode:         403,
 		},
+		{
+			description:          "foo-viewers can search resources",
+			method:               "GET",
+			url:                  "/api/core/v2/namespaces/default/search",
+			group:                "foo-viewers",
+			attributesMiddleware: AuthorizationAttributes{},
+			expectedCode:         200,
+		},
 		{
 			description:          "foo-viewers can't update the foo check",
 			method:               "PUT",
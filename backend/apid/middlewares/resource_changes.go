@@ -0,0 +1,25 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/sensu/sensu-go/backend/apid/handlers"
+	"github.com/sensu/sensu-go/backend/messaging"
+)
+
+// ResourceChanges is an HTTP middleware that has the resource handlers publish
+// the changes of the resources they write to Bus, see
+// messaging.TopicResourceChange
+type ResourceChanges struct {
+	Bus messaging.Publisher
+}
+
+// Then middleware
+func (c ResourceChanges) Then(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c.Bus != nil {
+			r = r.WithContext(handlers.ContextWithChangePublisher(r.Context(), c.Bus))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,58 @@
+package middlewares
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sensu/sensu-go/backend/apid/actions"
+)
+
+const (
+	// TimeoutQueryParam is the query parameter used by clients to request a
+	// server-side timeout, e.g. ?request_timeout=5s. It is distinct from the
+	// timeout parameter of the cluster member endpoints, in seconds.
+	TimeoutQueryParam = "request_timeout"
+
+	// DefaultMaxRequestTimeout is the maximum timeout clients can request
+	// when RequestTimeout is not configured with one.
+	DefaultMaxRequestTimeout = time.Minute
+)
+
+// RequestTimeout retrieves the "request_timeout" query parameter, and sets the
+// corresponding deadline on the request's context. Requested timeouts are
+// clamped to Max.
+type RequestTimeout struct {
+	Max time.Duration
+}
+
+// Then middleware
+func (t RequestTimeout) Then(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value := r.URL.Query().Get(TimeoutQueryParam)
+		if value == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			writeErr(w, actions.NewError(actions.InvalidArgument, fmt.Errorf("invalid timeout: %q", value)))
+			return
+		}
+
+		max := t.Max
+		if max <= 0 {
+			max = DefaultMaxRequestTimeout
+		}
+		if timeout > max {
+			timeout = max
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
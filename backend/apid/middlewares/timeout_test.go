@@ -0,0 +1,79 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRequestTimeoutMiddleware(t *testing.T) {
+	cases := []struct {
+		description    string
+		queryParams    string
+		max            time.Duration
+		expectedStatus int
+		expectDeadline bool
+		maxTimeout     time.Duration
+	}{
+		{
+			description:    "No timeout",
+			queryParams:    "",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			description:    "Valid timeout",
+			queryParams:    "?request_timeout=5s",
+			expectedStatus: http.StatusOK,
+			expectDeadline: true,
+			maxTimeout:     5 * time.Second,
+		},
+		{
+			description:    "Timeout is clamped to the configured max",
+			queryParams:    "?request_timeout=1h",
+			max:            10 * time.Second,
+			expectedStatus: http.StatusOK,
+			expectDeadline: true,
+			maxTimeout:     10 * time.Second,
+		},
+		{
+			description:    "Timeout is clamped to the default max",
+			queryParams:    "?request_timeout=1h",
+			expectedStatus: http.StatusOK,
+			expectDeadline: true,
+			maxTimeout:     DefaultMaxRequestTimeout,
+		},
+		{
+			description:    "Invalid timeout",
+			queryParams:    "?request_timeout=sandwich",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			description:    "Negative timeout",
+			queryParams:    "?request_timeout=-5s",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			mware := RequestTimeout{Max: tc.max}
+			handler := mware.Then(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				deadline, ok := r.Context().Deadline()
+				if ok != tc.expectDeadline {
+					t.Errorf("bad deadline: got %v, want %v", ok, tc.expectDeadline)
+				}
+				if ok && time.Until(deadline) > tc.maxTimeout {
+					t.Errorf("deadline too far: %s", time.Until(deadline))
+				}
+			}))
+
+			req, _ := http.NewRequest(http.MethodGet, "/"+tc.queryParams, nil)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			if w.Code != tc.expectedStatus {
+				t.Errorf("bad status: got %d, want %d", w.Code, tc.expectedStatus)
+			}
+		})
+	}
+}
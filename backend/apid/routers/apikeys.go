@@ -45,6 +45,7 @@ func (r *APIKeysRouter) Mount(parent *mux.Router) {
 	routes.List(r.handlers.ListResources, corev2.APIKeyFields)
 	parent.HandleFunc(routes.PathPrefix, r.create).Methods(http.MethodPost)
 	routes.Patch(r.handlers.PatchResource)
+	routes.Touch(r.handlers.TouchResource)
 }
 
 func (r *APIKeysRouter) create(w http.ResponseWriter, req *http.Request) {
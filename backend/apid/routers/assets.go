@@ -33,6 +33,9 @@ func (r *AssetsRouter) Mount(parent *mux.Router) {
 	routes.List(r.handlers.ListResources, corev2.AssetFields)
 	routes.ListAllNamespaces(r.handlers.ListResources, "/{resource:assets}", corev2.AssetFields)
 	routes.Patch(r.handlers.PatchResource)
+	routes.Touch(r.handlers.TouchResource)
+	routes.PendingChange(r.handlers.GetPendingChange)
+	routes.Approve(r.handlers.ApproveChange)
 	routes.Post(r.handlers.CreateResource)
 	routes.Put(r.handlers.CreateOrUpdateResource)
 	routes.Del(r.handlers.DeleteResource)
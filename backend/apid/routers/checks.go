@@ -52,6 +52,9 @@ func (r *ChecksRouter) Mount(parent *mux.Router) {
 	routes.List(r.handlers.ListResources, corev2.CheckConfigFields)
 	routes.ListAllNamespaces(r.handlers.ListResources, "/{resource:checks}", corev2.CheckConfigFields)
 	routes.Patch(r.handlers.PatchResource)
+	routes.Touch(r.handlers.TouchResource)
+	routes.PendingChange(r.handlers.GetPendingChange)
+	routes.Approve(r.handlers.ApproveChange)
 	routes.Post(r.handlers.CreateResource)
 	routes.Put(r.handlers.CreateOrUpdateResource)
 
@@ -7,9 +7,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	"github.com/sensu/sensu-go/backend/apid/middlewares"
 	"github.com/stretchr/testify/mock"
 	"go.etcd.io/etcd/client/v3"
 )
@@ -267,3 +269,63 @@ func TestGetClusterID(t *testing.T) {
 
 	controller.AssertCalled(t, "ClusterID", mock.Anything)
 }
+
+func TestClusterRouterTimeout(t *testing.T) {
+	controller := &mockClusterController{}
+	router := mux.NewRouter()
+	router.Use(middlewares.RequestTimeout{Max: time.Minute}.Then)
+	NewClusterRouter(controller).Mount(router)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantWithin time.Duration
+	}{
+		{
+			name:       "cluster timeout in seconds",
+			query:      "?timeout=5",
+			wantStatus: http.StatusOK,
+			wantWithin: 5 * time.Second,
+		},
+		{
+			name:       "request timeout",
+			query:      "?request_timeout=2s",
+			wantStatus: http.StatusOK,
+			wantWithin: 2 * time.Second,
+		},
+		{
+			name:       "cluster timeout as a duration",
+			query:      "?timeout=5s",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deadline time.Time
+			controller.ExpectedCalls = nil
+			controller.On("MemberList", mock.Anything).Run(func(args mock.Arguments) {
+				deadline, _ = args.Get(0).(context.Context).Deadline()
+			}).Return(new(clientv3.MemberListResponse), nil)
+
+			req := newRequest(t, http.MethodGet, server.URL+"/cluster/members"+tt.query, nil)
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				body, _ := ioutil.ReadAll(resp.Body)
+				t.Fatalf("bad status: %d (%q)", resp.StatusCode, string(body))
+			}
+			if tt.wantWithin == 0 {
+				return
+			}
+			if deadline.IsZero() || time.Until(deadline) > tt.wantWithin {
+				t.Errorf("bad deadline: %v", deadline)
+			}
+		})
+	}
+}
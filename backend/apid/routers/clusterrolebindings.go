@@ -33,6 +33,9 @@ func (r *ClusterRoleBindingsRouter) Mount(parent *mux.Router) {
 	routes.Get(r.handlers.GetResource)
 	routes.List(r.handlers.ListResources, corev2.ClusterRoleBindingFields)
 	routes.Patch(r.handlers.PatchResource)
+	routes.Touch(r.handlers.TouchResource)
+	routes.PendingChange(r.handlers.GetPendingChange)
+	routes.Approve(r.handlers.ApproveChange)
 	routes.Post(r.handlers.CreateResource)
 	routes.Put(r.handlers.CreateOrUpdateResource)
 }
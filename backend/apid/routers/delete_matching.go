@@ -0,0 +1,88 @@
+package routers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/api"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/authorization"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+// labelSelectorParam is the query parameter that selects the resources to
+// delete in bulk
+const labelSelectorParam = "labelSelector"
+
+// deleteMatchingResources are the kinds of resources that can be deleted in
+// bulk by label selector. Silenced entries are deleted in bulk by
+// subscription and check instead, by the SilencedRouter.
+var deleteMatchingResources = []corev2.Resource{
+	&corev2.Asset{},
+	&corev2.CheckConfig{},
+	&corev2.EventFilter{},
+	&corev2.Handler{},
+	&corev2.HookConfig{},
+	&corev2.Mutator{},
+	&corev2.Pipeline{},
+}
+
+// DeleteMatchingRouter handles the bulk deletion, by label selector, of the
+// resources of a namespace
+type DeleteMatchingRouter struct {
+	clients map[string]*api.GenericClient
+}
+
+// NewDeleteMatchingRouter instantiates a new router for deleting resources by
+// label selector
+func NewDeleteMatchingRouter(store store.ResourceStore, auth authorization.Authorizer) *DeleteMatchingRouter {
+	clients := make(map[string]*api.GenericClient, len(deleteMatchingResources))
+	for _, kind := range deleteMatchingResources {
+		clients[kind.RBACName()] = &api.GenericClient{
+			Kind:       kind,
+			Store:      store,
+			Auth:       auth,
+			APIGroup:   "core",
+			APIVersion: "v2",
+		}
+	}
+	return &DeleteMatchingRouter{clients: clients}
+}
+
+// Mount the DeleteMatchingRouter to a parent Router
+func (r *DeleteMatchingRouter) Mount(parent *mux.Router) {
+	names := make([]string, len(deleteMatchingResources))
+	for i, kind := range deleteMatchingResources {
+		names[i] = kind.RBACName()
+	}
+	routes := ResourceRoute{
+		Router:     parent,
+		PathPrefix: fmt.Sprintf("/namespaces/{namespace}/{resource:%s}", strings.Join(names, "|")),
+	}
+	routes.DelMatching(r.deleteMatching)
+}
+
+// deleteMatching deletes the resources of the requested kind whose labels
+// match the label selector of the request. The response reports how many
+// resources were deleted, and why the others could not be.
+func (r *DeleteMatchingRouter) deleteMatching(req *http.Request) (interface{}, error) {
+	client, ok := r.clients[mux.Vars(req)["resource"]]
+	if !ok {
+		return nil, actions.NewErrorf(actions.NotFound)
+	}
+	labels, err := api.ParseLabelSelector(req.URL.Query().Get(labelSelectorParam))
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	result, err := client.DeleteMatching(req.Context(), labels)
+	if err != nil {
+		if err == authorization.ErrUnauthorized {
+			return nil, actions.NewError(actions.PermissionDenied, err)
+		}
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,93 @@
+package routers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/testing/mockauthorizer"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeleteMatchingRouter(t *testing.T) {
+	labeledHandler := func(name, tier string) *corev2.Handler {
+		handler := corev2.FixtureHandler(name)
+		handler.Labels = map[string]string{"tier": tier}
+		return handler
+	}
+
+	s := &mockstore.MockStore{}
+	s.On("ListResources", mock.Anything, (&corev2.Handler{}).StorePrefix(), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		resources := args.Get(2).(*[]*corev2.Handler)
+		*resources = []*corev2.Handler{
+			labeledHandler("a", "web"),
+			labeledHandler("b", "db"),
+		}
+	}).Return(nil)
+	s.On("DeleteResourceIfMatch", mock.Anything, mock.Anything, "a", mock.Anything).Return(nil)
+
+	authorizer := &mockauthorizer.Authorizer{}
+	authorizer.On("Authorize", mock.Anything, mock.Anything).Return(true, nil)
+
+	router := NewDeleteMatchingRouter(s, authorizer)
+	parentRouter := mux.NewRouter()
+	parentRouter.Use(mockedClaims)
+	router.Mount(parentRouter)
+	server := httptest.NewServer(parentRouter)
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		path        string
+		wantStatus  int
+		wantDeleted int
+	}{
+		{
+			name:        "matching handlers",
+			path:        "/namespaces/default/handlers?labelSelector=tier%3Dweb",
+			wantStatus:  http.StatusOK,
+			wantDeleted: 1,
+		},
+		{
+			name:       "missing label selector",
+			path:       "/namespaces/default/checks",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unsupported resource",
+			path:       "/namespaces/default/entities?labelSelector=tier%3Dweb",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodDelete, server.URL+tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+			if res.StatusCode != tt.wantStatus {
+				t.Fatalf("bad status: got %d, want %d", res.StatusCode, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var result corev2.DeleteMatchingResult
+			if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+				t.Fatal(err)
+			}
+			if result.Deleted != tt.wantDeleted || len(result.Errors) != 0 {
+				t.Errorf("bad result: %+v", result)
+			}
+		})
+	}
+	s.AssertNumberOfCalls(t, "DeleteResourceIfMatch", 1)
+}
@@ -64,9 +64,13 @@ func (r *EntitiesRouter) Mount(parent *mux.Router) {
 
 	routes.Del(deleter.Delete)
 	routes.Get(r.find)
+	routes.History(r.configSubrouter.handlers.GetV3ResourceHistory)
 	routes.List(r.controller.List, corev2.EntityFields)
 	routes.ListAllNamespaces(r.controller.List, "/{resource:entities}", corev2.EntityFields)
 	routes.Patch(r.configSubrouter.handlers.PatchResource)
+	routes.Touch(r.configSubrouter.handlers.TouchResource)
+	routes.PendingChange(r.configSubrouter.handlers.GetPendingChange)
+	routes.Approve(r.configSubrouter.handlers.ApproveChange)
 	routes.Post(r.create)
 	routes.Put(r.createOrReplace)
 }
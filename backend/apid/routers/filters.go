@@ -34,6 +34,9 @@ func (r *EventFiltersRouter) Mount(parent *mux.Router) {
 	routes.List(r.handlers.ListResources, corev2.EventFilterFields)
 	routes.ListAllNamespaces(r.handlers.ListResources, "/{resource:filters}", corev2.EventFilterFields)
 	routes.Patch(r.handlers.PatchResource)
+	routes.Touch(r.handlers.TouchResource)
+	routes.PendingChange(r.handlers.GetPendingChange)
+	routes.Approve(r.handlers.ApproveChange)
 	routes.Post(r.handlers.CreateResource)
 	routes.Put(r.handlers.CreateOrUpdateResource)
 }
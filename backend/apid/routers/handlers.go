@@ -1,24 +1,43 @@
 package routers
 
 import (
+	"context"
+	"net/http"
+	"net/url"
+
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/apid/handlers"
 	"github.com/sensu/sensu-go/backend/store"
 )
 
+// HandlerTester runs a handler against a synthetic event.
+type HandlerTester interface {
+	TestHandler(ctx context.Context, name string, event *corev2.Event) (*corev2.HandlerTestResult, error)
+}
+
+// HandlerStatusGetter reports the error state of a handler.
+type HandlerStatusGetter interface {
+	HandlerStatus(ctx context.Context, name string) (*corev2.HandlerStatus, error)
+}
+
 // HandlersRouter handles requests for /handlers
 type HandlersRouter struct {
 	handlers handlers.Handlers
+	tester   HandlerTester
+	statuses HandlerStatusGetter
 }
 
 // NewHandlersRouter instantiates new router for controlling handler resources
-func NewHandlersRouter(store store.ResourceStore) *HandlersRouter {
+func NewHandlersRouter(store store.ResourceStore, tester HandlerTester, statuses HandlerStatusGetter) *HandlersRouter {
 	return &HandlersRouter{
 		handlers: handlers.Handlers{
 			Resource: &corev2.Handler{},
 			Store:    store,
 		},
+		tester:   tester,
+		statuses: statuses,
 	}
 }
 
@@ -33,6 +52,78 @@ func (r *HandlersRouter) Mount(parent *mux.Router) {
 	routes.List(r.handlers.ListResources, corev2.HandlerFields)
 	routes.ListAllNamespaces(r.handlers.ListResources, "/{resource:handlers}", corev2.HandlerFields)
 	routes.Patch(r.handlers.PatchResource)
+	routes.Touch(r.handlers.TouchResource)
+	routes.PendingChange(r.handlers.GetPendingChange)
+	routes.Approve(r.handlers.ApproveChange)
 	routes.Post(r.handlers.CreateResource)
 	routes.Put(r.handlers.CreateOrUpdateResource)
+
+	// Custom
+	routes.Path("{id}/test", r.testHandler).Methods(http.MethodPost)
+	routes.Path("{id}/status", r.handlerStatus).Methods(http.MethodGet)
+}
+
+func (r *HandlersRouter) handlerStatus(req *http.Request) (interface{}, error) {
+	if r.statuses == nil {
+		return nil, actions.NewErrorf(actions.InternalErr, "handler statuses are not available")
+	}
+
+	name, err := url.PathUnescape(mux.Vars(req)["id"])
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+
+	status, err := r.statuses.HandlerStatus(req.Context(), name)
+	if err != nil {
+		switch err := err.(type) {
+		case *store.ErrNotFound:
+			return nil, actions.NewError(actions.NotFound, err)
+		default:
+			return nil, actions.NewError(actions.InternalErr, err)
+		}
+	}
+
+	return status, nil
+}
+
+func (r *HandlersRouter) testHandler(req *http.Request) (interface{}, error) {
+	if r.tester == nil {
+		return nil, actions.NewErrorf(actions.InternalErr, "handler testing is not available")
+	}
+
+	params := mux.Vars(req)
+	name, err := url.PathUnescape(params["id"])
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	namespace, err := url.PathUnescape(params["namespace"])
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+
+	var event corev2.Event
+	if err := UnmarshalBody(req, &event); err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	if event.Entity != nil && event.Entity.Namespace == "" {
+		event.Entity.Namespace = namespace
+	}
+	if event.Check != nil && event.Check.Namespace == "" {
+		event.Check.Namespace = namespace
+	}
+	if err := event.Validate(); err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+
+	result, err := r.tester.TestHandler(req.Context(), name, &event)
+	if err != nil {
+		switch err := err.(type) {
+		case *store.ErrNotFound:
+			return nil, actions.NewError(actions.NotFound, err)
+		default:
+			return nil, actions.NewError(actions.InternalErr, err)
+		}
+	}
+
+	return result, nil
 }
@@ -1,17 +1,45 @@
 package routers
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/mock"
 )
 
+type mockHandlerTester struct {
+	mock.Mock
+}
+
+func (m *mockHandlerTester) TestHandler(ctx context.Context, name string, event *corev2.Event) (*corev2.HandlerTestResult, error) {
+	args := m.Called(ctx, name, event)
+	result, _ := args.Get(0).(*corev2.HandlerTestResult)
+	return result, args.Error(1)
+}
+
+type mockHandlerStatusGetter struct {
+	mock.Mock
+}
+
+func (m *mockHandlerStatusGetter) HandlerStatus(ctx context.Context, name string) (*corev2.HandlerStatus, error) {
+	args := m.Called(ctx, name)
+	status, _ := args.Get(0).(*corev2.HandlerStatus)
+	return status, args.Error(1)
+}
+
 func TestHandlersRouter(t *testing.T) {
 	// Setup the router
 	s := &mockstore.MockStore{}
-	router := NewHandlersRouter(s)
+	router := NewHandlersRouter(s, nil, nil)
 	parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
 	router.Mount(parentRouter)
 
@@ -28,3 +56,135 @@ func TestHandlersRouter(t *testing.T) {
 		run(t, tt, parentRouter, s)
 	}
 }
+
+func TestHandlersRouterTestHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       []byte
+		result     *corev2.HandlerTestResult
+		err        error
+		wantStatus int
+	}{
+		{
+			name: "handler result is returned",
+			body: func() []byte {
+				b, _ := json.Marshal(corev2.FixtureEvent("entity1", "check1"))
+				return b
+			}(),
+			result:     &corev2.HandlerTestResult{Output: "paged", Status: 0},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid event",
+			body:       []byte(`{"entity": null}`),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "handler not found",
+			body: func() []byte {
+				b, _ := json.Marshal(corev2.FixtureEvent("entity1", "check1"))
+				return b
+			}(),
+			err:        &store.ErrNotFound{Key: "foo"},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "handler failure",
+			body: func() []byte {
+				b, _ := json.Marshal(corev2.FixtureEvent("entity1", "check1"))
+				return b
+			}(),
+			err:        errors.New("unknown handler type"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tester := &mockHandlerTester{}
+			tester.On("TestHandler", mock.Anything, "foo", mock.Anything).Return(tt.result, tt.err)
+			router := NewHandlersRouter(&mockstore.MockStore{}, tester, nil)
+			parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
+			router.Mount(parentRouter)
+
+			req, err := http.NewRequest(http.MethodPost, "/api/core/v2/namespaces/default/handlers/foo/test", bytes.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			rr := httptest.NewRecorder()
+			parentRouter.ServeHTTP(rr, req)
+			if got, want := rr.Code, tt.wantStatus; got != want {
+				t.Fatalf("bad status: got %d, want %d: %s", got, want, rr.Body.String())
+			}
+			if tt.result == nil {
+				return
+			}
+			var result corev2.HandlerTestResult
+			if err := json.Unmarshal(rr.Body.Bytes(), &result); err != nil {
+				t.Fatal(err)
+			}
+			if result != *tt.result {
+				t.Errorf("bad result: got %v, want %v", result, *tt.result)
+			}
+		})
+	}
+}
+
+func TestHandlersRouterStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     *corev2.HandlerStatus
+		err        error
+		wantStatus int
+	}{
+		{
+			name: "returns the handler status",
+			status: &corev2.HandlerStatus{
+				Handler:             "foo",
+				Namespace:           "default",
+				LastError:           "connection refused",
+				LastErrorTimestamp:  1600000000,
+				ConsecutiveFailures: 3,
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing handler",
+			err:        &store.ErrNotFound{Key: "foo"},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "store failure",
+			err:        errors.New("store error"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statuses := &mockHandlerStatusGetter{}
+			statuses.On("HandlerStatus", mock.Anything, "foo").Return(tt.status, tt.err)
+			router := NewHandlersRouter(&mockstore.MockStore{}, nil, statuses)
+			parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
+			router.Mount(parentRouter)
+
+			req, err := http.NewRequest(http.MethodGet, "/api/core/v2/namespaces/default/handlers/foo/status", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rr := httptest.NewRecorder()
+			parentRouter.ServeHTTP(rr, req)
+			if got, want := rr.Code, tt.wantStatus; got != want {
+				t.Fatalf("bad status: got %d, want %d: %s", got, want, rr.Body.String())
+			}
+			if tt.status == nil {
+				return
+			}
+			var status corev2.HandlerStatus
+			if err := json.Unmarshal(rr.Body.Bytes(), &status); err != nil {
+				t.Fatal(err)
+			}
+			if status != *tt.status {
+				t.Errorf("bad handler status: got %v, want %v", status, *tt.status)
+			}
+		})
+	}
+}
@@ -355,6 +355,9 @@ var updateResourceInvalidTestCase = func(resource corev2.Resource) routerTestCas
 		path:   resource.URIPath(),
 		body:   marshal(resource),
 		storeFunc: func(s *mockstore.MockStore) {
+			s.On("GetResource", mock.Anything, resource.GetObjectMeta().Name, mock.AnythingOfType(typ)).
+				Return(&store.ErrNotFound{}).
+				Once()
 			s.On("CreateOrUpdateResource", mock.Anything, mock.AnythingOfType(typ)).
 				Return(&store.ErrNotValid{Err: errors.New("error")}).
 				Once()
@@ -372,6 +375,9 @@ var updateResourceStoreErrTestCase = func(resource corev2.Resource) routerTestCa
 		path:   resource.URIPath(),
 		body:   marshal(resource),
 		storeFunc: func(s *mockstore.MockStore) {
+			s.On("GetResource", mock.Anything, resource.GetObjectMeta().Name, mock.AnythingOfType(typ)).
+				Return(&store.ErrNotFound{}).
+				Once()
 			s.On("CreateOrUpdateResource", mock.Anything, mock.AnythingOfType(typ)).
 				Return(&store.ErrInternal{}).
 				Once()
@@ -389,6 +395,9 @@ var updateResourceSuccessTestCase = func(resource corev2.Resource) routerTestCas
 		path:   resource.URIPath(),
 		body:   marshal(resource),
 		storeFunc: func(s *mockstore.MockStore) {
+			s.On("GetResource", mock.Anything, resource.GetObjectMeta().Name, mock.AnythingOfType(typ)).
+				Return(&store.ErrNotFound{}).
+				Once()
 			s.On("CreateOrUpdateResource", mock.Anything, mock.AnythingOfType(typ)).
 				Return(nil).
 				Once()
@@ -423,6 +432,9 @@ var deleteResourceNotFoundTestCase = func(resource corev2.Resource) routerTestCa
 		path:   resource.URIPath(),
 		body:   []byte(`{"metadata": {"namespace":"default","name":"foo"}}`),
 		storeFunc: func(s *mockstore.MockStore) {
+			s.On("GetResource", mock.Anything, resource.GetObjectMeta().Name, mock.Anything).
+				Return(&store.ErrNotFound{}).
+				Once()
 			s.On("DeleteResource", mock.Anything, resource.StorePrefix(), resource.GetObjectMeta().Name).
 				Return(&store.ErrNotFound{}).
 				Once()
@@ -441,6 +453,9 @@ var deleteResourceStoreErrTestCase = func(resource corev2.Resource) routerTestCa
 		path:   resource.URIPath(),
 		body:   []byte(`{"metadata": {"namespace":"default","name":"foo"}}`),
 		storeFunc: func(s *mockstore.MockStore) {
+			s.On("GetResource", mock.Anything, resource.GetObjectMeta().Name, mock.Anything).
+				Return(&store.ErrNotFound{}).
+				Once()
 			s.On("DeleteResource", mock.Anything, resource.StorePrefix(), resource.GetObjectMeta().Name).
 				Return(&store.ErrInternal{}).
 				Once()
@@ -459,7 +474,10 @@ var deleteResourceSuccessTestCase = func(resource corev2.Resource) routerTestCas
 		path:   resource.URIPath(),
 		body:   []byte(`{"metadata": {"namespace":"default","name":"foo"}}`),
 		storeFunc: func(s *mockstore.MockStore) {
-			s.On("DeleteResource", mock.Anything, resource.StorePrefix(), resource.GetObjectMeta().Name).
+			s.On("GetResource", mock.Anything, resource.GetObjectMeta().Name, mock.Anything).
+				Return(nil).
+				Once()
+			s.On("DeleteResourceIfMatch", mock.Anything, mock.Anything, resource.GetObjectMeta().Name, mock.Anything).
 				Return(nil).
 				Once()
 			s.On("DeleteNamespace", mock.Anything, resource.GetObjectMeta().Name).
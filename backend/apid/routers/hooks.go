@@ -34,6 +34,9 @@ func (r *HooksRouter) Mount(parent *mux.Router) {
 	routes.List(r.handlers.ListResources, corev2.HookConfigFields)
 	routes.ListAllNamespaces(r.handlers.ListResources, "/{resource:hooks}", corev2.HookConfigFields)
 	routes.Patch(r.handlers.PatchResource)
+	routes.Touch(r.handlers.TouchResource)
+	routes.PendingChange(r.handlers.GetPendingChange)
+	routes.Approve(r.handlers.ApproveChange)
 	routes.Post(r.handlers.CreateResource)
 	routes.Put(r.handlers.CreateOrUpdateResource)
 }
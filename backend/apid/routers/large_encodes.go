@@ -0,0 +1,43 @@
+package routers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/sensu/sensu-go/backend/store/v2/wrap"
+)
+
+// LargeEncodesLister lists the exemplars of the largest resources encoded by
+// the store.
+type LargeEncodesLister interface {
+	Exemplars() []wrap.Exemplar
+}
+
+// LargeEncodesRouter handles requests for /large-encodes
+type LargeEncodesRouter struct {
+	lister LargeEncodesLister
+}
+
+// NewLargeEncodesRouter instantiates a new router for listing the exemplars of
+// large encodes. A nil lister, for backends that don't record them, lists
+// no exemplars.
+func NewLargeEncodesRouter(lister LargeEncodesLister) *LargeEncodesRouter {
+	return &LargeEncodesRouter{
+		lister: lister,
+	}
+}
+
+// Mount the LargeEncodesRouter to a parent Router
+func (r *LargeEncodesRouter) Mount(parent *mux.Router) {
+	parent.HandleFunc("/{resource:large-encodes}", r.list).Methods(http.MethodGet)
+}
+
+func (r *LargeEncodesRouter) list(w http.ResponseWriter, _ *http.Request) {
+	exemplars := []wrap.Exemplar{}
+	if r.lister != nil {
+		exemplars = r.lister.Exemplars()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(exemplars)
+}
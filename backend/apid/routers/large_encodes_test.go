@@ -0,0 +1,58 @@
+package routers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/sensu/sensu-go/backend/store/v2/wrap"
+)
+
+func TestLargeEncodesRouter(t *testing.T) {
+	ring := wrap.NewExemplarRing(2, 0)
+	ring.Add(wrap.Exemplar{Type: "CheckConfig", Namespace: "default", Name: "foo", Size: 1})
+	ring.Add(wrap.Exemplar{Type: "CheckConfig", Namespace: "default", Name: "bar", Size: 2})
+
+	tests := []struct {
+		name   string
+		lister LargeEncodesLister
+		want   []wrap.Exemplar
+	}{
+		{
+			name:   "recorded exemplars",
+			lister: ring,
+			want:   ring.Exemplars(),
+		},
+		{
+			name: "no lister",
+			want: []wrap.Exemplar{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := mux.NewRouter()
+			NewLargeEncodesRouter(tt.lister).Mount(router)
+			server := httptest.NewServer(router)
+			defer server.Close()
+
+			resp, err := http.Get(server.URL + "/large-encodes")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("bad status: %d", resp.StatusCode)
+			}
+			var got []wrap.Exemplar
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
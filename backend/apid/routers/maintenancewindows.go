@@ -0,0 +1,42 @@
+package routers
+
+import (
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/handlers"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+// MaintenanceWindowsRouter handles requests for MaintenanceWindows.
+type MaintenanceWindowsRouter struct {
+	handlers handlers.Handlers
+}
+
+// NewMaintenanceWindowsRouter instantiates a new router for
+// MaintenanceWindows.
+func NewMaintenanceWindowsRouter(store store.ResourceStore) *MaintenanceWindowsRouter {
+	return &MaintenanceWindowsRouter{
+		handlers: handlers.Handlers{
+			Resource: &corev2.MaintenanceWindow{},
+			Store:    store,
+		},
+	}
+}
+
+// Mount the MaintenanceWindowsRouter on the given parent Router
+func (r *MaintenanceWindowsRouter) Mount(parent *mux.Router) {
+	routes := ResourceRoute{
+		Router:     parent,
+		PathPrefix: "/{resource:maintenancewindows}",
+	}
+
+	routes.Del(r.handlers.DeleteResource)
+	routes.Get(r.handlers.GetResource)
+	routes.List(r.handlers.ListResources, corev2.MaintenanceWindowFields)
+	routes.Patch(r.handlers.PatchResource)
+	routes.Touch(r.handlers.TouchResource)
+	routes.PendingChange(r.handlers.GetPendingChange)
+	routes.Approve(r.handlers.ApproveChange)
+	routes.Post(r.handlers.CreateResource)
+	routes.Put(r.handlers.CreateOrUpdateResource)
+}
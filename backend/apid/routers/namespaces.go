@@ -2,9 +2,20 @@ package routers
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
@@ -15,6 +26,42 @@ import (
 	"github.com/sensu/sensu-go/backend/authorization"
 	"github.com/sensu/sensu-go/backend/store"
 	storev2 "github.com/sensu/sensu-go/backend/store/v2"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// ifMatchHeader is the header used to perform conditional namespace updates
+	// and deletions
+	ifMatchHeader = "If-Match"
+
+	// ifNoneMatchHeader is the header used to perform conditional namespace
+	// creations
+	ifNoneMatchHeader = "If-None-Match"
+
+	// idempotencyKeyHeader is the header used to identify namespace creations
+	// that clients may retry
+	idempotencyKeyHeader = "Idempotency-Key"
+
+	// idempotencyKeyTTL is the duration for which idempotency keys are retained
+	idempotencyKeyTTL = 10 * time.Minute
+
+	// reasonParam is the query parameter used to explain namespace deletions
+	reasonParam = "reason"
+
+	// maxReasonLength is the maximum length, in characters, of a deletion reason
+	maxReasonLength = 256
+
+	// namesParam is the query parameter holding the comma-separated names of
+	// the namespaces to fetch at once
+	namesParam = "names"
+
+	// maxFetchNamespaces is the maximum number of namespaces fetched at once,
+	// which bounds the size of the store transaction
+	maxFetchNamespaces = 100
+
+	// countsParam is the query parameter used to list namespaces along with
+	// the number of resources they contain
+	countsParam = "counts"
 )
 
 // NamespacesRouter handles requests for /namespaces
@@ -23,14 +70,16 @@ type NamespacesRouter struct {
 	store          store.ResourceStore
 	storev2        storev2.Interface
 	namespaceStore store.NamespaceStore
+	keyStore       store.IdempotencyStore
 	auth           authorization.Authorizer
 }
 
 // NewNamespacesRouter instantiates new router for controlling check resources
-func NewNamespacesRouter(store store.ResourceStore, namespaceStore store.NamespaceStore, auth authorization.Authorizer, storev2 storev2.Interface) *NamespacesRouter {
+func NewNamespacesRouter(store store.ResourceStore, namespaceStore store.NamespaceStore, keyStore store.IdempotencyStore, auth authorization.Authorizer, storev2 storev2.Interface) *NamespacesRouter {
 	return &NamespacesRouter{
 		store:          store,
 		namespaceStore: namespaceStore,
+		keyStore:       keyStore,
 		auth:           auth,
 		handlers: handlers.Handlers{
 			Resource: &corev2.Namespace{},
@@ -49,9 +98,12 @@ func (r *NamespacesRouter) Mount(parent *mux.Router) {
 
 	routes.Del(r.delete)
 	routes.Get(r.handlers.GetResource)
-	routes.List(r.list, corev2.NamespaceFields)
+	routes.Path("", r.fetch).Methods(http.MethodGet).Queries(namesParam, "{names}")
+	parent.HandleFunc("/{resource:namespaces}", r.listWithCounts).Methods(http.MethodGet).Queries(countsParam, "true")
+	routes.RangeList(r.list, corev2.NamespaceFields)
 	routes.Post(r.create)
 	routes.Patch(r.handlers.PatchResource)
+	routes.Touch(r.handlers.TouchResource)
 	routes.Put(r.update)
 }
 
@@ -59,6 +111,9 @@ func (r *NamespacesRouter) list(ctx context.Context, pred *store.SelectionPredic
 	client := api.NewNamespaceClient(r.store, r.namespaceStore, r.auth, r.storev2)
 	namespaces, err := client.ListNamespaces(ctx, pred)
 	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, actions.NewErrorf(actions.DeadlineExceeded, "the request did not complete within the requested timeout")
+		}
 		return nil, err
 	}
 	result := make([]corev2.Resource, len(namespaces))
@@ -68,10 +123,80 @@ func (r *NamespacesRouter) list(ctx context.Context, pred *store.SelectionPredic
 	return result, nil
 }
 
+// listWithCounts lists the namespaces along with the number of resources of
+// each type they contain, a page at a time.
+func (r *NamespacesRouter) listWithCounts(w http.ResponseWriter, req *http.Request) {
+	pred := &store.SelectionPredicate{
+		Continue: corev2.PageContinueFromContext(req.Context()),
+		Limit:    int64(corev2.PageSizeFromContext(req.Context())),
+	}
+
+	client := api.NewNamespaceClient(r.store, r.namespaceStore, r.auth, r.storev2)
+	namespaces, err := client.ListNamespacesWithCounts(req.Context(), pred)
+	if err != nil {
+		if err == authorization.ErrUnauthorized {
+			WriteError(w, actions.NewError(actions.PermissionDenied, err))
+		} else if req.Context().Err() == context.DeadlineExceeded {
+			WriteError(w, actions.NewErrorf(actions.DeadlineExceeded, "the request did not complete within the requested timeout"))
+		} else {
+			WriteError(w, actions.NewError(actions.InternalErr, err))
+		}
+		return
+	}
+
+	if pred.Continue != "" {
+		encodedContinue := base64.RawURLEncoding.EncodeToString([]byte(pred.Continue))
+		w.Header().Set(corev2.PaginationContinueHeader, encodedContinue)
+	}
+	RespondWith(w, req, namespaces)
+}
+
+// fetch fetches the namespaces named by the names query parameter, in a single
+// round-trip, and reports the ones that were not found.
+func (r *NamespacesRouter) fetch(req *http.Request) (interface{}, error) {
+	var names []string
+	seen := map[string]bool{}
+	for _, name := range strings.Split(req.URL.Query().Get(namesParam), ",") {
+		if name = strings.TrimSpace(name); name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, actions.NewErrorf(actions.InvalidArgument, "the %s query parameter is empty", namesParam)
+	}
+	if len(names) > maxFetchNamespaces {
+		return nil, actions.NewErrorf(actions.InvalidArgument, "cannot fetch more than %d namespaces at once", maxFetchNamespaces)
+	}
+
+	client := api.NewNamespaceClient(r.store, r.namespaceStore, r.auth, r.storev2)
+	namespaces, err := client.FetchNamespaces(req.Context(), names)
+	if err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+
+	result := corev2.NamespaceFetchResult{
+		Namespaces: []*corev2.Namespace{},
+		Missing:    []string{},
+	}
+	for _, name := range names {
+		if namespace, ok := namespaces[name]; ok {
+			result.Namespaces = append(result.Namespaces, namespace)
+		} else {
+			result.Missing = append(result.Missing, name)
+		}
+	}
+	return result, nil
+}
+
 func (r *NamespacesRouter) create(req *http.Request) (interface{}, error) {
 	ctx := req.Context()
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
 	var ns corev2.Namespace
-	if err := json.NewDecoder(req.Body).Decode(&ns); err != nil {
+	if err := json.Unmarshal(body, &ns); err != nil {
 		return nil, actions.NewError(actions.InvalidArgument, err)
 	}
 	meta := ns.GetObjectMeta()
@@ -85,20 +210,76 @@ func (r *NamespacesRouter) create(req *http.Request) (interface{}, error) {
 	if err := ns.Validate(); err != nil {
 		return nil, actions.NewError(actions.InvalidArgument, err)
 	}
+	// A repeated creation carries the idempotency key of the original one, and
+	// the same body. The key is reserved, with the hash of the body, before the
+	// namespace is created, and released if the creation fails, so that it can
+	// be retried. Once the namespace is created, the creation is recorded
+	// under a key of its own, so that retries that race with the original
+	// creation are told to retry later rather than answered as successful.
+	key := req.Header.Get(idempotencyKeyHeader)
+	sum := sha256.Sum256(body)
+	fingerprint := hex.EncodeToString(sum[:])
+	if key != "" {
+		key = path.Join("namespaces", key)
+		reserved, err := r.keyStore.ReserveIdempotencyKey(ctx, key, fingerprint, idempotencyKeyTTL)
+		if err != nil {
+			return nil, actions.NewError(actions.InternalErr, err)
+		}
+		if reserved == fingerprint {
+			completed, err := r.keyStore.GetIdempotencyKey(ctx, completedKey(key))
+			if err != nil {
+				return nil, actions.NewError(actions.InternalErr, err)
+			}
+			if completed != fingerprint {
+				return nil, actions.NewRetryableError(actions.AlreadyExistsErr, errIdempotencyKeyInFlight)
+			}
+			// Respond to the retry like to the original request
+			return nil, nil
+		}
+		if reserved != "" {
+			// The key identifies another request, it can't be reserved
+			key = ""
+		}
+	}
+	if ifNoneMatch := req.Header.Get(ifNoneMatchHeader); ifNoneMatch != "" {
+		ctx = store.ContextWithIfNoneMatch(ctx, ifNoneMatch)
+	}
 	client := api.NewNamespaceClient(r.store, r.namespaceStore, r.auth, r.storev2)
 	if err := client.CreateNamespace(ctx, &ns); err != nil {
+		if key != "" {
+			if err := r.keyStore.ReleaseIdempotencyKey(ctx, key, fingerprint); err != nil {
+				logger.WithError(err).Warn("could not release idempotency key, retries of this request will be told to retry later")
+			}
+		}
 		switch err := err.(type) {
 		case *store.ErrAlreadyExists:
 			return nil, actions.NewErrorf(actions.AlreadyExistsErr)
+		case *store.ErrPreconditionFailed:
+			return nil, actions.NewError(actions.PreconditionFailed, err)
 		case *store.ErrNotValid:
 			return nil, actions.NewError(actions.InvalidArgument, err)
 		default:
 			return nil, actions.NewError(actions.InternalErr, err)
 		}
 	}
+	if key != "" {
+		if _, err := r.keyStore.ReserveIdempotencyKey(ctx, completedKey(key), fingerprint, idempotencyKeyTTL); err != nil {
+			logger.WithError(err).Warn("could not record the completion of the request, its retries will be told to retry later")
+		}
+	}
 	return nil, nil
 }
 
+// errIdempotencyKeyInFlight is returned to the retries of a namespace creation
+// that is still in progress.
+var errIdempotencyKeyInFlight = errors.New("the request with this idempotency key is in progress, retry later")
+
+// completedKey returns the key under which the completion of the request
+// identified by the idempotency key key is recorded.
+func completedKey(key string) string {
+	return path.Join(key, "completed")
+}
+
 func (r *NamespacesRouter) update(req *http.Request) (interface{}, error) {
 	ctx := req.Context()
 	var ns corev2.Namespace
@@ -116,11 +297,17 @@ func (r *NamespacesRouter) update(req *http.Request) (interface{}, error) {
 	if err := ns.Validate(); err != nil {
 		return nil, actions.NewError(actions.InvalidArgument, err)
 	}
+	// Determine if we have a conditional request
+	conditions := &store.ETagCondition{
+		IfMatch: req.Header.Get(ifMatchHeader),
+	}
 	client := api.NewNamespaceClient(r.store, r.namespaceStore, r.auth, r.storev2)
-	if err := client.UpdateNamespace(ctx, &ns); err != nil {
+	if err := client.UpdateNamespace(ctx, &ns, conditions); err != nil {
 		switch err := err.(type) {
 		case *store.ErrNotValid:
 			return nil, actions.NewError(actions.InvalidArgument, err)
+		case *store.ErrPreconditionFailed:
+			return nil, actions.NewError(actions.PreconditionFailed, err)
 		default:
 			return nil, actions.NewError(actions.InternalErr, err)
 		}
@@ -134,16 +321,55 @@ func (r *NamespacesRouter) delete(req *http.Request) (interface{}, error) {
 	if err != nil {
 		return nil, actions.NewError(actions.InvalidArgument, err)
 	}
+	reason, err := deletionReason(req)
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
 
+	// Determine if we have a conditional request
+	conditions := &store.ETagCondition{
+		IfMatch: req.Header.Get(ifMatchHeader),
+	}
+	ctx := req.Context()
 	client := api.NewNamespaceClient(r.store, r.namespaceStore, r.auth, r.storev2)
-	if err := client.DeleteNamespace(req.Context(), name); err != nil {
+	if err := client.DeleteNamespace(ctx, name, conditions); err != nil {
 		switch err := err.(type) {
 		case *store.ErrNotFound:
 			return nil, actions.NewErrorf(actions.NotFound)
+		case *store.ErrPreconditionFailed:
+			return nil, actions.NewError(actions.PreconditionFailed, err)
 		default:
 			return nil, actions.NewError(actions.InternalErr, err)
 		}
 	}
 
+	// Record who deleted the namespace, and why
+	fields := logrus.Fields{"namespace": name}
+	if claims := jwt.GetClaimsFromContext(ctx); claims != nil {
+		fields["user"] = claims.StandardClaims.Subject
+	}
+	if reason == "" {
+		logger.WithFields(fields).Warn("namespace deleted without a reason")
+	} else {
+		fields["reason"] = reason
+		logger.WithFields(fields).Info("namespace deleted")
+	}
+
 	return nil, nil
 }
+
+// deletionReason returns the reason given for a deletion, stripped of control
+// characters so that it can be logged safely.
+func deletionReason(req *http.Request) (string, error) {
+	reason := strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, req.URL.Query().Get(reasonParam))
+	reason = strings.TrimSpace(reason)
+	if n := utf8.RuneCountInString(reason); n > maxReasonLength {
+		return "", fmt.Errorf("reason is too long: %d > %d characters", n, maxReasonLength)
+	}
+	return reason, nil
+}
@@ -1,12 +1,24 @@
 package routers
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/authorization/rbac"
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/backend/store/v2/wrap"
@@ -60,7 +72,7 @@ func TestNamespacesRouter(t *testing.T) {
 	s2 := new(mockstore.V2MockStore)
 	s2.On("List", mock.Anything, mock.Anything).Return(wrap.List{}, nil)
 
-	router := NewNamespacesRouter(s, s, authorizer, s2)
+	router := NewNamespacesRouter(s, s, s, authorizer, s2)
 	parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
 	parentRouter.Use(mockedClaims)
 	router.Mount(parentRouter)
@@ -123,7 +135,7 @@ func TestNamespaceRouterList(t *testing.T) {
 	auth := &rbac.Authorizer{Store: s}
 	s2 := new(mockstore.V2MockStore)
 	s2.On("List", mock.Anything, mock.Anything).Return(wrap.List{}, nil)
-	router := NewNamespacesRouter(s, s, auth, s2)
+	router := NewNamespacesRouter(s, s, s, auth, s2)
 	pred := &store.SelectionPredicate{Limit: 1}
 	got, err := router.list(ctx, pred)
 	if err != nil {
@@ -137,9 +149,518 @@ func TestNamespaceRouterList(t *testing.T) {
 	}
 }
 
+func TestNamespaceRouterListTimeout(t *testing.T) {
+	s := new(mockstore.MockStore)
+	s.On("ListResources", mock.Anything, corev2.NamespacesResource, mock.Anything, mock.Anything).Return(context.DeadlineExceeded)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	authorizer := &mockauthorizer.Authorizer{}
+	authorizer.On("Authorize", mock.Anything, mock.Anything).Return(true, nil)
+	router := NewNamespacesRouter(s, s, s, authorizer, new(mockstore.V2MockStore))
+	_, err := router.list(ctx, &store.SelectionPredicate{})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if code, _ := actions.StatusFromError(err); code != actions.DeadlineExceeded {
+		t.Fatalf("expected a deadline exceeded error, got %v", err)
+	}
+}
+
 func mockedClaims(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.WithValue(r.Context(), corev2.ClaimsKey, corev2.FixtureClaims("foo", []string{"cluster-admins"}))
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+func TestNamespacesRouterConditionalUpdate(t *testing.T) {
+	stored := corev2.FixtureNamespace("foo")
+	etag, err := store.ETag(stored)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		ifMatch        string
+		storeErr       error
+		wantStatusCode int
+	}{
+		{
+			name:           "no precondition",
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name:           "matching etag",
+			ifMatch:        etag,
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name:           "wildcard",
+			ifMatch:        "*",
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name:           "stale etag",
+			ifMatch:        `"stale"`,
+			wantStatusCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:           "missing namespace",
+			ifMatch:        etag,
+			storeErr:       &store.ErrNotFound{Key: "foo"},
+			wantStatusCode: http.StatusPreconditionFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &mockstore.MockStore{}
+			s.On("GetResource", mock.Anything, "foo", mock.AnythingOfType("*v2.Namespace")).
+				Run(func(args mock.Arguments) {
+					*args[2].(*corev2.Namespace) = *stored
+				}).Return(tt.storeErr)
+			s.On("CreateOrUpdateResource", mock.Anything, mock.Anything).Return(nil)
+
+			authorizer := &mockauthorizer.Authorizer{}
+			authorizer.On("Authorize", mock.Anything, mock.Anything).Return(true, nil)
+
+			s2 := new(mockstore.V2MockStore)
+			s2.On("List", mock.Anything, mock.Anything).Return(wrap.List{}, nil)
+
+			router := NewNamespacesRouter(s, s, s, authorizer, s2)
+			parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
+			parentRouter.Use(mockedClaims)
+			router.Mount(parentRouter)
+
+			server := httptest.NewServer(parentRouter)
+			defer server.Close()
+
+			body, _ := json.Marshal(corev2.FixtureNamespace("foo"))
+			req, err := http.NewRequest(http.MethodPut, server.URL+stored.URIPath(), bytes.NewReader(body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+			if res.StatusCode != tt.wantStatusCode {
+				t.Errorf("StatusCode = %v, wantStatusCode %v", res.StatusCode, tt.wantStatusCode)
+			}
+		})
+	}
+}
+
+func TestNamespacesRouterConditionalDelete(t *testing.T) {
+	stored := corev2.FixtureNamespace("foo")
+	etag, err := store.ETag(stored)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		ifMatch        string
+		storeErr       error
+		wantStatusCode int
+		wantDeleted    bool
+	}{
+		{
+			name:           "no precondition",
+			wantStatusCode: http.StatusNoContent,
+			wantDeleted:    true,
+		},
+		{
+			name:           "matching etag",
+			ifMatch:        etag,
+			wantStatusCode: http.StatusNoContent,
+			wantDeleted:    true,
+		},
+		{
+			name:           "stale etag",
+			ifMatch:        `"stale"`,
+			wantStatusCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:           "missing namespace",
+			ifMatch:        etag,
+			storeErr:       &store.ErrNotFound{Key: "foo"},
+			wantStatusCode: http.StatusPreconditionFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &mockstore.MockStore{}
+			s.On("GetResource", mock.Anything, "foo", mock.AnythingOfType("*v2.Namespace")).
+				Run(func(args mock.Arguments) {
+					*args[2].(*corev2.Namespace) = *stored
+				}).Return(tt.storeErr)
+			s.On("DeleteNamespace", mock.Anything, "foo").Return(nil)
+			s.On("DeleteResource", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			authorizer := &mockauthorizer.Authorizer{}
+			authorizer.On("Authorize", mock.Anything, mock.Anything).Return(true, nil)
+
+			router := NewNamespacesRouter(s, s, s, authorizer, new(mockstore.V2MockStore))
+			parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
+			parentRouter.Use(mockedClaims)
+			router.Mount(parentRouter)
+
+			server := httptest.NewServer(parentRouter)
+			defer server.Close()
+
+			req, err := http.NewRequest(http.MethodDelete, server.URL+stored.URIPath(), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+			if res.StatusCode != tt.wantStatusCode {
+				t.Errorf("StatusCode = %v, wantStatusCode %v", res.StatusCode, tt.wantStatusCode)
+			}
+			if tt.wantDeleted {
+				s.AssertCalled(t, "DeleteNamespace", mock.Anything, "foo")
+			} else {
+				s.AssertNotCalled(t, "DeleteNamespace", mock.Anything, "foo")
+			}
+		})
+	}
+}
+
+func TestNamespacesRouterIdempotentCreate(t *testing.T) {
+	body, _ := json.Marshal(corev2.FixtureNamespace("foo"))
+	sum := sha256.Sum256(body)
+	fingerprint := hex.EncodeToString(sum[:])
+
+	tests := []struct {
+		name           string
+		idempotencyKey string
+		ifNoneMatch    string
+		reserved       string
+		completed      string
+		reserveErr     error
+		createErr      error
+		wantStatusCode int
+		wantRetryable  bool
+		wantCreated    bool
+		wantReleased   bool
+		wantCompleted  bool
+	}{
+		{
+			name:           "created",
+			idempotencyKey: "abc",
+			wantStatusCode: http.StatusCreated,
+			wantCreated:    true,
+			wantCompleted:  true,
+		},
+		{
+			name:           "conflict without key",
+			createErr:      &store.ErrAlreadyExists{Key: "foo"},
+			wantStatusCode: http.StatusConflict,
+			wantCreated:    true,
+		},
+		{
+			name:           "retry",
+			idempotencyKey: "abc",
+			reserved:       fingerprint,
+			completed:      fingerprint,
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name:           "retry of a request in progress",
+			idempotencyKey: "abc",
+			reserved:       fingerprint,
+			wantStatusCode: http.StatusConflict,
+			wantRetryable:  true,
+		},
+		{
+			name:           "key of another request",
+			idempotencyKey: "abc",
+			reserved:       "bar",
+			createErr:      &store.ErrAlreadyExists{Key: "foo"},
+			wantStatusCode: http.StatusConflict,
+			wantCreated:    true,
+		},
+		{
+			name:           "conflict with a reserved key",
+			idempotencyKey: "abc",
+			createErr:      &store.ErrAlreadyExists{Key: "foo"},
+			wantStatusCode: http.StatusConflict,
+			wantCreated:    true,
+			wantReleased:   true,
+		},
+		{
+			name:           "failed creation with a reserved key",
+			idempotencyKey: "abc",
+			createErr:      errors.New("etcd is down"),
+			wantStatusCode: http.StatusInternalServerError,
+			wantCreated:    true,
+			wantReleased:   true,
+		},
+		{
+			name:           "key reservation failure",
+			idempotencyKey: "abc",
+			reserveErr:     errors.New("etcd is down"),
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "conditional create of an existing namespace",
+			ifNoneMatch:    "*",
+			createErr:      &store.ErrPreconditionFailed{Key: "foo"},
+			wantStatusCode: http.StatusPreconditionFailed,
+			wantCreated:    true,
+		},
+		{
+			name:           "retry of a conditional create",
+			idempotencyKey: "abc",
+			ifNoneMatch:    "*",
+			reserved:       fingerprint,
+			completed:      fingerprint,
+			wantStatusCode: http.StatusCreated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &mockstore.MockStore{}
+			s.On("CreateResource", mock.Anything, mock.AnythingOfType("*v2.Namespace")).Return(tt.createErr)
+			s.On("CreateOrUpdateResource", mock.Anything, mock.Anything).Return(nil)
+			s.On("ReserveIdempotencyKey", mock.Anything, "namespaces/abc", fingerprint, idempotencyKeyTTL).Return(tt.reserved, tt.reserveErr)
+			s.On("ReserveIdempotencyKey", mock.Anything, "namespaces/abc/completed", fingerprint, idempotencyKeyTTL).Return("", nil)
+			s.On("GetIdempotencyKey", mock.Anything, "namespaces/abc/completed").Return(tt.completed, nil)
+			s.On("ReleaseIdempotencyKey", mock.Anything, "namespaces/abc", fingerprint).Return(nil)
+
+			authorizer := &mockauthorizer.Authorizer{}
+			authorizer.On("Authorize", mock.Anything, mock.Anything).Return(true, nil)
+
+			s2 := new(mockstore.V2MockStore)
+			s2.On("List", mock.Anything, mock.Anything).Return(wrap.List{}, nil)
+
+			router := NewNamespacesRouter(s, s, s, authorizer, s2)
+			parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
+			parentRouter.Use(mockedClaims)
+			router.Mount(parentRouter)
+
+			server := httptest.NewServer(parentRouter)
+			defer server.Close()
+
+			req, err := http.NewRequest(http.MethodPost, server.URL+corev2.URLPrefix+"/namespaces", bytes.NewReader(body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.idempotencyKey != "" {
+				req.Header.Set("Idempotency-Key", tt.idempotencyKey)
+			}
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+			if res.StatusCode != tt.wantStatusCode {
+				t.Errorf("StatusCode = %v, wantStatusCode %v", res.StatusCode, tt.wantStatusCode)
+			}
+			if got, want := res.Header.Get(RetryableHeader) == "true", tt.wantRetryable; got != want {
+				t.Errorf("retryable = %v, want %v", got, want)
+			}
+			if tt.wantCreated {
+				s.AssertCalled(t, "CreateResource", mock.Anything, mock.AnythingOfType("*v2.Namespace"))
+			} else {
+				s.AssertNotCalled(t, "CreateResource", mock.Anything, mock.AnythingOfType("*v2.Namespace"))
+			}
+			if tt.wantReleased {
+				s.AssertCalled(t, "ReleaseIdempotencyKey", mock.Anything, "namespaces/abc", fingerprint)
+			} else {
+				s.AssertNotCalled(t, "ReleaseIdempotencyKey", mock.Anything, "namespaces/abc", fingerprint)
+			}
+			if tt.wantCompleted {
+				s.AssertCalled(t, "ReserveIdempotencyKey", mock.Anything, "namespaces/abc/completed", fingerprint, idempotencyKeyTTL)
+			} else {
+				s.AssertNotCalled(t, "ReserveIdempotencyKey", mock.Anything, "namespaces/abc/completed", fingerprint, idempotencyKeyTTL)
+			}
+		})
+	}
+}
+
+func TestDeletionReason(t *testing.T) {
+	tests := []struct {
+		name    string
+		reason  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "no reason",
+		},
+		{
+			name:   "reason",
+			reason: "decommissioned the team",
+			want:   "decommissioned the team",
+		},
+		{
+			name:   "control characters are stripped",
+			reason: " decommissioned\n\tthe team\x1b[0m ",
+			want:   "decommissionedthe team[0m",
+		},
+		{
+			name:    "too long",
+			reason:  strings.Repeat("x", maxReasonLength+1),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := "/namespaces/foo?" + url.Values{"reason": []string{tt.reason}}.Encode()
+			req := httptest.NewRequest(http.MethodDelete, target, nil)
+			got, err := deletionReason(req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("deletionReason() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("deletionReason() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNamespacesRouterFetch(t *testing.T) {
+	names := make([]string, maxFetchNamespaces+1)
+	for i := range names {
+		names[i] = fmt.Sprintf("ns%d", i)
+	}
+	tooManyNames := strings.Join(names, ",")
+
+	tests := []struct {
+		name           string
+		query          string
+		wantStatusCode int
+		wantNames      []string
+		want           corev2.NamespaceFetchResult
+	}{
+		{
+			name:           "found and missing namespaces",
+			query:          "names=dev,%20missing,dev,prod",
+			wantStatusCode: http.StatusOK,
+			wantNames:      []string{"dev", "missing", "prod"},
+			want: corev2.NamespaceFetchResult{
+				Namespaces: []*corev2.Namespace{corev2.FixtureNamespace("dev"), corev2.FixtureNamespace("prod")},
+				Missing:    []string{"missing"},
+			},
+		},
+		{
+			name:           "no names",
+			query:          "names=,",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "too many names",
+			query:          "names=" + tooManyNames,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &mockstore.MockStore{}
+			s.On("GetNamespaces", mock.Anything, tt.wantNames).Return([]*corev2.Namespace{
+				corev2.FixtureNamespace("dev"),
+				corev2.FixtureNamespace("prod"),
+			}, nil)
+
+			authorizer := &mockauthorizer.Authorizer{}
+			authorizer.On("Authorize", mock.Anything, mock.Anything).Return(true, nil)
+
+			router := NewNamespacesRouter(s, s, s, authorizer, new(mockstore.V2MockStore))
+			parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
+			parentRouter.Use(mockedClaims)
+			router.Mount(parentRouter)
+
+			server := httptest.NewServer(parentRouter)
+			defer server.Close()
+
+			res, err := http.Get(server.URL + corev2.URLPrefix + "/namespaces?" + tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+			if res.StatusCode != tt.wantStatusCode {
+				t.Fatalf("StatusCode = %v, wantStatusCode %v", res.StatusCode, tt.wantStatusCode)
+			}
+			if tt.wantStatusCode != http.StatusOK {
+				s.AssertNotCalled(t, "GetNamespaces", mock.Anything, mock.Anything)
+				return
+			}
+			var got corev2.NamespaceFetchResult
+			if err := json.NewDecoder(res.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNamespacesRouterListWithCounts(t *testing.T) {
+	s := &mockstore.MockStore{}
+	s.On("ListResources", mock.Anything, corev2.NamespacesResource, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		resources := args[2].(*[]*corev2.Namespace)
+		*resources = append(*resources, corev2.FixtureNamespace("dev"), corev2.FixtureNamespace("prod"))
+		pred := args[3].(*store.SelectionPredicate)
+		pred.Continue = "prod"
+	}).Return(nil)
+	s.On("CountNamespaceResources", mock.Anything, []string{"dev", "prod"}, mock.Anything).Return(map[string]map[string]int64{
+		"dev": {"checks": 3, "events": 12},
+	}, nil)
+
+	authorizer := &mockauthorizer.Authorizer{}
+	authorizer.On("Authorize", mock.Anything, mock.Anything).Return(true, nil)
+
+	router := NewNamespacesRouter(s, s, s, authorizer, new(mockstore.V2MockStore))
+	parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
+	parentRouter.Use(mockedClaims)
+	router.Mount(parentRouter)
+
+	server := httptest.NewServer(parentRouter)
+	defer server.Close()
+
+	res, err := http.Get(server.URL + corev2.URLPrefix + "/namespaces?counts=true")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("StatusCode = %v, wantStatusCode %v", res.StatusCode, http.StatusOK)
+	}
+	continueToken, _ := base64.RawURLEncoding.DecodeString(res.Header.Get(corev2.PaginationContinueHeader))
+	if got := string(continueToken); got != "prod" {
+		t.Errorf("bad continue token: got %q", got)
+	}
+
+	var got []corev2.NamespaceWithCounts
+	if err := json.NewDecoder(res.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d namespaces, want 2", len(got))
+	}
+	if got[0].Namespace.Name != "dev" || got[0].Counts["checks"] != 3 || got[0].Counts["events"] != 12 || got[0].Counts["handlers"] != 0 {
+		t.Errorf("bad counts for dev: %v", got[0].Counts)
+	}
+	if got[1].Namespace.Name != "prod" || got[1].Counts["checks"] != 0 {
+		t.Errorf("bad counts for prod: %v", got[1].Counts)
+	}
+	s.AssertNumberOfCalls(t, "CountNamespaceResources", 1)
+}
@@ -33,6 +33,9 @@ func (r *PipelinesRouter) Mount(parent *mux.Router) {
 	routes.List(r.handlers.ListResources, corev2.PipelineFields)
 	routes.ListAllNamespaces(r.handlers.ListResources, "/{resource:pipelines}", corev2.PipelineFields)
 	routes.Patch(r.handlers.PatchResource)
+	routes.Touch(r.handlers.TouchResource)
+	routes.PendingChange(r.handlers.GetPendingChange)
+	routes.Approve(r.handlers.ApproveChange)
 	routes.Post(r.handlers.CreateResource)
 	routes.Put(r.handlers.CreateOrUpdateResource)
 	routes.Del(r.handlers.DeleteResource)
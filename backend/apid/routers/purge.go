@@ -0,0 +1,82 @@
+package routers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+)
+
+const (
+	// olderThanParam is the query parameter holding how long ago resources
+	// must have been marked for deletion to be purged
+	olderThanParam = "olderThan"
+
+	// dryRunParam is the query parameter that reports what a purge would
+	// delete without deleting it
+	dryRunParam = "dryRun"
+)
+
+// Purger permanently deletes the resources that were marked for deletion
+// before a given time, across namespaces.
+type Purger interface {
+	Purge(ctx context.Context, before time.Time, dryRun bool) (map[string]int, error)
+}
+
+// PurgeResult is the response of a purge.
+type PurgeResult struct {
+	// DryRun is true if nothing was deleted, in which case Purged counts the
+	// resources that would have been.
+	DryRun bool `json:"dry_run"`
+
+	// Purged is the number of resources purged, by type.
+	Purged map[string]int `json:"purged"`
+}
+
+// PurgeRouter handles requests for /maintenance/purge. Purges span every
+// namespace, so they are authorized as the creation of the cluster-wide
+// maintenance resource, which only cluster administrators are granted by
+// default.
+type PurgeRouter struct {
+	purger Purger
+}
+
+// NewPurgeRouter instantiates a new router for purging resources marked for
+// deletion.
+func NewPurgeRouter(purger Purger) *PurgeRouter {
+	return &PurgeRouter{
+		purger: purger,
+	}
+}
+
+// Mount the PurgeRouter to a parent Router
+func (r *PurgeRouter) Mount(parent *mux.Router) {
+	handleAction(parent, "/{resource:maintenance}/purge", r.purge).Methods(http.MethodPost)
+}
+
+// purge deletes the resources that were marked for deletion longer ago than
+// the duration of the request.
+func (r *PurgeRouter) purge(req *http.Request) (interface{}, error) {
+	if r.purger == nil {
+		return nil, actions.NewErrorf(actions.InternalErr, "purging is not available")
+	}
+
+	query := req.URL.Query()
+	olderThan, err := time.ParseDuration(query.Get(olderThanParam))
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, fmt.Errorf("invalid %s: %w", olderThanParam, err))
+	}
+	if olderThan <= 0 {
+		return nil, actions.NewErrorf(actions.InvalidArgument, "%s must be positive", olderThanParam)
+	}
+	dryRun := query.Get(dryRunParam) == "true"
+
+	purged, err := r.purger.Purge(req.Context(), time.Now().Add(-olderThan), dryRun)
+	if err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	return PurgeResult{DryRun: dryRun, Purged: purged}, nil
+}
@@ -0,0 +1,104 @@
+package routers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+type fakePurger struct {
+	before time.Time
+	dryRun bool
+	err    error
+}
+
+func (p *fakePurger) Purge(ctx context.Context, before time.Time, dryRun bool) (map[string]int, error) {
+	p.before, p.dryRun = before, dryRun
+	if p.err != nil {
+		return nil, p.err
+	}
+	return map[string]int{"checks": 2}, nil
+}
+
+func TestPurgeRouter(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		purger     *fakePurger
+		wantStatus int
+		wantResult *PurgeResult
+	}{
+		{
+			name:       "purge",
+			query:      "?olderThan=720h",
+			purger:     &fakePurger{},
+			wantStatus: http.StatusOK,
+			wantResult: &PurgeResult{Purged: map[string]int{"checks": 2}},
+		},
+		{
+			name:       "dry run",
+			query:      "?olderThan=720h&dryRun=true",
+			purger:     &fakePurger{},
+			wantStatus: http.StatusOK,
+			wantResult: &PurgeResult{DryRun: true, Purged: map[string]int{"checks": 2}},
+		},
+		{
+			name:       "missing duration",
+			purger:     &fakePurger{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "negative duration",
+			query:      "?olderThan=-1h",
+			purger:     &fakePurger{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "store error",
+			query:      "?olderThan=720h",
+			purger:     &fakePurger{err: errors.New("error")},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := mux.NewRouter()
+			NewPurgeRouter(tt.purger).Mount(router)
+			server := httptest.NewServer(router)
+			defer server.Close()
+
+			start := time.Now()
+			resp, err := http.Post(server.URL+"/maintenance/purge"+tt.query, "application/json", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("bad status: got %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if tt.wantResult == nil {
+				return
+			}
+			var got PurgeResult
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(&got, tt.wantResult) {
+				t.Errorf("got %v, want %v", got, tt.wantResult)
+			}
+			if got, want := tt.purger.before, start.Add(-720*time.Hour); got.Before(want) {
+				t.Errorf("purged resources marked before %s, want %s or later", got, want)
+			}
+			if got, want := tt.purger.dryRun, tt.wantResult.DryRun; got != want {
+				t.Errorf("bad dry run: got %v, want %v", got, want)
+			}
+		})
+	}
+}
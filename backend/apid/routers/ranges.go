@@ -0,0 +1,138 @@
+package routers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+const (
+	// rangeUnit is the unit of the ranges of resources accepted by list
+	// endpoints, as in "Range: resources=0-99"
+	rangeUnit = "resources"
+
+	rangeHeader        = "Range"
+	acceptRangesHeader = "Accept-Ranges"
+	contentRangeHeader = "Content-Range"
+)
+
+// resourceRange is the range of resources requested with a Range header. Both
+// bounds are inclusive, and Last is -1 when the range extends to the end of
+// the collection.
+type resourceRange struct {
+	First int64
+	Last  int64
+}
+
+// parseRange parses the value of a Range header. It returns nil if the header
+// is empty or uses another unit than resources, in which case the whole
+// collection is requested.
+func parseRange(header string) (*resourceRange, error) {
+	prefix := rangeUnit + "="
+	if !strings.HasPrefix(header, prefix) {
+		return nil, nil
+	}
+	spec := strings.TrimSpace(strings.TrimPrefix(header, prefix))
+	if strings.Contains(spec, ",") {
+		return nil, errors.New("multiple ranges are not supported")
+	}
+	bounds := strings.SplitN(spec, "-", 2)
+	if len(bounds) != 2 {
+		return nil, fmt.Errorf("invalid range: %q", spec)
+	}
+	first, err := strconv.ParseInt(bounds[0], 10, 64)
+	if err != nil || first < 0 {
+		return nil, fmt.Errorf("invalid range start: %q", bounds[0])
+	}
+	rng := &resourceRange{First: first, Last: -1}
+	if bounds[1] != "" {
+		last, err := strconv.ParseInt(bounds[1], 10, 64)
+		if err != nil || last < first {
+			return nil, fmt.Errorf("invalid range end: %q", bounds[1])
+		}
+		rng.Last = last
+	}
+	return rng, nil
+}
+
+// RangeList is like List, but also supports paging with the Range header, as
+// in "Range: resources=0-99". Ranged requests are answered with a 206 Partial
+// Content response, whose Content-Range header holds the total number of
+// resources, as in "Content-Range: resources 0-99/250". The whole collection
+// is listed to count it, so it is only suited to small collections. Requests
+// without a Range header are handled by the active Lister.
+func RangeList(list ListControllerFunc, fields FieldsFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(acceptRangesHeader, rangeUnit)
+
+		rng, err := parseRange(r.Header.Get(rangeHeader))
+		if err != nil {
+			WriteError(w, actions.NewError(actions.InvalidArgument, err))
+			return
+		}
+		if rng == nil {
+			listerHandler(list, fields).ServeHTTP(w, r)
+			return
+		}
+		if corev2.PageContinueFromContext(r.Context()) != "" {
+			WriteError(w, actions.NewErrorf(actions.InvalidArgument, "the Range header cannot be combined with a continue token"))
+			return
+		}
+
+		pred := &store.SelectionPredicate{}
+		params := actions.QueryParams(mux.Vars(r))
+		if subcollection := url.PathEscape(params["subcollection"]); subcollection != "" {
+			pred.Subcollection = subcollection
+		}
+		results, err := list(r.Context(), pred)
+		if err != nil {
+			WriteError(w, err)
+			return
+		}
+
+		total := int64(len(results))
+		if rng.First >= total {
+			w.Header().Set(contentRangeHeader, fmt.Sprintf("%s */%d", rangeUnit, total))
+			writeRangeNotSatisfiable(w, rng, total)
+			return
+		}
+		last := rng.Last
+		if last < 0 || last >= total {
+			last = total - 1
+		}
+
+		b, err := json.Marshal(results[rng.First : last+1])
+		if err != nil {
+			WriteError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(contentRangeHeader, fmt.Sprintf("%s %d-%d/%d", rangeUnit, rng.First, last, total))
+		w.WriteHeader(http.StatusPartialContent)
+		if _, err := w.Write(b); err != nil {
+			logger.WithError(err).Error("failed to write response")
+		}
+	}
+}
+
+// writeRangeNotSatisfiable responds to a range that starts past the end of
+// the collection.
+func writeRangeNotSatisfiable(w http.ResponseWriter, rng *resourceRange, total int64) {
+	body, _ := json.Marshal(errorBody{
+		Message: fmt.Sprintf("range start %d is past the end of the %d resources", rng.First, total),
+		Code:    uint32(actions.InvalidArgument),
+	})
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,131 @@
+package routers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		header  string
+		want    *resourceRange
+		wantErr bool
+	}{
+		{header: ""},
+		{header: "bytes=0-99"},
+		{header: "resources=0-99", want: &resourceRange{First: 0, Last: 99}},
+		{header: "resources=10-", want: &resourceRange{First: 10, Last: -1}},
+		{header: "resources=5-5", want: &resourceRange{First: 5, Last: 5}},
+		{header: "resources=-10", wantErr: true},
+		{header: "resources=10-5", wantErr: true},
+		{header: "resources=0-9,20-29", wantErr: true},
+		{header: "resources=foo", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			got, err := parseRange(tt.header)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("parseRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRangeList(t *testing.T) {
+	var checks []corev2.Resource
+	for i := 0; i < 5; i++ {
+		checks = append(checks, corev2.FixtureCheckConfig(fmt.Sprintf("check%d", i)))
+	}
+	list := func(ctx context.Context, pred *store.SelectionPredicate) ([]corev2.Resource, error) {
+		if pred.Limit != 0 || pred.Continue != "" {
+			return nil, fmt.Errorf("unexpected predicate: %v", pred)
+		}
+		return checks, nil
+	}
+
+	tests := []struct {
+		name             string
+		rangeHeader      string
+		wantStatus       int
+		wantContentRange string
+		wantLen          int
+	}{
+		{
+			name:       "without range",
+			wantStatus: http.StatusOK,
+			wantLen:    5,
+		},
+		{
+			name:             "first page",
+			rangeHeader:      "resources=0-1",
+			wantStatus:       http.StatusPartialContent,
+			wantContentRange: "resources 0-1/5",
+			wantLen:          2,
+		},
+		{
+			name:             "range past the end",
+			rangeHeader:      "resources=3-99",
+			wantStatus:       http.StatusPartialContent,
+			wantContentRange: "resources 3-4/5",
+			wantLen:          2,
+		},
+		{
+			name:             "open range",
+			rangeHeader:      "resources=1-",
+			wantStatus:       http.StatusPartialContent,
+			wantContentRange: "resources 1-4/5",
+			wantLen:          4,
+		},
+		{
+			name:             "unsatisfiable range",
+			rangeHeader:      "resources=5-9",
+			wantStatus:       http.StatusRequestedRangeNotSatisfiable,
+			wantContentRange: "resources */5",
+		},
+		{
+			name:        "invalid range",
+			rangeHeader: "resources=9-5",
+			wantStatus:  http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/checks", nil)
+			if tt.rangeHeader != "" {
+				req.Header.Set("Range", tt.rangeHeader)
+			}
+			w := httptest.NewRecorder()
+			RangeList(list, corev2.CheckConfigFields).ServeHTTP(w, req)
+
+			if got := w.Code; got != tt.wantStatus {
+				t.Fatalf("bad status: got %d, want %d: %s", got, tt.wantStatus, w.Body)
+			}
+			if got := w.Header().Get("Accept-Ranges"); got != "resources" {
+				t.Errorf("bad Accept-Ranges: got %q", got)
+			}
+			if got := w.Header().Get("Content-Range"); got != tt.wantContentRange {
+				t.Errorf("bad Content-Range: got %q, want %q", got, tt.wantContentRange)
+			}
+			if tt.wantLen == 0 {
+				return
+			}
+			var got []*corev2.CheckConfig
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != tt.wantLen {
+				t.Errorf("bad number of resources: got %d, want %d", len(got), tt.wantLen)
+			}
+		})
+	}
+}
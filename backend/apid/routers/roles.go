@@ -34,6 +34,9 @@ func (r *RolesRouter) Mount(parent *mux.Router) {
 	routes.List(r.handlers.ListResources, corev2.RoleFields)
 	routes.ListAllNamespaces(r.handlers.ListResources, "/{resource:roles}", corev2.RoleFields)
 	routes.Patch(r.handlers.PatchResource)
+	routes.Touch(r.handlers.TouchResource)
+	routes.PendingChange(r.handlers.GetPendingChange)
+	routes.Approve(r.handlers.ApproveChange)
 	routes.Post(r.handlers.CreateResource)
 	routes.Put(r.handlers.CreateOrUpdateResource)
 }
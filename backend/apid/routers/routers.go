@@ -10,13 +10,19 @@ import (
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	corev3 "github.com/sensu/sensu-go/api/core/v3"
 	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/apid/handlers"
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/types"
 )
 
+// RetryableHeader is set on error responses of requests that may succeed if
+// they are attempted again.
+const RetryableHeader = "Sensu-Retryable"
+
 type errorBody struct {
-	Message string `json:"message"`
-	Code    uint32 `json:"code"`
+	Message   string `json:"message"`
+	Code      uint32 `json:"code"`
+	Retryable bool   `json:"retryable,omitempty"`
 }
 
 // RespondWith given writer and resource, marshal to JSON and write response.
@@ -53,6 +59,11 @@ func RespondWith(w http.ResponseWriter, r *http.Request, resources interface{})
 		return
 	}
 
+	// Changes that await approval are accepted, but not applied yet
+	if _, ok := resources.(*store.PendingChange); ok {
+		w.WriteHeader(http.StatusAccepted)
+	}
+
 	// Write response
 	if _, err := w.Write(bytes); err != nil {
 		logger.WithError(err).Error("failed to write response")
@@ -72,7 +83,11 @@ func WriteError(w http.ResponseWriter, err error) {
 	if ok {
 		errBody.Message = actionErr.Message
 		errBody.Code = uint32(actionErr.Code)
+		errBody.Retryable = actionErr.Retryable
 		st = HTTPStatusFromCode(actionErr.Code)
+		if actionErr.Retryable {
+			w.Header().Set(RetryableHeader, "true")
+		}
 	} else {
 		errBody.Message = err.Error()
 	}
@@ -182,8 +197,12 @@ type listHandlerFunc func(w http.ResponseWriter, req *http.Request) (interface{}
 //   routes.List(myIndexAction)   // given action is mounted at GET /checks
 //   routes.Put(myCreateAction)   // given action is mounted at PUT /checks/:id
 //   routes.Patch(myUpdateAction) // given action is mounted at PATCH /checks/:id
+//   routes.Touch(myTouchAction)  // given action is mounted at PUT /checks/:id/touch
+//   routes.PendingChange(myAction) // given action is mounted at GET /checks/:id/pending-change
+//   routes.Approve(myAction)     // given action is mounted at PUT /checks/:id/pending-change/:change/approve
 //   routes.Post(myCreateAction)  // given action is mounted at POST /checks
 //   routes.Del(myCreateAction)   // given action is mounted at DELETE /checks/:id
+//   routes.DelMatching(myAction) // given action is mounted at DELETE /checks
 //   routes.Path("{id}/publish", publishAction).Methods(http.MethodDelete) // when you need something customer
 //
 type ResourceRoute struct {
@@ -196,11 +215,22 @@ func (r *ResourceRoute) Get(fn actionHandlerFunc) *mux.Route {
 	return r.Path("{id}", fn).Methods(http.MethodGet)
 }
 
+// History lists the retained versions of a resource
+func (r *ResourceRoute) History(fn actionHandlerFunc) *mux.Route {
+	return r.Path("{id}/history", fn).Methods(http.MethodGet)
+}
+
 // List resources
 func (r *ResourceRoute) List(fn ListControllerFunc, fields FieldsFunc) *mux.Route {
 	return r.Router.HandleFunc(r.PathPrefix, listerHandler(fn, fields)).Methods(http.MethodGet)
 }
 
+// RangeList lists resources like List, and also supports paging with the Range
+// header, see RangeList
+func (r *ResourceRoute) RangeList(fn ListControllerFunc, fields FieldsFunc) *mux.Route {
+	return r.Router.HandleFunc(r.PathPrefix, RangeList(fn, fields)).Methods(http.MethodGet)
+}
+
 // ListAllNamespaces return all resources across all namespaces
 func (r *ResourceRoute) ListAllNamespaces(fn ListControllerFunc, path string, fields FieldsFunc) *mux.Route {
 	return r.Router.HandleFunc(path, listerHandler(fn, fields)).Methods(http.MethodGet)
@@ -208,7 +238,29 @@ func (r *ResourceRoute) ListAllNamespaces(fn ListControllerFunc, path string, fi
 
 // Patch patches a resource
 func (r *ResourceRoute) Patch(fn actionHandlerFunc) *mux.Route {
-	return r.Path("{id}", fn).Methods(http.MethodPatch)
+	fullPath := path.Join(r.PathPrefix, "{id}")
+	handler := actionHandler(fn)
+	return r.Router.HandleFunc(fullPath, func(w http.ResponseWriter, req *http.Request) {
+		if warning := handlers.PatchWarning(req); warning != "" {
+			w.Header().Set("Warning", warning)
+		}
+		handler(w, req)
+	}).Methods(http.MethodPatch)
+}
+
+// Touch bumps the version of a resource without changing its content
+func (r *ResourceRoute) Touch(fn actionHandlerFunc) *mux.Route {
+	return r.Path("{id}/touch", fn).Methods(http.MethodPut)
+}
+
+// PendingChange reads the change of a resource that awaits approval
+func (r *ResourceRoute) PendingChange(fn actionHandlerFunc) *mux.Route {
+	return r.Path("{id}/pending-change", fn).Methods(http.MethodGet)
+}
+
+// Approve applies the change of a resource that awaits approval
+func (r *ResourceRoute) Approve(fn actionHandlerFunc) *mux.Route {
+	return r.Path("{id}/pending-change/{change}/approve", fn).Methods(http.MethodPut)
 }
 
 // Post creates
@@ -233,6 +285,11 @@ func (r *ResourceRoute) Del(fn actionHandlerFunc) *mux.Route {
 	return r.Path("{id}", fn).Methods(http.MethodDelete)
 }
 
+// DelMatching deletes the resources of a collection that match the request
+func (r *ResourceRoute) DelMatching(fn actionHandlerFunc) *mux.Route {
+	return r.Path("", fn).Methods(http.MethodDelete)
+}
+
 // Path adds custom path
 func (r *ResourceRoute) Path(p string, fn actionHandlerFunc) *mux.Route {
 	fullPath := path.Join(r.PathPrefix, p)
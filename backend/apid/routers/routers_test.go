@@ -2,6 +2,7 @@ package routers
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -78,18 +79,41 @@ func TestRespondWith(t *testing.T) {
 
 func TestWriteError(t *testing.T) {
 	type args struct {
-		w   http.ResponseWriter
 		err error
 	}
 	tests := []struct {
-		name string
-		args args
+		name          string
+		args          args
+		wantStatus    int
+		wantRetryable string
 	}{
-		// TODO: Add test cases.
+		{
+			name:       "plain error",
+			args:       args{err: errors.New("fail")},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "action error",
+			args:       args{err: actions.NewError(actions.PreconditionFailed, errors.New("fail"))},
+			wantStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:          "retryable action error",
+			args:          args{err: actions.NewRetryableError(actions.PreconditionFailed, errors.New("fail"))},
+			wantStatus:    http.StatusPreconditionFailed,
+			wantRetryable: "true",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			WriteError(tt.args.w, tt.args.err)
+			w := httptest.NewRecorder()
+			WriteError(w, tt.args.err)
+			if got := w.Code; got != tt.wantStatus {
+				t.Errorf("WriteError() status = %v, want %v", got, tt.wantStatus)
+			}
+			if got := w.Header().Get(RetryableHeader); got != tt.wantRetryable {
+				t.Errorf("WriteError() %s header = %q, want %q", RetryableHeader, got, tt.wantRetryable)
+			}
 		})
 	}
 }
@@ -244,6 +268,41 @@ func TestResourceRoute_ListAllNamespaces(t *testing.T) {
 	}
 }
 
+func TestResourceRoute_Patch(t *testing.T) {
+	router := mux.NewRouter()
+	routes := ResourceRoute{Router: router, PathPrefix: "/checks"}
+	routes.Patch(func(r *http.Request) (interface{}, error) {
+		return nil, nil
+	})
+
+	tests := []struct {
+		name        string
+		contentType string
+		wantWarning bool
+	}{
+		{
+			name:        "merge patch",
+			contentType: "application/merge-patch+json",
+		},
+		{
+			name:        "deprecated json content type",
+			contentType: "application/json",
+			wantWarning: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newRequest(t, http.MethodPatch, "/checks/foo", nil)
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if got := w.Header().Get("Warning"); (got != "") != tt.wantWarning {
+				t.Errorf("bad Warning header: %q", got)
+			}
+		})
+	}
+}
+
 func TestResourceRoute_Post(t *testing.T) {
 	type fields struct {
 		Router     *mux.Router
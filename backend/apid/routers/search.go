@@ -0,0 +1,97 @@
+package routers
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/api"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/authorization"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+const (
+	// searchQueryParam is the query parameter holding the text searched in
+	// resource names
+	searchQueryParam = "q"
+
+	// searchTypesParam is the query parameter holding the comma-separated
+	// types of resources to search
+	searchTypesParam = "types"
+
+	// defaultSearchLimit is the number of results returned by a search when
+	// the request doesn't specify a limit, and maxSearchLimit is the largest
+	// number of results a request can ask for
+	defaultSearchLimit = 100
+	maxSearchLimit     = 500
+)
+
+type searchClient interface {
+	Search(ctx context.Context, query string, types []string, pred *store.SelectionPredicate) ([]corev2.SearchResult, error)
+}
+
+// SearchRouter handles requests for /search. It searches resources by name
+// across types, and authorizes the listing of each type itself.
+type SearchRouter struct {
+	client searchClient
+}
+
+// NewSearchRouter instantiates a new router for searching resources.
+func NewSearchRouter(store store.Store, auth authorization.Authorizer) *SearchRouter {
+	return &SearchRouter{
+		client: api.NewSearchClient(store, auth),
+	}
+}
+
+// Mount the SearchRouter on the given parent Router
+func (r *SearchRouter) Mount(parent *mux.Router) {
+	parent.HandleFunc("/namespaces/{namespace}/{resource:search}", r.search).Methods(http.MethodGet)
+}
+
+func (r *SearchRouter) search(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query().Get(searchQueryParam)
+	if query == "" {
+		WriteError(w, actions.NewErrorf(actions.InvalidArgument, "the %s query parameter is required", searchQueryParam))
+		return
+	}
+	var types []string
+	for _, t := range strings.Split(req.URL.Query().Get(searchTypesParam), ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types = append(types, t)
+		}
+	}
+
+	limit := int64(corev2.PageSizeFromContext(req.Context()))
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+	pred := &store.SelectionPredicate{
+		Continue: corev2.PageContinueFromContext(req.Context()),
+		Limit:    limit,
+	}
+
+	results, err := r.client.Search(req.Context(), query, types, pred)
+	if err != nil {
+		if _, ok := err.(*store.ErrNotValid); ok {
+			WriteError(w, actions.NewError(actions.InvalidArgument, err))
+		} else if err == authorization.ErrUnauthorized {
+			WriteError(w, actions.NewError(actions.PermissionDenied, err))
+		} else {
+			WriteError(w, actions.NewError(actions.InternalErr, err))
+		}
+		return
+	}
+
+	if pred.Continue != "" {
+		encodedContinue := base64.RawURLEncoding.EncodeToString([]byte(pred.Continue))
+		w.Header().Set(corev2.PaginationContinueHeader, encodedContinue)
+	}
+	RespondWith(w, req, results)
+}
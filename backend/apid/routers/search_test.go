@@ -0,0 +1,120 @@
+package routers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/authorization"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+type fakeSearchClient struct {
+	query   string
+	types   []string
+	pred    store.SelectionPredicate
+	results []corev2.SearchResult
+	next    string
+	err     error
+}
+
+func (c *fakeSearchClient) Search(ctx context.Context, query string, types []string, pred *store.SelectionPredicate) ([]corev2.SearchResult, error) {
+	c.query, c.types, c.pred = query, types, *pred
+	pred.Continue = c.next
+	return c.results, c.err
+}
+
+func TestSearchRouter(t *testing.T) {
+	results := []corev2.SearchResult{{Type: "checks", Name: "check-cpu", Namespace: "default"}}
+	tests := []struct {
+		name         string
+		url          string
+		pageSize     int
+		client       *fakeSearchClient
+		wantStatus   int
+		wantTypes    []string
+		wantLimit    int64
+		wantContinue string
+	}{
+		{
+			name:       "default limit",
+			url:        "/namespaces/default/search?q=cpu",
+			client:     &fakeSearchClient{results: results},
+			wantStatus: http.StatusOK,
+			wantLimit:  defaultSearchLimit,
+		},
+		{
+			name:         "types and bounded limit",
+			url:          "/namespaces/default/search?q=cpu&types=checks,%20entities",
+			pageSize:     10000,
+			client:       &fakeSearchClient{results: results, next: "checks/check-cpu"},
+			wantStatus:   http.StatusOK,
+			wantTypes:    []string{"checks", "entities"},
+			wantLimit:    maxSearchLimit,
+			wantContinue: "checks/check-cpu",
+		},
+		{
+			name:       "missing query",
+			url:        "/namespaces/default/search",
+			client:     &fakeSearchClient{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid type",
+			url:        "/namespaces/default/search?q=cpu&types=events",
+			client:     &fakeSearchClient{err: &store.ErrNotValid{Err: errors.New("events")}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unauthorized",
+			url:        "/namespaces/default/search?q=cpu&types=checks",
+			client:     &fakeSearchClient{err: authorization.ErrUnauthorized},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := mux.NewRouter()
+			(&SearchRouter{client: tt.client}).Mount(router)
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			req = req.WithContext(context.WithValue(req.Context(), corev2.PageSizeKey, tt.pageSize))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("bad status: got %d, want %d (%q)", w.Code, tt.wantStatus, w.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			if tt.client.query != "cpu" {
+				t.Errorf("bad query: got %q", tt.client.query)
+			}
+			if !reflect.DeepEqual(tt.client.types, tt.wantTypes) {
+				t.Errorf("bad types: got %v, want %v", tt.client.types, tt.wantTypes)
+			}
+			if tt.client.pred.Limit != tt.wantLimit {
+				t.Errorf("bad limit: got %d, want %d", tt.client.pred.Limit, tt.wantLimit)
+			}
+			continueToken, _ := base64.RawURLEncoding.DecodeString(w.Header().Get(corev2.PaginationContinueHeader))
+			if got := string(continueToken); got != tt.wantContinue {
+				t.Errorf("bad continue token: got %q, want %q", got, tt.wantContinue)
+			}
+			var got []corev2.SearchResult
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, results) {
+				t.Errorf("bad results: got %v, want %v", got, results)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package routers
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// The insecure features reported by the SecurityRouter
+const (
+	insecureAPIWithoutTLS        = "api-without-tls"
+	insecureSkipTLSVerify        = "insecure-skip-tls-verify"
+	insecureEtcdClientWithoutTLS = "etcd-client-without-tls"
+	insecureEtcdClientSkipVerify = "etcd-client-insecure-skip-verify"
+)
+
+// SecurityPosture is the effective security configuration of the backend, as
+// returned by the SecurityRouter.
+type SecurityPosture struct {
+	// TLSEnabled is true when the API is served over TLS.
+	TLSEnabled bool `json:"tls_enabled"`
+
+	// TLSMinVersion is the oldest TLS version accepted by the API, as in
+	// "TLS 1.2". It is empty when the API isn't served over TLS.
+	TLSMinVersion string `json:"tls_min_version"`
+
+	// CipherSuites are the cipher suites accepted by the API.
+	CipherSuites []string `json:"cipher_suites"`
+
+	// MutualTLSRequired is true when API clients must present a certificate
+	// signed by the trusted CA.
+	MutualTLSRequired bool `json:"mutual_tls_required"`
+
+	// InsecureSkipTLSVerify is true when the backend skips the verification
+	// of the certificates presented by the servers it connects to.
+	InsecureSkipTLSVerify bool `json:"insecure_skip_tls_verify"`
+
+	// EtcdClientTLSEnabled is true when the backend connects to etcd over TLS.
+	EtcdClientTLSEnabled bool `json:"etcd_client_tls_enabled"`
+
+	// InsecureFeatures lists the insecure features that are enabled, so that
+	// an empty list means none of them is.
+	InsecureFeatures []string `json:"insecure_features"`
+}
+
+// SecurityRouter handles requests for /security. It reports the security
+// posture of the backend, so that it can be verified without access to the
+// configuration of the nodes.
+type SecurityRouter struct {
+	posture *SecurityPosture
+}
+
+// NewSecurityRouter instantiates a new router for the security posture of the
+// backend, derived from the TLS options of the API and the TLS configuration
+// of its etcd client. Both are nil when TLS is disabled.
+func NewSecurityRouter(apiTLS *corev2.TLSOptions, etcdClientTLS *tls.Config) *SecurityRouter {
+	return &SecurityRouter{
+		posture: newSecurityPosture(apiTLS, etcdClientTLS),
+	}
+}
+
+// Mount the SecurityRouter on the given parent Router
+func (r *SecurityRouter) Mount(parent *mux.Router) {
+	routes := ResourceRoute{
+		Router:     parent,
+		PathPrefix: "/security",
+	}
+
+	routes.Path("", r.get).Methods(http.MethodGet)
+}
+
+func (r *SecurityRouter) get(req *http.Request) (interface{}, error) {
+	return r.posture, nil
+}
+
+func newSecurityPosture(apiTLS *corev2.TLSOptions, etcdClientTLS *tls.Config) *SecurityPosture {
+	posture := &SecurityPosture{
+		CipherSuites:     []string{},
+		InsecureFeatures: []string{},
+	}
+
+	if apiTLS == nil {
+		posture.InsecureFeatures = append(posture.InsecureFeatures, insecureAPIWithoutTLS)
+	} else {
+		// The hardened settings don't depend on the certificates, so they are
+		// read without loading them, which can't fail
+		serverTLS, _ := (&corev2.TLSOptions{ClientAuthType: apiTLS.ClientAuthType}).ToServerTLSConfig()
+		posture.TLSEnabled = true
+		posture.TLSMinVersion = tlsVersionName(serverTLS.MinVersion)
+		for _, id := range serverTLS.CipherSuites {
+			posture.CipherSuites = append(posture.CipherSuites, tls.CipherSuiteName(id))
+		}
+		posture.MutualTLSRequired = serverTLS.ClientAuth == tls.RequireAndVerifyClientCert
+		posture.InsecureSkipTLSVerify = apiTLS.InsecureSkipVerify
+		if apiTLS.InsecureSkipVerify {
+			posture.InsecureFeatures = append(posture.InsecureFeatures, insecureSkipTLSVerify)
+		}
+	}
+
+	if etcdClientTLS == nil {
+		posture.InsecureFeatures = append(posture.InsecureFeatures, insecureEtcdClientWithoutTLS)
+	} else {
+		posture.EtcdClientTLSEnabled = true
+		if etcdClientTLS.InsecureSkipVerify {
+			posture.InsecureFeatures = append(posture.InsecureFeatures, insecureEtcdClientSkipVerify)
+		}
+	}
+
+	return posture
+}
+
+func tlsVersionName(version uint16) string {
+	switch version {
+	case tls.VersionTLS10:
+		return "TLS 1.0"
+	case tls.VersionTLS11:
+		return "TLS 1.1"
+	case tls.VersionTLS12:
+		return "TLS 1.2"
+	case tls.VersionTLS13:
+		return "TLS 1.3"
+	}
+	return fmt.Sprintf("0x%04X", version)
+}
@@ -0,0 +1,87 @@
+package routers
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+func TestSecurityRouter(t *testing.T) {
+	tests := []struct {
+		name          string
+		apiTLS        *corev2.TLSOptions
+		etcdClientTLS *tls.Config
+		want          func(*SecurityPosture)
+	}{
+		{
+			name: "tls is disabled",
+			want: func(p *SecurityPosture) {
+				if p.TLSEnabled || p.TLSMinVersion != "" || len(p.CipherSuites) != 0 {
+					t.Errorf("unexpected tls posture: %+v", p)
+				}
+				want := []string{insecureAPIWithoutTLS, insecureEtcdClientWithoutTLS}
+				if !reflect.DeepEqual(p.InsecureFeatures, want) {
+					t.Errorf("bad insecure features: got %v, want %v", p.InsecureFeatures, want)
+				}
+			},
+		},
+		{
+			name:          "mutual tls",
+			apiTLS:        &corev2.TLSOptions{CertFile: "cert.pem", KeyFile: "key.pem", ClientAuthType: true},
+			etcdClientTLS: &tls.Config{},
+			want: func(p *SecurityPosture) {
+				if !p.TLSEnabled || !p.MutualTLSRequired || !p.EtcdClientTLSEnabled {
+					t.Errorf("unexpected tls posture: %+v", p)
+				}
+				if got, want := p.TLSMinVersion, "TLS 1.2"; got != want {
+					t.Errorf("bad tls min version: got %q, want %q", got, want)
+				}
+				if got, want := len(p.CipherSuites), len(corev2.DefaultCipherSuites); got != want {
+					t.Errorf("bad number of cipher suites: got %d, want %d", got, want)
+				}
+				if len(p.InsecureFeatures) != 0 {
+					t.Errorf("unexpected insecure features: %v", p.InsecureFeatures)
+				}
+			},
+		},
+		{
+			name:          "insecure skip verify",
+			apiTLS:        &corev2.TLSOptions{CertFile: "cert.pem", KeyFile: "key.pem", InsecureSkipVerify: true},
+			etcdClientTLS: &tls.Config{InsecureSkipVerify: true},
+			want: func(p *SecurityPosture) {
+				if !p.InsecureSkipTLSVerify || p.MutualTLSRequired {
+					t.Errorf("unexpected tls posture: %+v", p)
+				}
+				want := []string{insecureSkipTLSVerify, insecureEtcdClientSkipVerify}
+				if !reflect.DeepEqual(p.InsecureFeatures, want) {
+					t.Errorf("bad insecure features: got %v, want %v", p.InsecureFeatures, want)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := mux.NewRouter()
+			NewSecurityRouter(tt.apiTLS, tt.etcdClientTLS).Mount(router)
+
+			req := httptest.NewRequest(http.MethodGet, "/security", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != http.StatusOK {
+				t.Fatalf("bad status: %d (%q)", w.Code, w.Body)
+			}
+
+			var got SecurityPosture
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			tt.want(&got)
+		})
+	}
+}
@@ -2,6 +2,9 @@ package routers
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 
@@ -22,8 +25,11 @@ type SilencedRouter struct {
 type silencedController interface {
 	Create(ctx context.Context, entry *corev2.Silenced) error
 	CreateOrReplace(ctx context.Context, entry *corev2.Silenced) error
+	CreateBatch(ctx context.Context, entries []*corev2.Silenced, strict bool) ([]corev2.SilencedBatchResult, error)
+	DeleteMatching(ctx context.Context, sub, check string) (int, error)
 	List(ctx context.Context, sub, check string) ([]*corev2.Silenced, error)
 	Get(ctx context.Context, name string) (*corev2.Silenced, error)
+	Watch(ctx context.Context) (<-chan actions.SilencedWatchEvent, error)
 }
 
 // NewSilencedRouter instantiates new router for controlling user resources
@@ -44,7 +50,15 @@ func (r *SilencedRouter) Mount(parent *mux.Router) {
 		PathPrefix: "/namespaces/{namespace}/{resource:silenced}",
 	}
 
+	// The watch route must be registered before the resource routes, so it
+	// doesn't get interpreted as the name of a silenced entry
+	routes.Router.HandleFunc("/{resource:silenced}/watch", r.watch).Methods(http.MethodGet)
+	routes.Router.HandleFunc(routes.PathPrefix+"/watch", r.watch).Methods(http.MethodGet)
+
+	routes.Path("batch", r.createBatch).Methods(http.MethodPost)
+
 	routes.Del(r.handlers.DeleteResource)
+	routes.DelMatching(r.deleteMatching)
 	routes.Get(r.get)
 	routes.Post(r.create)
 	routes.Put(r.createOrReplace)
@@ -94,6 +108,45 @@ func (r *SilencedRouter) createOrReplace(req *http.Request) (interface{}, error)
 	return nil, err
 }
 
+func (r *SilencedRouter) createBatch(req *http.Request) (interface{}, error) {
+	entries := []*corev2.Silenced{}
+	if err := UnmarshalBody(req, &entries); err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+
+	vars := mux.Vars(req)
+	namespace, err := url.PathUnescape(vars["namespace"])
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	for _, entry := range entries {
+		if entry == nil {
+			return nil, actions.NewErrorf(actions.InvalidArgument, "silenced entries must not be null")
+		}
+		if entry.Namespace == "" {
+			entry.Namespace = namespace
+		}
+		if err := handlers.CheckMeta(entry, vars, "id"); err != nil {
+			return nil, actions.NewError(actions.InvalidArgument, err)
+		}
+	}
+
+	strict := req.URL.Query().Get("strict") == "true"
+
+	return r.controller.CreateBatch(req.Context(), entries, strict)
+}
+
+// deleteMatching deletes the silenced entries of the namespace that match the
+// subscription and check query parameters.
+func (r *SilencedRouter) deleteMatching(req *http.Request) (interface{}, error) {
+	query := req.URL.Query()
+	deleted, err := r.controller.DeleteMatching(req.Context(), query.Get("subscription"), query.Get("check"))
+	if err != nil {
+		return nil, err
+	}
+	return corev2.SilencedDeleteResult{Deleted: deleted}, nil
+}
+
 func (r *SilencedRouter) listr(ctx context.Context, pred *store.SelectionPredicate) ([]corev2.Resource, error) {
 	entries, err := r.controller.List(ctx, "", "")
 	if err != nil {
@@ -110,3 +163,37 @@ func (r *SilencedRouter) list(w http.ResponseWriter, req *http.Request) (interfa
 	params := mux.Vars(req)
 	return r.controller.List(req.Context(), params["subscription"], params["check"])
 }
+
+// watch streams the changes to the silenced entries as server-sent events.
+// The stream is bounded by the write timeout of the API server, so clients
+// are expected to reconnect, like EventSource does.
+func (r *SilencedRouter) watch(w http.ResponseWriter, req *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		WriteError(w, actions.NewError(actions.InternalErr, errors.New("streaming is not supported")))
+		return
+	}
+
+	events, err := r.controller.Watch(req.Context())
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for event := range events {
+		data, err := json.Marshal(event.Silenced)
+		if err != nil {
+			logger.WithError(err).Error("could not marshal silenced entry")
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Action, data); err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
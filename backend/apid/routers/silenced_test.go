@@ -44,6 +44,17 @@ func (m *mockSilencedController) CreateOrReplace(ctx context.Context, entry *cor
 	return m.Called(ctx, entry).Error(0)
 }
 
+func (m *mockSilencedController) CreateBatch(ctx context.Context, entries []*corev2.Silenced, strict bool) ([]corev2.SilencedBatchResult, error) {
+	args := m.Called(ctx, entries, strict)
+	results, _ := args.Get(0).([]corev2.SilencedBatchResult)
+	return results, args.Error(1)
+}
+
+func (m *mockSilencedController) DeleteMatching(ctx context.Context, sub, check string) (int, error) {
+	args := m.Called(ctx, sub, check)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *mockSilencedController) List(ctx context.Context, sub, check string) ([]*corev2.Silenced, error) {
 	args := m.Called(ctx, sub, check)
 	return args.Get(0).([]*corev2.Silenced), args.Error(1)
@@ -54,6 +65,12 @@ func (m *mockSilencedController) Get(ctx context.Context, name string) (*corev2.
 	return args.Get(0).(*corev2.Silenced), args.Error(1)
 }
 
+func (m *mockSilencedController) Watch(ctx context.Context) (<-chan actions.SilencedWatchEvent, error) {
+	args := m.Called(ctx)
+	ch, _ := args.Get(0).(<-chan actions.SilencedWatchEvent)
+	return ch, args.Error(1)
+}
+
 func TestSilencedRouterCustomRoutes(t *testing.T) {
 	type controllerFunc func(*mockSilencedController)
 
@@ -211,6 +228,68 @@ func TestSilencedRouterCustomRoutes(t *testing.T) {
 			},
 			wantStatusCode: http.StatusInternalServerError,
 		},
+		{
+			name:           "it returns 400 if the batch to create is not decodable",
+			method:         http.MethodPost,
+			path:           empty.URIPath() + "/batch",
+			body:           []byte(`foo`),
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "it returns 400 if a batch entry has a mismatched namespace",
+			method:         http.MethodPost,
+			path:           empty.URIPath() + "/batch",
+			body:           []byte(`[{"metadata": {"namespace":"acme"}, "check": "check-cpu"}]`),
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "it returns 200 when a batch is processed",
+			method: http.MethodPost,
+			path:   empty.URIPath() + "/batch?strict=true",
+			body:   []byte(`[{"check": "check-cpu"}]`),
+			controllerFunc: func(c *mockSilencedController) {
+				c.On("CreateBatch", mock.Anything, mock.MatchedBy(func(entries []*corev2.Silenced) bool {
+					return len(entries) == 1 && entries[0].Namespace == "default"
+				}), true).
+					Return([]corev2.SilencedBatchResult{{Name: "*:check-cpu", Created: true}}, nil).
+					Once()
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "it returns 200 when matching entries are deleted",
+			method: http.MethodDelete,
+			path:   empty.URIPath() + "?subscription=app",
+			controllerFunc: func(c *mockSilencedController) {
+				c.On("DeleteMatching", mock.Anything, "app", "").
+					Return(2, nil).
+					Once()
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "it returns 400 when deleting without a filter",
+			method: http.MethodDelete,
+			path:   empty.URIPath(),
+			controllerFunc: func(c *mockSilencedController) {
+				c.On("DeleteMatching", mock.Anything, "", "").
+					Return(0, actions.NewErrorf(actions.InvalidArgument)).
+					Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "it returns 500 on watch error",
+			method: http.MethodGet,
+			path:   empty.URIPath() + "/watch",
+			body:   nil,
+			controllerFunc: func(c *mockSilencedController) {
+				c.On("Watch", mock.Anything).
+					Return(nil, actions.NewErrorf(actions.InternalErr)).
+					Once()
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -246,3 +325,42 @@ func TestSilencedRouterCustomRoutes(t *testing.T) {
 		})
 	}
 }
+
+func TestSilencedRouterWatch(t *testing.T) {
+	controller := &mockSilencedController{}
+	router := SilencedRouter{controller: controller}
+	parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
+	router.Mount(parentRouter)
+
+	events := make(chan actions.SilencedWatchEvent, 2)
+	events <- actions.SilencedWatchEvent{Action: actions.SilencedCreated, Silenced: corev2.FixtureSilenced("linux:check-cpu")}
+	events <- actions.SilencedWatchEvent{Action: actions.SilencedExpired, Silenced: corev2.FixtureSilenced("linux:check-cpu")}
+	close(events)
+	controller.On("Watch", mock.Anything).Return((<-chan actions.SilencedWatchEvent)(events), nil).Once()
+
+	server := httptest.NewServer(parentRouter)
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/api/core/v2/namespaces/default/silenced/watch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if got, want := res.StatusCode, http.StatusOK; got != want {
+		t.Fatalf("bad status code: got %d, want %d", got, want)
+	}
+	if got, want := res.Header.Get("Content-Type"), "text/event-stream"; got != want {
+		t.Errorf("bad content type: got %q, want %q", got, want)
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := string(marshal(corev2.FixtureSilenced("linux:check-cpu")))
+	want := "event: created\ndata: " + data + "\n\n" + "event: expired\ndata: " + data + "\n\n"
+	if got := string(body); got != want {
+		t.Errorf("bad body: got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,77 @@
+package routers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/store"
+	storev2 "github.com/sensu/sensu-go/backend/store/v2"
+)
+
+// wrapperKeyParam is the query parameter holding the store key of the wrapper
+const wrapperKeyParam = "key"
+
+// WrappersRouter handles requests for /wrappers, which return the raw store
+// wrappers of resources, as stored, for debugging storage issues.
+type WrappersRouter struct {
+	store storev2.Interface
+}
+
+// NewWrappersRouter instantiates a new router for raw store wrappers.
+func NewWrappersRouter(store storev2.Interface) *WrappersRouter {
+	return &WrappersRouter{
+		store: store,
+	}
+}
+
+// Mount the WrappersRouter to a parent Router
+func (r *WrappersRouter) Mount(parent *mux.Router) {
+	handleAction(parent, "/{resource:wrappers}", r.get).Methods(http.MethodGet)
+}
+
+// get returns the wrapper stored at the key given in the query, without
+// unwrapping it, so that wrappers whose value is corrupt can be inspected.
+func (r *WrappersRouter) get(req *http.Request) (interface{}, error) {
+	storeName, namespace, name, err := parseWrapperKey(req.URL.Query().Get(wrapperKeyParam))
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	wrapper, err := r.store.Get(storev2.NewResourceRequest(req.Context(), namespace, name, storeName))
+	if err != nil {
+		switch err := err.(type) {
+		case *store.ErrNotFound:
+			return nil, actions.NewErrorf(actions.NotFound)
+		case *store.ErrNotValid:
+			return nil, actions.NewError(actions.InvalidArgument, err)
+		default:
+			return nil, actions.NewError(actions.InternalErr, err)
+		}
+	}
+	return wrapper, nil
+}
+
+// parseWrapperKey splits the store key of a wrapper, such as
+// /sensu.io/entity_configs/default/foo, into its store name, namespace and
+// name. Keys of resources that are not namespaced have no namespace.
+func parseWrapperKey(key string) (storeName, namespace, name string, err error) {
+	prefix := store.Root + "/"
+	if !strings.HasPrefix(key, prefix) {
+		return "", "", "", errors.New("the key must start with " + prefix)
+	}
+	parts := strings.SplitN(strings.TrimPrefix(key, prefix), "/", 3)
+	for _, part := range parts {
+		if part == "" {
+			return "", "", "", errors.New("the key has an empty component")
+		}
+	}
+	switch len(parts) {
+	case 2:
+		return parts[0], "", parts[1], nil
+	case 3:
+		return parts[0], parts[1], parts[2], nil
+	}
+	return "", "", "", errors.New("the key must reference a single resource")
+}
@@ -0,0 +1,114 @@
+package routers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gorilla/mux"
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+	"github.com/sensu/sensu-go/backend/store"
+	storev2 "github.com/sensu/sensu-go/backend/store/v2"
+	"github.com/sensu/sensu-go/backend/store/v2/wrap"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseWrapperKey(t *testing.T) {
+	tests := []struct {
+		key           string
+		wantStoreName string
+		wantNamespace string
+		wantName      string
+		wantErr       bool
+	}{
+		{key: "/sensu.io/entity_configs/default/foo", wantStoreName: "entity_configs", wantNamespace: "default", wantName: "foo"},
+		{key: "/sensu.io/namespaces/default", wantStoreName: "namespaces", wantName: "default"},
+		{key: "/sensu.io/entity_configs/default/foo/bar", wantStoreName: "entity_configs", wantNamespace: "default", wantName: "foo/bar"},
+		{key: "", wantErr: true},
+		{key: "/foo/entity_configs/default/foo", wantErr: true},
+		{key: "/sensu.io/entity_configs", wantErr: true},
+		{key: "/sensu.io/entity_configs/default/", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			storeName, namespace, name, err := parseWrapperKey(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseWrapperKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if storeName != tt.wantStoreName || namespace != tt.wantNamespace || name != tt.wantName {
+				t.Errorf("parseWrapperKey() = %q, %q, %q", storeName, namespace, name)
+			}
+		})
+	}
+}
+
+func TestWrappersRouter(t *testing.T) {
+	wrapper, err := wrap.Resource(corev3.FixtureEntityConfig("foo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name           string
+		key            string
+		storeFunc      func(*mockstore.V2MockStore)
+		wantStatusCode int
+	}{
+		{
+			name: "found",
+			key:  "/sensu.io/entity_configs/default/foo",
+			storeFunc: func(s *mockstore.V2MockStore) {
+				s.On("Get", mock.MatchedBy(func(req storev2.ResourceRequest) bool {
+					return req.StoreName == "entity_configs" && req.Namespace == "default" && req.Name == "foo"
+				})).Return(wrapper, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "not found",
+			key:  "/sensu.io/entity_configs/default/bar",
+			storeFunc: func(s *mockstore.V2MockStore) {
+				s.On("Get", mock.Anything).Return(nil, &store.ErrNotFound{})
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "invalid key",
+			key:            "entity_configs",
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &mockstore.V2MockStore{}
+			if tt.storeFunc != nil {
+				tt.storeFunc(s)
+			}
+			router := mux.NewRouter()
+			NewWrappersRouter(s).Mount(router)
+			server := httptest.NewServer(router)
+			defer server.Close()
+
+			resp, err := http.Get(server.URL + "/wrappers?key=" + url.QueryEscape(tt.key))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Fatalf("bad status: got %d, want %d", resp.StatusCode, tt.wantStatusCode)
+			}
+			if resp.StatusCode != http.StatusOK {
+				return
+			}
+			var got wrap.Wrapper
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(wrapper) {
+				t.Errorf("got wrapper %v, want %v", got, wrapper)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"google.golang.org/grpc"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
 	"github.com/sensu/sensu-go/asset"
 	"github.com/sensu/sensu-go/backend/agentd"
 	"github.com/sensu/sensu-go/backend/api"
@@ -44,6 +45,8 @@ import (
 	"github.com/sensu/sensu-go/backend/pipeline/mutator"
 	"github.com/sensu/sensu-go/backend/pipelined"
 	"github.com/sensu/sensu-go/backend/queue"
+	"github.com/sensu/sensu-go/backend/reaperd"
+	"github.com/sensu/sensu-go/backend/retentiond"
 	"github.com/sensu/sensu-go/backend/ringv2"
 	"github.com/sensu/sensu-go/backend/schedulerd"
 	"github.com/sensu/sensu-go/backend/secrets"
@@ -51,6 +54,7 @@ import (
 	etcdstore "github.com/sensu/sensu-go/backend/store/etcd"
 	storev2 "github.com/sensu/sensu-go/backend/store/v2"
 	etcdstorev2 "github.com/sensu/sensu-go/backend/store/v2/etcdstore"
+	"github.com/sensu/sensu-go/backend/store/v2/wrap"
 	"github.com/sensu/sensu-go/backend/tessend"
 	"github.com/sensu/sensu-go/command"
 	"github.com/sensu/sensu-go/metrics"
@@ -312,12 +316,22 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 	// Create the store, which lives on top of etcd
 	stor := etcdstore.NewStore(b.Client, config.EtcdName)
 	b.Store = stor
-	storv2 := etcdstorev2.NewStore(b.Client)
+	storv2 := etcdstorev2.NewStore(b.Client, etcdstorev2.WithHistoryDepth(config.StoreHistoryDepth))
 	var storev2Proxy storev2.Proxy
 	storev2Proxy.UpdateStore(storv2)
 	b.StoreV2 = &storev2Proxy
 	b.StoreV2Updater = &storev2Proxy
 
+	// Record the largest resources written through the store, for profiling
+	var largeEncodes *wrap.ExemplarRing
+	if config.StoreLargeEncodeSize > 0 {
+		largeEncodes = wrap.NewExemplarRing(wrap.DefaultExemplarRingSize, config.StoreLargeEncodeSize)
+		wrapResource := storev2.WrapResource
+		storev2.WrapResource = func(resource corev3.Resource, opts ...wrap.Option) (storev2.Wrapper, error) {
+			return wrapResource(resource, append(opts, wrap.RecordLargeEncodes(largeEncodes))...)
+		}
+	}
+
 	// Create the ring pool for round-robin functionality
 	b.RingPool = ringv2.NewRingPool(func(path string) ringv2.Interface {
 		return ringv2.New(b.Client, path)
@@ -349,8 +363,13 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 	// Initialize the LicenseGetter
 	b.LicenseGetter = config.LicenseGetter
 
-	// Initialize the bus
-	bus, err := messaging.NewWizardBus(messaging.WizardBusConfig{})
+	// Initialize the bus. Raw events are rejected rather than queued when
+	// eventd falls behind, so that agent sessions can push back on agents.
+	bus, err := messaging.NewWizardBus(messaging.WizardBusConfig{
+		OverflowPolicies: map[string]messaging.OverflowPolicy{
+			messaging.TopicEventRaw: messaging.OverflowReject,
+		},
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error initializing %s: %s", bus.Name(), err)
 	}
@@ -384,9 +403,10 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 
 	// Initialize pipelined
 	pipelineDaemon, err := pipelined.New(pipelined.Config{
-		Bus:         bus,
-		BufferSize:  viper.GetInt(FlagPipelinedBufferSize),
-		WorkerCount: viper.GetInt(FlagPipelinedWorkers),
+		Bus:            bus,
+		BufferSize:     viper.GetInt(FlagPipelinedBufferSize),
+		WorkerCount:    viper.GetInt(FlagPipelinedWorkers),
+		DefaultHandler: config.PipelinedDefaultHandler,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error initializing %s: %s", pipelineDaemon.Name(), err)
@@ -395,8 +415,24 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 	// Initialize PipelineAdapterV1
 	storeTimeout := 2 * time.Minute
 	b.PipelineAdapterV1 = pipeline.AdapterV1{
-		Store:        b.Store,
-		StoreTimeout: storeTimeout,
+		Store:          b.Store,
+		StoreTimeout:   storeTimeout,
+		SkipSilenced:   config.PipelinedSkipSilenced,
+		DefaultHandler: config.PipelinedDefaultHandler,
+		Maintenance:    &pipeline.MaintenanceWindows{Store: b.Store},
+		HandlerErrors:  &pipeline.HandlerErrors{},
+	}
+	if len(config.PipelinedHandlerConcurrency) > 0 {
+		b.PipelineAdapterV1.HandlerLimiter = &pipeline.HandlerLimiter{
+			Limits:    config.PipelinedHandlerConcurrency,
+			QueueSize: config.PipelinedHandlerQueueSize,
+		}
+	}
+	if config.PipelinedAsyncHandlerWorkers > 0 {
+		b.PipelineAdapterV1.AsyncHandlers = &pipeline.AsyncHandlers{
+			Workers:   config.PipelinedAsyncHandlerWorkers,
+			QueueSize: config.PipelinedAsyncHandlerQueueSize,
+		}
 	}
 
 	// Initialize PipelineAdapterV1 filter adapters
@@ -454,18 +490,19 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 	event, err := eventd.New(
 		b.RunContext(),
 		eventd.Config{
-			Store:               b.StoreV2,
-			EventStore:          b.Store,
-			Bus:                 bus,
-			LivenessFactory:     liveness.EtcdFactory(b.RunContext(), b.Client),
-			Client:              b.Client,
-			BufferSize:          viper.GetInt(FlagEventdBufferSize),
-			WorkerCount:         viper.GetInt(FlagEventdWorkers),
-			StoreTimeout:        2 * time.Minute,
-			LogPath:             b.Cfg.EventLogFile,
-			LogBufferSize:       b.Cfg.EventLogBufferSize,
-			LogBufferWait:       b.Cfg.EventLogBufferWait,
-			LogParallelEncoders: b.Cfg.EventLogParallelEncoders,
+			Store:                b.StoreV2,
+			EventStore:           b.Store,
+			Bus:                  bus,
+			LivenessFactory:      liveness.EtcdFactory(b.RunContext(), b.Client),
+			Client:               b.Client,
+			BufferSize:           viper.GetInt(FlagEventdBufferSize),
+			WorkerCount:          viper.GetInt(FlagEventdWorkers),
+			StoreTimeout:         2 * time.Minute,
+			LogPath:              b.Cfg.EventLogFile,
+			LogBufferSize:        b.Cfg.EventLogBufferSize,
+			LogBufferWait:        b.Cfg.EventLogBufferWait,
+			LogParallelEncoders:  b.Cfg.EventLogParallelEncoders,
+			SilencedMetricsLimit: viper.GetInt(FlagEventdSilencedMetricsLimit),
 		},
 	)
 	if err != nil {
@@ -516,6 +553,7 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 		RingPool:            b.RingPool,
 		WriteTimeout:        config.AgentWriteTimeout,
 		Client:              b.Client,
+		Storev2:             b.StoreV2,
 		Watcher:             entityConfigWatcher,
 		EtcdClientTLSConfig: b.EtcdClientTLSConfig,
 	})
@@ -603,6 +641,7 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 		ListenAddress:       config.APIListenAddress,
 		RequestLimit:        config.APIRequestLimit,
 		WriteTimeout:        config.APIWriteTimeout,
+		MaxRequestTimeout:   config.APIMaxRequestTimeout,
 		URL:                 config.APIURL,
 		Bus:                 bus,
 		Store:               b.Store,
@@ -616,6 +655,12 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 		ClusterVersion:      clusterVersion,
 		GraphQLService:      b.GraphQLService,
 		HealthRouter:        b.HealthRouter,
+		HandlerTester:       &b.PipelineAdapterV1,
+		HandlerStatuses:     &b.PipelineAdapterV1,
+		Purger:              &reaperd.Purger{Store: b.Store},
+	}
+	if largeEncodes != nil {
+		b.APIDConfig.LargeEncodes = largeEncodes
 	}
 	api, err := apid.New(b.APIDConfig)
 	if err != nil {
@@ -638,6 +683,27 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 	}
 	b.Daemons = append(b.Daemons, tessen)
 
+	// Initialize retentiond
+	retention, err := retentiond.New(
+		b.RunContext(),
+		retentiond.Config{
+			NamespaceStore: b.Store,
+			EventStore:     b.Store,
+			Bus:            bus,
+			Client:         b.Client,
+		})
+	if err != nil {
+		return nil, fmt.Errorf("error initializing %s: %s", retention.Name(), err)
+	}
+	b.Daemons = append(b.Daemons, retention)
+
+	// Initialize reaperd
+	reaper, err := reaperd.New(b.RunContext(), reaperd.Config{Store: b.Store, Client: b.Client})
+	if err != nil {
+		return nil, fmt.Errorf("error initializing %s: %s", reaper.Name(), err)
+	}
+	b.Daemons = append(b.Daemons, reaper)
+
 	return b, nil
 }
 
@@ -746,6 +812,10 @@ func (b *Backend) runOnce() error {
 			derr = err
 		}
 	}
+	if b.PipelineAdapterV1.AsyncHandlers != nil {
+		// Wait for the asynchronous handlers that were dispatched to be over
+		b.PipelineAdapterV1.AsyncHandlers.Stop()
+	}
 	if derr == nil {
 		derr = b.RunContext().Err()
 	}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/sensu/sensu-go/backend/store/v2/etcdstore"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// ETagsCommand checks that the wrappers of stores hold the ETag of their
+// content, to detect the resources that were corrupted after they were
+// written.
+func ETagsCommand() *cobra.Command {
+	var setupErr error
+	cmd := &cobra.Command{
+		Use:           "etags STORE-NAME...",
+		Short:         "report the resources of the given stores whose etag does not match their content",
+		Args:          cobra.MinimumNArgs(1),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_ = viper.BindPFlags(cmd.Flags())
+			if setupErr != nil {
+				return setupErr
+			}
+
+			client, err := newScanClient()
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+
+			s := etcdstore.NewStore(client)
+			mismatches := 0
+			for _, storeName := range args {
+				report, err := s.CheckETags(context.Background(), storeName)
+				if err != nil {
+					return fmt.Errorf("error scanning %s: %s", storeName, err)
+				}
+				for _, key := range report.Mismatches {
+					fmt.Fprintf(os.Stdout, "%s: etag does not match the content\n", key)
+				}
+				fmt.Fprintf(os.Stdout, "%s: %d checked, %d mismatched, %d without a computed etag, %d undecodable\n",
+					storeName, report.Checked, len(report.Mismatches), report.Skipped, report.Undecodable)
+				mismatches += len(report.Mismatches)
+			}
+			if mismatches > 0 {
+				return fmt.Errorf("%d resources have an etag that does not match their content", mismatches)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagTimeout, defaultTimeout, "duration to wait before a connection attempt to etcd is considered failed (must be >= 1s)")
+
+	setupErr = handleConfig(cmd, os.Args[1:], false)
+
+	return cmd
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sensu/sensu-go/backend/etcd"
+	"github.com/sensu/sensu-go/backend/store/v2/etcdstore"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.etcd.io/etcd/client/pkg/v3/transport"
+	"go.etcd.io/etcd/client/v3"
+)
+
+// FormatsCommand counts the wrappers of stores by encoding and compression,
+// to help plan the re-encoding of a database.
+func FormatsCommand() *cobra.Command {
+	var setupErr error
+	cmd := &cobra.Command{
+		Use:           "formats STORE-NAME...",
+		Short:         "count the resources of the given stores by encoding and compression",
+		Args:          cobra.MinimumNArgs(1),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_ = viper.BindPFlags(cmd.Flags())
+			if setupErr != nil {
+				return setupErr
+			}
+
+			client, err := newScanClient()
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+
+			s := etcdstore.NewStore(client)
+			w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+			fmt.Fprintln(w, "STORE\tENCODING\tCOMPRESSION\tCOUNT")
+			for _, storeName := range args {
+				counts, err := s.CountFormats(context.Background(), storeName)
+				if err != nil {
+					return fmt.Errorf("error scanning %s: %s", storeName, err)
+				}
+				formats := make([]etcdstore.WrapperFormat, 0, len(counts.Formats))
+				for format := range counts.Formats {
+					formats = append(formats, format)
+				}
+				sort.Slice(formats, func(i, j int) bool {
+					if formats[i].Encoding != formats[j].Encoding {
+						return formats[i].Encoding < formats[j].Encoding
+					}
+					return formats[i].Compression < formats[j].Compression
+				})
+				for _, format := range formats {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%d\n", storeName, format.Encoding.Name(), format.Compression.Name(), counts.Formats[format])
+				}
+				if counts.Undecodable > 0 {
+					fmt.Fprintf(w, "%s\t-\t-\t%d\n", storeName, counts.Undecodable)
+				}
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().String(flagTimeout, defaultTimeout, "duration to wait before a connection attempt to etcd is considered failed (must be >= 1s)")
+
+	setupErr = handleConfig(cmd, os.Args[1:], false)
+
+	return cmd
+}
+
+// newScanClient connects to etcd, as configured by the flags of the commands
+// that scan stores.
+func newScanClient() (*clientv3.Client, error) {
+	// Convert the TLS config into etcd's transport.TLSInfo
+	tlsInfo := (transport.TLSInfo)(etcd.TLSInfo{
+		CertFile:       viper.GetString(flagEtcdCertFile),
+		KeyFile:        viper.GetString(flagEtcdKeyFile),
+		TrustedCAFile:  viper.GetString(flagEtcdTrustedCAFile),
+		ClientCertAuth: viper.GetBool(flagEtcdClientCertAuth),
+	})
+	tlsConfig, err := tlsInfo.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := viper.GetDuration(flagTimeout)
+	if timeout < 1*time.Second {
+		timeout = timeout * time.Second
+	}
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   fallbackStringSlice(flagEtcdClientURLs, flagEtcdAdvertiseClientURLs),
+		DialTimeout: timeout,
+		TLS:         tlsConfig,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to cluster: %s", err)
+	}
+	return client, nil
+}
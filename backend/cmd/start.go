@@ -10,6 +10,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -20,6 +21,7 @@ import (
 	"github.com/sensu/sensu-go/asset"
 	"github.com/sensu/sensu-go/backend"
 	"github.com/sensu/sensu-go/backend/etcd"
+	"github.com/sensu/sensu-go/backend/eventd"
 	"github.com/sensu/sensu-go/util/path"
 	stringsutil "github.com/sensu/sensu-go/util/strings"
 	"github.com/sirupsen/logrus"
@@ -36,6 +38,7 @@ var DeprecateDashboardFlags = true
 var (
 	annotations               map[string]string
 	labels                    map[string]string
+	handlerConcurrency        map[string]string
 	configFileDefaultLocation = filepath.Join(path.SystemConfigDir(), "backend.yml")
 )
 
@@ -50,6 +53,9 @@ const (
 	flagAPIRequestLimit       = "api-request-limit"
 	flagAPIURL                = "api-url"
 	flagAPIWriteTimeout       = "api-write-timeout"
+	flagAPIMaxRequestTimeout  = "api-max-request-timeout"
+	flagStoreHistoryDepth     = "store-history-depth"
+	flagStoreLargeEncodeSize  = "store-large-encode-size"
 	flagAssetsRateLimit       = "assets-rate-limit"
 	flagAssetsBurstLimit      = "assets-burst-limit"
 	flagDashboardHost         = "dashboard-host"
@@ -120,6 +126,28 @@ const (
 	// flagEventLogParallelEncoders used to indicate parallel encoders should be used for event logging
 	flagEventLogParallelEncoders = "event-log-parallel-encoders"
 
+	// flagPipelinedHandlerConcurrency is the maximum number of concurrent
+	// invocations of handlers, keyed by handler name
+	flagPipelinedHandlerConcurrency = "pipelined-handler-concurrency"
+
+	// flagPipelinedHandlerQueueSize is the maximum number of invocations of a
+	// limited handler that can wait for their turn
+	flagPipelinedHandlerQueueSize = "pipelined-handler-queue-size"
+
+	// flagPipelinedAsyncHandlerWorkers is the number of workers invoking the
+	// handlers annotated to run asynchronously
+	flagPipelinedAsyncHandlerWorkers = "pipelined-async-handler-workers"
+
+	// flagPipelinedAsyncHandlerQueueSize is the maximum number of asynchronous
+	// handler invocations that can wait for a worker
+	flagPipelinedAsyncHandlerQueueSize = "pipelined-async-handler-queue-size"
+
+	// flagPipelinedSkipSilenced skips the handlers of silenced events
+	flagPipelinedSkipSilenced = "pipelined-skip-silenced"
+
+	// flagPipelinedDefaultHandler is the handler of checks without handlers
+	flagPipelinedDefaultHandler = "pipelined-default-handler"
+
 	// Default values
 
 	// defaultEtcdClientURL is the default URL to listen for Etcd clients
@@ -176,6 +204,19 @@ var (
 // to initialize the backend
 type InitializeFunc func(context.Context, *backend.Config) (*backend.Backend, error)
 
+// parseHandlerConcurrency parses the concurrency limit of each handler.
+func parseHandlerConcurrency(limits map[string]string) (map[string]int, error) {
+	result := make(map[string]int, len(limits))
+	for handler, limit := range limits {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid --%s for handler %q: %q is not a positive integer", flagPipelinedHandlerConcurrency, handler, limit)
+		}
+		result[handler] = n
+	}
+	return result, nil
+}
+
 func fallbackStringSlice(newFlag, oldFlag string) []string {
 	slice := viper.GetStringSlice(newFlag)
 	if len(slice) == 0 {
@@ -223,6 +264,9 @@ func StartCommand(initialize InitializeFunc) *cobra.Command {
 				APIRequestLimit:       viper.GetInt64(flagAPIRequestLimit),
 				APIURL:                viper.GetString(flagAPIURL),
 				APIWriteTimeout:       viper.GetDuration(flagAPIWriteTimeout),
+				APIMaxRequestTimeout:  viper.GetDuration(flagAPIMaxRequestTimeout),
+				StoreHistoryDepth:     viper.GetInt(flagStoreHistoryDepth),
+				StoreLargeEncodeSize:  viper.GetInt(flagStoreLargeEncodeSize),
 				AssetsRateLimit:       rate.Limit(viper.GetFloat64(flagAssetsRateLimit)),
 				AssetsBurstLimit:      viper.GetInt(flagAssetsBurstLimit),
 				DashboardHost:         viper.GetString(flagDashboardHost),
@@ -263,6 +307,11 @@ func StartCommand(initialize InitializeFunc) *cobra.Command {
 				EventLogBufferWait:             viper.GetDuration(flagEventLogBufferWait),
 				EventLogFile:                   viper.GetString(flagEventLogFile),
 				EventLogParallelEncoders:       viper.GetBool(flagEventLogParallelEncoders),
+				PipelinedHandlerQueueSize:      viper.GetInt(flagPipelinedHandlerQueueSize),
+				PipelinedAsyncHandlerWorkers:   viper.GetInt(flagPipelinedAsyncHandlerWorkers),
+				PipelinedAsyncHandlerQueueSize: viper.GetInt(flagPipelinedAsyncHandlerQueueSize),
+				PipelinedSkipSilenced:          viper.GetBool(flagPipelinedSkipSilenced),
+				PipelinedDefaultHandler:        viper.GetString(flagPipelinedDefaultHandler),
 			}
 
 			if flag := cmd.Flags().Lookup(flagLabels); flag != nil && flag.Changed {
@@ -271,6 +320,13 @@ func StartCommand(initialize InitializeFunc) *cobra.Command {
 			if flag := cmd.Flags().Lookup(flagAnnotations); flag != nil && flag.Changed {
 				cfg.Annotations = annotations
 			}
+			concurrency := viper.GetStringMapString(flagPipelinedHandlerConcurrency)
+			if flag := cmd.Flags().Lookup(flagPipelinedHandlerConcurrency); flag != nil && flag.Changed {
+				concurrency = handlerConcurrency
+			}
+			if cfg.PipelinedHandlerConcurrency, err = parseHandlerConcurrency(concurrency); err != nil {
+				return err
+			}
 
 			// Sensu APIs TLS config
 			certFile := viper.GetString(flagCertFile)
@@ -392,6 +448,9 @@ func handleConfig(cmd *cobra.Command, arguments []string, server bool) error {
 		viper.SetDefault(flagAPIRequestLimit, middlewares.MaxBytesLimit)
 		viper.SetDefault(flagAPIURL, "http://localhost:8080")
 		viper.SetDefault(flagAPIWriteTimeout, "15s")
+		viper.SetDefault(flagAPIMaxRequestTimeout, middlewares.DefaultMaxRequestTimeout)
+		viper.SetDefault(flagStoreHistoryDepth, 0)
+		viper.SetDefault(flagStoreLargeEncodeSize, 0)
 		viper.SetDefault(flagAssetsRateLimit, asset.DefaultAssetsRateLimit)
 		viper.SetDefault(flagAssetsBurstLimit, asset.DefaultAssetsBurstLimit)
 		viper.SetDefault(flagDashboardHost, "[::]")
@@ -409,6 +468,7 @@ func handleConfig(cmd *cobra.Command, arguments []string, server bool) error {
 		viper.SetDefault(flagLogLevel, "warn")
 		viper.SetDefault(backend.FlagEventdWorkers, 100)
 		viper.SetDefault(backend.FlagEventdBufferSize, 1000)
+		viper.SetDefault(backend.FlagEventdSilencedMetricsLimit, eventd.DefaultSilencedMetricsLimit)
 		viper.SetDefault(backend.FlagKeepalivedWorkers, 100)
 		viper.SetDefault(backend.FlagKeepalivedBufferSize, 1000)
 		viper.SetDefault(backend.FlagPipelinedWorkers, 100)
@@ -421,6 +481,11 @@ func handleConfig(cmd *cobra.Command, arguments []string, server bool) error {
 		viper.SetDefault(flagEventLogBufferSize, 100000)
 		viper.SetDefault(flagEventLogFile, "")
 		viper.SetDefault(flagEventLogParallelEncoders, false)
+		viper.SetDefault(flagPipelinedHandlerQueueSize, 100)
+		viper.SetDefault(flagPipelinedAsyncHandlerWorkers, 10)
+		viper.SetDefault(flagPipelinedAsyncHandlerQueueSize, 1000)
+		viper.SetDefault(flagPipelinedSkipSilenced, false)
+		viper.SetDefault(flagPipelinedDefaultHandler, "")
 	}
 
 	// Etcd defaults
@@ -519,6 +584,9 @@ func flagSet(server bool) *pflag.FlagSet {
 		flagSet.Int64(flagAPIRequestLimit, viper.GetInt64(flagAPIRequestLimit), "maximum API request body size, in bytes")
 		flagSet.String(flagAPIURL, viper.GetString(flagAPIURL), "url of the api to connect to")
 		flagSet.Duration(flagAPIWriteTimeout, viper.GetDuration(flagAPIWriteTimeout), "maximum duration before timing out writes of responses")
+		flagSet.Duration(flagAPIMaxRequestTimeout, viper.GetDuration(flagAPIMaxRequestTimeout), "maximum server-side timeout clients can request with the request_timeout query parameter")
+		flagSet.Int(flagStoreHistoryDepth, viper.GetInt(flagStoreHistoryDepth), "number of versions of each core/v3 resource to retain for the history API (0 disables history)")
+		flagSet.Int(flagStoreLargeEncodeSize, viper.GetInt(flagStoreLargeEncodeSize), "size in bytes from which encoded resources are recorded as exemplars, listed by the large-encodes API (0 disables recording)")
 		flagSet.Float64(flagAssetsRateLimit, viper.GetFloat64(flagAssetsRateLimit), "maximum number of assets fetched per second")
 		flagSet.Int(flagAssetsBurstLimit, viper.GetInt(flagAssetsBurstLimit), "asset fetch burst limit")
 		flagSet.String(flagDashboardHost, viper.GetString(flagDashboardHost), "dashboard listener host")
@@ -538,6 +606,7 @@ func flagSet(server bool) *pflag.FlagSet {
 		flagSet.String(flagEtcdLogLevel, viper.GetString(flagEtcdLogLevel), "etcd logging level [panic, fatal, error, warn, info, debug]")
 		flagSet.Int(backend.FlagEventdWorkers, viper.GetInt(backend.FlagEventdWorkers), "number of workers spawned for processing incoming events")
 		flagSet.Int(backend.FlagEventdBufferSize, viper.GetInt(backend.FlagEventdBufferSize), "number of incoming events that can be buffered")
+		flagSet.Int(backend.FlagEventdSilencedMetricsLimit, viper.GetInt(backend.FlagEventdSilencedMetricsLimit), "number of silenced entries whose suppressed events are counted in their own metric series")
 		flagSet.Int(backend.FlagKeepalivedWorkers, viper.GetInt(backend.FlagKeepalivedWorkers), "number of workers spawned for processing incoming keepalives")
 		flagSet.Int(backend.FlagKeepalivedBufferSize, viper.GetInt(backend.FlagKeepalivedBufferSize), "number of incoming keepalives that can be buffered")
 		flagSet.Int(backend.FlagPipelinedWorkers, viper.GetInt(backend.FlagPipelinedWorkers), "number of workers spawned for handling events through the event pipeline")
@@ -547,6 +616,12 @@ func flagSet(server bool) *pflag.FlagSet {
 		flagSet.String(backend.FlagJWTPublicKeyFile, viper.GetString(backend.FlagJWTPublicKeyFile), "path to the PEM-encoded public key to use to verify JWT signatures")
 		flagSet.StringToStringVar(&labels, flagLabels, nil, "entity labels map")
 		flagSet.StringToStringVar(&annotations, flagAnnotations, nil, "entity annotations map")
+		flagSet.StringToStringVar(&handlerConcurrency, flagPipelinedHandlerConcurrency, nil, "maximum number of concurrent invocations of handlers, keyed by handler name")
+		flagSet.Int(flagPipelinedHandlerQueueSize, viper.GetInt(flagPipelinedHandlerQueueSize), "maximum number of invocations of a limited handler that can wait for their turn, per namespace")
+		flagSet.Int(flagPipelinedAsyncHandlerWorkers, viper.GetInt(flagPipelinedAsyncHandlerWorkers), "number of workers invoking the handlers annotated with sensu.io/async=true, which run synchronously when 0")
+		flagSet.Int(flagPipelinedAsyncHandlerQueueSize, viper.GetInt(flagPipelinedAsyncHandlerQueueSize), "maximum number of asynchronous handler invocations that can wait for a worker")
+		flagSet.Bool(flagPipelinedSkipSilenced, viper.GetBool(flagPipelinedSkipSilenced), "skip the handlers of silenced events, except for handlers annotated with sensu.io/run_when_silenced=true")
+		flagSet.String(flagPipelinedDefaultHandler, viper.GetString(flagPipelinedDefaultHandler), "name of the handler, or handler set, of checks without handlers, looked up in the namespace of each event")
 		flagSet.Bool(flagDisablePlatformMetrics, viper.GetBool(flagDisablePlatformMetrics), "disable platform metrics logging")
 		flagSet.Duration(flagPlatformMetricsLoggingInterval, viper.GetDuration(flagPlatformMetricsLoggingInterval), "platform metrics logging interval")
 		flagSet.String(flagPlatformMetricsLogFile, viper.GetString(flagPlatformMetricsLogFile), "platform metrics log file path")
@@ -23,6 +23,9 @@ const (
 	FlagEventdWorkers = "eventd-workers"
 	// FlagEventdBufferSize defines the buffer size for eventd
 	FlagEventdBufferSize = "eventd-buffer-size"
+	// FlagEventdSilencedMetricsLimit defines the number of silenced entries
+	// whose suppressed events are counted separately by eventd
+	FlagEventdSilencedMetricsLimit = "eventd-silenced-metrics-limit"
 	// FlagKeepalivedWorkers defines the number of workers for keepalived
 	FlagKeepalivedWorkers = "keepalived-workers"
 	// FlagKeepalivedBufferSize defines buffer size for keepalived
@@ -62,6 +65,18 @@ type Config struct {
 	APIURL           string
 	APIWriteTimeout  time.Duration
 
+	// APIMaxRequestTimeout is the maximum server-side timeout that clients
+	// can request with the request_timeout query parameter.
+	APIMaxRequestTimeout time.Duration
+
+	// StoreHistoryDepth is the number of versions of each resource that the
+	// store retains for the history API. Zero disables history.
+	StoreHistoryDepth int
+
+	// StoreLargeEncodeSize is the size, in bytes, from which encoded resources
+	// are recorded as exemplars of large encodes. Zero disables recording.
+	StoreLargeEncodeSize int
+
 	// AssetsRateLimit is the maximum number of assets per second that will be fetched.
 	AssetsRateLimit rate.Limit
 
@@ -78,6 +93,31 @@ type Config struct {
 	// Pipelined Configuration
 	DeregistrationHandler string
 
+	// PipelinedHandlerConcurrency is the maximum number of concurrent
+	// invocations of handlers, keyed by handler name
+	PipelinedHandlerConcurrency map[string]int
+
+	// PipelinedHandlerQueueSize is the maximum number of invocations of a
+	// limited handler that can wait for their turn
+	PipelinedHandlerQueueSize int
+
+	// PipelinedAsyncHandlerWorkers is the number of workers invoking the
+	// handlers annotated to run asynchronously. Annotated handlers run
+	// synchronously when it is 0.
+	PipelinedAsyncHandlerWorkers int
+
+	// PipelinedAsyncHandlerQueueSize is the maximum number of asynchronous
+	// handler invocations that can wait for a worker
+	PipelinedAsyncHandlerQueueSize int
+
+	// PipelinedSkipSilenced skips the handlers of silenced events, except for
+	// those annotated to run when silenced
+	PipelinedSkipSilenced bool
+
+	// PipelinedDefaultHandler is the name of the handler, looked up in the
+	// namespace of each event, of checks without handlers
+	PipelinedDefaultHandler string
+
 	// Labels are key-value pairs that users can provide to backend entities
 	Labels map[string]string
 
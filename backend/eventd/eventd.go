@@ -95,6 +95,10 @@ const (
 
 	// defaultStoreTimeout is the store timeout used if the backend did not configure one
 	defaultStoreTimeout = time.Minute
+
+	// silencedCacheWarmTimeout bounds the time eventd waits for the silenced
+	// cache to be reloaded before it starts processing events
+	silencedCacheWarmTimeout = 30 * time.Second
 )
 
 var (
@@ -207,11 +211,13 @@ type Eventd struct {
 	logBufferSize       int
 	logBufferWait       time.Duration
 	logParallelEncoders bool
+	silencedMetrics     *silencedMetrics
 }
 
 // Cache interfaces the cache.Resource struct for easier testing
 type Cache interface {
 	Get(namespace string) []cache.Value
+	Warm(ctx context.Context) error
 }
 
 // Option is a functional option.
@@ -231,6 +237,10 @@ type Config struct {
 	LogBufferSize       int
 	LogBufferWait       time.Duration
 	LogParallelEncoders bool
+
+	// SilencedMetricsLimit bounds the number of silenced entries whose
+	// suppressed events are counted under their own series.
+	SilencedMetricsLimit int
 }
 
 // New creates a new Eventd.
@@ -247,6 +257,9 @@ func New(ctx context.Context, c Config, opts ...Option) (*Eventd, error) {
 		logger.Warn("StoreTimeout not configured")
 		c.StoreTimeout = defaultStoreTimeout
 	}
+	if c.SilencedMetricsLimit == 0 {
+		c.SilencedMetricsLimit = DefaultSilencedMetricsLimit
+	}
 
 	e := &Eventd{
 		store:               c.Store,
@@ -265,6 +278,7 @@ func New(ctx context.Context, c Config, opts ...Option) (*Eventd, error) {
 		logBufferSize:       c.LogBufferSize,
 		logBufferWait:       c.LogBufferWait,
 		logParallelEncoders: c.LogParallelEncoders,
+		silencedMetrics:     newSilencedMetrics(c.SilencedMetricsLimit),
 		Logger:              NoopLogger{},
 	}
 
@@ -314,6 +328,7 @@ func New(ctx context.Context, c Config, opts ...Option) (*Eventd, error) {
 	_ = prometheus.Register(livenessFactoryDuration)
 	_ = prometheus.Register(switchesAliveDuration)
 	_ = prometheus.Register(switchesBuryDuration)
+	_ = prometheus.Register(silencedSuppressedEvents)
 
 	return e, nil
 }
@@ -325,6 +340,15 @@ func (e *Eventd) Receiver() chan<- interface{} {
 
 // Start eventd.
 func (e *Eventd) Start() error {
+	// Reload the silenced entries before processing any event, so that entries
+	// created since eventd was instantiated are applied to the first events.
+	// A failure is not fatal, since the cache was loaded upon creation.
+	ctx, cancel := context.WithTimeout(e.ctx, silencedCacheWarmTimeout)
+	if err := e.silencedCache.Warm(ctx); err != nil {
+		logger.WithError(err).Warn("could not warm the silenced cache, it may be stale until its next refresh")
+	}
+	cancel()
+
 	e.wg.Add(e.workerCount)
 	sub, err := e.bus.Subscribe(messaging.TopicEventRaw, "eventd", e)
 	e.subscription = sub
@@ -546,6 +570,7 @@ func (e *Eventd) handleMessage(msg interface{}) (fEvent *corev2.Event, fErr erro
 	getSilenced(ctx, event, e.silencedCache)
 	if len(event.Check.Silenced) > 0 {
 		event.Check.IsSilenced = true
+		e.silencedMetrics.record(event.Check.Namespace, event.Check.Silenced)
 	}
 
 	// Merge the new event with the stored event if a match is found
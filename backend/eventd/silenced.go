@@ -5,6 +5,7 @@ import (
 	"time"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store/cache"
 	stringsutil "github.com/sensu/sensu-go/util/strings"
 )
 
@@ -20,12 +21,50 @@ func addToSilencedBy(id string, ids []string) []string {
 // getSilenced retrieves all silenced entries for a given event, using the
 // entity subscription, the check subscription and the check name while
 // supporting wildcard silenced entries (e.g. subscription:*)
-func getSilenced(ctx context.Context, event *corev2.Event, cache Cache) {
+func getSilenced(ctx context.Context, event *corev2.Event, cache SilencesCache) {
 	if !event.HasCheck() {
 		return
 	}
 
-	resources := cache.Get(event.Check.Namespace)
+	entries := unexpiredSilences(cache, event.Check.Namespace)
+
+	// Determine which entries silence this event
+	silencedIDs := silencedBy(event, entries)
+
+	// Add to the event all silenced entries ID that actually silence it
+	event.Check.Silenced = silencedIDs
+}
+
+// SilencesCache is the cache of silenced entries, by namespace.
+type SilencesCache interface {
+	Get(namespace string) []cache.Value
+}
+
+// ActiveSilences returns the silenced entries of the namespace that currently
+// silence at least one of the given events that is an incident, as opposed to
+// all the configured entries. Entries that have expired or not begun yet are
+// never active. Events of other namespaces are ignored.
+func ActiveSilences(ctx context.Context, namespace string, cache SilencesCache, events []*corev2.Event) []*corev2.Silenced {
+	entries := unexpiredSilences(cache, namespace)
+	active := make([]*corev2.Silenced, 0, len(entries))
+	for _, entry := range entries {
+		for _, event := range events {
+			if !event.IsIncident() || event.Check.Namespace != namespace {
+				continue
+			}
+			if event.IsSilencedBy(entry) {
+				active = append(active, entry)
+				break
+			}
+		}
+	}
+	return active
+}
+
+// unexpiredSilences returns the silenced entries of the namespace that have
+// not expired.
+func unexpiredSilences(cache SilencesCache, namespace string) []*corev2.Silenced {
+	resources := cache.Get(namespace)
 	entries := make([]*corev2.Silenced, 0, len(resources))
 	for _, resource := range resources {
 		silenced := resource.Resource.(*corev2.Silenced)
@@ -35,12 +74,7 @@ func getSilenced(ctx context.Context, event *corev2.Event, cache Cache) {
 		}
 		entries = append(entries, silenced)
 	}
-
-	// Determine which entries silence this event
-	silencedIDs := silencedBy(event, entries)
-
-	// Add to the event all silenced entries ID that actually silence it
-	event.Check.Silenced = silencedIDs
+	return entries
 }
 
 // silencedBy determines which of the given silenced entries silenced a given
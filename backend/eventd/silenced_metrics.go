@@ -0,0 +1,78 @@
+package eventd
+
+import (
+	"path"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// SilencedSuppressedEventsCounterVec is the name of the prometheus counter
+	// vec used to count the events suppressed by each silenced entry.
+	SilencedSuppressedEventsCounterVec = "sensu_go_silenced_suppressed_events"
+
+	// SilencedLabelName is the name of the label which identifies the
+	// silenced entry that suppressed an event.
+	SilencedLabelName = "silenced"
+
+	// SilencedLabelOther is the value of the silenced label used for the
+	// events suppressed by silenced entries past the cardinality limit.
+	SilencedLabelOther = "_other"
+
+	// DefaultSilencedMetricsLimit is the number of silenced entries that get
+	// their own series if the backend did not configure a limit.
+	DefaultSilencedMetricsLimit = 1000
+)
+
+var silencedSuppressedEvents = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: SilencedSuppressedEventsCounterVec,
+		Help: "The total number of events suppressed by each silenced entry",
+	},
+	[]string{"namespace", SilencedLabelName},
+)
+
+// silencedMetrics counts the events suppressed by each silenced entry. To keep
+// the cardinality of the counter bounded, only the first limit entries seen get
+// their own series; the events suppressed by any other entry are counted under
+// SilencedLabelOther, in their namespace. A series is never forgotten, even if
+// its entry is deleted.
+type silencedMetrics struct {
+	mu    sync.Mutex
+	limit int
+	seen  map[string]struct{}
+}
+
+func newSilencedMetrics(limit int) *silencedMetrics {
+	return &silencedMetrics{
+		limit: limit,
+		seen:  make(map[string]struct{}),
+	}
+}
+
+// record counts one suppressed event for each of the silenced entries of the
+// namespace that silence it.
+func (m *silencedMetrics) record(namespace string, silenced []string) {
+	if m == nil {
+		return
+	}
+	for _, id := range silenced {
+		silencedSuppressedEvents.WithLabelValues(namespace, m.label(namespace, id)).Inc()
+	}
+}
+
+// label returns the silenced label value to use for the entry of the namespace.
+func (m *silencedMetrics) label(namespace, id string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := path.Join(namespace, id)
+	if _, ok := m.seen[key]; ok {
+		return id
+	}
+	if len(m.seen) >= m.limit {
+		return SilencedLabelOther
+	}
+	m.seen[key] = struct{}{}
+	return id
+}
@@ -0,0 +1,37 @@
+package eventd
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestSilencedMetricsRecord(t *testing.T) {
+	m := newSilencedMetrics(2)
+	m.record("silenced-metrics", []string{"entity:foo:*", "*:check_cpu"})
+	m.record("silenced-metrics", []string{"entity:foo:*", "linux:*"})
+	m.record("silenced-metrics", []string{"windows:*"})
+
+	tests := []struct {
+		silenced string
+		want     float64
+	}{
+		{silenced: "entity:foo:*", want: 2},
+		{silenced: "*:check_cpu", want: 1},
+		{silenced: "linux:*", want: 0},
+		{silenced: "windows:*", want: 0},
+		{silenced: SilencedLabelOther, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.silenced, func(t *testing.T) {
+			counter := silencedSuppressedEvents.WithLabelValues("silenced-metrics", tt.silenced)
+			if got := testutil.ToFloat64(counter); got != tt.want {
+				t.Errorf("got %v suppressed events, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// A nil tracker, in Eventd values built without New, records nothing
+	var nilMetrics *silencedMetrics
+	nilMetrics.record("silenced-metrics", []string{"entity:foo:*"})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"sync"
 	"testing"
+	"time"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	corev3 "github.com/sensu/sensu-go/api/core/v3"
@@ -189,6 +190,10 @@ func (m *mockCache) Get(namespace string) []cache.Value {
 	return args.Get(0).([]cache.Value)
 }
 
+func (m *mockCache) Warm(ctx context.Context) error {
+	return nil
+}
+
 func TestEventd_handleMessage(t *testing.T) {
 	type busFunc func(*mockbus.MockBus)
 	type cacheFunc func(*mockCache)
@@ -305,3 +310,40 @@ func TestEventd_handleMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestActiveSilences(t *testing.T) {
+	expired := corev2.FixtureSilenced("entity:foo:check_mem")
+	expired.ExpireAt = time.Now().Add(-time.Minute).Unix()
+	future := corev2.FixtureSilenced("entity:foo:check_disk")
+	future.Begin = time.Now().Add(time.Hour).Unix()
+	entries := []corev2.Resource{
+		corev2.FixtureSilenced("entity:foo:check_cpu"),
+		corev2.FixtureSilenced("*:check_cpu"),
+		corev2.FixtureSilenced("entity:bar:*"),
+		corev2.FixtureSilenced("entity:foo:check_ok"),
+		expired,
+		future,
+	}
+	c := cache.NewFromResources(entries, false)
+
+	incident := func(entity, check string) *corev2.Event {
+		event := corev2.FixtureEvent(entity, check)
+		event.Check.Status = 2
+		return event
+	}
+	otherNamespace := incident("bar", "check_cpu")
+	otherNamespace.Check.Namespace = "acme"
+	events := []*corev2.Event{
+		incident("foo", "check_cpu"),
+		incident("foo", "check_mem"),
+		incident("foo", "check_disk"),
+		corev2.FixtureEvent("foo", "check_ok"),
+		otherNamespace,
+	}
+
+	var names []string
+	for _, entry := range ActiveSilences(context.Background(), "default", c, events) {
+		names = append(names, entry.Name)
+	}
+	assert.ElementsMatch(t, []string{"entity:foo:check_cpu", "*:check_cpu"}, names)
+}
@@ -632,7 +632,7 @@ func (k *Keepalived) dead(key string, prev liveness.State, leader bool) bool {
 	}
 	event.Check.Output = fmt.Sprintf("No keepalive sent from %s for %v seconds (>= %v)", event.Entity.Name, timeSinceLastSeen, timeout)
 
-	if err := k.bus.Publish(messaging.TopicEventRaw, event); err != nil {
+	if err := messaging.PublishWait(ctx, k.bus, messaging.TopicEventRaw, event); err != nil {
 		lager.WithError(err).Error("error publishing event")
 		return false
 	}
@@ -735,5 +735,5 @@ func (k *Keepalived) handleUpdate(e *corev2.Event) error {
 		}
 	}
 
-	return k.bus.Publish(messaging.TopicEventRaw, event)
+	return messaging.PublishWait(ctx, k.bus, messaging.TopicEventRaw, event)
 }
@@ -0,0 +1,92 @@
+// Package leader elects the single backend of the cluster that runs a
+// cluster-wide task, such as the periodic sweeps of the daemons.
+package leader
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sensu/sensu-go/backend/etcd"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+)
+
+// leaseTTL is the TTL, in seconds, of the lease of the lock. Another backend
+// takes over at most this long after the leader stops.
+const leaseTTL = 60
+
+// Leader elects the single backend of the cluster that runs a task.
+type Leader interface {
+	// Lead returns true if this backend is the leader, trying to become it
+	// otherwise.
+	Lead(context.Context) (bool, error)
+
+	// Close gives up the leadership.
+	Close() error
+}
+
+// Etcd is a Leader holding an etcd lock, associated with a lease so that it is
+// released when the backend stops.
+type Etcd struct {
+	// Client is the etcd client.
+	Client *clientv3.Client
+
+	// Key is the key of the lock, which is unique to the task.
+	Key string
+
+	// Component labels the lease operations of the lock in metrics.
+	Component string
+
+	session *concurrency.Session
+	mutex   *concurrency.Mutex
+	locked  bool
+}
+
+// Lead returns true if this backend holds the lock, trying to acquire it
+// otherwise.
+func (l *Etcd) Lead(ctx context.Context) (bool, error) {
+	if l.session != nil {
+		select {
+		case <-l.session.Done():
+			// The lease expired, and the lock with it
+			l.session, l.mutex, l.locked = nil, nil, false
+		default:
+		}
+	}
+	if l.locked {
+		return true, nil
+	}
+
+	if l.session == nil {
+		resp, err := l.Client.Grant(ctx, leaseTTL)
+		etcd.LeaseOperationsCounter.WithLabelValues(l.Component, etcd.LeaseOperationTypeGrant, etcd.LeaseStatusFor(err)).Inc()
+		if err != nil {
+			return false, fmt.Errorf("failed to create etcd lease: %w", err)
+		}
+		session, err := concurrency.NewSession(l.Client, concurrency.WithLease(resp.ID))
+		etcd.LeaseOperationsCounter.WithLabelValues(l.Component, etcd.LeaseOperationTypePut, etcd.LeaseStatusFor(err)).Inc()
+		if err != nil {
+			return false, fmt.Errorf("failed to start etcd session: %w", err)
+		}
+		l.session = session
+		l.mutex = concurrency.NewMutex(session, l.Key)
+	}
+
+	if err := l.mutex.TryLock(ctx); err != nil {
+		if err == concurrency.ErrLocked {
+			return false, nil
+		}
+		return false, err
+	}
+	l.locked = true
+	return true, nil
+}
+
+// Close gives up the lock.
+func (l *Etcd) Close() error {
+	if l.session == nil {
+		return nil
+	}
+	// Closing the session revokes its lease, which releases the lock
+	return l.session.Close()
+}
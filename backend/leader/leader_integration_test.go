@@ -0,0 +1,43 @@
+// +build integration,!race
+
+package leader
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sensu/sensu-go/backend/etcd"
+)
+
+func TestEtcd(t *testing.T) {
+	e, cleanup := etcd.NewTestEtcd(t)
+	defer cleanup()
+
+	client := e.NewEmbeddedClient()
+	defer client.Close()
+
+	ctx := context.Background()
+	first := &Etcd{Client: client, Key: ".test.lock", Component: "test"}
+	second := &Etcd{Client: client, Key: ".test.lock", Component: "test"}
+
+	if ok, err := first.Lead(ctx); err != nil || !ok {
+		t.Fatalf("first backend is not the leader: %v", err)
+	}
+	if ok, err := first.Lead(ctx); err != nil || !ok {
+		t.Fatalf("first backend lost the leadership: %v", err)
+	}
+	if ok, err := second.Lead(ctx); err != nil || ok {
+		t.Fatalf("second backend is also the leader: %v", err)
+	}
+
+	// The lock is released when the leader gives up
+	if err := first.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := second.Lead(ctx); err != nil || !ok {
+		t.Fatalf("second backend did not take over: %v", err)
+	}
+	if err := second.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -3,7 +3,9 @@
 package messaging
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sensu/sensu-go/backend/daemon"
@@ -34,10 +36,18 @@ const (
 	// TopicTessenMetric is the topic prefix for tessen api metrics to Tessend.
 	TopicTessenMetric = "sensu:tessen-metric"
 
+	// TopicResourceChange is the topic for the changes of resources written
+	// through the API, see ResourceChange.
+	TopicResourceChange = "sensu:resource-change"
+
 	// TopicKeepaliveRaw is a separate channel for keepalives that
 	// allows eventd to process keepalives at a higher priority than
 	// regular events.
 	TopicKeepaliveRaw = "sensu:keepalive-raw"
+
+	// publishRetryInterval is the time PublishWait waits between two attempts
+	// at publishing to a full topic.
+	publishRetryInterval = 10 * time.Millisecond
 )
 
 var (
@@ -61,6 +71,7 @@ type Subscriber interface {
 type Subscription struct {
 	id     string
 	cancel func(string) error
+	active func(string) bool
 }
 
 // Cancel a WizardSubscription.
@@ -68,6 +79,12 @@ func (t Subscription) Cancel() error {
 	return t.cancel(t.id)
 }
 
+// Active reports whether the subscription still receives the messages of its
+// topic. It is no longer active once cancelled, or once the bus is stopped.
+func (t Subscription) Active() bool {
+	return t.active != nil && t.active(t.id)
+}
+
 type ChanSubscriber chan interface{}
 
 func (c ChanSubscriber) Receiver() chan<- interface{} {
@@ -98,6 +115,35 @@ type MessageBus interface {
 	Publish(topic string, message interface{}) error
 }
 
+// A Publisher publishes messages to topics. MessageBus implementations are
+// publishers.
+type Publisher interface {
+	Publish(topic string, message interface{}) error
+}
+
+// PublishWait publishes a message to a topic, like Publish, but waits for
+// room in topics that have the OverflowReject policy instead of returning
+// ErrTopicFull, until ctx is done. It is meant for publishers that have no way
+// of pushing back on whatever produces their messages.
+func PublishWait(ctx context.Context, bus Publisher, topic string, message interface{}) error {
+	err := bus.Publish(topic, message)
+	if err != ErrTopicFull {
+		return err
+	}
+	ticker := time.NewTicker(publishRetryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		if err := bus.Publish(topic, message); err != ErrTopicFull {
+			return err
+		}
+	}
+}
+
 // EntityConfigTopic is a helper to determine the proper topic name for an
 // entity
 func EntityConfigTopic(namespace, name string) string {
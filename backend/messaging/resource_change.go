@@ -0,0 +1,40 @@
+package messaging
+
+const (
+	// ResourceCreated is the operation of changes that create a resource
+	ResourceCreated = "create"
+
+	// ResourceUpdated is the operation of changes that replace a resource
+	ResourceUpdated = "update"
+
+	// ResourcePatched is the operation of changes that patch a resource
+	ResourcePatched = "patch"
+
+	// ResourceDeleted is the operation of changes that delete a resource
+	ResourceDeleted = "delete"
+)
+
+// ResourceChange is published to TopicResourceChange after a resource is
+// successfully written through the API, so that subscribers can react to
+// changes of any resource.
+type ResourceChange struct {
+	// APIVersion is the API version of the resource, e.g. core/v2
+	APIVersion string `json:"api_version"`
+
+	// Type is the type of the resource, e.g. CheckConfig
+	Type string `json:"type"`
+
+	// Namespace is the namespace of the resource, empty for cluster-wide
+	// resources
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name is the name of the resource
+	Name string `json:"name"`
+
+	// Operation is the operation that changed the resource, one of
+	// ResourceCreated, ResourceUpdated, ResourcePatched or ResourceDeleted
+	Operation string `json:"operation"`
+
+	// ETag is the ETag of the resource once changed, empty for deletions
+	ETag string `json:"etag,omitempty"`
+}
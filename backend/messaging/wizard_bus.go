@@ -13,7 +13,13 @@ import (
 const (
 	WizardBusMessagesPublished      = "sensu_go_bus_messages_published"
 	WizardBusMessagePublishDuration = "sensu_go_bus_message_duration"
+	WizardBusTopicSubscribers       = "sensu_go_bus_topic_subscribers"
 	WizardBusTopicLabelName         = "topic"
+
+	// DefaultMaxSubscribersPerTopic is the maximum number of subscribers of a
+	// single topic, when WizardBusConfig does not specify one. It is meant to
+	// be far above what a healthy cluster requires.
+	DefaultMaxSubscribersPerTopic = 100000
 )
 
 var (
@@ -33,11 +39,44 @@ var (
 		},
 		[]string{WizardBusTopicLabelName},
 	)
+
+	topicSubscribersGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: WizardBusTopicSubscribers,
+			Help: "The current number of subscribers of a wizard bus topic",
+		},
+		[]string{WizardBusTopicLabelName},
+	)
+
+	// ErrTooManySubscribers is returned by Subscribe when a topic already has
+	// the maximum number of subscribers.
+	ErrTooManySubscribers = errors.New("topic has reached its maximum number of subscribers")
+
+	// ErrTopicFull is returned by Publish when the topic has the OverflowReject
+	// policy and one of its subscribers has no room left for the message.
+	ErrTopicFull = errors.New("topic subscribers are full")
+)
+
+// OverflowPolicy describes what publishing to a topic does when one of its
+// subscribers is not keeping up.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock makes Publish wait until every subscriber has received the
+	// message. It is the default policy.
+	OverflowBlock OverflowPolicy = iota
+
+	// OverflowReject makes Publish return ErrTopicFull, without sending the
+	// message to any subscriber, when the buffer of one of the subscribers is
+	// full. Publishers can then push back on their own producers instead of
+	// piling up blocked goroutines.
+	OverflowReject
 )
 
 func init() {
 	_ = prometheus.Register(messagePublishedCounter)
 	_ = prometheus.Register(messagePublishedDurations)
+	_ = prometheus.Register(topicSubscribersGauge)
 }
 
 // WizardBus is a message bus.
@@ -49,13 +88,23 @@ func init() {
 // message types over a single topic, however, as we do not want to introduce
 // a dependency on reflection to determine the type of the received interface{}.
 type WizardBus struct {
-	running atomic.Value
-	topics  sync.Map
-	errchan chan error
+	running        atomic.Value
+	topics         sync.Map
+	errchan        chan error
+	maxSubscribers int
+	overflow       map[string]OverflowPolicy
 }
 
 // WizardBusConfig configures a WizardBus
-type WizardBusConfig struct{}
+type WizardBusConfig struct {
+	// MaxSubscribersPerTopic is the maximum number of subscribers of a single
+	// topic. Defaults to DefaultMaxSubscribersPerTopic.
+	MaxSubscribersPerTopic int
+
+	// OverflowPolicies are the overflow policies of topics, keyed by topic.
+	// Topics that are not listed use OverflowBlock.
+	OverflowPolicies map[string]OverflowPolicy
+}
 
 // WizardOption is a functional option.
 type WizardOption func(*WizardBus) error
@@ -63,7 +112,12 @@ type WizardOption func(*WizardBus) error
 // NewWizardBus creates a new WizardBus.
 func NewWizardBus(cfg WizardBusConfig, opts ...WizardOption) (*WizardBus, error) {
 	bus := &WizardBus{
-		errchan: make(chan error, 1),
+		errchan:        make(chan error, 1),
+		maxSubscribers: cfg.MaxSubscribersPerTopic,
+		overflow:       cfg.OverflowPolicies,
+	}
+	if bus.maxSubscribers <= 0 {
+		bus.maxSubscribers = DefaultMaxSubscribersPerTopic
 	}
 	for _, opt := range opts {
 		if err := opt(bus); err != nil {
@@ -112,9 +166,11 @@ func (b *WizardBus) Name() string {
 // topic's mutex.
 func (b *WizardBus) createTopic(topic string) *wizardTopic {
 	wTopic := &wizardTopic{
-		id:       topic,
-		bindings: make(map[string]Subscriber),
-		done:     make(chan struct{}),
+		id:             topic,
+		bindings:       make(map[string]Subscriber),
+		done:           make(chan struct{}),
+		maxSubscribers: b.maxSubscribers,
+		overflow:       b.overflow[topic],
 	}
 	return wTopic
 }
@@ -131,6 +187,9 @@ func (b *WizardBus) createTopic(topic string) *wizardTopic {
 // Modifying received messages will introduce data races. While these _may_ be
 // detected by the Golang race detector, this is not always the case and is
 // only exacerbated by the fact that we test each package individually.
+//
+// ErrTooManySubscribers is returned if the topic already has the maximum
+// number of subscribers configured for the bus.
 func (b *WizardBus) Subscribe(topic string, consumer string, sub Subscriber) (Subscription, error) {
 	if !b.running.Load().(bool) {
 		return Subscription{}, errors.New("bus no longer running")
@@ -158,7 +217,9 @@ func findGenericTopic(topic string) string {
 }
 
 // Publish publishes a message to a topic. If the topic does not
-// exist, this is a noop.
+// exist, this is a noop. ErrTopicFull is returned if the topic has the
+// OverflowReject policy and the message could not be delivered without
+// blocking.
 func (b *WizardBus) Publish(topic string, msg interface{}) error {
 	genericTopic := findGenericTopic(topic)
 	then := time.Now()
@@ -173,8 +234,10 @@ func (b *WizardBus) Publish(topic string, msg interface{}) error {
 	value, ok := b.topics.Load(topic)
 	if ok {
 		wTopic := value.(*wizardTopic)
-		defer messagePublishedCounter.WithLabelValues(genericTopic).Inc()
-		wTopic.Send(msg)
+		if err := wTopic.Send(msg); err != nil {
+			return err
+		}
+		messagePublishedCounter.WithLabelValues(genericTopic).Inc()
 	}
 
 	return nil
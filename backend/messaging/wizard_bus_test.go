@@ -134,3 +134,97 @@ func TestBug1407(t *testing.T) {
 	assert.False(t, topic.IsClosed())
 
 }
+
+func TestWizardBusMaxSubscribersPerTopic(t *testing.T) {
+	b, err := NewWizardBus(WizardBusConfig{MaxSubscribersPerTopic: 2})
+	require.NoError(t, err)
+	require.NoError(t, b.Start())
+	defer func() {
+		_ = b.Stop()
+	}()
+
+	sub := channelSubscriber{make(chan interface{}, 10)}
+
+	subscr1, err := b.Subscribe("topic", "1", sub)
+	require.NoError(t, err)
+	_, err = b.Subscribe("topic", "2", sub)
+	require.NoError(t, err)
+
+	// Subscribing again with the same consumer replaces the binding
+	_, err = b.Subscribe("topic", "2", sub)
+	require.NoError(t, err)
+
+	_, err = b.Subscribe("topic", "3", sub)
+	assert.Equal(t, ErrTooManySubscribers, err)
+
+	// Other topics are not affected
+	_, err = b.Subscribe("other", "3", sub)
+	assert.NoError(t, err)
+
+	// Cancelling a subscription makes room for another one
+	require.NoError(t, subscr1.Cancel())
+	_, err = b.Subscribe("topic", "3", sub)
+	assert.NoError(t, err)
+}
+
+func TestWizardBusOverflowReject(t *testing.T) {
+	b, err := NewWizardBus(WizardBusConfig{
+		OverflowPolicies: map[string]OverflowPolicy{"topic": OverflowReject},
+	})
+	require.NoError(t, err)
+	require.NoError(t, b.Start())
+	defer func() {
+		_ = b.Stop()
+	}()
+
+	full := channelSubscriber{make(chan interface{}, 1)}
+	other := channelSubscriber{make(chan interface{}, 10)}
+	_, err = b.Subscribe("topic", "full", full)
+	require.NoError(t, err)
+	_, err = b.Subscribe("topic", "other", other)
+	require.NoError(t, err)
+
+	require.NoError(t, b.Publish("topic", "first"))
+	assert.Equal(t, ErrTopicFull, b.Publish("topic", "second"))
+
+	// The rejected message is not delivered to any subscriber
+	assert.Equal(t, 1, len(other.Channel))
+
+	// Once the subscriber catches up, messages are accepted again
+	<-full.Channel
+	assert.NoError(t, b.Publish("topic", "third"))
+	assert.Equal(t, 2, len(other.Channel))
+
+	// Topics without a policy block instead
+	_, err = b.Subscribe("blocking", "full", full)
+	require.NoError(t, err)
+	published := make(chan error)
+	go func() {
+		published <- b.Publish("blocking", "fourth")
+	}()
+	<-full.Channel
+	assert.NoError(t, <-published)
+}
+
+func TestWizardBusSubscriptionActive(t *testing.T) {
+	b, err := NewWizardBus(WizardBusConfig{})
+	require.NoError(t, err)
+	require.NoError(t, b.Start())
+
+	sub := channelSubscriber{make(chan interface{}, 10)}
+	subscr1, err := b.Subscribe("topic", "1", sub)
+	require.NoError(t, err)
+	subscr2, err := b.Subscribe("topic", "2", sub)
+	require.NoError(t, err)
+	assert.True(t, subscr1.Active())
+	assert.True(t, subscr2.Active())
+
+	require.NoError(t, subscr1.Cancel())
+	assert.False(t, subscr1.Active(), "active once cancelled")
+	assert.True(t, subscr2.Active())
+
+	require.NoError(t, b.Stop())
+	assert.False(t, subscr2.Active(), "active once the bus is stopped")
+
+	assert.False(t, Subscription{}.Active(), "zero subscription is active")
+}
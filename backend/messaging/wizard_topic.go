@@ -10,11 +10,16 @@ type wizardTopic struct {
 	id       string
 	bindings map[string]Subscriber
 	sync.RWMutex
-	done chan struct{}
+	done           chan struct{}
+	maxSubscribers int
+	overflow       OverflowPolicy
 }
 
-// Send a message to all subscribers to this topic.
-func (t *wizardTopic) Send(msg interface{}) {
+// Send a message to all subscribers to this topic. With the OverflowReject
+// policy, ErrTopicFull is returned and nothing is sent if the buffer of one of
+// the subscribers is full. This is a best effort: concurrent publishers can
+// still fill a buffer between the check and the send, which then blocks.
+func (t *wizardTopic) Send(msg interface{}) error {
 	t.RLock()
 	subscribers := make([]Subscriber, 0, len(t.bindings))
 	for _, subscriber := range t.bindings {
@@ -22,10 +27,20 @@ func (t *wizardTopic) Send(msg interface{}) {
 	}
 	t.RUnlock()
 
+	if t.overflow == OverflowReject {
+		for _, subscriber := range subscribers {
+			receiver := subscriber.Receiver()
+			if cap(receiver) > 0 && len(receiver) >= cap(receiver) {
+				return ErrTopicFull
+			}
+		}
+	}
+
 	for _, subscriber := range subscribers {
 		topicCounter.WithLabelValues(t.id).Set(float64(len(subscriber.Receiver())))
 		safeSend(subscriber.Receiver(), msg, t.done)
 	}
+	return nil
 }
 
 // safeSend can attempt to send to a closed channel without panicking.
@@ -47,19 +62,34 @@ func safeSend(c chan<- interface{}, message interface{}, done chan struct{}) {
 // Subscribe a Subscriber to this topic and receive a Subscription.
 func (t *wizardTopic) Subscribe(id string, sub Subscriber) (Subscription, error) {
 	t.Lock()
+	if _, ok := t.bindings[id]; !ok && t.maxSubscribers > 0 && len(t.bindings) >= t.maxSubscribers {
+		t.Unlock()
+		return Subscription{}, ErrTooManySubscribers
+	}
 	t.bindings[id] = sub
+	topicSubscribersGauge.WithLabelValues(t.id).Set(float64(len(t.bindings)))
 	t.Unlock()
 
 	return Subscription{
 		id:     id,
 		cancel: t.unsubscribe,
+		active: t.isSubscribed,
 	}, nil
 }
 
+// isSubscribed reports whether a consumer is bound to this open topic.
+func (t *wizardTopic) isSubscribed(id string) bool {
+	t.RLock()
+	_, ok := t.bindings[id]
+	t.RUnlock()
+	return ok && !t.IsClosed()
+}
+
 // Unsubscribe a consumer from this topic.
 func (t *wizardTopic) unsubscribe(id string) error {
 	t.Lock()
 	delete(t.bindings, id)
+	topicSubscribersGauge.WithLabelValues(t.id).Set(float64(len(t.bindings)))
 	if len(t.bindings) == 0 {
 		select {
 		case <-t.done:
@@ -84,6 +114,7 @@ func (t *wizardTopic) Close() {
 	for consumer := range t.bindings {
 		delete(t.bindings, consumer)
 	}
+	topicSubscribersGauge.WithLabelValues(t.id).Set(0)
 	t.Unlock()
 }
 
@@ -118,6 +118,31 @@ type AdapterV1 struct {
 	FilterAdapters  []FilterAdapter
 	MutatorAdapters []MutatorAdapter
 	HandlerAdapters []HandlerAdapter
+
+	// HandlerLimiter, if set, limits the concurrent invocations of handlers.
+	HandlerLimiter *HandlerLimiter
+
+	// HandlerErrors, if set, records the last error of each handler.
+	HandlerErrors *HandlerErrors
+
+	// SkipSilenced, if set, skips the workflows of silenced events entirely,
+	// unless their handler is annotated with corev2.RunWhenSilencedAnnotation.
+	SkipSilenced bool
+
+	// DefaultHandler, if set, is the name of the handler, or handler set, that
+	// handles the events of checks without handlers. It is looked up in the
+	// namespace of the event, so that each namespace can define its own.
+	DefaultHandler string
+
+	// Maintenance, if set, looks up the maintenance windows during which the
+	// workflows of events are skipped entirely, unless their handler is
+	// annotated with corev2.RunDuringMaintenanceAnnotation.
+	Maintenance *MaintenanceWindows
+
+	// AsyncHandlers, if set, invokes the handlers annotated with
+	// corev2.AsyncHandlerAnnotation in the background. Annotated handlers
+	// are invoked synchronously if it is not set.
+	AsyncHandlers *AsyncHandlers
 }
 
 func (a *AdapterV1) Name() string {
@@ -155,11 +180,13 @@ func (a *AdapterV1) Run(ctx context.Context, ref *corev2.ResourceReference, reso
 	fields := event.LogFields(false)
 	fields["adapter_name"] = a.Name()
 	fields["pipeline"] = ref.LogFields(false)
+	fields["correlation_id"] = corev2.ContextCorrelationID(ctx)
 
 	// Prepare debug log entry
 	debugFields := event.LogFields(true)
 	debugFields["adapter_name"] = fields["adapter_name"]
 	debugFields["pipeline"] = fields["pipeline"]
+	debugFields["correlation_id"] = fields["correlation_id"]
 	logger.WithFields(debugFields).Debugf("adapter received event")
 
 	ctx = context.WithValue(ctx, corev2.NamespaceKey, event.Entity.Namespace)
@@ -171,15 +198,39 @@ func (a *AdapterV1) Run(ctx context.Context, ref *corev2.ResourceReference, reso
 	ctx = context.WithValue(ctx, corev2.PipelineKey, pipeline.Name)
 
 	if len(pipeline.Workflows) < 1 {
+		if ref.Name == LegacyPipelineName && !event.HasHandlers() {
+			// The namespace has no default handler
+			logger.WithFields(fields).Debug("no default handler found, skipping legacy pipeline")
+			return nil
+		}
 		return &ErrNoWorkflows{}
 	}
 
+	window := a.activeMaintenanceWindow(ctx, event)
+
 	for _, workflow := range pipeline.Workflows {
 		ctx = context.WithValue(ctx, corev2.PipelineWorkflowKey, workflow.Name)
 
 		fields["pipeline_workflow"] = workflow.Name
 		debugFields["pipeline_workflow"] = workflow.Name
 
+		// Skip the workflow before doing any work if the event is silenced
+		if a.SkipSilenced && event.IsSilenced() && !a.runsWhenSilenced(ctx, workflow.Handler) {
+			logger.WithFields(fields).WithField("handler", workflow.Handler.GetName()).
+				Debug("event is silenced, skipping workflow")
+			continue
+		}
+
+		// Skip the workflow if the event occurred during a maintenance window
+		if window != nil && !a.runsDuringMaintenance(ctx, workflow.Handler) {
+			maintenanceSuppressedCounter.WithLabelValues(window.Name).Inc()
+			logger.WithFields(fields).WithFields(logrus.Fields{
+				"handler":            workflow.Handler.GetName(),
+				"maintenance_window": window.Name,
+			}).Debug("event occurred during a maintenance window, skipping workflow")
+			continue
+		}
+
 		// Process the event through the workflow filters
 		filtered, err := a.processFilters(ctx, workflow.Filters, event)
 		if err != nil {
@@ -189,6 +240,13 @@ func (a *AdapterV1) Run(ctx context.Context, ref *corev2.ResourceReference, reso
 			continue
 		}
 
+		// Skip the handler if another workflow already ran it for this event
+		if workflow.Handler != nil && !claimHandler(ctx, workflow.Handler, event) {
+			logger.WithFields(fields).WithField("handler", workflow.Handler.ResourceID()).
+				Debug("handler already ran for this event, skipping workflow")
+			continue
+		}
+
 		// If no workflow mutator is set, use the JSON mutator
 		if workflow.Mutator == nil {
 			workflow.Mutator = &corev2.ResourceReference{
@@ -206,6 +264,12 @@ func (a *AdapterV1) Run(ctx context.Context, ref *corev2.ResourceReference, reso
 
 		// Process the event through the workflow handler
 		handlerRequestsTotalCounter.Inc()
+		if a.runsAsync(ctx, workflow.Handler) {
+			if err := a.dispatchHandler(ctx, workflow.Handler, event, mutatedData); err != nil {
+				return err
+			}
+			continue
+		}
 		err = a.processHandler(ctx, workflow.Handler, event, mutatedData)
 		incrementCounter(workflow.Handler, err)
 		if err != nil {
@@ -266,14 +330,19 @@ func (a *AdapterV1) getPipelineFromStore(ctx context.Context, ref *corev2.Resour
 }
 
 // generateLegacyPipeline will build an event pipeline with a pipeline
-// workflow for each event.Check.Handlers & event.Metrics.Handlers
+// workflow for each event.Check.Handlers & event.Metrics.Handlers. Checks
+// without handlers use the default handler, if any.
 func (a *AdapterV1) generateLegacyPipeline(ctx context.Context, event *corev2.Event) (*corev2.Pipeline, error) {
 	// initialize a list of handler names for storing the names of any legacy
 	// check and/or metrics handlers.
 	legacyHandlerNames := []string{}
 
 	if event.HasCheck() {
-		legacyHandlerNames = append(legacyHandlerNames, event.Check.Handlers...)
+		if len(event.Check.Handlers) > 0 {
+			legacyHandlerNames = append(legacyHandlerNames, event.Check.Handlers...)
+		} else if a.DefaultHandler != "" {
+			legacyHandlerNames = append(legacyHandlerNames, a.DefaultHandler)
+		}
 	}
 
 	if event.HasMetrics() {
@@ -719,6 +719,7 @@ func TestAdapterV1_generateLegacyPipeline(t *testing.T) {
 		FilterAdapters  []FilterAdapter
 		MutatorAdapters []MutatorAdapter
 		HandlerAdapters []HandlerAdapter
+		DefaultHandler  string
 	}
 	type args struct {
 		ctx   context.Context
@@ -842,6 +843,70 @@ func TestAdapterV1_generateLegacyPipeline(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "the default handler is used for checks without handlers",
+			args: args{
+				ctx: context.Background(),
+				event: func() *corev2.Event {
+					event := corev2.FixtureEvent("entity1", "check1")
+					event.Check.Handlers = nil
+					return event
+				}(),
+			},
+			fields: fields{
+				Store: func() store.Store {
+					handler := corev2.FixtureHandler("team-defaults")
+					stor := &mockstore.MockStore{}
+					stor.On("GetHandlerByName", mock.Anything, handler.GetName()).
+						Return(handler, nil)
+					return stor
+				}(),
+				DefaultHandler: "team-defaults",
+			},
+			want: &corev2.Pipeline{
+				ObjectMeta: corev2.NewObjectMeta("legacy-pipeline", "default"),
+				Workflows: []*corev2.PipelineWorkflow{{
+					Name: "legacy-pipeline-workflow-team-defaults",
+					Handler: &corev2.ResourceReference{
+						APIVersion: "core/v2",
+						Type:       "Handler",
+						Name:       "team-defaults",
+					},
+				}},
+			},
+		},
+		{
+			name: "check handlers take precedence over the default handler",
+			args: args{
+				ctx: context.Background(),
+				event: func() *corev2.Event {
+					event := corev2.FixtureEvent("entity1", "check1")
+					event.Check.Handlers = []string{"handler1"}
+					return event
+				}(),
+			},
+			fields: fields{
+				Store: func() store.Store {
+					handler := corev2.FixtureHandler("handler1")
+					stor := &mockstore.MockStore{}
+					stor.On("GetHandlerByName", mock.Anything, handler.GetName()).
+						Return(handler, nil)
+					return stor
+				}(),
+				DefaultHandler: "team-defaults",
+			},
+			want: &corev2.Pipeline{
+				ObjectMeta: corev2.NewObjectMeta("legacy-pipeline", "default"),
+				Workflows: []*corev2.PipelineWorkflow{{
+					Name: "legacy-pipeline-workflow-handler1",
+					Handler: &corev2.ResourceReference{
+						APIVersion: "core/v2",
+						Type:       "Handler",
+						Name:       "handler1",
+					},
+				}},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -851,6 +916,7 @@ func TestAdapterV1_generateLegacyPipeline(t *testing.T) {
 				FilterAdapters:  tt.fields.FilterAdapters,
 				MutatorAdapters: tt.fields.MutatorAdapters,
 				HandlerAdapters: tt.fields.HandlerAdapters,
+				DefaultHandler:  tt.fields.DefaultHandler,
 			}
 			got, err := a.generateLegacyPipeline(tt.args.ctx, tt.args.event)
 			if (err != nil) != tt.wantErr {
@@ -1048,3 +1114,118 @@ func TestAdapterV1_expandHandlers(t *testing.T) {
 		})
 	}
 }
+
+type countingHandlerAdapter struct {
+	Count *int
+}
+
+func (countingHandlerAdapter) Name() string {
+	return "counting_handler_adapter"
+}
+
+func (countingHandlerAdapter) CanHandle(*corev2.ResourceReference) bool {
+	return true
+}
+
+func (c countingHandlerAdapter) Handle(context.Context, *corev2.ResourceReference, *corev2.Event, []byte) error {
+	*c.Count++
+	return nil
+}
+
+func TestAdapterV1_RunSkipSilenced(t *testing.T) {
+	handlerRef := func(name string) *corev2.ResourceReference {
+		return &corev2.ResourceReference{
+			APIVersion: "core/v2",
+			Type:       "Handler",
+			Name:       name,
+		}
+	}
+	pipeline := &corev2.Pipeline{
+		ObjectMeta: corev2.NewObjectMeta("pipeline1", "default"),
+		Workflows: []*corev2.PipelineWorkflow{
+			{Name: "workflow1", Handler: handlerRef("handler1")},
+			{Name: "workflow2", Handler: handlerRef("handler2")},
+		},
+	}
+	handler1 := corev2.FixtureHandler("handler1")
+	handler2 := corev2.FixtureHandler("handler2")
+	handler2.Annotations = map[string]string{corev2.RunWhenSilencedAnnotation: "true"}
+
+	tests := []struct {
+		name         string
+		skipSilenced bool
+		silenced     []string
+		wantCount    int
+	}{
+		{
+			name:      "silenced events run every handler by default",
+			silenced:  []string{"entity:entity1:*"},
+			wantCount: 2,
+		},
+		{
+			name:         "unsilenced events run every handler",
+			skipSilenced: true,
+			wantCount:    2,
+		},
+		{
+			name:         "silenced events only run handlers marked to run anyway",
+			skipSilenced: true,
+			silenced:     []string{"entity:entity1:*"},
+			wantCount:    1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stor := &mockstore.MockStore{}
+			stor.On("GetPipelineByName", mock.Anything, "pipeline1").Return(pipeline, nil)
+			stor.On("GetHandlerByName", mock.Anything, "handler1").Return(handler1, nil)
+			stor.On("GetHandlerByName", mock.Anything, "handler2").Return(handler2, nil)
+
+			var count int
+			a := &AdapterV1{
+				Store:        stor,
+				StoreTimeout: time.Second,
+				MutatorAdapters: []MutatorAdapter{
+					&mutator.JSONAdapter{},
+				},
+				HandlerAdapters: []HandlerAdapter{
+					countingHandlerAdapter{Count: &count},
+				},
+				SkipSilenced: tt.skipSilenced,
+			}
+			event := corev2.FixtureEvent("entity1", "check1")
+			event.Check.Silenced = tt.silenced
+			if err := a.Run(context.Background(), corev2.FixturePipelineReference("pipeline1"), event); err != nil {
+				t.Fatal(err)
+			}
+			if count != tt.wantCount {
+				t.Errorf("handlers ran %d times, want %d", count, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestAdapterV1_RunWithoutDefaultHandler(t *testing.T) {
+	stor := &mockstore.MockStore{}
+	stor.On("GetHandlerByName", mock.Anything, "team-defaults").Return((*corev2.Handler)(nil), nil)
+	a := &AdapterV1{
+		Store:          stor,
+		StoreTimeout:   time.Second,
+		DefaultHandler: "team-defaults",
+	}
+	event := corev2.FixtureEvent("entity1", "check1")
+	event.Check.Handlers = nil
+
+	// A namespace without the default handler has nothing to run
+	if err := a.Run(context.Background(), LegacyPipelineReference(), event); err != nil {
+		t.Fatal(err)
+	}
+
+	// Explicit handlers that don't exist are still reported
+	stor.On("GetHandlerByName", mock.Anything, "handler1").Return((*corev2.Handler)(nil), nil)
+	event.Check.Handlers = []string{"handler1"}
+	err := a.Run(context.Background(), LegacyPipelineReference(), event)
+	if _, ok := err.(*ErrNoWorkflows); !ok {
+		t.Fatalf("expected ErrNoWorkflows, got %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// ErrAsyncQueueFull is returned when an asynchronous handler invocation would
+// exceed the number of invocations allowed to wait for a worker.
+var ErrAsyncQueueFull = errors.New("too many asynchronous handler invocations are queued")
+
+// ErrAsyncHandlersStopped is returned when an asynchronous handler invocation
+// is dispatched after the worker pool was stopped.
+var ErrAsyncHandlersStopped = errors.New("asynchronous handlers are stopped")
+
+// AsyncHandlers is a bounded pool of workers that invokes the handlers
+// annotated with corev2.AsyncHandlerAnnotation, so that slow handlers don't
+// hold up the pipeline workers. Workers are started on the first dispatch.
+//
+// Asynchronous invocations are not ordered: the events of an entity may be
+// handled in a different order than they were received, and an event may be
+// handled by an asynchronous handler after the pipelines of later events of
+// the same entity ran. Handlers that depend on ordering must not be async.
+type AsyncHandlers struct {
+	// Workers is the number of concurrent asynchronous invocations. It is 1
+	// if not set.
+	Workers int
+
+	// QueueSize is the maximum number of invocations that can wait for a
+	// worker. Any further invocation is dropped.
+	QueueSize int
+
+	// OnResult, if set, is called by the worker with the result of each
+	// invocation, once it is over.
+	OnResult func(ctx context.Context, ref *corev2.ResourceReference, event *corev2.Event, err error)
+
+	once    sync.Once
+	mu      sync.RWMutex
+	stopped bool
+	jobs    chan func()
+	wg      sync.WaitGroup
+}
+
+func (h *AsyncHandlers) start() {
+	workers := h.Workers
+	if workers <= 0 {
+		workers = 1
+	}
+	h.jobs = make(chan func(), h.QueueSize)
+	for i := 0; i < workers; i++ {
+		h.wg.Add(1)
+		go func() {
+			defer h.wg.Done()
+			for job := range h.jobs {
+				job()
+			}
+		}()
+	}
+}
+
+// Dispatch queues fn for a worker, and returns without waiting for it to run.
+// The result of fn is passed to OnResult. It returns ErrAsyncQueueFull when
+// too many invocations are already waiting for a worker.
+func (h *AsyncHandlers) Dispatch(ctx context.Context, ref *corev2.ResourceReference, event *corev2.Event, fn func(context.Context) error) error {
+	h.once.Do(h.start)
+	job := func() {
+		err := fn(ctx)
+		if h.OnResult != nil {
+			h.OnResult(ctx, ref, event, err)
+		}
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.stopped {
+		return ErrAsyncHandlersStopped
+	}
+	select {
+	case h.jobs <- job:
+		return nil
+	default:
+		handlerDroppedInvocationsCounter.WithLabelValues(ref.GetName()).Inc()
+		return ErrAsyncQueueFull
+	}
+}
+
+// Stop stops accepting invocations, and waits for the queued ones to be over.
+func (h *AsyncHandlers) Stop() {
+	h.once.Do(h.start)
+	h.mu.Lock()
+	if !h.stopped {
+		h.stopped = true
+		close(h.jobs)
+	}
+	h.mu.Unlock()
+	h.wg.Wait()
+}
+
+// runsAsync returns true if the referenced handler is annotated with
+// corev2.AsyncHandlerAnnotation and the adapter has a pool of workers for
+// asynchronous handlers.
+func (a *AdapterV1) runsAsync(ctx context.Context, ref *corev2.ResourceReference) bool {
+	if a.AsyncHandlers == nil {
+		return false
+	}
+	return a.handlerAnnotated(ctx, ref, corev2.AsyncHandlerAnnotation)
+}
+
+// dispatchHandler hands the invocation of the handler over to the pool of
+// asynchronous handlers. Its result is counted and logged by the worker, and
+// passed to the OnResult callback of the pool. Invocations that can't be
+// queued are dropped. The handler gets a copy of the event, since the next
+// workflows of the pipeline may modify the event while the handler runs.
+func (a *AdapterV1) dispatchHandler(ctx context.Context, ref *corev2.ResourceReference, event *corev2.Event, mutatedData []byte) error {
+	event = proto.Clone(event).(*corev2.Event)
+	err := a.AsyncHandlers.Dispatch(ctx, ref, event, func(ctx context.Context) error {
+		err := a.processHandler(ctx, ref, event, mutatedData)
+		incrementCounter(ref, err)
+		if err != nil {
+			fields := event.LogFields(false)
+			fields["handler"] = ref.ResourceID()
+			logger.WithFields(fields).WithError(err).Error("asynchronous handler failed")
+		}
+		return err
+	})
+	if err == ErrAsyncQueueFull || err == ErrAsyncHandlersStopped {
+		fields := event.LogFields(false)
+		fields["handler"] = ref.ResourceID()
+		logger.WithFields(fields).WithError(err).Warn("could not dispatch the asynchronous handler, dropping event")
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,220 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/pipeline/filter"
+	"github.com/sensu/sensu-go/backend/pipeline/mutator"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/mock"
+)
+
+type blockingHandlerAdapter struct {
+	release chan struct{}
+	err     error
+}
+
+func (blockingHandlerAdapter) Name() string { return "blocking" }
+
+func (blockingHandlerAdapter) CanHandle(*corev2.ResourceReference) bool { return true }
+
+func (b blockingHandlerAdapter) Handle(ctx context.Context, ref *corev2.ResourceReference, event *corev2.Event, data []byte) error {
+	<-b.release
+	return b.err
+}
+
+func TestAsyncHandlersQueueFull(t *testing.T) {
+	release := make(chan struct{})
+	results := make(chan error, 3)
+	pool := &AsyncHandlers{
+		Workers:   1,
+		QueueSize: 1,
+		OnResult: func(ctx context.Context, ref *corev2.ResourceReference, event *corev2.Event, err error) {
+			results <- err
+		},
+	}
+	ctx := context.Background()
+	ref := &corev2.ResourceReference{APIVersion: "core/v2", Type: "Handler", Name: "slow"}
+	event := corev2.FixtureEvent("entity1", "check1")
+	started := make(chan struct{})
+	fail := errors.New("failed")
+
+	// The first invocation keeps the only worker busy
+	if err := pool.Dispatch(ctx, ref, event, func(context.Context) error {
+		close(started)
+		<-release
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	<-started
+
+	// The second one waits in the queue, and the third one is dropped
+	if err := pool.Dispatch(ctx, ref, event, func(context.Context) error { return fail }); err != nil {
+		t.Fatal(err)
+	}
+	if err := pool.Dispatch(ctx, ref, event, func(context.Context) error { return nil }); err != ErrAsyncQueueFull {
+		t.Fatalf("expected ErrAsyncQueueFull, got %v", err)
+	}
+
+	close(release)
+	pool.Stop()
+	if err := <-results; err != nil {
+		t.Errorf("got result %v, want nil", err)
+	}
+	if err := <-results; err != fail {
+		t.Errorf("got result %v, want %v", err, fail)
+	}
+	if err := pool.Dispatch(ctx, ref, event, func(context.Context) error { return nil }); err != ErrAsyncHandlersStopped {
+		t.Errorf("expected ErrAsyncHandlersStopped, got %v", err)
+	}
+}
+
+func TestAdapterV1_RunAsyncHandler(t *testing.T) {
+	pipeline := &corev2.Pipeline{
+		ObjectMeta: corev2.NewObjectMeta("pipeline1", "default"),
+		Workflows: []*corev2.PipelineWorkflow{
+			{
+				Name:    "workflow1",
+				Handler: &corev2.ResourceReference{APIVersion: "core/v2", Type: "Handler", Name: "slow"},
+			},
+		},
+	}
+	handler := corev2.FixtureHandler("slow")
+	handler.Annotations = map[string]string{corev2.AsyncHandlerAnnotation: "true"}
+
+	stor := &mockstore.MockStore{}
+	stor.On("GetPipelineByName", mock.Anything, "pipeline1").Return(pipeline, nil)
+	stor.On("GetHandlerByName", mock.Anything, "slow").Return(handler, nil)
+
+	fail := errors.New("ticket not created")
+	release := make(chan struct{})
+	results := make(chan error, 1)
+	a := &AdapterV1{
+		Store:        stor,
+		StoreTimeout: time.Second,
+		MutatorAdapters: []MutatorAdapter{
+			&mutator.JSONAdapter{},
+		},
+		HandlerAdapters: []HandlerAdapter{
+			blockingHandlerAdapter{release: release, err: fail},
+		},
+		HandlerErrors: &HandlerErrors{},
+		AsyncHandlers: &AsyncHandlers{
+			Workers:   1,
+			QueueSize: 1,
+			OnResult: func(ctx context.Context, ref *corev2.ResourceReference, event *corev2.Event, err error) {
+				results <- err
+			},
+		},
+	}
+	defer a.AsyncHandlers.Stop()
+
+	// Run returns while the handler is still blocked
+	event := corev2.FixtureEvent("entity1", "check1")
+	if err := a.Run(context.Background(), corev2.FixturePipelineReference("pipeline1"), event); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-results:
+		t.Fatalf("handler returned %v before it was released", err)
+	default:
+	}
+
+	close(release)
+	select {
+	case err := <-results:
+		if err != fail {
+			t.Errorf("got result %v, want %v", err, fail)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no result from the asynchronous handler")
+	}
+	if got := a.HandlerErrors.Status("default", "slow").ConsecutiveFailures; got != 1 {
+		t.Errorf("got %d consecutive failures, want 1", got)
+	}
+}
+
+type recordingHandlerAdapter struct {
+	events chan *corev2.Event
+}
+
+func (recordingHandlerAdapter) Name() string { return "recording" }
+
+func (recordingHandlerAdapter) CanHandle(*corev2.ResourceReference) bool { return true }
+
+func (r recordingHandlerAdapter) Handle(ctx context.Context, ref *corev2.ResourceReference, event *corev2.Event, data []byte) error {
+	_ = event.LogFields(false)
+	r.events <- event
+	return nil
+}
+
+func TestAdapterV1_RunAsyncHandlerBeforeWorkflow(t *testing.T) {
+	pipeline := &corev2.Pipeline{
+		ObjectMeta: corev2.NewObjectMeta("pipeline1", "default"),
+		Workflows: []*corev2.PipelineWorkflow{
+			{
+				Name:    "workflow1",
+				Handler: &corev2.ResourceReference{APIVersion: "core/v2", Type: "Handler", Name: "slow"},
+			},
+			{
+				Name: "workflow2",
+				Filters: []*corev2.ResourceReference{
+					{APIVersion: "core/v2", Type: "EventFilter", Name: "filter1"},
+				},
+				Handler: &corev2.ResourceReference{APIVersion: "core/v2", Type: "Handler", Name: "fast"},
+			},
+		},
+	}
+	slow := corev2.FixtureHandler("slow")
+	slow.Annotations = map[string]string{corev2.AsyncHandlerAnnotation: "true"}
+	fast := corev2.FixtureHandler("fast")
+	eventFilter := corev2.FixtureEventFilter("filter1")
+	eventFilter.Expressions = []string{"event.check.name == 'check1'"}
+
+	stor := &mockstore.MockStore{}
+	stor.On("GetPipelineByName", mock.Anything, "pipeline1").Return(pipeline, nil)
+	stor.On("GetHandlerByName", mock.Anything, "slow").Return(slow, nil)
+	stor.On("GetHandlerByName", mock.Anything, "fast").Return(fast, nil)
+	stor.On("GetEventFilterByName", mock.Anything, "filter1").Return(eventFilter, nil)
+
+	events := make(chan *corev2.Event, 2)
+	a := &AdapterV1{
+		Store:        stor,
+		StoreTimeout: time.Second,
+		FilterAdapters: []FilterAdapter{
+			&filter.LegacyAdapter{Store: stor, StoreTimeout: time.Second},
+		},
+		MutatorAdapters: []MutatorAdapter{
+			&mutator.JSONAdapter{},
+		},
+		HandlerAdapters: []HandlerAdapter{
+			recordingHandlerAdapter{events: events},
+		},
+		HandlerErrors: &HandlerErrors{},
+		AsyncHandlers: &AsyncHandlers{Workers: 1, QueueSize: 1},
+	}
+
+	// The filter of the second workflow redacts the entity of the event while
+	// the asynchronous handler of the first one is running
+	event := corev2.FixtureEvent("entity1", "check1")
+	if err := a.Run(context.Background(), corev2.FixturePipelineReference("pipeline1"), event); err != nil {
+		t.Fatal(err)
+	}
+	a.AsyncHandlers.Stop()
+	close(events)
+	handled := 0
+	for e := range events {
+		handled++
+		if e.Entity.Name != "entity1" {
+			t.Errorf("bad event: %v", e)
+		}
+	}
+	if handled != 2 {
+		t.Errorf("got %d handled events, want 2", handled)
+	}
+}
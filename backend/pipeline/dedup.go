@@ -0,0 +1,43 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+type handlerDedupKey struct{}
+
+// handlerDedup records the handlers that already ran for an event.
+type handlerDedup struct {
+	mu   sync.Mutex
+	seen map[string]struct{}
+}
+
+// WithHandlerDedup returns a context in which a given handler runs at most once
+// per event, even when several pipelines or workflows reference it. It is
+// meant to be scoped to the processing of a single event.
+func WithHandlerDedup(ctx context.Context) context.Context {
+	return context.WithValue(ctx, handlerDedupKey{}, &handlerDedup{
+		seen: make(map[string]struct{}),
+	})
+}
+
+// claimHandler reports whether the handler may run for the event, and if so,
+// records that it did. Handlers can always run outside of WithHandlerDedup.
+func claimHandler(ctx context.Context, ref *corev2.ResourceReference, event *corev2.Event) bool {
+	dedup, ok := ctx.Value(handlerDedupKey{}).(*handlerDedup)
+	if !ok {
+		return true
+	}
+	key := fmt.Sprintf("%s/%x", ref.ResourceID(), event.ID)
+	dedup.mu.Lock()
+	defer dedup.mu.Unlock()
+	if _, ok := dedup.seen[key]; ok {
+		return false
+	}
+	dedup.seen[key] = struct{}{}
+	return true
+}
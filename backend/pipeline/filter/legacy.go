@@ -138,6 +138,24 @@ func evaluateEventFilter(ctx context.Context, event *corev2.Event, filter *corev
 		}
 	}
 
+	// The entity label selector is evaluated before the expressions, so that
+	// filters that only rely on entity labels never need a javascript VM
+	if len(filter.EntityLabelSelector) > 0 {
+		match := filter.MatchesEntityLabels(event.Entity.Labels)
+
+		// Allow - The entity labels did not match, filter the event
+		if filter.Action == corev2.EventFilterActionAllow && !match {
+			logger.WithFields(fields).Debug("denying event that does not match entity label selector")
+			return true
+		}
+
+		// Deny - The entity labels did not match, do not filter the event
+		if filter.Action == corev2.EventFilterActionDeny && !match {
+			logger.WithFields(fields).Debug("allowing event that does not match entity label selector")
+			return false
+		}
+	}
+
 	// Guard against nil metadata labels and annotations to improve the user
 	// experience of querying these them.
 	if event.ObjectMeta.Annotations == nil {
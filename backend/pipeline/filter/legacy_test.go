@@ -374,6 +374,74 @@ func Test_evaluateEventFilter(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "returns false when the entity labels match with action allow",
+			args: args{
+				ctx:   context.Background(),
+				event: labeledEvent(map[string]string{"region": "us-west-2"}),
+				filter: &corev2.EventFilter{
+					ObjectMeta:          corev2.ObjectMeta{Name: "entity_labels_allow"},
+					Action:              corev2.EventFilterActionAllow,
+					EntityLabelSelector: map[string]string{"region": "us-west-2"},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "returns true when the entity labels do not match with action allow",
+			args: args{
+				ctx:   context.Background(),
+				event: labeledEvent(map[string]string{"region": "eu-west-1"}),
+				filter: &corev2.EventFilter{
+					ObjectMeta:          corev2.ObjectMeta{Name: "entity_labels_allow"},
+					Action:              corev2.EventFilterActionAllow,
+					EntityLabelSelector: map[string]string{"region": "us-west-2"},
+					Expressions:         []string{"true"},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "returns true when the entity labels match with action deny",
+			args: args{
+				ctx:   context.Background(),
+				event: labeledEvent(map[string]string{"region": "us-west-2"}),
+				filter: &corev2.EventFilter{
+					ObjectMeta:          corev2.ObjectMeta{Name: "entity_labels_deny"},
+					Action:              corev2.EventFilterActionDeny,
+					EntityLabelSelector: map[string]string{"region": "us-west-2"},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "returns false when the entity labels do not match with action deny",
+			args: args{
+				ctx:   context.Background(),
+				event: labeledEvent(nil),
+				filter: &corev2.EventFilter{
+					ObjectMeta:          corev2.ObjectMeta{Name: "entity_labels_deny"},
+					Action:              corev2.EventFilterActionDeny,
+					EntityLabelSelector: map[string]string{"region": "us-west-2"},
+					Expressions:         []string{"true"},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "evaluates the expressions when the entity labels match",
+			args: args{
+				ctx:   context.Background(),
+				event: labeledEvent(map[string]string{"region": "us-west-2"}),
+				filter: &corev2.EventFilter{
+					ObjectMeta:          corev2.ObjectMeta{Name: "entity_labels_allow"},
+					Action:              corev2.EventFilterActionAllow,
+					EntityLabelSelector: map[string]string{"region": "us-west-2"},
+					Expressions:         []string{"event.check.name == 'nope'"},
+				},
+			},
+			want: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -384,6 +452,12 @@ func Test_evaluateEventFilter(t *testing.T) {
 	}
 }
 
+func labeledEvent(labels map[string]string) *corev2.Event {
+	event := corev2.FixtureEvent("entity1", "check1")
+	event.Entity.Labels = labels
+	return event
+}
+
 func TestJavascriptStoreAccess(t *testing.T) {
 	st := new(mockstore.MockStore)
 	pipelineRoleBinding := &corev2.RoleBinding{
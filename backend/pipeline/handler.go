@@ -6,6 +6,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
 	metricspkg "github.com/sensu/sensu-go/metrics"
 )
 
@@ -32,6 +33,12 @@ type HandlerAdapter interface {
 	Handle(context.Context, *corev2.ResourceReference, *corev2.Event, []byte) error
 }
 
+// HandlerTester is implemented by handler adapters that can report the result
+// of a handler execution, rather than only whether it failed.
+type HandlerTester interface {
+	TestHandle(context.Context, *corev2.ResourceReference, *corev2.Event, []byte) (*corev2.HandlerTestResult, error)
+}
+
 func init() {
 	if err := prometheus.Register(handlerDuration); err != nil {
 		panic(fmt.Errorf("error registering %s: %s", HandlerDuration, err))
@@ -53,7 +60,25 @@ func (a *AdapterV1) processHandler(ctx context.Context, ref *corev2.ResourceRefe
 		return err
 	}
 
-	return handler.Handle(ctx, ref, event, mutatedData)
+	if a.HandlerLimiter != nil {
+		release, err := a.HandlerLimiter.Acquire(ctx, corev2.ContextNamespace(ctx), ref.Name)
+		if err == ErrHandlerQueueFull {
+			fields := event.LogFields(false)
+			fields["handler"] = ref.ResourceID()
+			logger.WithFields(fields).Warn("too many invocations of the handler are queued, dropping event")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
+
+	err = handler.Handle(ctx, ref, event, mutatedData)
+	if a.HandlerErrors != nil {
+		a.HandlerErrors.Record(corev2.ContextNamespace(ctx), ref.Name, err)
+	}
+	return err
 }
 
 func (a *AdapterV1) getHandlerAdapterForResource(ctx context.Context, ref *corev2.ResourceReference) (HandlerAdapter, error) {
@@ -64,3 +89,47 @@ func (a *AdapterV1) getHandlerAdapterForResource(ctx context.Context, ref *corev
 	}
 	return nil, fmt.Errorf("no handler adapters were found that can handle the resource: %s.%s = %s", ref.APIVersion, ref.Type, ref.Name)
 }
+
+// TestHandler runs the named handler against the given event and returns the
+// result of its execution. The event goes through the handler's mutator, but
+// its filters are bypassed so that the handler is always executed.
+func (a *AdapterV1) TestHandler(ctx context.Context, name string, event *corev2.Event) (*corev2.HandlerTestResult, error) {
+	tctx, cancel := context.WithTimeout(ctx, a.StoreTimeout)
+	handler, err := a.Store.GetHandlerByName(tctx, name)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	if handler == nil {
+		return nil, &store.ErrNotFound{Key: name}
+	}
+	if handler.Type == "set" {
+		return nil, fmt.Errorf("handler %q is a handler set and cannot be tested", name)
+	}
+
+	workflowName := fmt.Sprintf(LegacyPipelineWorkflowName, handler.Name)
+	workflow := corev2.PipelineWorkflowFromHandler(ctx, workflowName, handler)
+	if workflow.Mutator == nil {
+		workflow.Mutator = &corev2.ResourceReference{
+			APIVersion: "core/v2",
+			Type:       "Mutator",
+			Name:       "json",
+		}
+	}
+
+	mutatedData, err := a.processMutator(ctx, workflow.Mutator, event)
+	if err != nil {
+		return nil, err
+	}
+
+	adapter, err := a.getHandlerAdapterForResource(ctx, workflow.Handler)
+	if err != nil {
+		return nil, err
+	}
+	tester, ok := adapter.(HandlerTester)
+	if !ok {
+		return nil, fmt.Errorf("handler adapter %s does not support testing handlers", adapter.Name())
+	}
+
+	return tester.TestHandle(ctx, workflow.Handler, event, mutatedData)
+}
@@ -25,6 +25,10 @@ const (
 
 	// LegacyAdapterName is the name of the handler adapter.
 	LegacyAdapterName = "LegacyAdapter"
+
+	// CorrelationIDEnvVar is the environment variable through which pipe
+	// handlers receive the correlation ID of the event they handle.
+	CorrelationIDEnvVar = "SENSU_CORRELATION_ID"
 )
 
 // LegacyAdapter is a handler adapter that supports the legacy core.v2/Handler
@@ -59,6 +63,7 @@ func (l *LegacyAdapter) Handle(ctx context.Context, ref *corev2.ResourceReferenc
 	fields := utillogging.EventFields(event, false)
 	fields["pipeline"] = corev2.ContextPipeline(ctx)
 	fields["pipeline_workflow"] = corev2.ContextPipelineWorkflow(ctx)
+	fields["correlation_id"] = corev2.ContextCorrelationID(ctx)
 
 	tctx, cancel := context.WithTimeout(ctx, l.StoreTimeout)
 	handler, err := l.Store.GetHandlerByName(tctx, ref.Name)
@@ -92,6 +97,33 @@ func (l *LegacyAdapter) Handle(ctx context.Context, ref *corev2.ResourceReferenc
 	return nil
 }
 
+// TestHandle executes the referenced handler like Handle does, and returns the
+// result of its execution.
+func (l *LegacyAdapter) TestHandle(ctx context.Context, ref *corev2.ResourceReference, event *corev2.Event, mutatedData []byte) (*corev2.HandlerTestResult, error) {
+	tctx, cancel := context.WithTimeout(ctx, l.StoreTimeout)
+	handler, err := l.Store.GetHandlerByName(tctx, ref.Name)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch handler from store: %v", err)
+	}
+
+	switch handler.Type {
+	case "pipe":
+		result, err := l.pipeHandler(ctx, handler, event, mutatedData)
+		if err != nil {
+			return nil, err
+		}
+		return &corev2.HandlerTestResult{Output: result.Output, Status: result.Status}, nil
+	case "tcp", "udp":
+		if _, err := l.socketHandler(ctx, handler, event, mutatedData); err != nil {
+			return nil, err
+		}
+		return &corev2.HandlerTestResult{}, nil
+	default:
+		return nil, errors.New("unknown handler type")
+	}
+}
+
 // pipeHandler fork/executes a child process for a Sensu pipe handler command
 // and writes the mutated data to it via STDIN.
 func (l *LegacyAdapter) pipeHandler(ctx context.Context, handler *corev2.Handler, event *corev2.Event, mutatedData []byte) (*command.ExecutionResponse, error) {
@@ -103,6 +135,7 @@ func (l *LegacyAdapter) pipeHandler(ctx context.Context, handler *corev2.Handler
 	fields["handler_namespace"] = handler.Namespace
 	fields["pipeline"] = corev2.ContextPipeline(ctx)
 	fields["pipeline_workflow"] = corev2.ContextPipelineWorkflow(ctx)
+	fields["correlation_id"] = corev2.ContextCorrelationID(ctx)
 
 	if l.LicenseGetter != nil {
 		if license := l.LicenseGetter.Get(); license != "" {
@@ -120,8 +153,13 @@ func (l *LegacyAdapter) pipeHandler(ctx context.Context, handler *corev2.Handler
 		secrets = append(secrets, substituted...)
 	}
 
-	// Prepare environment variables
-	env := environment.MergeEnvironments(os.Environ(), handler.EnvVars, secrets)
+	// Prepare environment variables, including the correlation ID of the event
+	// so that handlers can pass it on
+	correlation := []string{}
+	if id := corev2.ContextCorrelationID(ctx); id != "" {
+		correlation = append(correlation, fmt.Sprintf("%s=%s", CorrelationIDEnvVar, id))
+	}
+	env := environment.MergeEnvironments(os.Environ(), handler.EnvVars, secrets, correlation)
 
 	handlerExec := command.ExecutionRequest{}
 	handlerExec.Command = handler.Command
@@ -149,7 +187,7 @@ func (l *LegacyAdapter) pipeHandler(ctx context.Context, handler *corev2.Handler
 				return nil, err
 			}
 		} else {
-			handlerExec.Env = environment.MergeEnvironments(os.Environ(), assets.Env(), handler.EnvVars, secrets)
+			handlerExec.Env = environment.MergeEnvironments(os.Environ(), assets.Env(), handler.EnvVars, secrets, correlation)
 		}
 	}
 
@@ -171,6 +209,7 @@ func (l *LegacyAdapter) socketHandler(ctx context.Context, handler *corev2.Handl
 	fields["handler_protocol"] = protocol
 	fields["pipeline"] = corev2.ContextPipeline(ctx)
 	fields["pipeline_workflow"] = corev2.ContextPipelineWorkflow(ctx)
+	fields["correlation_id"] = corev2.ContextCorrelationID(ctx)
 
 	// If Timeout is not specified, use the default.
 	if timeout == 0 {
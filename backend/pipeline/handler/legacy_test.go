@@ -267,6 +267,33 @@ func TestLegacyAdapter_pipeHandler(t *testing.T) {
 			},
 			want: command.FixtureExecutionResponse(0, ""),
 		},
+		{
+			name: "adds the correlation id of the event to envvars",
+			fields: fields{
+				Executor: func() command.Executor {
+					ex := &mockexecutor.MockExecutor{}
+					ex.SetRequestFunc(func(_ context.Context, request command.ExecutionRequest) {
+						for _, env := range request.Env {
+							if env == "SENSU_CORRELATION_ID=abc" {
+								response := command.FixtureExecutionResponse(0, "")
+								ex.UnsafeReturn(response, nil)
+								return
+							}
+						}
+						response := command.FixtureExecutionResponse(1, "")
+						ex.UnsafeReturn(response, nil)
+					})
+					return ex
+				}(),
+			},
+			args: args{
+				ctx:         context.WithValue(context.Background(), corev2.CorrelationIDKey, "abc"),
+				handler:     corev2.FixtureHandler("handler1"),
+				event:       corev2.FixtureEvent("entity1", "check1"),
+				mutatedData: []byte{},
+			},
+			want: command.FixtureExecutionResponse(0, ""),
+		},
 		{
 			name: "returns an error if secret retrieval fails",
 			fields: fields{
@@ -0,0 +1,102 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+const (
+	// HandlerConsecutiveFailures is the name of the prometheus gauge vec used
+	// to track the number of failed invocations of handlers since their last
+	// successful one.
+	HandlerConsecutiveFailures = "sensu_go_handler_consecutive_failures"
+)
+
+var handlerConsecutiveFailuresGauge = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: HandlerConsecutiveFailures,
+		Help: "The number of failed handler invocations since the last successful one",
+	},
+	[]string{"namespace", "handler"},
+)
+
+func init() {
+	if err := prometheus.Register(handlerConsecutiveFailuresGauge); err != nil {
+		panic(fmt.Errorf("error registering %s: %s", HandlerConsecutiveFailures, err))
+	}
+}
+
+// HandlerErrors records the last error of each handler, and the number of its
+// invocations that failed in a row, so that failing handlers can be spotted
+// without going through the logs. It only knows about the invocations made by
+// this backend.
+type HandlerErrors struct {
+	mu       sync.Mutex
+	statuses map[string]*corev2.HandlerStatus
+}
+
+// Record updates the status of the handler of the namespace with the result
+// of one of its invocations. A failure records err as the last error of the
+// handler, and a success resets its count of consecutive failures.
+func (h *HandlerErrors) Record(namespace, handler string, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.statuses == nil {
+		h.statuses = make(map[string]*corev2.HandlerStatus)
+	}
+	key := path.Join(namespace, handler)
+	status, ok := h.statuses[key]
+	if !ok {
+		if err == nil {
+			// Don't keep track of handlers that never failed
+			return
+		}
+		status = &corev2.HandlerStatus{Handler: handler, Namespace: namespace}
+		h.statuses[key] = status
+	}
+	if err == nil {
+		status.ConsecutiveFailures = 0
+	} else {
+		status.LastError = err.Error()
+		status.LastErrorTimestamp = time.Now().Unix()
+		status.ConsecutiveFailures++
+	}
+	handlerConsecutiveFailuresGauge.WithLabelValues(namespace, handler).Set(float64(status.ConsecutiveFailures))
+}
+
+// Status returns the status of the handler of the namespace.
+func (h *HandlerErrors) Status(namespace, handler string) *corev2.HandlerStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if status, ok := h.statuses[path.Join(namespace, handler)]; ok {
+		result := *status
+		return &result
+	}
+	return &corev2.HandlerStatus{Handler: handler, Namespace: namespace}
+}
+
+// HandlerStatus returns the error state of the named handler, in the namespace
+// of ctx. It returns an error if the handler does not exist.
+func (a *AdapterV1) HandlerStatus(ctx context.Context, name string) (*corev2.HandlerStatus, error) {
+	tctx, cancel := context.WithTimeout(ctx, a.StoreTimeout)
+	handler, err := a.Store.GetHandlerByName(tctx, name)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	if handler == nil {
+		return nil, &store.ErrNotFound{Key: name}
+	}
+	namespace := corev2.ContextNamespace(ctx)
+	if a.HandlerErrors == nil {
+		return &corev2.HandlerStatus{Handler: name, Namespace: namespace}, nil
+	}
+	return a.HandlerErrors.Status(namespace, name), nil
+}
@@ -0,0 +1,82 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/testing/mockpipeline"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandlerErrorsRecord(t *testing.T) {
+	h := &HandlerErrors{}
+
+	h.Record("default", "slack", nil)
+	if got := h.Status("default", "slack"); got.ConsecutiveFailures != 0 || got.LastError != "" {
+		t.Fatalf("handler that never failed has an error: %v", got)
+	}
+
+	h.Record("default", "slack", errors.New("connection refused"))
+	h.Record("default", "slack", errors.New("timeout"))
+	got := h.Status("default", "slack")
+	if got.ConsecutiveFailures != 2 {
+		t.Errorf("bad consecutive failures: got %d, want 2", got.ConsecutiveFailures)
+	}
+	if got.LastError != "timeout" {
+		t.Errorf("bad last error: got %q, want %q", got.LastError, "timeout")
+	}
+	if got.LastErrorTimestamp == 0 {
+		t.Error("last error timestamp is not set")
+	}
+	if other := h.Status("acme", "slack"); other.ConsecutiveFailures != 0 {
+		t.Errorf("failures were recorded in the wrong namespace: %v", other)
+	}
+
+	h.Record("default", "slack", nil)
+	got = h.Status("default", "slack")
+	if got.ConsecutiveFailures != 0 {
+		t.Errorf("success did not reset consecutive failures: got %d", got.ConsecutiveFailures)
+	}
+	if got.LastError != "timeout" {
+		t.Errorf("success cleared the last error: got %q", got.LastError)
+	}
+}
+
+func TestAdapterV1_HandlerStatus(t *testing.T) {
+	s := &mockstore.MockStore{}
+	s.On("GetHandlerByName", mock.Anything, "handler1").Return(corev2.FixtureHandler("handler1"), nil)
+	s.On("GetHandlerByName", mock.Anything, "handler2").Return((*corev2.Handler)(nil), nil)
+	handlerAdapter := &mockpipeline.HandlerAdapter{}
+	handlerAdapter.On("CanHandle", mock.Anything).Return(true)
+	handlerAdapter.On("Handle", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("handler error"))
+	a := &AdapterV1{
+		Store:           s,
+		StoreTimeout:    time.Second,
+		HandlerAdapters: []HandlerAdapter{handlerAdapter},
+		HandlerErrors:   &HandlerErrors{},
+	}
+	ctx := context.WithValue(context.Background(), corev2.NamespaceKey, "default")
+	ref := &corev2.ResourceReference{APIVersion: "core/v2", Type: "Handler", Name: "handler1"}
+	if err := a.processHandler(ctx, ref, corev2.FixtureEvent("entity1", "check1"), nil); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	status, err := a.HandlerStatus(ctx, "handler1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Namespace != "default" || status.Handler != "handler1" {
+		t.Errorf("bad handler: got %s/%s", status.Namespace, status.Handler)
+	}
+	if status.ConsecutiveFailures != 1 || status.LastError != "handler error" {
+		t.Errorf("bad status: %v", status)
+	}
+
+	if _, err := a.HandlerStatus(ctx, "handler2"); err == nil {
+		t.Error("expected an error for a missing handler")
+	}
+}
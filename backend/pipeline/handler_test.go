@@ -9,6 +9,7 @@ import (
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/testing/mockpipeline"
+	"github.com/sensu/sensu-go/testing/mockstore"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -225,3 +226,68 @@ func TestAdapterV1_getHandlerAdapterForResource(t *testing.T) {
 		})
 	}
 }
+
+func TestAdapterV1_TestHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    *corev2.Handler
+		storeErr   error
+		want       *corev2.HandlerTestResult
+		wantErrMsg string
+	}{
+		{
+			name:    "returns the handler result",
+			handler: corev2.FixtureHandler("handler1"),
+			want:    &corev2.HandlerTestResult{Output: "ok", Status: 0},
+		},
+		{
+			name:       "returns an error when the handler does not exist",
+			wantErrMsg: "key handler1 not found",
+		},
+		{
+			name:       "returns an error when the store fails",
+			storeErr:   errors.New("store error"),
+			wantErrMsg: "store error",
+		},
+		{
+			name: "returns an error for handler sets",
+			handler: func() *corev2.Handler {
+				handler := corev2.FixtureSetHandler("handler1", "handler2")
+				handler.Type = "set"
+				return handler
+			}(),
+			wantErrMsg: `handler "handler1" is a handler set and cannot be tested`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &mockstore.MockStore{}
+			s.On("GetHandlerByName", mock.Anything, "handler1").Return(tt.handler, tt.storeErr)
+			mutatorAdapter := &mockpipeline.MutatorAdapter{}
+			mutatorAdapter.On("CanMutate", mock.Anything).Return(true)
+			mutatorAdapter.On("Mutate", mock.Anything, mock.Anything, mock.Anything).Return([]byte("{}"), nil)
+			handlerAdapter := &mockpipeline.HandlerAdapter{}
+			handlerAdapter.On("CanHandle", mock.Anything).Return(true)
+			handlerAdapter.On("TestHandle", mock.Anything, mock.Anything, mock.Anything, []byte("{}")).Return(tt.want, nil)
+			a := &AdapterV1{
+				Store:           s,
+				StoreTimeout:    time.Second,
+				MutatorAdapters: []MutatorAdapter{mutatorAdapter},
+				HandlerAdapters: []HandlerAdapter{handlerAdapter},
+			}
+			got, err := a.TestHandler(context.Background(), "handler1", corev2.FixtureEvent("entity1", "check1"))
+			if tt.wantErrMsg != "" {
+				if err == nil || err.Error() != tt.wantErrMsg {
+					t.Fatalf("AdapterV1.TestHandler() error = %v, wantErrMsg %v", err, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *got != *tt.want {
+				t.Errorf("AdapterV1.TestHandler() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// HandlerDroppedInvocations is the name of the prometheus counter vec used
+	// to count the handler invocations dropped by a HandlerLimiter.
+	HandlerDroppedInvocations = "sensu_go_handler_dropped_invocations"
+)
+
+// ErrHandlerQueueFull is returned when an invocation of a handler would exceed
+// the number of invocations allowed to wait for it.
+var ErrHandlerQueueFull = errors.New("too many invocations of the handler are queued")
+
+var handlerDroppedInvocationsCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: HandlerDroppedInvocations,
+		Help: "The number of handler invocations dropped because too many were queued",
+	},
+	[]string{"handler"},
+)
+
+func init() {
+	if err := prometheus.Register(handlerDroppedInvocationsCounter); err != nil {
+		panic(fmt.Errorf("error registering %s: %s", HandlerDroppedInvocations, err))
+	}
+}
+
+// HandlerLimiter limits the number of concurrent invocations of handlers, so
+// that handlers calling rate limited services are not overwhelmed when many
+// events need handling at once. Excess invocations wait for their turn.
+type HandlerLimiter struct {
+	// Limits is the maximum number of concurrent invocations of each handler of
+	// a namespace, keyed by handler name. Handlers that are not part of Limits
+	// are not limited.
+	Limits map[string]int
+
+	// QueueSize is the maximum number of invocations of a handler, per
+	// namespace, that can wait for their turn. Any further invocation is
+	// dropped.
+	QueueSize int
+
+	mu    sync.Mutex
+	slots map[string]*handlerSlots
+}
+
+type handlerSlots struct {
+	sem    chan struct{}
+	queued int
+}
+
+// Acquire waits until the handler of the namespace can be invoked, and returns
+// a function that must be called once the invocation is over. It returns
+// ErrHandlerQueueFull, without waiting, when too many invocations of the
+// handler are already waiting.
+func (l *HandlerLimiter) Acquire(ctx context.Context, namespace, handler string) (release func(), err error) {
+	limit := l.Limits[handler]
+	if limit <= 0 {
+		return func() {}, nil
+	}
+
+	l.mu.Lock()
+	if l.slots == nil {
+		l.slots = make(map[string]*handlerSlots)
+	}
+	key := path.Join(namespace, handler)
+	slots, ok := l.slots[key]
+	if !ok {
+		slots = &handlerSlots{sem: make(chan struct{}, limit)}
+		l.slots[key] = slots
+	}
+	release = func() { <-slots.sem }
+	select {
+	case slots.sem <- struct{}{}:
+		l.mu.Unlock()
+		return release, nil
+	default:
+	}
+	if slots.queued >= l.QueueSize {
+		l.mu.Unlock()
+		handlerDroppedInvocationsCounter.WithLabelValues(handler).Inc()
+		return nil, ErrHandlerQueueFull
+	}
+	slots.queued++
+	l.mu.Unlock()
+
+	defer func() {
+		l.mu.Lock()
+		slots.queued--
+		l.mu.Unlock()
+	}()
+	select {
+	case slots.sem <- struct{}{}:
+		return release, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,80 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestHandlerLimiter(t *testing.T) {
+	limiter := &HandlerLimiter{
+		Limits:    map[string]int{"pagerduty": 1},
+		QueueSize: 1,
+	}
+	ctx := context.Background()
+
+	// Handlers without a limit are never held back
+	for i := 0; i < 3; i++ {
+		if _, err := limiter.Acquire(ctx, "default", "slack"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	release, err := limiter.Acquire(ctx, "default", "pagerduty")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Limits apply to each namespace separately
+	releaseOther, err := limiter.Acquire(ctx, "other", "pagerduty")
+	if err != nil {
+		t.Fatal(err)
+	}
+	releaseOther()
+
+	// The next invocation waits for the first one to be over
+	acquired := make(chan func())
+	go func() {
+		release, err := limiter.Acquire(ctx, "default", "pagerduty")
+		if err != nil {
+			t.Error(err)
+		}
+		acquired <- release
+	}()
+
+	// Wait for the invocation to be queued, after which any further invocation
+	// exceeds the queue bound and is dropped
+	deadline := time.Now().Add(5 * time.Second)
+	for queued := 0; queued == 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("invocation was never queued")
+		}
+		time.Sleep(time.Millisecond)
+		limiter.mu.Lock()
+		queued = limiter.slots["default/pagerduty"].queued
+		limiter.mu.Unlock()
+	}
+	if _, err := limiter.Acquire(ctx, "default", "pagerduty"); err != ErrHandlerQueueFull {
+		t.Fatalf("expected ErrHandlerQueueFull, got %v", err)
+	}
+
+	select {
+	case <-acquired:
+		t.Fatal("invocation was not held back")
+	default:
+	}
+	release()
+	(<-acquired)()
+
+	// An invocation that waits can be cancelled
+	release, err = limiter.Acquire(ctx, "default", "pagerduty")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+	cctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.Acquire(cctx, "default", "pagerduty"); err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
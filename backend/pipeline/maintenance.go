@@ -0,0 +1,116 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+const (
+	// MaintenanceSuppressed is the name of the prometheus counter vec used to
+	// count the workflows skipped because of an active maintenance window.
+	MaintenanceSuppressed = "sensu_go_pipeline_maintenance_suppressed"
+
+	// DefaultMaintenanceCacheTTL is how long maintenance windows are cached
+	// when MaintenanceWindows doesn't specify a CacheTTL.
+	DefaultMaintenanceCacheTTL = 10 * time.Second
+)
+
+var maintenanceSuppressedCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: MaintenanceSuppressed,
+		Help: "The number of event workflows suppressed by maintenance windows",
+	},
+	[]string{"maintenance_window"},
+)
+
+func init() {
+	if err := prometheus.Register(maintenanceSuppressedCounter); err != nil {
+		panic(fmt.Errorf("error registering %s: %s", MaintenanceSuppressed, err))
+	}
+}
+
+// MaintenanceWindows looks up the maintenance windows that are active at a
+// given time. Windows are read from the store at most once per CacheTTL, since
+// every event goes through the lookup.
+type MaintenanceWindows struct {
+	Store    store.ResourceStore
+	CacheTTL time.Duration
+
+	mu      sync.Mutex
+	windows []*corev2.MaintenanceWindow
+	expires time.Time
+}
+
+// Active returns the first maintenance window, by name, that is active at t,
+// or nil if there is none.
+func (m *MaintenanceWindows) Active(ctx context.Context, t time.Time) (*corev2.MaintenanceWindow, error) {
+	windows, err := m.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, window := range windows {
+		if window.IsActive(t) {
+			return window, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MaintenanceWindows) list(ctx context.Context) ([]*corev2.MaintenanceWindow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(m.expires) {
+		return m.windows, nil
+	}
+
+	// Maintenance windows are global to the cluster
+	ctx = context.WithValue(ctx, corev2.NamespaceKey, "")
+	var windows []*corev2.MaintenanceWindow
+	if err := m.Store.ListResources(ctx, corev2.MaintenanceWindowsResource, &windows, &store.SelectionPredicate{}); err != nil {
+		return nil, err
+	}
+
+	ttl := m.CacheTTL
+	if ttl <= 0 {
+		ttl = DefaultMaintenanceCacheTTL
+	}
+	m.windows = windows
+	m.expires = now.Add(ttl)
+	return windows, nil
+}
+
+// activeMaintenanceWindow returns the maintenance window that is active at the
+// time of the event, if any. Windows that cannot be retrieved are considered
+// inactive, so that events still get handled.
+func (a *AdapterV1) activeMaintenanceWindow(ctx context.Context, event *corev2.Event) *corev2.MaintenanceWindow {
+	if a.Maintenance == nil {
+		return nil
+	}
+	at := time.Now()
+	if event.Timestamp > 0 {
+		at = time.Unix(event.Timestamp, 0)
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, a.StoreTimeout)
+	defer cancel()
+	window, err := a.Maintenance.Active(tctx, at)
+	if err != nil {
+		logger.WithError(err).Warn("could not retrieve the maintenance windows, handling event")
+		return nil
+	}
+	return window
+}
+
+// runsDuringMaintenance returns true if the referenced handler is a core/v2
+// handler annotated with corev2.RunDuringMaintenanceAnnotation.
+func (a *AdapterV1) runsDuringMaintenance(ctx context.Context, ref *corev2.ResourceReference) bool {
+	return a.handlerAnnotated(ctx, ref, corev2.RunDuringMaintenanceAnnotation)
+}
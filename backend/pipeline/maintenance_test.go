@@ -0,0 +1,138 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/pipeline/mutator"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMaintenanceWindowsActive(t *testing.T) {
+	now := time.Now()
+	past := corev2.FixtureMaintenanceWindow("past", now.Add(-2*time.Hour).Unix())
+	current := corev2.FixtureMaintenanceWindow("current", now.Add(-time.Minute).Unix())
+
+	stor := &mockstore.MockStore{}
+	stor.On("ListResources", mock.Anything, corev2.MaintenanceWindowsResource, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		if ns := corev2.ContextNamespace(args.Get(0).(context.Context)); ns != "" {
+			t.Errorf("maintenance windows listed in namespace %q", ns)
+		}
+		arg := args.Get(2).(*[]*corev2.MaintenanceWindow)
+		*arg = []*corev2.MaintenanceWindow{current, past}
+	}).Return(nil).Once()
+
+	m := &MaintenanceWindows{Store: stor, CacheTTL: time.Hour}
+	ctx := context.WithValue(context.Background(), corev2.NamespaceKey, "default")
+	window, err := m.Active(ctx, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if window != current {
+		t.Errorf("got window %v, want %v", window, current)
+	}
+
+	// The windows are cached, so that the store is only listed once
+	window, err = m.Active(ctx, now.Add(-90*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if window != past {
+		t.Errorf("got window %v, want %v", window, past)
+	}
+	window, err = m.Active(ctx, now.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if window != nil {
+		t.Errorf("got window %v, want none", window)
+	}
+	stor.AssertExpectations(t)
+}
+
+func TestAdapterV1_RunDuringMaintenance(t *testing.T) {
+	handlerRef := func(name string) *corev2.ResourceReference {
+		return &corev2.ResourceReference{
+			APIVersion: "core/v2",
+			Type:       "Handler",
+			Name:       name,
+		}
+	}
+	pipeline := &corev2.Pipeline{
+		ObjectMeta: corev2.NewObjectMeta("pipeline1", "default"),
+		Workflows: []*corev2.PipelineWorkflow{
+			{Name: "workflow1", Handler: handlerRef("handler1")},
+			{Name: "workflow2", Handler: handlerRef("handler2")},
+		},
+	}
+	handler1 := corev2.FixtureHandler("handler1")
+	handler2 := corev2.FixtureHandler("handler2")
+	handler2.Annotations = map[string]string{corev2.RunDuringMaintenanceAnnotation: "true"}
+
+	event := corev2.FixtureEvent("entity1", "check1")
+	window := corev2.FixtureMaintenanceWindow("window", event.Timestamp-60)
+
+	tests := []struct {
+		name      string
+		windows   []*corev2.MaintenanceWindow
+		listErr   error
+		timestamp int64
+		wantCount int
+	}{
+		{
+			name:      "events run every handler outside of maintenance windows",
+			timestamp: event.Timestamp + 3600,
+			windows:   []*corev2.MaintenanceWindow{window},
+			wantCount: 2,
+		},
+		{
+			name:      "events during maintenance windows only run handlers marked to run anyway",
+			timestamp: event.Timestamp,
+			windows:   []*corev2.MaintenanceWindow{window},
+			wantCount: 1,
+		},
+		{
+			name:      "events run every handler when windows cannot be retrieved",
+			timestamp: event.Timestamp,
+			listErr:   errors.New("error"),
+			wantCount: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stor := &mockstore.MockStore{}
+			stor.On("GetPipelineByName", mock.Anything, "pipeline1").Return(pipeline, nil)
+			stor.On("GetHandlerByName", mock.Anything, "handler1").Return(handler1, nil)
+			stor.On("GetHandlerByName", mock.Anything, "handler2").Return(handler2, nil)
+			stor.On("ListResources", mock.Anything, corev2.MaintenanceWindowsResource, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*[]*corev2.MaintenanceWindow)
+				*arg = tt.windows
+			}).Return(tt.listErr)
+
+			var count int
+			a := &AdapterV1{
+				Store:        stor,
+				StoreTimeout: time.Second,
+				MutatorAdapters: []MutatorAdapter{
+					&mutator.JSONAdapter{},
+				},
+				HandlerAdapters: []HandlerAdapter{
+					countingHandlerAdapter{Count: &count},
+				},
+				Maintenance: &MaintenanceWindows{Store: stor},
+			}
+			event := corev2.FixtureEvent("entity1", "check1")
+			event.Timestamp = tt.timestamp
+			if err := a.Run(context.Background(), corev2.FixturePipelineReference("pipeline1"), event); err != nil {
+				t.Fatal(err)
+			}
+			if count != tt.wantCount {
+				t.Errorf("handlers ran %d times, want %d", count, tt.wantCount)
+			}
+		})
+	}
+}
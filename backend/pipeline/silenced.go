@@ -0,0 +1,37 @@
+package pipeline
+
+import (
+	"context"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// runsWhenSilenced returns true if the referenced handler is a core/v2 handler
+// annotated with corev2.RunWhenSilencedAnnotation.
+func (a *AdapterV1) runsWhenSilenced(ctx context.Context, ref *corev2.ResourceReference) bool {
+	return a.handlerAnnotated(ctx, ref, corev2.RunWhenSilencedAnnotation)
+}
+
+// handlerAnnotated returns true if the referenced handler is a core/v2 handler
+// with the annotation set to "true". Handlers that cannot be retrieved are
+// considered unmarked, and are skipped.
+func (a *AdapterV1) handlerAnnotated(ctx context.Context, ref *corev2.ResourceReference, annotation string) bool {
+	if ref == nil || ref.APIVersion != "core/v2" || ref.Type != "Handler" {
+		return false
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, a.StoreTimeout)
+	defer cancel()
+	handler, err := a.Store.GetHandlerByName(tctx, ref.Name)
+	if err != nil {
+		logger.WithError(err).WithFields(map[string]interface{}{
+			"handler":    ref.ResourceID(),
+			"annotation": annotation,
+		}).Warn("could not retrieve the handler, skipping it")
+		return false
+	}
+	if handler == nil {
+		return false
+	}
+	return handler.Annotations[annotation] == "true"
+}
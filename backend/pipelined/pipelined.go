@@ -8,6 +8,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/prometheus/client_golang/prometheus"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/messaging"
@@ -29,6 +30,8 @@ const (
 var (
 	defaultStoreTimeout = time.Minute
 
+	defaultHealthInterval = 30 * time.Second
+
 	messageHandlerDuration = prometheus.NewSummaryVec(
 		prometheus.SummaryOpts{
 			Name:       MessageHandlerDuration,
@@ -55,6 +58,17 @@ type Pipelined struct {
 	store        store.Store
 	storeTimeout time.Duration
 	adapters     []pipeline.Adapter
+
+	// healthInterval is the time within which a queued event must be picked
+	// up by a worker for pipelined to be healthy
+	healthInterval time.Duration
+
+	// lastDequeue is the time, in nanoseconds since the epoch, at which a
+	// worker last picked up an event
+	lastDequeue atomic.Int64
+
+	// defaultHandler is the name of the handler of checks without handlers
+	defaultHandler string
 }
 
 // Config configures a Pipelined.
@@ -64,6 +78,14 @@ type Config struct {
 	Store        store.Store
 	StoreTimeout time.Duration
 	WorkerCount  int
+
+	// HealthInterval is the time within which queued events must start being
+	// processed for pipelined to report itself as healthy.
+	HealthInterval time.Duration
+
+	// DefaultHandler is the name of the handler that handles the events of
+	// checks without handlers, see pipeline.AdapterV1.DefaultHandler.
+	DefaultHandler string
 }
 
 // Option is a functional option used to configure Pipelined.
@@ -90,17 +112,22 @@ func New(c Config, options ...Option) (*Pipelined, error) {
 		logger.Warn("StoreTimeout not configured")
 		c.StoreTimeout = defaultStoreTimeout
 	}
+	if c.HealthInterval == 0 {
+		c.HealthInterval = defaultHealthInterval
+	}
 
 	p := &Pipelined{
-		bus:          c.Bus,
-		stopping:     make(chan struct{}, 1),
-		running:      &atomic.Value{},
-		wg:           &sync.WaitGroup{},
-		errChan:      make(chan error, 1),
-		eventChan:    make(chan interface{}, c.BufferSize),
-		workerCount:  c.WorkerCount,
-		store:        c.Store,
-		storeTimeout: c.StoreTimeout,
+		bus:            c.Bus,
+		stopping:       make(chan struct{}, 1),
+		running:        &atomic.Value{},
+		wg:             &sync.WaitGroup{},
+		errChan:        make(chan error, 1),
+		eventChan:      make(chan interface{}, c.BufferSize),
+		workerCount:    c.WorkerCount,
+		store:          c.Store,
+		storeTimeout:   c.StoreTimeout,
+		healthInterval: c.HealthInterval,
+		defaultHandler: c.DefaultHandler,
 	}
 	for _, o := range options {
 		if err := o(p); err != nil {
@@ -133,6 +160,8 @@ func (p *Pipelined) Start() error {
 	}
 	p.subscription = sub
 
+	p.lastDequeue.Store(time.Now().UnixNano())
+	p.running.Store(true)
 	p.createWorkers(p.workerCount, p.eventChan)
 
 	return nil
@@ -150,6 +179,24 @@ func (p *Pipelined) Stop() error {
 	return err
 }
 
+// Healthy reports whether pipelined is subscribed to events and consuming
+// them. It is unhealthy once stopped, once its subscription to events is no
+// longer active, such as when the bus was stopped, or when queued events have
+// not been picked up by any worker within the health interval.
+func (p *Pipelined) Healthy() bool {
+	if running, _ := p.running.Load().(bool); !running {
+		return false
+	}
+	if !p.subscription.Active() {
+		return false
+	}
+	if len(p.eventChan) == 0 {
+		return true
+	}
+	lastDequeue := time.Unix(0, p.lastDequeue.Load())
+	return time.Since(lastDequeue) < p.healthInterval
+}
+
 // Err returns a channel to listen for terminal errors on.
 func (p *Pipelined) Err() <-chan error {
 	return p.errChan
@@ -177,6 +224,7 @@ func (p *Pipelined) createWorkers(count int, channel chan interface{}) {
 				case <-p.stopping:
 					return
 				case msg := <-channel:
+					p.lastDequeue.Store(time.Now().UnixNano())
 					if _, err := p.handleMessage(context.Background(), msg); err != nil {
 						if _, ok := err.(*store.ErrInternal); ok {
 							select {
@@ -220,7 +268,15 @@ func (p *Pipelined) handleMessage(ctx context.Context, msg interface{}) (hadPipe
 	// Add a legacy pipeline "reference" if msg is a
 	// corev2.Event & has handlers.
 	if event, ok := msg.(*corev2.Event); ok {
-		if event.HasHandlers() {
+		// The correlation ID follows the event through every pipeline
+		correlationID := event.CorrelationID()
+		if correlationID == "" {
+			correlationID = uuid.New().String()
+		}
+		ctx = context.WithValue(ctx, corev2.CorrelationIDKey, correlationID)
+		fields["correlation_id"] = correlationID
+
+		if event.HasHandlers() || (p.defaultHandler != "" && event.HasCheck()) {
 			pipelineRefs = append(pipelineRefs, pipeline.LegacyPipelineReference())
 		} else {
 			logger.WithFields(fields).Debug("event has no handlers defined, skipping addition of legacy pipeline reference")
@@ -232,6 +288,9 @@ func (p *Pipelined) handleMessage(ctx context.Context, msg interface{}) (hadPipe
 		return false, nil
 	}
 
+	// Pipelines that share a handler must not run it twice for the same event
+	ctx = pipeline.WithHandlerDedup(ctx)
+
 	// loop through list of pipeline references and find
 	// adapters that can run each of them.
 	for _, ref := range pipelineRefs {
@@ -2,11 +2,18 @@
 package pipelined
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/messaging"
+	"github.com/sensu/sensu-go/backend/pipeline"
+	"github.com/sensu/sensu-go/backend/pipeline/mutator"
+	"github.com/sensu/sensu-go/backend/pipelined/pipelinedtest"
+	"github.com/sensu/sensu-go/testing/mockstore"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -17,15 +24,169 @@ func TestPipelined(t *testing.T) {
 
 	p, err := New(Config{Bus: bus})
 	require.NoError(t, err)
+	recorder := pipelinedtest.NewRecordingHandler()
+	p.AddAdapter(recorder)
 	require.NoError(t, p.Start())
 
+	for status := uint32(0); status < 2; status++ {
+		// Published events are shared with pipelined, so each one is new
+		event := corev2.FixtureEvent("entity1", "check1")
+		event.Check.Handlers = []string{"recorder"}
+		event.Check.Status = status
+		event.Metrics = corev2.FixtureMetrics()
+		assert.NoError(t, bus.Publish(messaging.TopicEvent, event))
+	}
+
+	events, err := recorder.WaitForEvents(2, 5*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0), events[0].Check.Status)
+	assert.Equal(t, uint32(1), events[1].Check.Status)
+	assert.NotNil(t, events[0].Metrics)
+
+	assert.NoError(t, p.Stop())
+}
+
+type countingHandlerAdapter struct {
+	calls map[string]int
+}
+
+func (countingHandlerAdapter) Name() string {
+	return "counting_handler_adapter"
+}
+
+func (countingHandlerAdapter) CanHandle(*corev2.ResourceReference) bool {
+	return true
+}
+
+func (c countingHandlerAdapter) Handle(ctx context.Context, ref *corev2.ResourceReference, event *corev2.Event, data []byte) error {
+	c.calls[ref.Name]++
+	return nil
+}
+
+func TestPipelinedHandlerDedup(t *testing.T) {
+	workflow := func(name, handler string) *corev2.PipelineWorkflow {
+		return &corev2.PipelineWorkflow{
+			Name: name,
+			Handler: &corev2.ResourceReference{
+				APIVersion: "core/v2",
+				Type:       "Handler",
+				Name:       handler,
+			},
+		}
+	}
+	pipeline1 := &corev2.Pipeline{
+		ObjectMeta: corev2.NewObjectMeta("pipeline1", "default"),
+		Workflows:  []*corev2.PipelineWorkflow{workflow("page", "pagerduty"), workflow("chat", "slack")},
+	}
+	pipeline2 := &corev2.Pipeline{
+		ObjectMeta: corev2.NewObjectMeta("pipeline2", "default"),
+		Workflows:  []*corev2.PipelineWorkflow{workflow("page", "pagerduty")},
+	}
+	stor := &mockstore.MockStore{}
+	stor.On("GetPipelineByName", mock.Anything, "pipeline1").Return(pipeline1, nil)
+	stor.On("GetPipelineByName", mock.Anything, "pipeline2").Return(pipeline2, nil)
+
+	handlerAdapter := countingHandlerAdapter{calls: map[string]int{}}
+	p, err := New(Config{Store: stor})
+	require.NoError(t, err)
+	p.AddAdapter(&pipeline.AdapterV1{
+		Store:           stor,
+		StoreTimeout:    time.Second,
+		MutatorAdapters: []pipeline.MutatorAdapter{&mutator.JSONAdapter{}},
+		HandlerAdapters: []pipeline.HandlerAdapter{handlerAdapter},
+	})
+
 	event := corev2.FixtureEvent("entity1", "check1")
-	event.Metrics = corev2.FixtureMetrics()
+	event.Check.Handlers = nil
+	event.Pipelines = []*corev2.ResourceReference{
+		corev2.FixturePipelineReference("pipeline1"),
+		corev2.FixturePipelineReference("pipeline2"),
+	}
 
-	assert.NoError(t, bus.Publish(messaging.TopicEvent, event))
+	_, err = p.handleMessage(context.Background(), event)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"pagerduty": 1, "slack": 1}, handlerAdapter.calls)
 
-	event.Check.Status = 1
-	assert.NoError(t, bus.Publish(messaging.TopicEvent, event))
+	// The deduplication is scoped to the processing of a single event
+	_, err = p.handleMessage(context.Background(), event)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"pagerduty": 2, "slack": 2}, handlerAdapter.calls)
+}
 
-	assert.NoError(t, p.Stop())
+func TestPipelinedDefaultHandler(t *testing.T) {
+	stor := &mockstore.MockStore{}
+	stor.On("GetHandlerByName", mock.Anything, "team-defaults").Return(corev2.FixtureHandler("team-defaults"), nil)
+
+	handlerAdapter := countingHandlerAdapter{calls: map[string]int{}}
+	p, err := New(Config{Store: stor, DefaultHandler: "team-defaults"})
+	require.NoError(t, err)
+	p.AddAdapter(&pipeline.AdapterV1{
+		Store:           stor,
+		StoreTimeout:    time.Second,
+		MutatorAdapters: []pipeline.MutatorAdapter{&mutator.JSONAdapter{}},
+		HandlerAdapters: []pipeline.HandlerAdapter{handlerAdapter},
+		DefaultHandler:  "team-defaults",
+	})
+
+	event := corev2.FixtureEvent("entity1", "check1")
+	event.Check.Handlers = nil
+
+	hadPipelines, err := p.handleMessage(context.Background(), event)
+	require.NoError(t, err)
+	assert.True(t, hadPipelines)
+	assert.Equal(t, map[string]int{"team-defaults": 1}, handlerAdapter.calls)
+}
+
+func TestPipelinedHealthy(t *testing.T) {
+	bus, err := messaging.NewWizardBus(messaging.WizardBusConfig{})
+	require.NoError(t, err)
+	require.NoError(t, bus.Start())
+
+	p, err := New(Config{Bus: bus, HealthInterval: time.Minute})
+	require.NoError(t, err)
+	assert.False(t, p.Healthy(), "healthy before start")
+
+	require.NoError(t, p.Start())
+	assert.True(t, p.Healthy(), "unhealthy after start")
+
+	require.NoError(t, p.Stop())
+	assert.False(t, p.Healthy(), "healthy after stop")
+}
+
+func TestPipelinedUnhealthyWithoutSubscription(t *testing.T) {
+	bus, err := messaging.NewWizardBus(messaging.WizardBusConfig{})
+	require.NoError(t, err)
+	require.NoError(t, bus.Start())
+
+	p, err := New(Config{Bus: bus, HealthInterval: time.Minute})
+	require.NoError(t, err)
+	require.NoError(t, p.Start())
+	defer func() { _ = p.Stop() }()
+
+	// A stopped bus delivers nothing, although nothing is queued
+	require.NoError(t, bus.Stop())
+	assert.False(t, p.Healthy(), "healthy without an active subscription")
+}
+
+func TestPipelinedUnhealthyWhenStalled(t *testing.T) {
+	bus, err := messaging.NewWizardBus(messaging.WizardBusConfig{})
+	require.NoError(t, err)
+	require.NoError(t, bus.Start())
+
+	p, err := New(Config{Bus: bus, HealthInterval: time.Minute})
+	require.NoError(t, err)
+
+	// Simulate workers that have been stuck for longer than the health
+	// interval while events are queued
+	p.subscription, err = bus.Subscribe(messaging.TopicEvent, "pipelined", p)
+	require.NoError(t, err)
+	p.running.Store(true)
+	p.lastDequeue.Store(time.Now().Add(-2 * time.Minute).UnixNano())
+	assert.True(t, p.Healthy(), "unhealthy without queued events")
+
+	p.eventChan <- corev2.FixtureEvent("entity1", "check1")
+	assert.False(t, p.Healthy(), "healthy with stalled workers")
+
+	p.lastDequeue.Store(time.Now().UnixNano())
+	assert.True(t, p.Healthy(), "unhealthy with active workers")
 }
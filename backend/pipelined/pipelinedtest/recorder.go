@@ -0,0 +1,105 @@
+// Package pipelinedtest provides helpers to test the event pipeline.
+package pipelinedtest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// RecordingHandler records every event it receives, in the order they are
+// received, so that tests can assert on them once they have been published.
+// It can be added to pipelined as a pipeline adapter, in which case it runs
+// every pipeline, or to a pipeline.AdapterV1 as a handler adapter, in which
+// case it handles every handler.
+//
+// It is safe for concurrent use.
+type RecordingHandler struct {
+	mu     sync.Mutex
+	events []*corev2.Event
+
+	// received is closed, and replaced, every time an event is received
+	received chan struct{}
+}
+
+// NewRecordingHandler returns a RecordingHandler that hasn't received any
+// event.
+func NewRecordingHandler() *RecordingHandler {
+	return &RecordingHandler{
+		received: make(chan struct{}),
+	}
+}
+
+// Name returns the name of the handler.
+func (h *RecordingHandler) Name() string {
+	return "recording_handler"
+}
+
+// CanRun returns true for every pipeline.
+func (h *RecordingHandler) CanRun(*corev2.ResourceReference) bool {
+	return true
+}
+
+// Run records the event, and ignores other resources.
+func (h *RecordingHandler) Run(ctx context.Context, ref *corev2.ResourceReference, resource interface{}) error {
+	if event, ok := resource.(*corev2.Event); ok {
+		h.record(event)
+	}
+	return nil
+}
+
+// CanHandle returns true for every handler.
+func (h *RecordingHandler) CanHandle(*corev2.ResourceReference) bool {
+	return true
+}
+
+// Handle records the event.
+func (h *RecordingHandler) Handle(ctx context.Context, ref *corev2.ResourceReference, event *corev2.Event, mutatedData []byte) error {
+	h.record(event)
+	return nil
+}
+
+func (h *RecordingHandler) record(event *corev2.Event) {
+	// Events are often modified and published again by tests, so a copy of
+	// them is recorded
+	event = proto.Clone(event).(*corev2.Event)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, event)
+	close(h.received)
+	h.received = make(chan struct{})
+}
+
+// Events returns the events received so far.
+func (h *RecordingHandler) Events() []*corev2.Event {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]*corev2.Event(nil), h.events...)
+}
+
+// WaitForEvents waits until at least n events have been received, and returns
+// them. It returns an error if they haven't been received within timeout.
+func (h *RecordingHandler) WaitForEvents(n int, timeout time.Duration) ([]*corev2.Event, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		h.mu.Lock()
+		events := append([]*corev2.Event(nil), h.events...)
+		received := h.received
+		h.mu.Unlock()
+
+		if len(events) >= n {
+			return events, nil
+		}
+		select {
+		case <-received:
+		case <-timer.C:
+			return events, fmt.Errorf("received %d events within %s, expected %d", len(events), timeout, n)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package pipelinedtest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+func TestRecordingHandler(t *testing.T) {
+	h := NewRecordingHandler()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			event := corev2.FixtureEvent(fmt.Sprintf("entity%d", i), "check1")
+			if err := h.Handle(context.Background(), nil, event, nil); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+
+	events, err := h.WaitForEvents(10, 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(events), 10; got != want {
+		t.Errorf("bad number of events: got %d, want %d", got, want)
+	}
+	wg.Wait()
+}
+
+func TestRecordingHandlerCopiesEvents(t *testing.T) {
+	h := NewRecordingHandler()
+	event := corev2.FixtureEvent("entity1", "check1")
+	if err := h.Run(context.Background(), nil, event); err != nil {
+		t.Fatal(err)
+	}
+	event.Check.Status = 2
+
+	events := h.Events()
+	if len(events) != 1 {
+		t.Fatalf("bad number of events: got %d, want 1", len(events))
+	}
+	if got := events[0].Check.Status; got != 0 {
+		t.Errorf("the recorded event was modified: status %d", got)
+	}
+}
+
+func TestRecordingHandlerTimeout(t *testing.T) {
+	h := NewRecordingHandler()
+	if err := h.Run(context.Background(), nil, corev2.FixtureEvent("entity1", "check1")); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := h.WaitForEvents(2, 10*time.Millisecond)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(events) != 1 {
+		t.Errorf("bad number of events: got %d, want 1", len(events))
+	}
+}
@@ -0,0 +1,64 @@
+package reaperd
+
+import (
+	"context"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sirupsen/logrus"
+)
+
+// Purger permanently deletes the resources that were marked for deletion
+// before a given time, whether or not their finalizers were removed, so that
+// resources blocked by a finalizer that is never cleared do not outlive a
+// data retention policy.
+type Purger struct {
+	Store store.ResourceStore
+
+	// Resources are the types of resources to purge. They default to
+	// DefaultResources.
+	Resources []corev2.Resource
+}
+
+// Purge deletes the resources of every purged type, across all namespaces,
+// that were marked for deletion before before, and returns how many were
+// deleted by type. With dryRun, nothing is deleted, and the counts are those
+// of the resources that would be. Resources modified since they were listed
+// are left for a later purge.
+func (p *Purger) Purge(ctx context.Context, before time.Time, dryRun bool) (map[string]int, error) {
+	resources := p.Resources
+	if resources == nil {
+		resources = DefaultResources
+	}
+	counts := make(map[string]int, len(resources))
+	for _, kind := range resources {
+		expired, err := listMarked(ctx, p.Store, kind, func(meta *corev2.ObjectMeta) bool {
+			return meta.DeletedAt < before.Unix()
+		})
+		if err != nil {
+			return nil, err
+		}
+		purged := 0
+		for _, resource := range expired {
+			if !dryRun {
+				deleted, err := deleteUnchanged(ctx, p.Store, kind, resource)
+				if err != nil {
+					return nil, err
+				}
+				if !deleted {
+					continue
+				}
+				meta := resource.GetObjectMeta()
+				logger.WithFields(logrus.Fields{
+					"resource":  kind.RBACName(),
+					"namespace": meta.Namespace,
+					"name":      meta.Name,
+				}).Info("purged resource marked for deletion")
+			}
+			purged++
+		}
+		counts[kind.RBACName()] = purged
+	}
+	return counts, nil
+}
@@ -0,0 +1,56 @@
+package reaperd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurge(t *testing.T) {
+	now := time.Now()
+	live := corev2.FixtureCheckConfig("live")
+	recent := corev2.FixtureCheckConfig("recent")
+	recent.DeletedAt = now.Unix()
+	expired := corev2.FixtureCheckConfig("expired")
+	expired.Namespace = "dev"
+	expired.Finalizers = []string{"cleanup"}
+	expired.DeletedAt = now.Add(-48 * time.Hour).Unix()
+	gone := corev2.FixtureCheckConfig("gone")
+	gone.DeletedAt = now.Add(-48 * time.Hour).Unix()
+
+	for _, dryRun := range []bool{false, true} {
+		stor := &mockstore.MockStore{}
+		stor.On("ListResources", mock.Anything, "checks", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			list := args.Get(2).(*[]*corev2.CheckConfig)
+			*list = []*corev2.CheckConfig{live, recent, expired, gone}
+		}).Return(nil)
+		etag, err := store.ETag(expired)
+		require.NoError(t, err)
+		var deleted []string
+		stor.On("DeleteResourceIfMatch", mock.Anything, mock.AnythingOfType("*v2.CheckConfig"), "expired", etag).Run(func(args mock.Arguments) {
+			assert.Equal(t, "dev", corev2.ContextNamespace(args.Get(0).(context.Context)))
+			deleted = append(deleted, args.String(2))
+		}).Return(nil)
+		// The resource was deleted by another backend, or modified, since it
+		// was listed
+		stor.On("DeleteResourceIfMatch", mock.Anything, mock.Anything, "gone", mock.Anything).Return(&store.ErrPreconditionFailed{})
+
+		p := &Purger{Store: stor, Resources: []corev2.Resource{&corev2.CheckConfig{}}}
+		counts, err := p.Purge(context.Background(), now.Add(-24*time.Hour), dryRun)
+		require.NoError(t, err)
+		if dryRun {
+			assert.Equal(t, map[string]int{"checks": 2}, counts)
+			stor.AssertNotCalled(t, "DeleteResourceIfMatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			continue
+		}
+		assert.Equal(t, map[string]int{"checks": 1}, counts)
+		assert.Equal(t, []string{"expired"}, deleted)
+	}
+}
@@ -0,0 +1,234 @@
+// Package reaperd deletes the resources that were marked for deletion once
+// their finalizers are removed.
+package reaperd
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/leader"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sirupsen/logrus"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const (
+	// componentName identifies Reaperd as the component/daemon implemented in
+	// this package.
+	componentName = "reaperd"
+
+	// defaultInterval is the default interval at which resources are swept.
+	defaultInterval = time.Minute
+
+	// resourcesPageSize is the number of resources listed at once.
+	resourcesPageSize = 500
+
+	// leaderLockKey is the key of the lock held by the backend that sweeps
+	// resources.
+	leaderLockKey = ".reaperd.lock"
+)
+
+// DefaultResources are the types of resources swept by default, which are the
+// core/v2 resources deleted by the generic API handlers.
+var DefaultResources = []corev2.Resource{
+	&corev2.APIKey{},
+	&corev2.Asset{},
+	&corev2.CheckConfig{},
+	&corev2.ClusterRole{},
+	&corev2.ClusterRoleBinding{},
+	&corev2.EventFilter{},
+	&corev2.Handler{},
+	&corev2.HookConfig{},
+	&corev2.MaintenanceWindow{},
+	&corev2.Mutator{},
+	&corev2.Pipeline{},
+	&corev2.Role{},
+	&corev2.RoleBinding{},
+	&corev2.Silenced{},
+}
+
+// Reaperd is the daemon that deletes the resources marked for deletion whose
+// finalizers have all been removed. Only one backend of the cluster, the one
+// holding the lock of the daemon, sweeps resources at any given time.
+type Reaperd struct {
+	store     store.ResourceStore
+	leader    leader.Leader
+	resources []corev2.Resource
+	interval  time.Duration
+	ctx       context.Context
+	cancel    context.CancelFunc
+	errChan   chan error
+	wg        sync.WaitGroup
+}
+
+// Config configures Reaperd.
+type Config struct {
+	Store  store.ResourceStore
+	Client *clientv3.Client
+
+	// Resources are the types of resources to sweep. They default to
+	// DefaultResources.
+	Resources []corev2.Resource
+
+	// Interval is the interval at which resources are swept. It defaults to
+	// one minute.
+	Interval time.Duration
+}
+
+// New creates a new Reaperd.
+func New(ctx context.Context, c Config) (*Reaperd, error) {
+	if c.Interval == 0 {
+		c.Interval = defaultInterval
+	}
+	if c.Resources == nil {
+		c.Resources = DefaultResources
+	}
+	r := &Reaperd{
+		store:     c.Store,
+		leader:    &leader.Etcd{Client: c.Client, Key: leaderLockKey, Component: "reaper"},
+		resources: c.Resources,
+		interval:  c.Interval,
+		errChan:   make(chan error, 1),
+	}
+	r.ctx, r.cancel = context.WithCancel(ctx)
+	return r, nil
+}
+
+// Start the daemon.
+func (r *Reaperd) Start() error {
+	r.wg.Add(1)
+	go r.run()
+	return nil
+}
+
+// Stop the daemon, giving up its leadership.
+func (r *Reaperd) Stop() error {
+	r.cancel()
+	r.wg.Wait()
+	return r.leader.Close()
+}
+
+// Err returns a channel on which to listen for terminal errors.
+func (r *Reaperd) Err() <-chan error {
+	return r.errChan
+}
+
+// Name returns the daemon name.
+func (r *Reaperd) Name() string {
+	return componentName
+}
+
+func (r *Reaperd) run() {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+			r.sweep(r.ctx)
+		}
+	}
+}
+
+// sweep deletes the resources of every swept type that are marked for
+// deletion and have no finalizers left, if this backend is the leader.
+func (r *Reaperd) sweep(ctx context.Context) {
+	isLeader, err := r.leader.Lead(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.WithError(err).Error("error electing the backend that deletes finalized resources")
+		}
+		return
+	}
+	if !isLeader {
+		logger.Debug("another backend is deleting finalized resources")
+		return
+	}
+	for _, kind := range r.resources {
+		if err := r.sweepKind(ctx, kind); err != nil && ctx.Err() == nil {
+			logger.WithError(err).WithField("resource", kind.RBACName()).Error("error deleting finalized resources")
+		}
+	}
+}
+
+// sweepKind lists the resources of the type of kind across all namespaces,
+// and deletes the finalized ones, unless they were modified after they were
+// listed.
+func (r *Reaperd) sweepKind(ctx context.Context, kind corev2.Resource) error {
+	finalized, err := listMarked(ctx, r.store, kind, func(meta *corev2.ObjectMeta) bool {
+		return len(meta.Finalizers) == 0
+	})
+	if err != nil {
+		return err
+	}
+	for _, resource := range finalized {
+		deleted, err := deleteUnchanged(ctx, r.store, kind, resource)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			continue
+		}
+		meta := resource.GetObjectMeta()
+		logger.WithFields(logrus.Fields{
+			"resource":  kind.RBACName(),
+			"namespace": meta.Namespace,
+			"name":      meta.Name,
+		}).Info("deleted finalized resource")
+	}
+	return nil
+}
+
+// listMarked lists the resources of the type of kind, across all namespaces,
+// that are marked for deletion and selected by keep. There is no index of
+// the deletion times, so every resource of the type is read.
+func listMarked(ctx context.Context, s store.ResourceStore, kind corev2.Resource, keep func(*corev2.ObjectMeta) bool) ([]corev2.Resource, error) {
+	// An empty namespace lists the resources of all the namespaces
+	listCtx := context.WithValue(ctx, corev2.NamespaceKey, "")
+
+	var marked []corev2.Resource
+	pred := &store.SelectionPredicate{Limit: resourcesPageSize}
+	for {
+		page := reflect.New(reflect.SliceOf(reflect.TypeOf(kind)))
+		if err := s.ListResources(listCtx, kind.StorePrefix(), page.Interface(), pred); err != nil {
+			return nil, err
+		}
+		for i := 0; i < page.Elem().Len(); i++ {
+			resource := page.Elem().Index(i).Interface().(corev2.Resource)
+			meta := resource.GetObjectMeta()
+			if meta.DeletedAt != 0 && keep(&meta) {
+				marked = append(marked, resource)
+			}
+		}
+		if pred.Continue == "" {
+			break
+		}
+	}
+	return marked, nil
+}
+
+// deleteUnchanged deletes resource, of the type of kind, unless it was
+// modified or deleted since it was listed, in which case it returns false.
+func deleteUnchanged(ctx context.Context, s store.ResourceStore, kind, resource corev2.Resource) (bool, error) {
+	meta := resource.GetObjectMeta()
+	etag, err := store.ETag(resource)
+	if err != nil {
+		return false, err
+	}
+	deleteCtx := context.WithValue(ctx, corev2.NamespaceKey, meta.Namespace)
+	stored := reflect.New(reflect.TypeOf(kind).Elem()).Interface().(corev2.Resource)
+	if err := s.DeleteResourceIfMatch(deleteCtx, stored, meta.Name, etag); err != nil {
+		if _, ok := err.(*store.ErrPreconditionFailed); ok {
+			// The resource was deleted, or a finalizer was added, since it
+			// was listed
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
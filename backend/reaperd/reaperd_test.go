@@ -0,0 +1,88 @@
+package reaperd
+
+import (
+	"context"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeLeader struct {
+	leader bool
+}
+
+func (l *fakeLeader) Lead(context.Context) (bool, error) {
+	return l.leader, nil
+}
+
+func (l *fakeLeader) Close() error {
+	return nil
+}
+
+func TestSweep(t *testing.T) {
+	live := corev2.FixtureCheckConfig("live")
+	pending := corev2.FixtureCheckConfig("pending")
+	pending.Finalizers = []string{"cleanup"}
+	pending.DeletedAt = 1
+	finalized := corev2.FixtureCheckConfig("finalized")
+	finalized.Namespace = "dev"
+	finalized.DeletedAt = 1
+	gone := corev2.FixtureCheckConfig("gone")
+	gone.DeletedAt = 1
+
+	stor := &mockstore.MockStore{}
+	stor.On("ListResources", mock.Anything, "checks", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		if ns := corev2.ContextNamespace(args.Get(0).(context.Context)); ns != "" {
+			t.Errorf("checks listed in namespace %q", ns)
+		}
+		pred := args.Get(3).(*store.SelectionPredicate)
+		list := args.Get(2).(*[]*corev2.CheckConfig)
+		if pred.Continue == "" {
+			*list = []*corev2.CheckConfig{live, pending}
+			pred.Continue = "next"
+		} else {
+			*list = []*corev2.CheckConfig{finalized, gone}
+			pred.Continue = ""
+		}
+	}).Return(nil)
+	etag, err := store.ETag(finalized)
+	require.NoError(t, err)
+	var deleted []string
+	stor.On("DeleteResourceIfMatch", mock.Anything, mock.AnythingOfType("*v2.CheckConfig"), "finalized", etag).Run(func(args mock.Arguments) {
+		assert.Equal(t, "dev", corev2.ContextNamespace(args.Get(0).(context.Context)))
+		deleted = append(deleted, args.String(2))
+	}).Return(nil)
+	// The resource was deleted by another backend, or modified, since it was
+	// listed
+	stor.On("DeleteResourceIfMatch", mock.Anything, mock.Anything, "gone", mock.Anything).Return(&store.ErrPreconditionFailed{})
+
+	r, err := New(context.Background(), Config{Store: stor, Resources: []corev2.Resource{&corev2.CheckConfig{}}})
+	require.NoError(t, err)
+	require.NoError(t, r.sweepKind(context.Background(), &corev2.CheckConfig{}))
+	assert.Equal(t, []string{"finalized"}, deleted)
+	stor.AssertNotCalled(t, "DeleteResourceIfMatch", mock.Anything, mock.Anything, "live", mock.Anything)
+	stor.AssertNotCalled(t, "DeleteResourceIfMatch", mock.Anything, mock.Anything, "pending", mock.Anything)
+}
+
+func TestSweepLeader(t *testing.T) {
+	for _, isLeader := range []bool{true, false} {
+		stor := &mockstore.MockStore{}
+		stor.On("ListResources", mock.Anything, "checks", mock.Anything, mock.Anything).Return(nil)
+
+		r, err := New(context.Background(), Config{Store: stor, Resources: []corev2.Resource{&corev2.CheckConfig{}}})
+		require.NoError(t, err)
+		r.leader = &fakeLeader{leader: isLeader}
+		r.sweep(context.Background())
+		if isLeader {
+			stor.AssertCalled(t, "ListResources", mock.Anything, "checks", mock.Anything, mock.Anything)
+		} else {
+			// Only the leader sweeps resources
+			stor.AssertNotCalled(t, "ListResources", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// Package retentiond enforces the event retention policies of namespaces.
+package retentiond
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/leader"
+	"github.com/sensu/sensu-go/backend/messaging"
+	"github.com/sensu/sensu-go/backend/store"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const (
+	// componentName identifies Retentiond as the component/daemon implemented
+	// in this package.
+	componentName = "retentiond"
+
+	// defaultInterval is the default interval at which events are pruned.
+	defaultInterval = time.Minute
+
+	// eventsPageSize is the number of events listed at once.
+	eventsPageSize = 500
+
+	// leaderLockKey is the key of the lock held by the backend that prunes
+	// events.
+	leaderLockKey = ".retentiond.lock"
+)
+
+// Retentiond is the daemon that deletes the events that fall outside of the
+// retention policy of their namespace. Only one backend of the cluster, the
+// one holding the lock of the daemon, prunes events at any given time.
+// Namespaces without a policy are left alone, and keepalive events are never
+// pruned, since they track the liveness of their entity.
+type Retentiond struct {
+	namespaceStore store.NamespaceStore
+	eventStore     store.EventStore
+	events         actions.EventController
+	leader         leader.Leader
+	interval       time.Duration
+	now            func() time.Time
+	ctx            context.Context
+	cancel         context.CancelFunc
+	errChan        chan error
+	wg             sync.WaitGroup
+}
+
+// Config configures Retentiond.
+type Config struct {
+	NamespaceStore store.NamespaceStore
+	EventStore     store.EventStore
+	Bus            messaging.MessageBus
+	Client         *clientv3.Client
+
+	// Interval is the interval at which events are pruned. It defaults to one
+	// minute.
+	Interval time.Duration
+}
+
+// New creates a new Retentiond.
+func New(ctx context.Context, c Config) (*Retentiond, error) {
+	if c.Interval == 0 {
+		c.Interval = defaultInterval
+	}
+	r := &Retentiond{
+		namespaceStore: c.NamespaceStore,
+		eventStore:     c.EventStore,
+		events:         actions.NewEventController(c.EventStore, c.Bus),
+		leader:         &leader.Etcd{Client: c.Client, Key: leaderLockKey, Component: "retention"},
+		interval:       c.Interval,
+		now:            time.Now,
+		errChan:        make(chan error, 1),
+	}
+	r.ctx, r.cancel = context.WithCancel(ctx)
+	return r, nil
+}
+
+// Start the daemon.
+func (r *Retentiond) Start() error {
+	r.wg.Add(1)
+	go r.run()
+	return nil
+}
+
+// Stop the daemon, giving up its leadership.
+func (r *Retentiond) Stop() error {
+	r.cancel()
+	r.wg.Wait()
+	return r.leader.Close()
+}
+
+// Err returns a channel on which to listen for terminal errors.
+func (r *Retentiond) Err() <-chan error {
+	return r.errChan
+}
+
+// Name returns the daemon name.
+func (r *Retentiond) Name() string {
+	return componentName
+}
+
+func (r *Retentiond) run() {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.prune(r.ctx); err != nil && r.ctx.Err() == nil {
+				logger.WithError(err).Error("error pruning events")
+			}
+		}
+	}
+}
+
+// prune deletes the events outside of the retention policies of all the
+// namespaces, if this backend is the leader.
+func (r *Retentiond) prune(ctx context.Context) error {
+	isLeader, err := r.leader.Lead(ctx)
+	if err != nil {
+		return err
+	}
+	if !isLeader {
+		logger.Debug("another backend is pruning events")
+		return nil
+	}
+
+	namespaces, err := r.namespaceStore.ListNamespaces(ctx, &store.SelectionPredicate{})
+	if err != nil {
+		return err
+	}
+	for _, namespace := range namespaces {
+		if !namespace.EventRetention.Enabled() {
+			continue
+		}
+		if err := r.pruneNamespace(ctx, namespace); err != nil {
+			logger.WithError(err).WithField("namespace", namespace.Name).Error("error pruning events")
+		}
+	}
+	return nil
+}
+
+// pruneNamespace deletes the events of the namespace that are older than the
+// maximum age of its policy, and then the oldest events beyond its maximum
+// count. Events are deleted like they are by the API, so that eventd stops
+// monitoring the TTL of their check.
+func (r *Retentiond) pruneNamespace(ctx context.Context, namespace *corev2.Namespace) error {
+	ctx = context.WithValue(ctx, corev2.NamespaceKey, namespace.Name)
+	policy := namespace.EventRetention
+
+	var events []*corev2.Event
+	pred := &store.SelectionPredicate{Limit: eventsPageSize}
+	for {
+		page, err := r.eventStore.GetEvents(ctx, pred)
+		if err != nil {
+			return err
+		}
+		for _, event := range page {
+			if event.HasCheck() && event.Check.Name == corev2.KeepaliveCheckName {
+				continue
+			}
+			events = append(events, event)
+		}
+		if pred.Continue == "" {
+			break
+		}
+	}
+
+	// Most recent events first
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp > events[j].Timestamp
+	})
+
+	var expired []*corev2.Event
+	if policy.MaxAge > 0 {
+		oldest := r.now().Unix() - policy.MaxAge
+		for len(events) > 0 && events[len(events)-1].Timestamp < oldest {
+			expired = append(expired, events[len(events)-1])
+			events = events[:len(events)-1]
+		}
+	}
+	if policy.MaxCount > 0 && int64(len(events)) > policy.MaxCount {
+		expired = append(expired, events[policy.MaxCount:]...)
+	}
+
+	for _, event := range expired {
+		if err := r.events.Delete(ctx, event.Entity.Name, event.Check.Name); err != nil {
+			if code, ok := actions.StatusFromError(err); ok && code == actions.NotFound {
+				// The event was deleted since it was listed
+				continue
+			}
+			return err
+		}
+	}
+	if len(expired) > 0 {
+		logger.WithField("namespace", namespace.Name).Infof("deleted %d events outside of the event retention policy", len(expired))
+	}
+	return nil
+}
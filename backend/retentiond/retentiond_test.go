@@ -0,0 +1,135 @@
+package retentiond
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/messaging"
+	"github.com/sensu/sensu-go/testing/mockbus"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeLeader struct {
+	leader bool
+}
+
+func (l *fakeLeader) Lead(context.Context) (bool, error) {
+	return l.leader, nil
+}
+
+func (l *fakeLeader) Close() error {
+	return nil
+}
+
+func fixtureEvents(now time.Time, ages ...time.Duration) []*corev2.Event {
+	var events []*corev2.Event
+	for i, age := range ages {
+		event := corev2.FixtureEvent("entity1", fmt.Sprintf("check%d", i))
+		event.Timestamp = now.Add(-age).Unix()
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestPrune(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name        string
+		retention   *corev2.EventRetentionPolicy
+		leader      bool
+		wantDeleted []string
+	}{
+		{
+			name:   "no policy",
+			leader: true,
+		},
+		{
+			name:      "not the leader",
+			retention: &corev2.EventRetentionPolicy{MaxAge: 60},
+		},
+		{
+			name:        "max age",
+			retention:   &corev2.EventRetentionPolicy{MaxAge: 60},
+			leader:      true,
+			wantDeleted: []string{"check2", "check1"},
+		},
+		{
+			name:        "max count",
+			retention:   &corev2.EventRetentionPolicy{MaxCount: 2},
+			leader:      true,
+			wantDeleted: []string{"check1", "check2"},
+		},
+		{
+			name:        "max age and count",
+			retention:   &corev2.EventRetentionPolicy{MaxAge: 600, MaxCount: 1},
+			leader:      true,
+			wantDeleted: []string{"check2", "check3", "check1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stor := &mockstore.MockStore{}
+			namespace := corev2.FixtureNamespace("default")
+			namespace.EventRetention = tt.retention
+			stor.On("ListNamespaces", mock.Anything, mock.Anything).Return([]*corev2.Namespace{namespace}, nil)
+			events := fixtureEvents(now, 0, 5*time.Minute, time.Hour, 10*time.Second)
+			stor.On("GetEvents", mock.Anything, mock.Anything).Return(events, nil)
+			for _, event := range events {
+				stor.On("GetEventByEntityCheck", mock.Anything, "entity1", event.Check.Name).Return(event, nil)
+			}
+			var deleted []string
+			stor.On("DeleteEventByEntityCheck", mock.Anything, "entity1", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				deleted = append(deleted, args.String(2))
+			})
+
+			r, err := New(context.Background(), Config{NamespaceStore: stor, EventStore: stor})
+			require.NoError(t, err)
+			r.leader = &fakeLeader{leader: tt.leader}
+			r.now = func() time.Time { return now }
+
+			require.NoError(t, r.prune(context.Background()))
+			assert.Equal(t, tt.wantDeleted, deleted)
+			if !tt.leader {
+				stor.AssertNotCalled(t, "ListNamespaces", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestPruneTTLAndKeepalive(t *testing.T) {
+	now := time.Now()
+	stor := &mockstore.MockStore{}
+	namespace := corev2.FixtureNamespace("default")
+	namespace.EventRetention = &corev2.EventRetentionPolicy{MaxAge: 60}
+	stor.On("ListNamespaces", mock.Anything, mock.Anything).Return([]*corev2.Namespace{namespace}, nil)
+	keepalive := corev2.FixtureEvent("entity1", corev2.KeepaliveCheckName)
+	keepalive.Timestamp = now.Add(-time.Hour).Unix()
+	ttl := corev2.FixtureEvent("entity1", "ttl")
+	ttl.Check.Ttl = 30
+	ttl.Timestamp = now.Add(-time.Hour).Unix()
+	stor.On("GetEvents", mock.Anything, mock.Anything).Return([]*corev2.Event{keepalive, ttl}, nil)
+	stor.On("GetEventByEntityCheck", mock.Anything, "entity1", "ttl").Return(ttl, nil)
+	stor.On("DeleteEventByEntityCheck", mock.Anything, "entity1", "ttl").Return(nil)
+
+	// eventd is told to stop monitoring the TTL of the deleted event
+	bus := &mockbus.MockBus{}
+	bus.On("Publish", messaging.TopicEventRaw, mock.MatchedBy(func(event *corev2.Event) bool {
+		return event.Check.Name == "ttl" && event.Check.Ttl < 0
+	})).Return(nil)
+
+	r, err := New(context.Background(), Config{NamespaceStore: stor, EventStore: stor, Bus: bus})
+	require.NoError(t, err)
+	r.leader = &fakeLeader{leader: true}
+	r.now = func() time.Time { return now }
+
+	require.NoError(t, r.prune(context.Background()))
+	bus.AssertExpectations(t)
+	stor.AssertCalled(t, "DeleteEventByEntityCheck", mock.Anything, "entity1", "ttl")
+	stor.AssertNotCalled(t, "DeleteEventByEntityCheck", mock.Anything, "entity1", corev2.KeepaliveCheckName)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/backend/store/etcd"
 	"github.com/sensu/sensu-go/types/dynamic"
+	"github.com/sirupsen/logrus"
 	"go.etcd.io/etcd/client/v3"
 )
 
@@ -99,6 +100,7 @@ type Resource struct {
 	synthesize bool
 	resourceT  corev2.Resource
 	client     *clientv3.Client
+	refreshLog logThrottle
 }
 
 // getResources retrieves the resources from the store
@@ -148,6 +150,7 @@ func New(ctx context.Context, client *clientv3.Client, resource corev2.Resource,
 		synthesize: synthesize,
 		resourceT:  resource,
 		client:     client,
+		refreshLog: logThrottle{interval: refreshLogInterval},
 	}
 	atomic.StoreInt64(&cacher.count, int64(len(resources)))
 
@@ -184,6 +187,24 @@ func (r *Resource) GetAll() []Value {
 	return values
 }
 
+// Warm synchronously reloads the cache from the store, so that it contains
+// every resource that existed when Warm was called. Watchers are notified if
+// the cache changed. Caches created with NewFromResources have no store to
+// reload from, and are left untouched.
+func (r *Resource) Warm(ctx context.Context) error {
+	if r.client == nil {
+		return nil
+	}
+	updates, err := r.rebuild(ctx)
+	if err != nil {
+		return err
+	}
+	if updates {
+		r.notifyWatchers()
+	}
+	return nil
+}
+
 // Count returns the total count of all cached resources across all namespaces.
 func (r *Resource) Count() int64 {
 	return atomic.LoadInt64(&r.count)
@@ -238,9 +259,7 @@ func (r *Resource) start(ctx context.Context) {
 			return
 		case <-ticker.C:
 			updates, err := r.rebuild(ctx)
-			if err != nil {
-				logger.WithError(err).Error("couldn't rebuild cache")
-			}
+			r.logRefresh(updates, err)
 			if updates {
 				r.notifyWatchers()
 			}
@@ -248,9 +267,26 @@ func (r *Resource) start(ctx context.Context) {
 	}
 }
 
+// logRefresh logs the outcome of a cache refresh, at most once per refresh log
+// interval. Debug lines only count towards the interval when they are
+// actually written, so that they never hide an error.
+func (r *Resource) logRefresh(updates bool, err error) {
+	if err == nil && !logger.Logger.IsLevelEnabled(logrus.DebugLevel) {
+		return
+	}
+	entry, ok := r.refreshLog.entry(time.Now())
+	if !ok {
+		return
+	}
+	if err != nil {
+		entry.WithError(err).Error("couldn't rebuild cache")
+		return
+	}
+	entry.WithField("updates", updates).Debugf("rebuilt the cache for resource type %T", r.resourceT)
+}
+
 // rebuild the cache using the store as the source of truth
 func (r *Resource) rebuild(ctx context.Context) (bool, error) {
-	logger.Debugf("rebuilding the cache for resource type %T", r.resourceT)
 	resources, err := getResources(ctx, r.client, r.resourceT)
 	if err != nil {
 		return false, err
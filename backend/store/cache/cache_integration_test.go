@@ -128,3 +128,42 @@ func checkResources(t testing.TB, got, want []Value) bool {
 	}
 	return success
 }
+
+func TestResourceCacheWarm(t *testing.T) {
+	e, cleanup := etcd.NewTestEtcd(t)
+	defer cleanup()
+
+	client := e.NewEmbeddedClient()
+
+	store := store.NewStore(client, e.Name())
+
+	if err := store.CreateNamespace(context.Background(), types.FixtureNamespace("default")); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.WithValue(context.Background(), corev2.NamespaceKey, "default")
+
+	cacheCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cache, err := New(cacheCtx, client, &corev2.Silenced{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(cache.Get("default")); got != 0 {
+		t.Fatalf("got %d silenced entries, want 0", got)
+	}
+
+	silenced := corev2.FixtureSilenced("linux:check-cpu")
+	if err := store.UpdateSilencedEntry(ctx, silenced); err != nil {
+		t.Fatal(err)
+	}
+
+	// The entry must be cached as soon as Warm returns, without waiting for
+	// the periodic rebuild
+	if err := cache.Warm(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := cache.Get("default"), []Value{getCacheValue(silenced, false)}; !checkResources(t, got, want) {
+		t.Fatal("bad resources")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/sensu/sensu-go/backend/store/etcd"
 	"github.com/sensu/sensu-go/types"
@@ -203,3 +204,34 @@ func BenchmarkGetAll(b *testing.B) {
 		}
 	}
 }
+
+func TestLogThrottle(t *testing.T) {
+	throttle := logThrottle{interval: time.Minute}
+	now := time.Now()
+
+	entry, ok := throttle.entry(now)
+	require.True(t, ok)
+	assert.NotContains(t, entry.Data, "suppressed")
+
+	for i := 0; i < 3; i++ {
+		_, ok := throttle.entry(now.Add(time.Duration(i) * time.Second))
+		assert.False(t, ok)
+	}
+
+	entry, ok = throttle.entry(now.Add(time.Minute))
+	require.True(t, ok)
+	assert.Equal(t, 3, entry.Data["suppressed"])
+
+	entry, ok = throttle.entry(now.Add(2 * time.Minute))
+	require.True(t, ok)
+	assert.NotContains(t, entry.Data, "suppressed")
+}
+
+func TestLogThrottleDisabled(t *testing.T) {
+	var throttle logThrottle
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		_, ok := throttle.entry(now)
+		assert.True(t, ok)
+	}
+}
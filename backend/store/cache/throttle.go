@@ -0,0 +1,41 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// refreshLogInterval is the minimum interval between two log lines of the
+// cache refresh path, so that resources changing rapidly, such as silenced
+// entries during a silence storm, do not flood the logs.
+const refreshLogInterval = time.Minute
+
+// logThrottle limits a log to one line per interval, and counts the lines it
+// suppressed in between. A zero interval does not throttle.
+type logThrottle struct {
+	mu         sync.Mutex
+	interval   time.Duration
+	last       time.Time
+	suppressed int
+}
+
+// entry returns the entry to log the next line with, or false if the line
+// must be suppressed. The entry carries the number of lines suppressed since
+// the previous one, if any.
+func (t *logThrottle) entry(now time.Time) (*logrus.Entry, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.last.IsZero() && now.Sub(t.last) < t.interval {
+		t.suppressed++
+		return nil, false
+	}
+	entry := logger
+	if t.suppressed > 0 {
+		entry = logger.WithField("suppressed", t.suppressed)
+	}
+	t.last = now
+	t.suppressed = 0
+	return entry, true
+}
@@ -1,6 +1,7 @@
 package store
 
 import (
+	"context"
 	"fmt"
 	"net/textproto"
 	"strings"
@@ -24,6 +25,51 @@ type ETagCondition struct {
 	IfNoneMatch string
 }
 
+type ifNoneMatchKey struct{}
+
+// ContextWithIfNoneMatch returns a context carrying the If-None-Match header of
+// a conditional create. When the header is "*", stores that find the resource
+// already exists fail the create with a *ErrPreconditionFailed, rather than a
+// *ErrAlreadyExists, so that clients can tell the two apart. Stores that honor
+// it in updates only create the resource if it doesn't exist yet.
+func ContextWithIfNoneMatch(ctx context.Context, header string) context.Context {
+	return context.WithValue(ctx, ifNoneMatchKey{}, header)
+}
+
+// IfNoneMatchFromContext returns the If-None-Match header carried by ctx, if
+// any.
+func IfNoneMatchFromContext(ctx context.Context) string {
+	header, _ := ctx.Value(ifNoneMatchKey{}).(string)
+	return header
+}
+
+type ifMatchKey struct{}
+
+// ContextWithIfMatch returns a context carrying the If-Match header of a
+// conditional update. Stores that honor it only write the resource if the
+// ETag of its stored version matches the header, and compare the stored value
+// in the same transaction as the write, so that a concurrent update fails the
+// write with a *ErrPreconditionFailed rather than being overwritten.
+func ContextWithIfMatch(ctx context.Context, header string) context.Context {
+	return context.WithValue(ctx, ifMatchKey{}, header)
+}
+
+// IfMatchFromContext returns the If-Match header carried by ctx, if any.
+func IfMatchFromContext(ctx context.Context) string {
+	header, _ := ctx.Value(ifMatchKey{}).(string)
+	return header
+}
+
+// ConditionalCreateError returns the error of a create made with ctx. A
+// *ErrAlreadyExists is turned into a *ErrPreconditionFailed if the create was
+// conditioned on If-None-Match: *, and other errors are returned as is.
+func ConditionalCreateError(ctx context.Context, err error) error {
+	if exists, ok := err.(*ErrAlreadyExists); ok && IfNoneMatchFromContext(ctx) == "*" {
+		return &ErrPreconditionFailed{Key: exists.Key}
+	}
+	return err
+}
+
 // CheckIfMatch determines if any of the etag provided in the If-Match header
 // match the stored etag. This function was largely inspired by the net/http
 // package
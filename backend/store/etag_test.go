@@ -1,6 +1,8 @@
 package store
 
 import (
+	"context"
+	"reflect"
 	"testing"
 )
 
@@ -46,3 +48,56 @@ func TestETag(t *testing.T) {
 		})
 	}
 }
+
+func TestConditionalCreateError(t *testing.T) {
+	exists := &ErrAlreadyExists{Key: "foo"}
+	internal := &ErrInternal{Message: "oops"}
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		err         error
+		want        error
+	}{
+		{
+			name: "unconditional create",
+			err:  exists,
+			want: exists,
+		},
+		{
+			name:        "conditional create of an existing resource",
+			ifNoneMatch: "*",
+			err:         exists,
+			want:        &ErrPreconditionFailed{Key: "foo"},
+		},
+		{
+			name:        "conditional create with an etag",
+			ifNoneMatch: `"abc"`,
+			err:         exists,
+			want:        exists,
+		},
+		{
+			name:        "other errors",
+			ifNoneMatch: "*",
+			err:         internal,
+			want:        internal,
+		},
+		{
+			name:        "successful create",
+			ifNoneMatch: "*",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.ifNoneMatch != "" {
+				ctx = ContextWithIfNoneMatch(ctx, tt.ifNoneMatch)
+			}
+			if got := IfNoneMatchFromContext(ctx); got != tt.ifNoneMatch {
+				t.Errorf("IfNoneMatchFromContext() = %q, want %q", got, tt.ifNoneMatch)
+			}
+			if got := ConditionalCreateError(ctx, tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ConditionalCreateError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
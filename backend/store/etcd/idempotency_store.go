@@ -0,0 +1,92 @@
+package etcd
+
+import (
+	"context"
+	"time"
+
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/backend/store/etcd/kvc"
+	"go.etcd.io/etcd/client/v3"
+)
+
+const (
+	idempotencyPrefix = "idempotency"
+)
+
+var (
+	idempotencyKeyBuilder = store.NewKeyBuilder(idempotencyPrefix)
+)
+
+// ReserveIdempotencyKey records the fingerprint of the request identified by
+// key, unless the key is already recorded, in which case it returns the
+// fingerprint recorded for it. The key is attached to a lease, so that etcd
+// deletes it once ttl has elapsed.
+func (s *Store) ReserveIdempotencyKey(ctx context.Context, key, fingerprint string, ttl time.Duration) (string, error) {
+	seconds := int64(ttl / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	var lease *clientv3.LeaseGrantResponse
+	err := kvc.Backoff(ctx).Retry(func(n int) (done bool, err error) {
+		lease, err = s.client.Grant(ctx, seconds)
+		return kvc.RetryRequest(n, err)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	k := idempotencyKeyBuilder.Build(key)
+	cmp := clientv3.Compare(clientv3.Version(k), "=", 0)
+	putOp := clientv3.OpPut(k, fingerprint, clientv3.WithLease(lease.ID))
+	getOp := clientv3.OpGet(k)
+
+	var resp *clientv3.TxnResponse
+	err = kvc.Backoff(ctx).Retry(func(n int) (done bool, err error) {
+		resp, err = s.client.Txn(ctx).If(cmp).Then(putOp).Else(getOp).Commit()
+		return kvc.RetryRequest(n, err)
+	})
+	if err != nil {
+		return "", err
+	}
+	if resp.Succeeded {
+		return "", nil
+	}
+
+	// The lease is not attached to anything, let it go
+	_, _ = s.client.Revoke(ctx, lease.ID)
+	kvs := resp.Responses[0].GetResponseRange().Kvs
+	if len(kvs) == 0 {
+		return "", nil
+	}
+	return string(kvs[0].Value), nil
+}
+
+// ReleaseIdempotencyKey deletes key, if the fingerprint recorded for it is
+// fingerprint.
+func (s *Store) ReleaseIdempotencyKey(ctx context.Context, key, fingerprint string) error {
+	k := idempotencyKeyBuilder.Build(key)
+	cmp := clientv3.Compare(clientv3.Value(k), "=", fingerprint)
+	delOp := clientv3.OpDelete(k)
+
+	return kvc.Backoff(ctx).Retry(func(n int) (done bool, err error) {
+		_, err = s.client.Txn(ctx).If(cmp).Then(delOp).Commit()
+		return kvc.RetryRequest(n, err)
+	})
+}
+
+// GetIdempotencyKey returns the fingerprint recorded for key.
+func (s *Store) GetIdempotencyKey(ctx context.Context, key string) (string, error) {
+	var resp *clientv3.GetResponse
+	err := kvc.Backoff(ctx).Retry(func(n int) (done bool, err error) {
+		resp, err = s.client.Get(ctx, idempotencyKeyBuilder.Build(key), clientv3.WithLimit(1))
+		return kvc.RetryRequest(n, err)
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(resp.Kvs) == 0 {
+		return "", nil
+	}
+	return string(resp.Kvs[0].Value), nil
+}
@@ -0,0 +1,64 @@
+// +build integration,!race
+
+package etcd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+func TestIdempotencyKeyStorage(t *testing.T) {
+	testWithEtcd(t, func(store store.Store) {
+		ctx := context.Background()
+
+		fingerprint, err := store.GetIdempotencyKey(ctx, "namespaces/abc")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fingerprint != "" {
+			t.Fatalf("expected no fingerprint, got %q", fingerprint)
+		}
+
+		fingerprint, err = store.ReserveIdempotencyKey(ctx, "namespaces/abc", "foo", time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fingerprint != "" {
+			t.Fatalf("expected the key to be reserved, got %q", fingerprint)
+		}
+
+		// Reserving the key again does not replace its fingerprint
+		fingerprint, err = store.ReserveIdempotencyKey(ctx, "namespaces/abc", "bar", time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := fingerprint, "foo"; got != want {
+			t.Fatalf("bad reserved fingerprint: got %q, want %q", got, want)
+		}
+
+		fingerprint, err = store.GetIdempotencyKey(ctx, "namespaces/abc")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := fingerprint, "foo"; got != want {
+			t.Fatalf("bad fingerprint: got %q, want %q", got, want)
+		}
+
+		// The key is only released with its own fingerprint
+		if err := store.ReleaseIdempotencyKey(ctx, "namespaces/abc", "bar"); err != nil {
+			t.Fatal(err)
+		}
+		if fingerprint, _ = store.GetIdempotencyKey(ctx, "namespaces/abc"); fingerprint != "foo" {
+			t.Fatalf("key released with another fingerprint, got %q", fingerprint)
+		}
+		if err := store.ReleaseIdempotencyKey(ctx, "namespaces/abc", "foo"); err != nil {
+			t.Fatal(err)
+		}
+		if fingerprint, _ = store.GetIdempotencyKey(ctx, "namespaces/abc"); fingerprint != "" {
+			t.Fatalf("key not released, got %q", fingerprint)
+		}
+	})
+}
@@ -58,7 +58,8 @@ func (s *Store) CreateNamespace(ctx context.Context, namespace *corev2.Namespace
 	return nil
 }
 
-// DeleteNamespace deletes the namespace with the given name
+// DeleteNamespace deletes the namespace with the given name. See
+// store.ContextWithIfMatch for conditional deletes.
 func (s *Store) DeleteNamespace(ctx context.Context, name string) error {
 	if name == "" {
 		return &store.ErrNotValid{Err: errors.New("must specify name")}
@@ -88,7 +89,22 @@ func (s *Store) DeleteNamespace(ctx context.Context, name string) error {
 		}
 	}
 
-	return Delete(ctx, s.client, getNamespacePath(name))
+	key := getNamespacePath(name)
+	if ifMatch := store.IfMatchFromContext(ctx); ifMatch != "" {
+		// Only delete the namespace if it matches the condition, and ensure
+		// it isn't modified in the mean time
+		value, err := s.checkIfMatch(ctx, key, &corev2.Namespace{}, ifMatch)
+		if err != nil {
+			return err
+		}
+		err = DeleteWithComparisons(ctx, s.client, key, kvc.KeyHasValue(key, value))
+		if _, ok := err.(*store.ErrNotFound); ok {
+			// The namespace was deleted since it was read
+			return &store.ErrPreconditionFailed{Key: key}
+		}
+		return err
+	}
+	return Delete(ctx, s.client, key)
 }
 
 // GetNamespace returns a single namespace with the given name
@@ -104,6 +120,84 @@ func (s *Store) GetNamespace(ctx context.Context, name string) (*corev2.Namespac
 	return &namespace, nil
 }
 
+// GetNamespaces returns the namespaces with the given names, fetched in a
+// single transaction. Namespaces that do not exist are omitted. Callers should
+// bound the number of names, since etcd limits the number of operations of a
+// transaction.
+func (s *Store) GetNamespaces(ctx context.Context, names []string) ([]*corev2.Namespace, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	ops := make([]v3.Op, len(names))
+	for i, name := range names {
+		ops[i] = v3.OpGet(getNamespacePath(name), v3.WithLimit(1))
+	}
+
+	var resp *v3.TxnResponse
+	err := kvc.Backoff(ctx).Retry(func(n int) (done bool, err error) {
+		resp, err = s.client.Txn(ctx).Then(ops...).Commit()
+		return kvc.RetryRequest(n, err)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := make([]*corev2.Namespace, 0, len(names))
+	for _, r := range resp.Responses {
+		kvs := r.GetResponseRange().Kvs
+		if len(kvs) == 0 {
+			continue
+		}
+		var namespace corev2.Namespace
+		if err := unmarshal(kvs[0].Value, &namespace); err != nil {
+			return nil, &store.ErrDecode{Key: string(kvs[0].Key), Err: err}
+		}
+		namespaces = append(namespaces, &namespace)
+	}
+	return namespaces, nil
+}
+
+// CountNamespaceResources counts the resources stored under each of the given
+// prefixes, for each of the given namespaces, with count-only reads, so that
+// only the keys of these namespaces are visited. The reads are batched in
+// transactions of at most maxTxnOps operations.
+func (s *Store) CountNamespaceResources(ctx context.Context, namespaces, prefixes []string) (map[string]map[string]int64, error) {
+	counts := make(map[string]map[string]int64, len(namespaces))
+	type count struct {
+		namespace, prefix string
+	}
+	var keys []count
+	var ops []v3.Op
+	for _, namespace := range namespaces {
+		counts[namespace] = make(map[string]int64, len(prefixes))
+		for _, prefix := range prefixes {
+			key := path.Join(EtcdRoot, prefix, namespace) + "/"
+			keys = append(keys, count{namespace: namespace, prefix: prefix})
+			ops = append(ops, v3.OpGet(key, v3.WithPrefix(), v3.WithCountOnly()))
+		}
+	}
+
+	for start := 0; start < len(ops); start += maxTxnOps {
+		end := start + maxTxnOps
+		if end > len(ops) {
+			end = len(ops)
+		}
+		var resp *v3.TxnResponse
+		err := kvc.Backoff(ctx).Retry(func(n int) (done bool, err error) {
+			resp, err = s.client.Txn(ctx).Then(ops[start:end]...).Commit()
+			return kvc.RetryRequest(n, err)
+		})
+		if err != nil {
+			return nil, err
+		}
+		for i, r := range resp.Responses {
+			key := keys[start+i]
+			counts[key.namespace][key.prefix] = r.GetResponseRange().Count
+		}
+	}
+	return counts, nil
+}
+
 // ListNamespaces returns all namespaces
 func (s *Store) ListNamespaces(ctx context.Context, pred *store.SelectionPredicate) ([]*corev2.Namespace, error) {
 	namespaces := []*corev2.Namespace{}
@@ -41,6 +41,13 @@ func TestNamespaceStorage(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Nil(t, result)
 
+		// Get several namespaces at once, omitting the missing ones
+		results, err := s.GetNamespaces(ctx, []string{"default", "missing", namespace.Name})
+		assert.NoError(t, err)
+		require.Equal(t, 2, len(results))
+		assert.Equal(t, "default", results[0].Name)
+		assert.Equal(t, namespace.Name, results[1].Name)
+
 		// Get all namespaces
 		namespaces, err = s.ListNamespaces(ctx, pred)
 		assert.NoError(t, err)
@@ -53,6 +60,12 @@ func TestNamespaceStorage(t *testing.T) {
 		require.NoError(t, s.UpdateCheckConfig(ctx, check))
 		err = s.DeleteNamespace(ctx, namespace.Name)
 		assert.Error(t, err)
+
+		// Count the resources of each namespace
+		counts, err := s.CountNamespaceResources(ctx, []string{namespace.Name}, []string{"checks", "handlers"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), counts[namespace.Name]["checks"])
+		assert.Equal(t, int64(0), counts[namespace.Name]["handlers"])
 		err = s.DeleteCheckConfigByName(ctx, check.ObjectMeta.Name)
 		assert.NoError(t, err)
 
@@ -164,3 +177,23 @@ func testListNamespacesPagination(t *testing.T, ctx context.Context, etcd store.
 		}
 	}
 }
+
+func TestDeleteNamespaceIfMatch(t *testing.T) {
+	testWithEtcd(t, func(s store.Store) {
+		ctx := context.Background()
+		namespace := types.FixtureNamespace("foo")
+		require.NoError(t, s.CreateNamespace(ctx, namespace))
+		etag, err := store.ETag(namespace)
+		require.NoError(t, err)
+
+		// A namespace modified after it was checked is not deleted
+		err = s.DeleteNamespace(store.ContextWithIfMatch(ctx, `"abc"`), namespace.Name)
+		assert.IsType(t, &store.ErrPreconditionFailed{}, err)
+
+		require.NoError(t, s.DeleteNamespace(store.ContextWithIfMatch(ctx, etag), namespace.Name))
+
+		// A deleted namespace fails the condition
+		err = s.DeleteNamespace(store.ContextWithIfMatch(ctx, etag), namespace.Name)
+		assert.IsType(t, &store.ErrPreconditionFailed{}, err)
+	})
+}
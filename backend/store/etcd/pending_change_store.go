@@ -0,0 +1,83 @@
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/backend/store/etcd/kvc"
+	"go.etcd.io/etcd/client/v3"
+)
+
+const (
+	pendingChangesPrefix = "pendingchanges"
+)
+
+func pendingChangeKey(ctx context.Context, resourcePrefix, name string) string {
+	return store.NewKeyBuilder(pendingChangesPrefix).WithContext(ctx).Build(resourcePrefix, name)
+}
+
+// PutPendingChange records the pending change of a resource, replacing the
+// previous one if any.
+func (s *Store) PutPendingChange(ctx context.Context, change *store.PendingChange) error {
+	key := pendingChangeKey(ctx, change.Resource, change.Name)
+	bytes, err := json.Marshal(change)
+	if err != nil {
+		return &store.ErrEncode{Key: key, Err: err}
+	}
+
+	return kvc.Backoff(ctx).Retry(func(n int) (done bool, err error) {
+		_, err = s.client.Put(ctx, key, string(bytes))
+		return kvc.RetryRequest(n, err)
+	})
+}
+
+// GetPendingChange returns the pending change of a resource, or nil if it has
+// none.
+func (s *Store) GetPendingChange(ctx context.Context, resourcePrefix, name string) (*store.PendingChange, error) {
+	change, _, err := s.getPendingChange(ctx, pendingChangeKey(ctx, resourcePrefix, name))
+	return change, err
+}
+
+// getPendingChange returns the pending change stored at key along with its
+// encoding, or nil if there is none.
+func (s *Store) getPendingChange(ctx context.Context, key string) (*store.PendingChange, []byte, error) {
+	var resp *clientv3.GetResponse
+	err := kvc.Backoff(ctx).Retry(func(n int) (done bool, err error) {
+		resp, err = s.client.Get(ctx, key, clientv3.WithLimit(1))
+		return kvc.RetryRequest(n, err)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil, nil
+	}
+
+	change := &store.PendingChange{}
+	if err := json.Unmarshal(resp.Kvs[0].Value, change); err != nil {
+		return nil, nil, &store.ErrDecode{Key: key, Err: err}
+	}
+	return change, resp.Kvs[0].Value, nil
+}
+
+// DeletePendingChange deletes the pending change of a resource if it is the
+// change with the given ID. A change that replaced it is left pending.
+func (s *Store) DeletePendingChange(ctx context.Context, resourcePrefix, name, id string) error {
+	key := pendingChangeKey(ctx, resourcePrefix, name)
+	change, value, err := s.getPendingChange(ctx, key)
+	if err != nil {
+		return err
+	}
+	if change == nil || change.ID != id {
+		return nil
+	}
+
+	err = DeleteWithComparisons(ctx, s.client, key, kvc.KeyHasValue(key, value))
+	switch err.(type) {
+	case *store.ErrNotFound, *store.ErrPreconditionFailed:
+		// The change was deleted or replaced in the meantime
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,70 @@
+// +build integration,!race
+
+package etcd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+func TestPendingChangeStorage(t *testing.T) {
+	testWithEtcd(t, func(s store.Store) {
+		ctx := store.NamespaceContext(context.Background(), "default")
+
+		change, err := s.GetPendingChange(ctx, "checks", "check1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if change != nil {
+			t.Fatalf("expected no pending change, got %v", change)
+		}
+
+		first := &store.PendingChange{ID: "1", Namespace: "default", Resource: "checks", Name: "check1", Proposed: []byte(`{}`)}
+		second := &store.PendingChange{ID: "2", Namespace: "default", Resource: "checks", Name: "check1", Proposed: []byte(`{}`)}
+		if err := s.PutPendingChange(ctx, first); err != nil {
+			t.Fatal(err)
+		}
+		// A new change of the resource replaces the previous one
+		if err := s.PutPendingChange(ctx, second); err != nil {
+			t.Fatal(err)
+		}
+		change, err = s.GetPendingChange(ctx, "checks", "check1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if change == nil || change.ID != "2" {
+			t.Fatalf("got pending change %v, want %v", change, second)
+		}
+
+		// Pending changes are namespaced
+		other := store.NamespaceContext(context.Background(), "other")
+		if change, err := s.GetPendingChange(other, "checks", "check1"); err != nil || change != nil {
+			t.Fatalf("expected no pending change in another namespace, got %v, %v", change, err)
+		}
+
+		// Only the change that is still pending is deleted
+		if err := s.DeletePendingChange(ctx, "checks", "check1", "1"); err != nil {
+			t.Fatal(err)
+		}
+		change, err = s.GetPendingChange(ctx, "checks", "check1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if change == nil || change.ID != "2" {
+			t.Fatalf("expected the replacing change to be left pending, got %v", change)
+		}
+
+		if err := s.DeletePendingChange(ctx, "checks", "check1", "2"); err != nil {
+			t.Fatal(err)
+		}
+		change, err = s.GetPendingChange(ctx, "checks", "check1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if change != nil {
+			t.Fatalf("expected the pending change to be deleted, got %v", change)
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
 
 	"github.com/gogo/protobuf/proto"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
@@ -30,7 +31,8 @@ func (s *Store) CreateResource(ctx context.Context, resource corev2.Resource) er
 }
 
 // CreateOrUpdateResource creates or updates the given resource regardless of
-// whether it already exists or not
+// whether it already exists or not. See store.ContextWithIfMatch and
+// store.ContextWithIfNoneMatch for conditional updates.
 func (s *Store) CreateOrUpdateResource(ctx context.Context, resource corev2.Resource) error {
 	if err := resource.Validate(); err != nil {
 		return &store.ErrNotValid{Err: err}
@@ -38,15 +40,83 @@ func (s *Store) CreateOrUpdateResource(ctx context.Context, resource corev2.Reso
 
 	key := store.KeyFromResource(resource)
 	namespace := resource.GetObjectMeta().Namespace
+	if ifMatch := store.IfMatchFromContext(ctx); ifMatch != "" {
+		return s.updateIfMatch(ctx, key, namespace, resource, ifMatch)
+	}
+	if store.IfNoneMatchFromContext(ctx) == "*" {
+		return Create(ctx, s.client, key, namespace, resource)
+	}
 	return CreateOrUpdate(ctx, s.client, key, namespace, resource)
 }
 
+// updateIfMatch updates the resource stored at key with resource, only if the
+// etag of the stored resource matches ifMatch and the stored resource is not
+// modified before it is updated.
+func (s *Store) updateIfMatch(ctx context.Context, key, namespace string, resource corev2.Resource, ifMatch string) error {
+	stored := reflect.New(reflect.TypeOf(resource).Elem()).Interface()
+	value, err := s.checkIfMatch(ctx, key, stored, ifMatch)
+	if err != nil {
+		return err
+	}
+
+	err = UpdateWithComparisons(ctx, s.client, key, resource,
+		kvc.NamespaceExists(namespace),
+		kvc.KeyHasValue(key, value),
+	)
+	if _, ok := err.(*store.ErrNotFound); ok {
+		// The resource was deleted since it was read
+		return &store.ErrPreconditionFailed{Key: key}
+	}
+	return err
+}
+
+// checkIfMatch decodes the object stored at key into stored and returns its
+// raw value, if the etag of the object matches ifMatch. Writes can then
+// compare the raw value to ensure the object wasn't modified since.
+func (s *Store) checkIfMatch(ctx context.Context, key string, stored interface{}, ifMatch string) ([]byte, error) {
+	resp, err := GetWithResponse(ctx, s.client, key, stored)
+	if err != nil {
+		if _, ok := err.(*store.ErrNotFound); ok {
+			return nil, &store.ErrPreconditionFailed{Key: key}
+		}
+		return nil, err
+	}
+	etag, err := store.ETag(stored)
+	if err != nil {
+		return nil, err
+	}
+	if !store.CheckIfMatch(ifMatch, etag) {
+		return nil, &store.ErrPreconditionFailed{Key: key}
+	}
+	return resp.Kvs[0].Value, nil
+}
+
 // DeleteResource deletes the resource using the given resource prefix and name
 func (s *Store) DeleteResource(ctx context.Context, resourcePrefix, name string) error {
 	key := store.KeyFromArgs(ctx, resourcePrefix, name)
 	return Delete(ctx, s.client, key)
 }
 
+// DeleteResourceIfMatch deletes the resource with the given name, only if the
+// etag of its stored version, decoded into resource, matches ifMatch
+func (s *Store) DeleteResourceIfMatch(ctx context.Context, resource corev2.Resource, name, ifMatch string) error {
+	key := store.KeyFromArgs(ctx, resource.StorePrefix(), name)
+
+	// Get the stored resource along with the etcd response so we can use its
+	// value to ensure the resource wasn't modified in the mean time
+	value, err := s.checkIfMatch(ctx, key, resource, ifMatch)
+	if err != nil {
+		return err
+	}
+
+	err = DeleteWithComparisons(ctx, s.client, key, kvc.KeyHasValue(key, value))
+	if _, ok := err.(*store.ErrNotFound); ok {
+		// The resource was deleted since it was read
+		return &store.ErrPreconditionFailed{Key: key}
+	}
+	return err
+}
+
 // GetResource retrieves a resource with the given name and stores it into the
 // resource pointer
 func (s *Store) GetResource(ctx context.Context, name string, resource corev2.Resource) error {
@@ -90,21 +160,28 @@ func (s *Store) PatchResource(ctx context.Context, resource corev2.Resource, nam
 		}
 	}
 
-	// Encode the stored resource to the JSON format
-	original, err := json.Marshal(resource)
-	if err != nil {
-		return err
-	}
+	if rp, ok := patcher.(patch.ResourcePatcher); ok {
+		// Apply the patch directly to the stored resource
+		if err := rp.PatchResource(resource); err != nil {
+			return err
+		}
+	} else {
+		// Encode the stored resource to the JSON format
+		original, err := json.Marshal(resource)
+		if err != nil {
+			return err
+		}
 
-	// Apply the patch to our original document (stored resource)
-	patchedResource, err := patcher.Patch(original)
-	if err != nil {
-		return err
-	}
+		// Apply the patch to our original document (stored resource)
+		patchedResource, err := patcher.Patch(original)
+		if err != nil {
+			return err
+		}
 
-	// Decode the resulting JSON document back into our resource
-	if err := json.Unmarshal(patchedResource, &resource); err != nil {
-		return err
+		// Decode the resulting JSON document back into our resource
+		if err := json.Unmarshal(patchedResource, &resource); err != nil {
+			return err
+		}
 	}
 
 	// Validate the resource
@@ -75,3 +75,72 @@ func TestStore_PatchResource(t *testing.T) {
 		}
 	})
 }
+
+func TestStore_CreateOrUpdateResourceConditional(t *testing.T) {
+	testWithEtcdClient(t, func(s store.Store, client *clientv3.Client) {
+		obj := &GenericObject{ObjectMeta: corev2.ObjectMeta{Name: "foo", Namespace: "default"}}
+		ctx := context.WithValue(context.Background(), corev2.NamespaceKey, "default")
+
+		// A resource that doesn't exist fails If-Match
+		err := s.CreateOrUpdateResource(store.ContextWithIfMatch(ctx, `"12345"`), obj)
+		if _, ok := err.(*store.ErrPreconditionFailed); !ok {
+			t.Fatalf("expected an error of type *store.ErrPreconditionFailed, got %v", err)
+		}
+
+		// It can be created with If-None-Match: *, but only once
+		if err := s.CreateOrUpdateResource(store.ContextWithIfNoneMatch(ctx, "*"), obj); err != nil {
+			t.Fatalf("could not create a resource: %s", err)
+		}
+		err = s.CreateOrUpdateResource(store.ContextWithIfNoneMatch(ctx, "*"), obj)
+		if _, ok := err.(*store.ErrPreconditionFailed); !ok {
+			t.Fatalf("expected an error of type *store.ErrPreconditionFailed, got %v", err)
+		}
+
+		// Only the stored version can be updated with If-Match
+		etag, err := store.ETag(obj)
+		if err != nil {
+			t.Fatalf("could not determine the etag: %s", err)
+		}
+		obj.Revision = 42
+		if err := s.CreateOrUpdateResource(store.ContextWithIfMatch(ctx, etag), obj); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		err = s.CreateOrUpdateResource(store.ContextWithIfMatch(ctx, etag), obj)
+		if _, ok := err.(*store.ErrPreconditionFailed); !ok {
+			t.Fatalf("expected an error of type *store.ErrPreconditionFailed, got %v", err)
+		}
+	})
+}
+
+func TestStore_DeleteResourceIfMatch(t *testing.T) {
+	testWithEtcdClient(t, func(s store.Store, client *clientv3.Client) {
+		obj := &GenericObject{ObjectMeta: corev2.ObjectMeta{Name: "foo", Namespace: "default"}}
+		ctx := context.WithValue(context.Background(), corev2.NamespaceKey, "default")
+		if err := s.CreateOrUpdateResource(ctx, obj); err != nil {
+			t.Fatalf("could not create a resource: %s", err)
+		}
+		etag, err := store.ETag(obj)
+		if err != nil {
+			t.Fatalf("could not determine the etag: %s", err)
+		}
+
+		// A resource modified since it was read is not deleted
+		err = s.DeleteResourceIfMatch(ctx, &GenericObject{}, "foo", `"12345"`)
+		if _, ok := err.(*store.ErrPreconditionFailed); !ok {
+			t.Fatalf("expected an error of type *store.ErrPreconditionFailed, got %v", err)
+		}
+
+		if err := s.DeleteResourceIfMatch(ctx, &GenericObject{}, "foo", etag); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if err := s.GetResource(ctx, "foo", &GenericObject{}); err == nil {
+			t.Fatal("expected the resource to be deleted")
+		}
+
+		// A deleted resource fails the condition
+		err = s.DeleteResourceIfMatch(ctx, &GenericObject{}, "foo", etag)
+		if _, ok := err.(*store.ErrPreconditionFailed); !ok {
+			t.Fatalf("expected an error of type *store.ErrPreconditionFailed, got %v", err)
+		}
+	})
+}
@@ -167,3 +167,35 @@ func TestSilencedStorageWithBeginAndExpire(t *testing.T) {
 		assert.Equal(t, entry.Expire, int64(15))
 	})
 }
+
+func TestSilencedWatcherCancelWhilePending(t *testing.T) {
+	testWithEtcd(t, func(s store.Store) {
+		ctx := context.WithValue(context.Background(), types.NamespaceKey, "default")
+		watchCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		ch := s.GetSilencedWatcher(watchCtx)
+
+		// The first event fills the channel, and the second one is left
+		// pending since the channel is not read
+		for _, sub := range []string{"first", "second"} {
+			silenced := types.FixtureSilenced(sub + ":checkname")
+			silenced.Namespace = "default"
+			require.NoError(t, s.UpdateSilencedEntry(ctx, silenced))
+		}
+		require.Eventually(t, func() bool { return len(ch) == cap(ch) }, 5*time.Second, 10*time.Millisecond)
+		time.Sleep(100 * time.Millisecond)
+
+		// The watcher gives up on the pending event once cancelled
+		cancel()
+		time.Sleep(100 * time.Millisecond)
+		<-ch
+		select {
+		case event, ok := <-ch:
+			if ok {
+				t.Fatalf("got event %v after the watcher was cancelled", event)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("the watcher was not closed")
+		}
+	})
+}
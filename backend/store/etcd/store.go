@@ -38,7 +38,8 @@ func NewStore(client *clientv3.Client, name string) *Store {
 	return store
 }
 
-// Create the given key with the serialized object.
+// Create the given key with the serialized object. See
+// store.ContextWithIfNoneMatch for conditional creates.
 func Create(ctx context.Context, client *clientv3.Client, key, namespace string, object interface{}) error {
 	bytes, err := marshal(object)
 	if err != nil {
@@ -51,7 +52,7 @@ func Create(ctx context.Context, client *clientv3.Client, key, namespace string,
 	)
 	op := clientv3.OpPut(key, string(bytes))
 
-	return kvc.Txn(ctx, client, comparator, op)
+	return store.ConditionalCreateError(ctx, kvc.Txn(ctx, client, comparator, op))
 }
 
 // CreateOrUpdate writes the given key with the serialized object, regarless of
@@ -86,6 +87,16 @@ func Delete(ctx context.Context, client *clientv3.Client, key string) error {
 	return nil
 }
 
+// DeleteWithComparisons deletes the given key, only if it exists and the
+// comparisons succeed
+func DeleteWithComparisons(ctx context.Context, client *clientv3.Client, key string, comparisons ...kvc.Predicate) error {
+	// Prepend the KeyIsFound key predicate
+	comparisons = append([]kvc.Predicate{kvc.KeyIsFound(key)}, comparisons...)
+	comparator := kvc.Comparisons(comparisons...)
+
+	return kvc.Txn(ctx, client, comparator, clientv3.OpDelete(key))
+}
+
 // Get retrieves an object with the given key
 func Get(ctx context.Context, client *clientv3.Client, key string, object interface{}) error {
 	_, err := GetWithResponse(ctx, client, key, object)
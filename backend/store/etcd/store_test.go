@@ -82,6 +82,16 @@ func TestCreate(t *testing.T) {
 			t.Errorf("Expected error ErrAlreadyExists, received %v", err)
 		}
 
+		// Creating it again with If-None-Match: * should fail its precondition
+		conditionalCtx := store.ContextWithIfNoneMatch(ctx, "*")
+		err = Create(conditionalCtx, s.client, "/default/foo", "default", obj)
+		switch err := err.(type) {
+		case *store.ErrPreconditionFailed:
+			break
+		default:
+			t.Errorf("Expected error ErrPreconditionFailed, received %v", err)
+		}
+
 		// Creating a namespaced key in a missing namespace should return an error
 		ctx = context.WithValue(context.Background(), types.NamespaceKey, "acme")
 		err = Create(ctx, s.client, "/acme/foo", "acme", obj)
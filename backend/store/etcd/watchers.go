@@ -90,6 +90,46 @@ func (s *Store) GetTessenConfigWatcher(ctx context.Context) <-chan store.WatchEv
 	return ch
 }
 
+// GetSilencedWatcher returns a channel that emits WatchEventSilenced structs
+// notifying the caller that a silenced entry was created, updated or deleted.
+// If the watcher runs into a terminal error or the context passed is
+// cancelled, then the channel will be closed.
+func (s *Store) GetSilencedWatcher(ctx context.Context) <-chan store.WatchEventSilenced {
+	key := silencedKeyBuilder.WithContext(ctx).Build()
+	w := Watch(ctx, s.client, key, true)
+	ch := make(chan store.WatchEventSilenced, 1)
+
+	go func() {
+		defer close(ch)
+		for response := range w.Result() {
+			if response.Type == store.WatchError {
+				continue
+			}
+
+			var silenced corev2.Silenced
+
+			if err := unmarshal(response.Object, &silenced); err != nil {
+				logger.WithField("key", response.Key).WithError(err).Error("unable to unmarshal silenced entry from key")
+				continue
+			}
+
+			event := store.WatchEventSilenced{
+				Action:   response.Type,
+				Silenced: &silenced,
+			}
+			// The caller may have stopped reading once it cancelled the
+			// context
+			select {
+			case ch <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
 // GetResourceWatcher ...
 func GetResourceWatcher(ctx context.Context, client *clientv3.Client, key string, elemType reflect.Type) <-chan store.WatchEventResource {
 	w := Watch(ctx, client, key, true)
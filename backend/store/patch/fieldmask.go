@@ -0,0 +1,148 @@
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ResourcePatcher is implemented by patchers that can be applied directly to a
+// decoded resource, which spares the stores a JSON round-trip.
+type ResourcePatcher interface {
+	Patcher
+
+	// PatchResource applies the patch to resource, in place.
+	PatchResource(resource interface{}) error
+}
+
+// FieldMask is a patcher that copies the fields listed by a field mask from a
+// decoded protobuf message to the patched resource, which must be of the same
+// type. Unlike merge patches, masked fields that hold their zero value in the
+// message clear the field of the resource, and fields that are not masked are
+// left alone even if the message sets them.
+type FieldMask struct {
+	// Paths are the dot-separated paths of the masked fields, e.g.
+	// "metadata.labels". Fields are named by their protobuf or JSON name.
+	Paths []string
+
+	// Message holds the new values of the masked fields.
+	Message interface{}
+}
+
+// InvalidFieldMaskError is returned when a path of a field mask does not
+// reference a field of the patched resource.
+type InvalidFieldMaskError struct {
+	Path   string
+	Reason string
+}
+
+func (e *InvalidFieldMaskError) Error() string {
+	return fmt.Sprintf("invalid field mask path %q: %s", e.Path, e.Reason)
+}
+
+// Patch applies the field mask to the JSON encoding of a resource, for stores
+// that don't decode resources themselves.
+func (f *FieldMask) Patch(document []byte) ([]byte, error) {
+	if f.Message == nil {
+		return nil, errors.New("a field mask patch needs a message")
+	}
+	resource := reflect.New(reflect.TypeOf(f.Message).Elem()).Interface()
+	if err := json.Unmarshal(document, resource); err != nil {
+		return nil, err
+	}
+	if err := f.PatchResource(resource); err != nil {
+		return nil, err
+	}
+	return json.Marshal(resource)
+}
+
+// PatchResource copies the masked fields of the message to resource.
+func (f *FieldMask) PatchResource(resource interface{}) error {
+	dst := reflect.ValueOf(resource)
+	src := reflect.ValueOf(f.Message)
+	if dst.Kind() != reflect.Ptr || dst.IsNil() {
+		return fmt.Errorf("cannot apply a field mask to %T", resource)
+	}
+	if src.Type() != dst.Type() || src.IsNil() {
+		return fmt.Errorf("cannot apply a field mask of %T to %T", f.Message, resource)
+	}
+	for _, path := range f.Paths {
+		if path == "" {
+			return &InvalidFieldMaskError{Path: path, Reason: "empty path"}
+		}
+		if err := applyMaskPath(dst.Elem(), src.Elem(), strings.Split(path, "."), path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyMaskPath copies the field of src referenced by names to dst. An invalid
+// src stands for a missing message, whose fields are all unset.
+func applyMaskPath(dst, src reflect.Value, names []string, path string) error {
+	field, ok := maskField(dst.Type(), names[0])
+	if !ok {
+		return &InvalidFieldMaskError{Path: path, Reason: fmt.Sprintf("unknown field %q", names[0])}
+	}
+	d := dst.FieldByIndex(field.Index)
+	var s reflect.Value
+	if src.IsValid() {
+		s = src.FieldByIndex(field.Index)
+	}
+
+	if len(names) == 1 {
+		if s.IsValid() {
+			d.Set(s)
+		} else {
+			d.Set(reflect.Zero(d.Type()))
+		}
+		return nil
+	}
+
+	switch d.Kind() {
+	case reflect.Struct:
+	case reflect.Ptr:
+		if d.Type().Elem().Kind() != reflect.Struct {
+			return &InvalidFieldMaskError{Path: path, Reason: fmt.Sprintf("field %q is not a message", names[0])}
+		}
+		if s.IsValid() && s.IsNil() {
+			s = reflect.Value{}
+		}
+		if d.IsNil() {
+			if !s.IsValid() {
+				// There is nothing to clear
+				return nil
+			}
+			d.Set(reflect.New(d.Type().Elem()))
+		}
+		d = d.Elem()
+		if s.IsValid() {
+			s = s.Elem()
+		}
+	default:
+		return &InvalidFieldMaskError{Path: path, Reason: fmt.Sprintf("field %q is not a message", names[0])}
+	}
+	return applyMaskPath(d, s, names[1:], path)
+}
+
+// maskField returns the field of the struct type t whose protobuf or JSON name
+// is name.
+func maskField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" || strings.HasPrefix(field.Name, "XXX_") {
+			continue
+		}
+		for _, option := range strings.Split(field.Tag.Get("protobuf"), ",") {
+			if option == "name="+name {
+				return field, true
+			}
+		}
+		if jsonName := strings.Split(field.Tag.Get("json"), ",")[0]; jsonName == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
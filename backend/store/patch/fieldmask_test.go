@@ -0,0 +1,134 @@
+package patch
+
+import (
+	"reflect"
+	"testing"
+)
+
+type maskTestMeta struct {
+	Name   string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Labels map[string]string `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty"`
+}
+
+type maskTestResource struct {
+	Metadata      *maskTestMeta `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Interval      uint32        `protobuf:"varint,2,opt,name=interval,proto3" json:"interval"`
+	Publish       bool          `protobuf:"varint,3,opt,name=publish,proto3" json:"publish"`
+	Subscriptions []string      `protobuf:"bytes,4,rep,name=subscriptions,proto3" json:"subscriptions"`
+	TTL           int64         `protobuf:"varint,5,opt,name=ttl,proto3" json:"check_ttl"`
+}
+
+func fixtureMaskTestResource() *maskTestResource {
+	return &maskTestResource{
+		Metadata:      &maskTestMeta{Name: "foo", Labels: map[string]string{"a": "1"}},
+		Interval:      60,
+		Publish:       true,
+		Subscriptions: []string{"linux"},
+		TTL:           120,
+	}
+}
+
+func TestFieldMask_PatchResource(t *testing.T) {
+	tests := []struct {
+		name    string
+		paths   []string
+		message *maskTestResource
+		want    func(*maskTestResource)
+		wantErr bool
+	}{
+		{
+			name:    "masked fields are replaced",
+			paths:   []string{"interval", "subscriptions"},
+			message: &maskTestResource{Interval: 30, Subscriptions: []string{"windows"}, TTL: 10},
+			want: func(r *maskTestResource) {
+				r.Interval = 30
+				r.Subscriptions = []string{"windows"}
+			},
+		},
+		{
+			name:    "masked fields are cleared by zero values",
+			paths:   []string{"publish", "subscriptions"},
+			message: &maskTestResource{},
+			want: func(r *maskTestResource) {
+				r.Publish = false
+				r.Subscriptions = nil
+			},
+		},
+		{
+			name:    "json names are accepted",
+			paths:   []string{"check_ttl"},
+			message: &maskTestResource{TTL: 10},
+			want: func(r *maskTestResource) {
+				r.TTL = 10
+			},
+		},
+		{
+			name:    "nested fields are replaced",
+			paths:   []string{"metadata.labels"},
+			message: &maskTestResource{Metadata: &maskTestMeta{Name: "bar", Labels: map[string]string{"b": "2"}}},
+			want: func(r *maskTestResource) {
+				r.Metadata.Labels = map[string]string{"b": "2"}
+			},
+		},
+		{
+			name:    "nested fields of a missing message are cleared",
+			paths:   []string{"metadata.labels"},
+			message: &maskTestResource{},
+			want: func(r *maskTestResource) {
+				r.Metadata.Labels = nil
+			},
+		},
+		{
+			name:    "unknown field",
+			paths:   []string{"command"},
+			message: &maskTestResource{},
+			wantErr: true,
+		},
+		{
+			name:    "scalar cannot be traversed",
+			paths:   []string{"interval.seconds"},
+			message: &maskTestResource{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fixtureMaskTestResource()
+			f := &FieldMask{Paths: tt.paths, Message: tt.message}
+			err := f.PatchResource(got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FieldMask.PatchResource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if _, ok := err.(*InvalidFieldMaskError); !ok {
+					t.Errorf("FieldMask.PatchResource() error = %T, want *InvalidFieldMaskError", err)
+				}
+				return
+			}
+			want := fixtureMaskTestResource()
+			tt.want(want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("FieldMask.PatchResource() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestFieldMask_PatchResourceTypeMismatch(t *testing.T) {
+	f := &FieldMask{Paths: []string{"name"}, Message: &maskTestMeta{}}
+	if err := f.PatchResource(fixtureMaskTestResource()); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestFieldMask_Patch(t *testing.T) {
+	f := &FieldMask{Paths: []string{"interval"}, Message: &maskTestResource{Interval: 30}}
+	got, err := f.Patch([]byte(`{"interval":60,"publish":true,"subscriptions":["linux"],"check_ttl":0}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"interval":30,"publish":true,"subscriptions":["linux"],"check_ttl":0}`
+	if string(got) != want {
+		t.Errorf("FieldMask.Patch() = %s, want %s", got, want)
+	}
+}
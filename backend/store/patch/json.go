@@ -0,0 +1,57 @@
+package patch
+
+import (
+	"errors"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+// JSON is a patcher for JSON Patches as defined in RFC6902. Its test
+// operations act as preconditions: the patch is only applied if every one of
+// them succeeds.
+type JSON struct {
+	JSONPatch []byte
+}
+
+// TestFailedError is returned when a test operation of a JSON Patch does not
+// match the document, so that the whole patch is rejected.
+type TestFailedError struct {
+	Path string
+	Err  error
+}
+
+func (e *TestFailedError) Error() string {
+	return fmt.Sprintf("test operation on %q failed: %s", e.Path, e.Err)
+}
+
+func (e *TestFailedError) Unwrap() error {
+	return e.Err
+}
+
+// Patch applies the operations of the patch to the original document, in
+// order. Failed test operations are reported as a *TestFailedError.
+func (j *JSON) Patch(document []byte) ([]byte, error) {
+	operations, err := jsonpatch.DecodePatch(j.JSONPatch)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply the operations one at a time, to tell which one failed
+	for _, op := range operations {
+		path, err := op.Path()
+		if err != nil {
+			return nil, err
+		}
+		document, err = jsonpatch.Patch{op}.Apply(document)
+		if err == nil {
+			continue
+		}
+		if op.Kind() == "test" && (errors.Is(err, jsonpatch.ErrTestFailed) || errors.Is(err, jsonpatch.ErrMissing)) {
+			return nil, &TestFailedError{Path: path, Err: err}
+		}
+		return nil, err
+	}
+
+	return document, nil
+}
@@ -0,0 +1,72 @@
+package patch
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJSON_Patch(t *testing.T) {
+	tests := []struct {
+		name         string
+		original     []byte
+		patch        []byte
+		want         []byte
+		wantErr      bool
+		wantTestFail bool
+	}{
+		{
+			name:     "value is replaced",
+			original: []byte(`{"interval":10,"name":"foo"}`),
+			patch:    []byte(`[{"op":"replace","path":"/interval","value":20}]`),
+			want:     []byte(`{"interval":20,"name":"foo"}`),
+		},
+		{
+			name:     "successful test",
+			original: []byte(`{"interval":10,"name":"foo"}`),
+			patch:    []byte(`[{"op":"test","path":"/interval","value":10},{"op":"replace","path":"/interval","value":20}]`),
+			want:     []byte(`{"interval":20,"name":"foo"}`),
+		},
+		{
+			name:         "failed test",
+			original:     []byte(`{"interval":10,"name":"foo"}`),
+			patch:        []byte(`[{"op":"test","path":"/interval","value":30},{"op":"replace","path":"/interval","value":20}]`),
+			wantErr:      true,
+			wantTestFail: true,
+		},
+		{
+			name:         "test of a missing value",
+			original:     []byte(`{"name":"foo"}`),
+			patch:        []byte(`[{"op":"test","path":"/metadata/name","value":"foo"}]`),
+			wantErr:      true,
+			wantTestFail: true,
+		},
+		{
+			name:     "missing path",
+			original: []byte(`{"name":"foo"}`),
+			patch:    []byte(`[{"op":"test","value":"foo"}]`),
+			wantErr:  true,
+		},
+		{
+			name:     "failed operation",
+			original: []byte(`{"name":"foo"}`),
+			patch:    []byte(`[{"op":"remove","path":"/interval"}]`),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &JSON{JSONPatch: tt.patch}
+			got, err := j.Patch(tt.original)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("JSON.Patch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if _, ok := err.(*TestFailedError); ok != tt.wantTestFail {
+				t.Errorf("JSON.Patch() error = %v, wantTestFail %v", err, tt.wantTestFail)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("JSON.Patch() = %s, want %s", string(got), string(tt.want))
+			}
+		})
+	}
+}
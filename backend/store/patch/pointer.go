@@ -0,0 +1,136 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Pointer is a patcher that replaces the values of a document referenced by
+// JSON Pointers, as defined in RFC6901. Unlike merge patches, it can replace a
+// single element of an array. The patch is a JSON object that maps pointers to
+// their new value, e.g. {"/subscriptions/2": "linux"}.
+type Pointer struct {
+	PointerPatch []byte
+}
+
+// InvalidPointerError is returned when a pointer of a patch does not
+// reference an existing value of the document.
+type InvalidPointerError struct {
+	Pointer string
+	Reason  string
+}
+
+func (e *InvalidPointerError) Error() string {
+	return fmt.Sprintf("invalid pointer %q: %s", e.Pointer, e.Reason)
+}
+
+// Patch replaces the values referenced by the pointers of the patch in the
+// original document
+func (p *Pointer) Patch(document []byte) ([]byte, error) {
+	var changes map[string]json.RawMessage
+	if err := json.Unmarshal(p.PointerPatch, &changes); err != nil {
+		return nil, err
+	}
+
+	var doc interface{}
+	if err := decodeUseNumber(document, &doc); err != nil {
+		return nil, err
+	}
+
+	// Apply the changes in a deterministic order
+	pointers := make([]string, 0, len(changes))
+	for pointer := range changes {
+		pointers = append(pointers, pointer)
+	}
+	sort.Strings(pointers)
+
+	for _, pointer := range pointers {
+		if pointer == "" {
+			return nil, &InvalidPointerError{Pointer: pointer, Reason: "the whole document cannot be replaced"}
+		}
+		if !strings.HasPrefix(pointer, "/") {
+			return nil, &InvalidPointerError{Pointer: pointer, Reason: "must start with a slash"}
+		}
+		var value interface{}
+		if err := decodeUseNumber(changes[pointer], &value); err != nil {
+			return nil, err
+		}
+		tokens := strings.Split(pointer[1:], "/")
+		for i, token := range tokens {
+			tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		}
+		var err error
+		if doc, err = replace(doc, tokens, value, pointer); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(doc)
+}
+
+// replace replaces the value of node referenced by tokens with value
+func replace(node interface{}, tokens []string, value interface{}, pointer string) (interface{}, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	token, rest := tokens[0], tokens[1:]
+
+	switch node := node.(type) {
+	case map[string]interface{}:
+		child, ok := node[token]
+		if !ok {
+			return nil, &InvalidPointerError{Pointer: pointer, Reason: fmt.Sprintf("member %q does not exist", token)}
+		}
+		replaced, err := replace(child, rest, value, pointer)
+		if err != nil {
+			return nil, err
+		}
+		node[token] = replaced
+		return node, nil
+	case []interface{}:
+		index, err := arrayIndex(token)
+		if err != nil {
+			return nil, &InvalidPointerError{Pointer: pointer, Reason: err.Error()}
+		}
+		if index >= len(node) {
+			return nil, &InvalidPointerError{
+				Pointer: pointer,
+				Reason:  fmt.Sprintf("index %d is out of range for an array of length %d", index, len(node)),
+			}
+		}
+		replaced, err := replace(node[index], rest, value, pointer)
+		if err != nil {
+			return nil, err
+		}
+		node[index] = replaced
+		return node, nil
+	}
+
+	return nil, &InvalidPointerError{Pointer: pointer, Reason: fmt.Sprintf("%q references a scalar value", token)}
+}
+
+// arrayIndex parses an array index reference token. Leading zeros and the "-"
+// token, which references a nonexistent element, are not allowed.
+func arrayIndex(token string) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%q is not a valid array index", token)
+	}
+	for _, c := range token {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("%q is not a valid array index", token)
+		}
+	}
+	return strconv.Atoi(token)
+}
+
+// decodeUseNumber decodes data into v, keeping numbers as json.Number so that
+// they round-trip without losing precision
+func decodeUseNumber(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
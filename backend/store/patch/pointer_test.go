@@ -0,0 +1,90 @@
+package patch
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPointer_Patch(t *testing.T) {
+	tests := []struct {
+		name     string
+		original []byte
+		patch    []byte
+		want     []byte
+		wantErr  bool
+	}{
+		{
+			name:     "array element is replaced",
+			original: []byte(`{"name":"foo","subscriptions":["a","b","c"]}`),
+			patch:    []byte(`{"/subscriptions/2":"d"}`),
+			want:     []byte(`{"name":"foo","subscriptions":["a","b","d"]}`),
+		},
+		{
+			name:     "nested member is replaced",
+			original: []byte(`{"metadata":{"labels":{"a/b":"1"},"name":"foo"}}`),
+			patch:    []byte(`{"/metadata/labels/a~1b":"2"}`),
+			want:     []byte(`{"metadata":{"labels":{"a/b":"2"},"name":"foo"}}`),
+		},
+		{
+			name:     "numbers keep their precision",
+			original: []byte(`{"interval":9007199254740993,"subscriptions":["a"]}`),
+			patch:    []byte(`{"/subscriptions/0":"b"}`),
+			want:     []byte(`{"interval":9007199254740993,"subscriptions":["b"]}`),
+		},
+		{
+			name:     "out of range index",
+			original: []byte(`{"subscriptions":["a","b"]}`),
+			patch:    []byte(`{"/subscriptions/2":"c"}`),
+			wantErr:  true,
+		},
+		{
+			name:     "append token is not supported",
+			original: []byte(`{"subscriptions":["a","b"]}`),
+			patch:    []byte(`{"/subscriptions/-":"c"}`),
+			wantErr:  true,
+		},
+		{
+			name:     "leading zero index",
+			original: []byte(`{"subscriptions":["a","b"]}`),
+			patch:    []byte(`{"/subscriptions/01":"c"}`),
+			wantErr:  true,
+		},
+		{
+			name:     "missing member",
+			original: []byte(`{"subscriptions":["a"]}`),
+			patch:    []byte(`{"/handlers/0":"c"}`),
+			wantErr:  true,
+		},
+		{
+			name:     "scalar cannot be traversed",
+			original: []byte(`{"command":"echo"}`),
+			patch:    []byte(`{"/command/0":"c"}`),
+			wantErr:  true,
+		},
+		{
+			name:     "whole document",
+			original: []byte(`{"command":"echo"}`),
+			patch:    []byte(`{"":{}}`),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Pointer{PointerPatch: tt.patch}
+			got, err := p.Patch(tt.original)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Pointer.Patch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				if _, ok := err.(*InvalidPointerError); !ok {
+					t.Errorf("Pointer.Patch() error = %T, want *InvalidPointerError", err)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Pointer.Patch() = %s, want %s", string(got), string(tt.want))
+			}
+		})
+	}
+}
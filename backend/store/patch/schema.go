@@ -0,0 +1,212 @@
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// SchemaError is returned when a merge patch sets a field that does not exist
+// on the target resource, or sets it to a value of an incompatible type.
+type SchemaError struct {
+	// Path is the JSON Pointer of the offending value in the patch
+	Path   string
+	Reason string
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("invalid patch at %q: %s", e.Path, e.Reason)
+}
+
+// ValidateSchema checks that the fields set by the merge patch exist on the
+// type of target, and that their values can be decoded into them, so that bad
+// patches are rejected before the store applies them. Null values remove
+// fields, and are therefore accepted for any existing field.
+func (m *Merge) ValidateSchema(target interface{}) error {
+	var patch interface{}
+	if err := decodeUseNumber(m.MergePatch, &patch); err != nil {
+		return err
+	}
+	if _, ok := patch.(map[string]interface{}); !ok {
+		return &SchemaError{Path: "", Reason: "a merge patch must be an object"}
+	}
+	t := reflect.TypeOf(target)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return nil
+	}
+
+	// Resources may implement json.Unmarshaler to fill in defaults, so the
+	// fields of the target itself are always checked
+	return validateStruct("", patch.(map[string]interface{}), t)
+}
+
+// validateValue checks that value can be decoded into a value of type t.
+func validateValue(path string, value interface{}, t reflect.Type) error {
+	if value == nil {
+		return nil
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	// Types that decode themselves can't be described
+	if reflect.PtrTo(t).Implements(unmarshalerType) {
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return mismatch(path, "an object", value)
+		}
+		return validateStruct(path, object, t)
+	case reflect.Map:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return mismatch(path, "an object", value)
+		}
+		for key, elem := range object {
+			if err := validateValue(path+"/"+escapePointerToken(key), elem, t.Elem()); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// Byte slices are encoded as base64 strings
+			if _, ok := value.(string); !ok {
+				return mismatch(path, "a string", value)
+			}
+			return nil
+		}
+		array, ok := value.([]interface{})
+		if !ok {
+			return mismatch(path, "an array", value)
+		}
+		for i, elem := range array {
+			if err := validateValue(path+"/"+strconv.Itoa(i), elem, t.Elem()); err != nil {
+				return err
+			}
+		}
+	case reflect.String:
+		if _, ok := value.(string); !ok {
+			return mismatch(path, "a string", value)
+		}
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			return mismatch(path, "a boolean", value)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := value.(json.Number)
+		if !ok {
+			return mismatch(path, "an integer", value)
+		}
+		if _, err := strconv.ParseInt(n.String(), 10, t.Bits()); err != nil {
+			return &SchemaError{Path: path, Reason: fmt.Sprintf("%s is not a valid %s", n, t.Kind())}
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := value.(json.Number)
+		if !ok {
+			return mismatch(path, "an unsigned integer", value)
+		}
+		if _, err := strconv.ParseUint(n.String(), 10, t.Bits()); err != nil {
+			return &SchemaError{Path: path, Reason: fmt.Sprintf("%s is not a valid %s", n, t.Kind())}
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := value.(json.Number); !ok {
+			return mismatch(path, "a number", value)
+		}
+	}
+	return nil
+}
+
+// validateStruct checks that each member of object is a field of the struct
+// type t, and that its value can be decoded into it.
+func validateStruct(path string, object map[string]interface{}, t reflect.Type) error {
+	fields := jsonFields(t)
+	for key, value := range object {
+		field, ok := fields[key]
+		if !ok {
+			// encoding/json falls back to case-insensitive matches
+			for name, f := range fields {
+				if strings.EqualFold(name, key) {
+					field, ok = f, true
+					break
+				}
+			}
+		}
+		memberPath := path + "/" + escapePointerToken(key)
+		if !ok {
+			return &SchemaError{Path: memberPath, Reason: "unknown field"}
+		}
+		if err := validateValue(memberPath, value, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonFields returns the types of the fields of the struct type t, keyed by
+// their JSON name. The fields of embedded structs without a JSON name are
+// promoted, like encoding/json does.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for name, f := range jsonFields(embedded) {
+					if _, ok := fields[name]; !ok {
+						fields[name] = f
+					}
+				}
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			// unexported fields are ignored by encoding/json
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+func mismatch(path, expected string, value interface{}) error {
+	var got string
+	switch value.(type) {
+	case map[string]interface{}:
+		got = "an object"
+	case []interface{}:
+		got = "an array"
+	case string:
+		got = "a string"
+	case bool:
+		got = "a boolean"
+	case json.Number:
+		got = "a number"
+	}
+	return &SchemaError{Path: path, Reason: fmt.Sprintf("expected %s, got %s", expected, got)}
+}
+
+func escapePointerToken(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
@@ -0,0 +1,95 @@
+package patch
+
+import (
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+func TestMerge_ValidateSchema(t *testing.T) {
+	tests := []struct {
+		name     string
+		patch    string
+		wantPath string
+		wantErr  bool
+	}{
+		{
+			name:  "valid fields",
+			patch: `{"interval":60,"subscriptions":["linux"],"publish":true,"metadata":{"labels":{"a/b":"c"}}}`,
+		},
+		{
+			name:  "null values remove fields",
+			patch: `{"subscriptions":null,"metadata":{"labels":null}}`,
+		},
+		{
+			name:  "case-insensitive field names",
+			patch: `{"Interval":60}`,
+		},
+		{
+			name:     "unknown field",
+			patch:    `{"invalid":["windows"]}`,
+			wantPath: "/invalid",
+			wantErr:  true,
+		},
+		{
+			name:     "unknown nested field",
+			patch:    `{"metadata":{"lables":{"a":"b"}}}`,
+			wantPath: "/metadata/lables",
+			wantErr:  true,
+		},
+		{
+			name:     "incompatible type",
+			patch:    `{"subscriptions":3}`,
+			wantPath: "/subscriptions",
+			wantErr:  true,
+		},
+		{
+			name:     "incompatible array element",
+			patch:    `{"subscriptions":["linux",3]}`,
+			wantPath: "/subscriptions/1",
+			wantErr:  true,
+		},
+		{
+			name:     "incompatible map value",
+			patch:    `{"metadata":{"labels":{"a/b":1}}}`,
+			wantPath: "/metadata/labels/a~1b",
+			wantErr:  true,
+		},
+		{
+			name:     "negative unsigned integer",
+			patch:    `{"interval":-1}`,
+			wantPath: "/interval",
+			wantErr:  true,
+		},
+		{
+			name:     "fractional integer",
+			patch:    `{"interval":1.5}`,
+			wantPath: "/interval",
+			wantErr:  true,
+		},
+		{
+			name:    "not an object",
+			patch:   `["interval"]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Merge{MergePatch: []byte(tt.patch)}
+			err := m.ValidateSchema(&corev2.CheckConfig{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Merge.ValidateSchema() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantPath == "" {
+				return
+			}
+			schemaErr, ok := err.(*SchemaError)
+			if !ok {
+				t.Fatalf("expected a SchemaError, got %T", err)
+			}
+			if schemaErr.Path != tt.wantPath {
+				t.Errorf("bad path: got %q, want %q", schemaErr.Path, tt.wantPath)
+			}
+		})
+	}
+}
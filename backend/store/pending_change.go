@@ -0,0 +1,42 @@
+package store
+
+import (
+	"encoding/json"
+)
+
+// PendingChange is an update or a deletion of a resource annotated with
+// corev2.RequiresApprovalAnnotation, which is only applied once a user other
+// than its requester approves it.
+type PendingChange struct {
+	// ID identifies the change, so that approvers approve the very change
+	// they reviewed rather than one that replaced it in the meantime
+	ID string `json:"id"`
+
+	// Namespace is the namespace of the resource, empty for cluster-wide
+	// resources
+	Namespace string `json:"namespace,omitempty"`
+
+	// Resource is the store prefix of the resource
+	Resource string `json:"resource"`
+
+	// Name is the name of the resource
+	Name string `json:"name"`
+
+	// Requester is the JWT subject of the user who requested the change
+	Requester string `json:"requester"`
+
+	// ETag is the ETag of the resource when the change was requested. The
+	// change can't be approved once the resource was modified.
+	ETag string `json:"etag"`
+
+	// Proposed is the JSON encoding of the resource once changed, empty for
+	// deletions
+	Proposed json.RawMessage `json:"proposed,omitempty"`
+
+	// Delete is whether the change deletes the resource
+	Delete bool `json:"delete,omitempty"`
+
+	// CreatedAt is the time the change was requested, in seconds since the
+	// Unix epoch
+	CreatedAt int64 `json:"created_at"`
+}
@@ -233,6 +233,39 @@ func (s *StoreProxy) UpdateHookConfig(ctx context.Context, check *types.HookConf
 	return s.do().UpdateHookConfig(ctx, check)
 }
 
+// ReserveIdempotencyKey records the fingerprint of the request identified by
+// key, unless the key is already recorded.
+func (s *StoreProxy) ReserveIdempotencyKey(ctx context.Context, key, fingerprint string, ttl time.Duration) (string, error) {
+	return s.do().ReserveIdempotencyKey(ctx, key, fingerprint, ttl)
+}
+
+// ReleaseIdempotencyKey deletes key if its recorded fingerprint is the given
+// one.
+func (s *StoreProxy) ReleaseIdempotencyKey(ctx context.Context, key, fingerprint string) error {
+	return s.do().ReleaseIdempotencyKey(ctx, key, fingerprint)
+}
+
+// GetIdempotencyKey returns the fingerprint recorded for key.
+func (s *StoreProxy) GetIdempotencyKey(ctx context.Context, key string) (string, error) {
+	return s.do().GetIdempotencyKey(ctx, key)
+}
+
+// PutPendingChange records the pending change of a resource.
+func (s *StoreProxy) PutPendingChange(ctx context.Context, change *PendingChange) error {
+	return s.do().PutPendingChange(ctx, change)
+}
+
+// GetPendingChange returns the pending change of a resource, if any.
+func (s *StoreProxy) GetPendingChange(ctx context.Context, resourcePrefix, name string) (*PendingChange, error) {
+	return s.do().GetPendingChange(ctx, resourcePrefix, name)
+}
+
+// DeletePendingChange deletes the pending change of a resource, if it is the
+// change with the given ID.
+func (s *StoreProxy) DeletePendingChange(ctx context.Context, resourcePrefix, name, id string) error {
+	return s.do().DeletePendingChange(ctx, resourcePrefix, name, id)
+}
+
 // DeleteEntity deletes an entity using the given entity struct.
 func (s *StoreProxy) DeleteEntity(ctx context.Context, entity *types.Entity) error {
 	return s.do().DeleteEntity(ctx, entity)
@@ -372,6 +405,18 @@ func (s *StoreProxy) GetNamespace(ctx context.Context, name string) (*types.Name
 	return s.do().GetNamespace(ctx, name)
 }
 
+// GetNamespaces returns the namespaces with the given names, in a single
+// read. Namespaces that were not found are omitted from the result.
+func (s *StoreProxy) GetNamespaces(ctx context.Context, names []string) ([]*types.Namespace, error) {
+	return s.do().GetNamespaces(ctx, names)
+}
+
+// CountNamespaceResources counts the resources stored under each of the given
+// store prefixes, per namespace, in a single read.
+func (s *StoreProxy) CountNamespaceResources(ctx context.Context, namespaces, prefixes []string) (map[string]map[string]int64, error) {
+	return s.do().CountNamespaceResources(ctx, namespaces, prefixes)
+}
+
 // UpdateNamespace updates an existing namespace.
 func (s *StoreProxy) UpdateNamespace(ctx context.Context, org *types.Namespace) error {
 	return s.do().UpdateNamespace(ctx, org)
@@ -388,6 +433,10 @@ func (s *StoreProxy) DeleteResource(ctx context.Context, kind, name string) erro
 	return s.do().DeleteResource(ctx, kind, name)
 }
 
+func (s *StoreProxy) DeleteResourceIfMatch(ctx context.Context, resource corev2.Resource, name, ifMatch string) error {
+	return s.do().DeleteResourceIfMatch(ctx, resource, name, ifMatch)
+}
+
 func (s *StoreProxy) GetResource(ctx context.Context, name string, resource corev2.Resource) error {
 	return s.do().GetResource(ctx, name, resource)
 }
@@ -505,6 +554,11 @@ func (s *StoreProxy) GetSilencedEntriesByName(ctx context.Context, id ...string)
 	return s.do().GetSilencedEntriesByName(ctx, id...)
 }
 
+// GetSilencedWatcher returns a silenced entry watcher
+func (s *StoreProxy) GetSilencedWatcher(ctx context.Context) <-chan WatchEventSilenced {
+	return s.do().GetSilencedWatcher(ctx)
+}
+
 // CreateOrUpdateTessenConfig creates or updates the tessen configuration
 func (s *StoreProxy) CreateOrUpdateTessenConfig(ctx context.Context, cfg *corev2.TessenConfig) error {
 	return s.do().CreateOrUpdateTessenConfig(ctx, cfg)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"time"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	corev3 "github.com/sensu/sensu-go/api/core/v3"
@@ -132,6 +133,13 @@ type WatchEventTessenConfig struct {
 	Action       WatchActionType
 }
 
+// WatchEventSilenced is a notification that a silenced entry has been
+// created, updated or deleted.
+type WatchEventSilenced struct {
+	Silenced *corev2.Silenced
+	Action   WatchActionType
+}
+
 // WatchEventResource is a store event about a specific resource
 type WatchEventResource struct {
 	Resource corev2.Resource
@@ -190,6 +198,13 @@ type Store interface {
 	// HookConfigStore provides an interface for managing hooks configuration
 	HookConfigStore
 
+	// IdempotencyStore provides an interface for recording idempotency keys
+	IdempotencyStore
+
+	// PendingChangeStore provides an interface for managing the changes that
+	// await approval
+	PendingChangeStore
+
 	// KeepaliveStore provides an interface for managing entities keepalives
 	KeepaliveStore
 
@@ -298,6 +313,41 @@ type ClusterIDStore interface {
 	GetClusterID(context.Context) (string, error)
 }
 
+// IdempotencyStore provides methods for recording idempotency keys, which
+// identify requests that clients may send again, e.g. after a network error.
+type IdempotencyStore interface {
+	// ReserveIdempotencyKey records the fingerprint of the request identified
+	// by key, for the given duration, unless the key is already recorded. It
+	// returns the fingerprint already recorded for the key, or an empty string
+	// if the key was reserved.
+	ReserveIdempotencyKey(ctx context.Context, key, fingerprint string, ttl time.Duration) (string, error)
+
+	// ReleaseIdempotencyKey deletes key if its recorded fingerprint is the
+	// given one, e.g. because the request it identifies failed.
+	ReleaseIdempotencyKey(ctx context.Context, key, fingerprint string) error
+
+	// GetIdempotencyKey returns the fingerprint recorded for key, or an empty
+	// string if the key was never recorded or has expired.
+	GetIdempotencyKey(ctx context.Context, key string) (string, error)
+}
+
+// PendingChangeStore provides methods for managing the changes of resources
+// that await approval, see PendingChange. Resources have at most one pending
+// change, in the namespace of their context.
+type PendingChangeStore interface {
+	// PutPendingChange records the pending change of a resource, replacing
+	// the previous one if any.
+	PutPendingChange(ctx context.Context, change *PendingChange) error
+
+	// GetPendingChange returns the pending change of the resource with the
+	// given store prefix and name, or nil if it has none.
+	GetPendingChange(ctx context.Context, resourcePrefix, name string) (*PendingChange, error)
+
+	// DeletePendingChange deletes the pending change of the resource with the
+	// given store prefix and name, if it is the change with the given ID.
+	DeletePendingChange(ctx context.Context, resourcePrefix, name, id string) error
+}
+
 // ClusterRoleBindingStore provides methods for managing RBAC cluster role
 // bindings
 type ClusterRoleBindingStore interface {
@@ -507,6 +557,15 @@ type NamespaceStore interface {
 	// result is nil if none was found.
 	GetNamespace(ctx context.Context, name string) (*types.Namespace, error)
 
+	// GetNamespaces returns the namespaces with the given names, in a single
+	// read. Namespaces that were not found are omitted from the result.
+	GetNamespaces(ctx context.Context, names []string) ([]*types.Namespace, error)
+
+	// CountNamespaceResources counts the resources stored under each of the
+	// given store prefixes, for each of the given namespaces. The counts are
+	// keyed by namespace, then by prefix.
+	CountNamespaceResources(ctx context.Context, namespaces, prefixes []string) (map[string]map[string]int64, error)
+
 	// UpdateNamespace updates an existing namespace.
 	UpdateNamespace(ctx context.Context, org *types.Namespace) error
 }
@@ -526,6 +585,12 @@ type ResourceStore interface {
 
 	DeleteResource(ctx context.Context, kind, name string) error
 
+	// DeleteResourceIfMatch deletes the resource with the given name and the
+	// type of resource, only if the etag of its stored version matches
+	// ifMatch and it is not modified before it is deleted. Otherwise a
+	// *ErrPreconditionFailed is returned.
+	DeleteResourceIfMatch(ctx context.Context, resource corev2.Resource, name, ifMatch string) error
+
 	GetResource(ctx context.Context, name string, resource corev2.Resource) error
 
 	ListResources(ctx context.Context, kind string, resources interface{}, pred *SelectionPredicate) error
@@ -608,6 +673,11 @@ type SilencedStore interface {
 
 	// GetSilencedEntriesByName gets all the named silenced entries.
 	GetSilencedEntriesByName(ctx context.Context, id ...string) ([]*types.Silenced, error)
+
+	// GetSilencedWatcher returns a channel that emits WatchEventSilenced
+	// structs notifying the caller that a silenced entry within the ctx's
+	// namespace was created, updated or deleted.
+	GetSilencedWatcher(ctx context.Context) <-chan WatchEventSilenced
 }
 
 // TessenConfigStore provides methods for managing the Tessen configuration
@@ -0,0 +1,77 @@
+package etcdstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/backend/store/etcd/kvc"
+	storev2 "github.com/sensu/sensu-go/backend/store/v2"
+	"go.etcd.io/etcd/client/v3"
+)
+
+// durabilityPollInterval is how often the members of the cluster are polled
+// while waiting for a write to be persisted by all of them.
+const durabilityPollInterval = 10 * time.Millisecond
+
+// txn commits the operations of a write, then waits for the durability the
+// request asked for.
+func (s *Store) txn(req storev2.ResourceRequest, comparator *kvc.Comparator, ops ...clientv3.Op) error {
+	if err := kvc.Txn(req.Context, s.client, comparator, ops...); err != nil {
+		return err
+	}
+	if req.Durability == storev2.DurabilitySync {
+		return s.waitForMembers(req.Context)
+	}
+	return nil
+}
+
+// waitForMembers waits until every member of the cluster has persisted the
+// latest revision of the store, or until ctx is done.
+func (s *Store) waitForMembers(ctx context.Context) error {
+	// A linearizable read returns a revision that is at least as recent as
+	// any write acknowledged before it
+	var resp *clientv3.GetResponse
+	err := kvc.Backoff(ctx).Retry(func(n int) (done bool, err error) {
+		resp, err = s.client.Get(ctx, store.Root, clientv3.WithCountOnly())
+		return kvc.RetryRequest(n, err)
+	})
+	if err != nil {
+		return err
+	}
+	revision := resp.Header.Revision
+
+	members, err := s.client.MemberList(ctx)
+	if err != nil {
+		return err
+	}
+	for _, member := range members.Members {
+		if len(member.ClientURLs) == 0 {
+			// The member has not started yet, it will catch up from a snapshot
+			continue
+		}
+		if err := s.waitForMember(ctx, member.ClientURLs[0], revision); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Store) waitForMember(ctx context.Context, endpoint string, revision int64) error {
+	ticker := time.NewTicker(durabilityPollInterval)
+	defer ticker.Stop()
+	for {
+		status, err := s.client.Status(ctx, endpoint)
+		if err != nil {
+			return err
+		}
+		if status.Header.Revision >= revision {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -62,13 +62,13 @@ func init() {
 	types.RegisterResolver("store/wrap_test", fixtureResolver)
 }
 
-func testWithEtcdStore(t testing.TB, f func(*etcdstorev2.Store)) {
+func testWithEtcdStore(t testing.TB, f func(*etcdstorev2.Store), opts ...etcdstorev2.Option) {
 	logrus.SetOutput(ioutil.Discard)
 	e, cleanup := etcd.NewTestEtcd(t)
 	defer cleanup()
 
 	client := e.NewEmbeddedClient()
-	s := etcdstorev2.NewStore(client)
+	s := etcdstorev2.NewStore(client, opts...)
 	f(s)
 }
 
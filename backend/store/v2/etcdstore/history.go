@@ -0,0 +1,126 @@
+package etcdstore
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/backend/store/etcd/kvc"
+	storev2 "github.com/sensu/sensu-go/backend/store/v2"
+	"github.com/sensu/sensu-go/backend/store/v2/wrap"
+	"go.etcd.io/etcd/client/v3"
+)
+
+// historyPrefix returns the prefix under which the versions of key are
+// retained. Versions are kept outside of the resource keyspace, so they never
+// show up when listing resources.
+func historyPrefix(key string) string {
+	return path.Join(store.Root, "history", strings.TrimPrefix(key, store.Root)) + "/"
+}
+
+// versionKey returns the key of the version of key written at t. Timestamps
+// are zero-padded so that versions sort chronologically.
+func versionKey(key string, t time.Time) string {
+	return fmt.Sprintf("%s%020d", historyPrefix(key), t.UnixNano())
+}
+
+// putOps returns the operations that write value to key. When history is
+// enabled, they also record value as the latest version of key, and delete the
+// oldest versions so that no more than the history depth of the store are
+// retained.
+//
+// The retained versions are read before, and outside of, the transaction that
+// runs the operations, since a transaction can't delete the oldest keys of a
+// prefix. Concurrent writers of the same key may then both keep the oldest
+// version, so that one more version than the depth is briefly retained per
+// concurrent writer, until the next write of the key trims the history again.
+// Without history, no read is made.
+func (s *Store) putOps(ctx context.Context, key string, value []byte) ([]clientv3.Op, error) {
+	ops := []clientv3.Op{clientv3.OpPut(key, string(value))}
+	depth := s.historyDepth
+	if depth <= 0 {
+		return ops, nil
+	}
+
+	prefix := historyPrefix(key)
+	var resp *clientv3.GetResponse
+	err := kvc.Backoff(ctx).Retry(func(n int) (done bool, err error) {
+		resp, err = s.client.Get(ctx, prefix,
+			clientv3.WithPrefix(),
+			clientv3.WithKeysOnly(),
+			clientv3.WithSort(clientv3.SortByKey, clientv3.SortDescend),
+			clientv3.WithLimit(int64(depth)),
+		)
+		return kvc.RetryRequest(n, err)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) >= depth {
+		// Make room for the new version by deleting the oldest retained version,
+		// along with anything older than it
+		oldest := string(resp.Kvs[len(resp.Kvs)-1].Key)
+		ops = append(ops, clientv3.OpDelete(prefix, clientv3.WithRange(oldest+"\x00")))
+	}
+	ops = append(ops, clientv3.OpPut(versionKey(key, time.Now()), string(value)))
+
+	return ops, nil
+}
+
+// History returns the retained versions of the resource, newest first. The
+// history of a resource is deleted along with it.
+func (s *Store) History(req storev2.ResourceRequest) ([]storev2.Version, error) {
+	key := StoreKey(req)
+	if err := req.Validate(); err != nil {
+		return nil, &store.ErrNotValid{Err: err}
+	}
+
+	ctx := req.Context
+	prefix := historyPrefix(key)
+	var resp *clientv3.GetResponse
+	err := kvc.Backoff(ctx).Retry(func(n int) (done bool, err error) {
+		resp, err = s.client.Get(ctx, prefix,
+			clientv3.WithPrefix(),
+			clientv3.WithSerializable(),
+			clientv3.WithSort(clientv3.SortByKey, clientv3.SortDescend),
+		)
+		return kvc.RetryRequest(n, err)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Kvs) == 0 {
+		// Distinguish a resource without history from a missing resource
+		exists, err := s.Exists(req)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, &store.ErrNotFound{Key: key}
+		}
+	}
+
+	versions := make([]storev2.Version, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		nanos, err := strconv.ParseInt(strings.TrimPrefix(string(kv.Key), prefix), 10, 64)
+		if err != nil {
+			return nil, &store.ErrDecode{Key: string(kv.Key), Err: err}
+		}
+		var wrapper wrap.Wrapper
+		if err := proto.Unmarshal(kv.Value, &wrapper); err != nil {
+			return nil, &store.ErrDecode{Key: string(kv.Key), Err: err}
+		}
+		versions = append(versions, storev2.Version{
+			WrittenAt: time.Unix(0, nanos),
+			Wrapper:   &wrapper,
+		})
+	}
+
+	return versions, nil
+}
@@ -0,0 +1,125 @@
+package etcdstore
+
+import (
+	"context"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/backend/store/etcd/kvc"
+	"github.com/sensu/sensu-go/backend/store/v2/wrap"
+	"go.etcd.io/etcd/client/v3"
+)
+
+// scanPageSize is the number of keys read per request when scanning a store.
+const scanPageSize = 500
+
+// WrapperFormat is the encoding and compression of a wrapper.
+type WrapperFormat struct {
+	Encoding    wrap.Encoding
+	Compression wrap.Compression
+}
+
+// FormatCounts is the number of wrappers of a store, grouped by format.
+type FormatCounts struct {
+	// Formats counts the wrappers of each format.
+	Formats map[WrapperFormat]int
+
+	// Undecodable counts the values that could not be decoded as wrappers.
+	Undecodable int
+}
+
+// CountFormats counts the wrappers of the given store, in every namespace, by
+// encoding and compression. Only the wrapper envelopes are decoded; their
+// values are never decompressed nor unwrapped.
+func (s *Store) CountFormats(ctx context.Context, storeName string) (*FormatCounts, error) {
+	counts := &FormatCounts{Formats: make(map[WrapperFormat]int)}
+	err := s.scan(ctx, storeName, func(key string, wrapper *wrap.Wrapper) {
+		if wrapper == nil {
+			counts.Undecodable++
+			return
+		}
+		counts.Formats[WrapperFormat{Encoding: wrapper.Encoding, Compression: wrapper.Compression}]++
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// ETagReport is the outcome of checking the ETags of the wrappers of a store.
+type ETagReport struct {
+	// Checked counts the wrappers whose ETag was checked.
+	Checked int
+
+	// Skipped counts the wrappers whose ETag was not computed from their
+	// value, which can't be checked.
+	Skipped int
+
+	// Undecodable counts the values that could not be decoded as wrappers.
+	Undecodable int
+
+	// Mismatches holds the keys of the wrappers whose ETag does not match
+	// their value.
+	Mismatches []string
+}
+
+// CheckETags checks that the wrappers of the given store, in every namespace,
+// hold the ETag of their type and value, as computed by wrap.ComputeETag. A
+// wrapper that does not was corrupted after its ETag was recorded. Values are
+// hashed as stored, they are never decompressed nor unwrapped.
+func (s *Store) CheckETags(ctx context.Context, storeName string) (*ETagReport, error) {
+	report := &ETagReport{}
+	err := s.scan(ctx, storeName, func(key string, wrapper *wrap.Wrapper) {
+		switch {
+		case wrapper == nil:
+			report.Undecodable++
+		case !wrapper.HasComputedETag():
+			report.Skipped++
+		default:
+			report.Checked++
+			// The wrapper has a type, so the ETag can be computed
+			if ok, _ := wrapper.ETagMatches(); !ok {
+				report.Mismatches = append(report.Mismatches, key)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// scan calls fn with the key and wrapper of each value of the given store, in
+// every namespace and in key order. Values that can't be decoded as wrappers
+// are passed as a nil wrapper.
+func (s *Store) scan(ctx context.Context, storeName string, fn func(key string, wrapper *wrap.Wrapper)) error {
+	prefix := store.NewKeyBuilder(storeName).Build("") + "/"
+	end := clientv3.GetPrefixRangeEnd(prefix)
+
+	for key := prefix; ; {
+		var resp *clientv3.GetResponse
+		err := kvc.Backoff(ctx).Retry(func(n int) (done bool, err error) {
+			resp, err = s.client.Get(ctx, key,
+				clientv3.WithRange(end),
+				clientv3.WithLimit(scanPageSize),
+				clientv3.WithSerializable(),
+			)
+			return kvc.RetryRequest(n, err)
+		})
+		if err != nil {
+			return err
+		}
+		for _, kv := range resp.Kvs {
+			var wrapper wrap.Wrapper
+			if err := proto.Unmarshal(kv.Value, &wrapper); err != nil || wrapper.TypeMeta == nil {
+				fn(string(kv.Key), nil)
+				continue
+			}
+			fn(string(kv.Key), &wrapper)
+		}
+		if !resp.More || len(resp.Kvs) == 0 {
+			return nil
+		}
+		key = string(resp.Kvs[len(resp.Kvs)-1].Key) + "\x00"
+	}
+}
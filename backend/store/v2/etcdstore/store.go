@@ -75,14 +75,30 @@ func StoreKey(req storev2.ResourceRequest) string {
 
 // Store is an implementation of the sensu-go/backend/store.Store iface.
 type Store struct {
-	client *clientv3.Client
+	client       *clientv3.Client
+	historyDepth int
+}
+
+// Option is a functional option of a Store.
+type Option func(*Store)
+
+// WithHistoryDepth makes the store retain depth versions of each resource,
+// including the current one. History is disabled when depth is zero, which is
+// the default, since it multiplies the number of writes made to etcd.
+func WithHistoryDepth(depth int) Option {
+	return func(s *Store) {
+		s.historyDepth = depth
+	}
 }
 
 // NewStore creates a new Store.
-func NewStore(client *clientv3.Client) *Store {
+func NewStore(client *clientv3.Client, opts ...Option) *Store {
 	store := &Store{
 		client: client,
 	}
+	for _, opt := range opts {
+		opt(store)
+	}
 
 	return store
 }
@@ -103,12 +119,61 @@ func (s *Store) CreateOrUpdate(req storev2.ResourceRequest, wrapper storev2.Wrap
 		return &store.ErrEncode{Key: key, Err: err}
 	}
 
-	comparator := kvc.Comparisons(
-		kvc.NamespaceExists(req.Namespace),
-	)
-	op := clientv3.OpPut(key, string(msg))
+	predicates := []kvc.Predicate{kvc.NamespaceExists(req.Namespace)}
+	ifMatch := store.IfMatchFromContext(req.Context)
+	if ifMatch != "" {
+		// Only update the stored resource if it matches the condition, and
+		// ensure it isn't modified in the mean time
+		value, err := s.checkIfMatch(req, ifMatch)
+		if err != nil {
+			return err
+		}
+		predicates = append(predicates, kvc.KeyIsFound(key), kvc.KeyHasValue(key, value))
+	} else if store.IfNoneMatchFromContext(req.Context) == "*" {
+		predicates = append(predicates, kvc.KeyIsNotFound(key))
+	}
+	comparator := kvc.Comparisons(predicates...)
+	ops, err := s.putOps(req.Context, key, msg)
+	if err != nil {
+		return err
+	}
 
-	return kvc.Txn(req.Context, s.client, comparator, op)
+	err = s.txn(req, comparator, ops...)
+	if _, ok := err.(*store.ErrNotFound); ok && ifMatch != "" {
+		// The resource was deleted since it was read
+		return &store.ErrPreconditionFailed{Key: key}
+	}
+	return store.ConditionalCreateError(req.Context, err)
+}
+
+// checkIfMatch returns the stored value of the resource if its etag matches
+// ifMatch.
+func (s *Store) checkIfMatch(req storev2.ResourceRequest, ifMatch string) ([]byte, error) {
+	key := StoreKey(req)
+	resp, err := s.GetWithResponse(req)
+	if err != nil {
+		if _, ok := err.(*store.ErrNotFound); ok {
+			return nil, &store.ErrPreconditionFailed{Key: key}
+		}
+		return nil, err
+	}
+	value := resp.Kvs[0].Value
+	var stored wrap.Wrapper
+	if err := proto.Unmarshal(value, &stored); err != nil {
+		return nil, &store.ErrDecode{Key: key, Err: err}
+	}
+	resource, err := stored.Unwrap()
+	if err != nil {
+		return nil, &store.ErrDecode{Key: key, Err: err}
+	}
+	etag, err := store.ETag(resource)
+	if err != nil {
+		return nil, err
+	}
+	if !store.CheckIfMatch(ifMatch, etag) {
+		return nil, &store.ErrPreconditionFailed{Key: key}
+	}
+	return value, nil
 }
 
 func (s *Store) Patch(req storev2.ResourceRequest, wrapper storev2.Wrapper, patcher patch.Patcher, conditions *store.ETagCondition) error {
@@ -130,12 +195,13 @@ func (s *Store) Patch(req storev2.ResourceRequest, wrapper storev2.Wrapper, patc
 		return err
 	}
 	value := resp.Kvs[0].Value
-	if err := proto.UnmarshalMerge(value, w); err != nil {
+	var stored wrap.Wrapper
+	if err := proto.Unmarshal(value, &stored); err != nil {
 		return &store.ErrDecode{Key: key, Err: err}
 	}
 
 	// Unwrap the stored resource
-	resource, err := w.Unwrap()
+	resource, err := stored.Unwrap()
 	if err != nil {
 		return &store.ErrDecode{Key: key, Err: err}
 	}
@@ -155,21 +221,32 @@ func (s *Store) Patch(req storev2.ResourceRequest, wrapper storev2.Wrapper, patc
 		}
 	}
 
-	// Encode the stored resource to the JSON format
-	original, err := json.Marshal(resource)
-	if err != nil {
-		return err
-	}
+	if rp, ok := patcher.(patch.ResourcePatcher); ok {
+		// Apply the patch directly to the stored resource
+		target := interface{}(resource)
+		if proxy, ok := resource.(*corev3.V2ResourceProxy); ok {
+			target = proxy.Resource
+		}
+		if err := rp.PatchResource(target); err != nil {
+			return err
+		}
+	} else {
+		// Encode the stored resource to the JSON format
+		original, err := json.Marshal(resource)
+		if err != nil {
+			return err
+		}
 
-	// Apply the patch to our original document (stored resource)
-	patchedResource, err := patcher.Patch(original)
-	if err != nil {
-		return err
-	}
+		// Apply the patch to our original document (stored resource)
+		patchedResource, err := patcher.Patch(original)
+		if err != nil {
+			return err
+		}
 
-	// Decode the resulting JSON document back into our resource
-	if err := json.Unmarshal(patchedResource, &resource); err != nil {
-		return err
+		// Decode the resulting JSON document back into our resource
+		if err := json.Unmarshal(patchedResource, &resource); err != nil {
+			return err
+		}
 	}
 
 	// Validate the resource
@@ -183,8 +260,12 @@ func (s *Store) Patch(req storev2.ResourceRequest, wrapper storev2.Wrapper, patc
 		e.Subscriptions = corev2.AddEntitySubscription(e.Metadata.Name, e.Subscriptions)
 	}
 
-	// Re-wrap the resource
-	wrappedPatch, err := wrap.Resource(resource)
+	// Re-wrap the resource the way it was stored, with the wrapper fields
+	// that unwrapping it exposed as annotations rather than the annotations
+	meta := resource.GetMetadata()
+	opts := append(stored.Options(), wrap.AnnotationOptions(meta)...)
+	resource.SetMetadata(wrap.StripAnnotations(meta))
+	wrappedPatch, err := wrap.Resource(resource, opts...)
 	if err != nil {
 		return &store.ErrEncode{Key: key, Err: err}
 	}
@@ -198,6 +279,106 @@ func (s *Store) Patch(req storev2.ResourceRequest, wrapper storev2.Wrapper, patc
 	return s.Update(req, w, comparisons...)
 }
 
+// maxMergeLabelsAttempts is the number of times MergeLabels will re-read the
+// stored resource when it was concurrently modified.
+const maxMergeLabelsAttempts = 10
+
+// MergeLabels merges the given labels and annotations into the metadata of the
+// stored resource. The update is only committed if the stored resource was not
+// modified since it was read; otherwise the merge is applied again to the new
+// version of the resource, so that concurrent merges of distinct keys never
+// clobber each other.
+func (s *Store) MergeLabels(req storev2.ResourceRequest, wrapper storev2.Wrapper, labels, annotations map[string]*string, conditions *store.ETagCondition) error {
+	if err := req.Validate(); err != nil {
+		return &store.ErrNotValid{Err: err}
+	}
+
+	w, ok := wrapper.(*wrap.Wrapper)
+	if !ok {
+		return &store.ErrNotValid{Err: fmt.Errorf("etcdstore only works with wrap.Wrapper, not %T", wrapper)}
+	}
+
+	key := StoreKey(req)
+
+	for attempt := 0; ; attempt++ {
+		resp, err := s.GetWithResponse(req)
+		if err != nil {
+			return err
+		}
+		value := resp.Kvs[0].Value
+
+		var stored wrap.Wrapper
+		if err := proto.UnmarshalMerge(value, &stored); err != nil {
+			return &store.ErrDecode{Key: key, Err: err}
+		}
+
+		resource, err := stored.Unwrap()
+		if err != nil {
+			return &store.ErrDecode{Key: key, Err: err}
+		}
+
+		if conditions != nil {
+			etag, err := store.ETag(resource)
+			if err != nil {
+				return err
+			}
+			if !store.CheckIfMatch(conditions.IfMatch, etag) {
+				return &store.ErrPreconditionFailed{Key: key}
+			}
+			if !store.CheckIfNoneMatch(conditions.IfNoneMatch, etag) {
+				return &store.ErrPreconditionFailed{Key: key}
+			}
+		}
+
+		meta := resource.GetMetadata()
+		if meta == nil {
+			return &store.ErrNotValid{Err: fmt.Errorf("resource %q has no metadata", key)}
+		}
+		meta.Labels = mergeStringMap(meta.Labels, labels)
+		meta.Annotations = mergeStringMap(meta.Annotations, annotations)
+
+		// Re-wrap the resource the way it was stored, with the wrapper
+		// fields that unwrapping it exposed as annotations rather than the
+		// annotations
+		opts := append(stored.Options(), wrap.AnnotationOptions(meta)...)
+		resource.SetMetadata(wrap.StripAnnotations(meta))
+		merged, err := wrap.Resource(resource, opts...)
+		if err != nil {
+			return &store.ErrEncode{Key: key, Err: err}
+		}
+
+		err = s.Update(req, merged, kvc.KeyIsFound(key), kvc.KeyHasValue(key, value))
+		if _, conflict := err.(*store.ErrPreconditionFailed); conflict && attempt+1 < maxMergeLabelsAttempts {
+			// The resource was modified since we read it, try again
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		*w = *merged
+		return nil
+	}
+}
+
+// mergeStringMap applies changes to m, deleting the keys whose value is nil.
+func mergeStringMap(m map[string]string, changes map[string]*string) map[string]string {
+	if len(changes) == 0 {
+		return m
+	}
+	if m == nil {
+		m = make(map[string]string, len(changes))
+	}
+	for k, v := range changes {
+		if v == nil {
+			delete(m, k)
+			continue
+		}
+		m[k] = *v
+	}
+	return m
+}
+
 func (s *Store) UpdateIfExists(req storev2.ResourceRequest, wrapper storev2.Wrapper) error {
 	w, ok := wrapper.(*wrap.Wrapper)
 	if !ok {
@@ -228,9 +409,12 @@ func (s *Store) Update(req storev2.ResourceRequest, wrapper storev2.Wrapper, com
 	}
 
 	comparator := kvc.Comparisons(comparisons...)
-	op := clientv3.OpPut(key, string(msg))
+	ops, err := s.putOps(req.Context, key, msg)
+	if err != nil {
+		return err
+	}
 
-	return kvc.Txn(req.Context, s.client, comparator, op)
+	return s.txn(req, comparator, ops...)
 }
 
 func (s *Store) CreateIfNotExists(req storev2.ResourceRequest, wrapper storev2.Wrapper) error {
@@ -252,9 +436,12 @@ func (s *Store) CreateIfNotExists(req storev2.ResourceRequest, wrapper storev2.W
 		kvc.NamespaceExists(req.Namespace),
 		kvc.KeyIsNotFound(key),
 	)
-	op := clientv3.OpPut(key, string(msg))
+	ops, err := s.putOps(req.Context, key, msg)
+	if err != nil {
+		return err
+	}
 
-	return kvc.Txn(req.Context, s.client, comparator, op)
+	return store.ConditionalCreateError(req.Context, s.txn(req, comparator, ops...))
 }
 
 func (s *Store) Get(req storev2.ResourceRequest) (storev2.Wrapper, error) {
@@ -298,12 +485,29 @@ func (s *Store) Delete(req storev2.ResourceRequest) error {
 		return &store.ErrNotValid{Err: err}
 	}
 
-	comparator := kvc.Comparisons(
-		kvc.KeyIsFound(key),
-	)
-	op := clientv3.OpDelete(key)
+	predicates := []kvc.Predicate{kvc.KeyIsFound(key)}
+	ifMatch := store.IfMatchFromContext(req.Context)
+	if ifMatch != "" {
+		// Only delete the stored resource if it matches the condition, and
+		// ensure it isn't modified in the mean time
+		value, err := s.checkIfMatch(req, ifMatch)
+		if err != nil {
+			return err
+		}
+		predicates = append(predicates, kvc.KeyHasValue(key, value))
+	}
+	comparator := kvc.Comparisons(predicates...)
+	ops := []clientv3.Op{
+		clientv3.OpDelete(key),
+		clientv3.OpDelete(historyPrefix(key), clientv3.WithPrefix()),
+	}
 
-	return kvc.Txn(req.Context, s.client, comparator, op)
+	err := s.txn(req, comparator, ops...)
+	if _, ok := err.(*store.ErrNotFound); ok && ifMatch != "" {
+		// The resource was deleted since it was read
+		return &store.ErrPreconditionFailed{Key: key}
+	}
+	return err
 }
 
 func (s *Store) List(req storev2.ResourceRequest, pred *store.SelectionPredicate) (storev2.WrapList, error) {
@@ -5,11 +5,15 @@ package etcdstore_test
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/gogo/protobuf/proto"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/backend/store/patch"
 	storev2 "github.com/sensu/sensu-go/backend/store/v2"
 	"github.com/sensu/sensu-go/backend/store/v2/etcdstore"
 	"github.com/sensu/sensu-go/backend/store/v2/wrap"
@@ -68,6 +72,160 @@ func TestCreateOrUpdate(t *testing.T) {
 	})
 }
 
+func TestCreateOrUpdateConditional(t *testing.T) {
+	testWithEtcdStore(t, func(s *etcdstore.Store) {
+		ns := &corev2.Namespace{Name: "default"}
+		ctx := context.Background()
+		req := storev2.NewResourceRequestFromV2Resource(ctx, ns)
+		wrapper, err := wrap.V2Resource(ns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.CreateOrUpdate(req, wrapper); err != nil {
+			t.Fatal(err)
+		}
+
+		fixture := fixtureTestResource("foo")
+		req = storev2.NewResourceRequestFromResource(ctx, fixture)
+		wrapper, err = wrap.Resource(fixture)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// A resource that doesn't exist fails If-Match
+		req.Context = store.ContextWithIfMatch(ctx, `"abc"`)
+		if err := s.CreateOrUpdate(req, wrapper); err == nil {
+			t.Error("expected non-nil error")
+		} else if _, ok := err.(*store.ErrPreconditionFailed); !ok {
+			t.Errorf("wrong error: %s", err)
+		}
+
+		// It can be created with If-None-Match: *, but only once
+		req.Context = store.ContextWithIfNoneMatch(ctx, "*")
+		if err := s.CreateOrUpdate(req, wrapper); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.CreateOrUpdate(req, wrapper); err == nil {
+			t.Error("expected non-nil error")
+		} else if _, ok := err.(*store.ErrPreconditionFailed); !ok {
+			t.Errorf("wrong error: %s", err)
+		}
+
+		// Only the stored version can be updated with If-Match
+		stored, err := wrapper.Unwrap()
+		if err != nil {
+			t.Fatal(err)
+		}
+		etag, err := store.ETag(stored)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fixture.Metadata.Labels["foo"] = "bar"
+		wrapper, err = wrap.Resource(fixture)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Context = store.ContextWithIfMatch(ctx, etag)
+		if err := s.CreateOrUpdate(req, wrapper); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.CreateOrUpdate(req, wrapper); err == nil {
+			t.Error("expected non-nil error")
+		} else if _, ok := err.(*store.ErrPreconditionFailed); !ok {
+			t.Errorf("wrong error: %s", err)
+		}
+	})
+}
+
+func TestDeleteConditional(t *testing.T) {
+	testWithEtcdStore(t, func(s *etcdstore.Store) {
+		ns := &corev2.Namespace{Name: "default"}
+		ctx := context.Background()
+		req := storev2.NewResourceRequestFromV2Resource(ctx, ns)
+		wrapper, err := wrap.V2Resource(ns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.CreateOrUpdate(req, wrapper); err != nil {
+			t.Fatal(err)
+		}
+
+		fixture := fixtureTestResource("foo")
+		req = storev2.NewResourceRequestFromResource(ctx, fixture)
+		wrapper, err = wrap.Resource(fixture)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.CreateOrUpdate(req, wrapper); err != nil {
+			t.Fatal(err)
+		}
+		etag, err := store.ETag(fixture)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Only the stored version can be deleted with If-Match
+		req.Context = store.ContextWithIfMatch(ctx, `"abc"`)
+		if err := s.Delete(req); err == nil {
+			t.Error("expected non-nil error")
+		} else if _, ok := err.(*store.ErrPreconditionFailed); !ok {
+			t.Errorf("wrong error: %s", err)
+		}
+		req.Context = store.ContextWithIfMatch(ctx, etag)
+		if err := s.Delete(req); err != nil {
+			t.Fatal(err)
+		}
+
+		// A resource that doesn't exist fails If-Match
+		if err := s.Delete(req); err == nil {
+			t.Error("expected non-nil error")
+		} else if _, ok := err.(*store.ErrPreconditionFailed); !ok {
+			t.Errorf("wrong error: %s", err)
+		}
+	})
+}
+
+func TestSyncDurability(t *testing.T) {
+	testWithEtcdStore(t, func(s *etcdstore.Store) {
+		// Create a namespace to work within
+		ns := &corev2.Namespace{Name: "default"}
+		ctx := context.Background()
+		req := storev2.NewResourceRequestFromV2Resource(ctx, ns)
+		wrapper, err := wrap.V2Resource(ns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.CreateOrUpdate(req, wrapper); err != nil {
+			t.Fatal(err)
+		}
+
+		fixture := fixtureTestResource("foo")
+		req = storev2.NewResourceRequestFromResource(ctx, fixture)
+		req.Durability = storev2.DurabilitySync
+		wrapper, err = wrap.Resource(fixture)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.CreateOrUpdate(req, wrapper); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.UpdateIfExists(req, wrapper); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.Get(req); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Delete(req); err != nil {
+			t.Fatal(err)
+		}
+
+		// A write that was not committed must not wait for the members
+		if err := s.UpdateIfExists(req, wrapper); err == nil {
+			t.Error("expected non-nil error")
+		}
+	})
+}
+
 func TestUpdateIfExists(t *testing.T) {
 	testWithEtcdStore(t, func(s *etcdstore.Store) {
 		// Create a namespace to work within
@@ -377,3 +535,295 @@ func TestExists(t *testing.T) {
 	})
 
 }
+
+func TestPatchKeepsWrapper(t *testing.T) {
+	testWithEtcdStore(t, func(s *etcdstore.Store) {
+		ns := &corev2.Namespace{Name: "default"}
+		ctx := context.Background()
+		req := storev2.NewResourceRequestFromV2Resource(ctx, ns)
+		wrapper, err := wrap.V2Resource(ns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.CreateOrUpdate(req, wrapper); err != nil {
+			t.Fatal(err)
+		}
+		fixture := fixtureTestResource("foo")
+		req = storev2.NewResourceRequestFromResource(ctx, fixture)
+		wrapper, err = wrap.Resource(fixture, wrap.EncodeJSON, wrap.ContentTypeFromEncoding, wrap.Ephemeral(), wrap.SetDisplayName("Foo"), wrap.SetDescription("The foo resource"), wrap.WithETag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.CreateOrUpdate(req, wrapper); err != nil {
+			t.Fatal(err)
+		}
+
+		check := func(t *testing.T) {
+			t.Helper()
+			got, err := s.Get(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			stored := got.(*wrap.Wrapper)
+			if stored.ContentType != wrapper.ContentType || stored.Class != wrapper.Class ||
+				stored.DisplayName != wrapper.DisplayName || stored.Description != wrapper.Description {
+				t.Errorf("the fields of the wrapper were not kept: %v", stored)
+			}
+			if !stored.HasComputedETag() {
+				t.Errorf("expected a computed etag, got %q", stored.ETag)
+			}
+			if ok, err := stored.ETagMatches(); err != nil || !ok {
+				t.Errorf("expected the etag to match, got %v, %v", ok, err)
+			}
+			// UnwrapRaw doesn't expose the fields of the wrapper as annotations
+			raw, err := stored.UnwrapRaw()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if annotations := raw.(*testResource).Metadata.Annotations; len(annotations) != 0 {
+				t.Errorf("expected no stored annotations, got %v", annotations)
+			}
+		}
+
+		patcher := &patch.Merge{MergePatch: []byte(`{"metadata":{"labels":{"foo":"bar"}}}`)}
+		if err := s.Patch(req, &wrap.Wrapper{}, patcher, nil); err != nil {
+			t.Fatal(err)
+		}
+		t.Run("patch", check)
+
+		value := "baz"
+		if err := s.MergeLabels(req, &wrap.Wrapper{}, map[string]*string{"foo": &value}, nil, nil); err != nil {
+			t.Fatal(err)
+		}
+		t.Run("merge labels", check)
+	})
+}
+
+func TestMergeLabels(t *testing.T) {
+	testWithEtcdStore(t, func(s *etcdstore.Store) {
+		// Create a namespace to work within
+		ns := &corev2.Namespace{Name: "default"}
+		ctx := context.Background()
+		req := storev2.NewResourceRequestFromV2Resource(ctx, ns)
+		wrapper, err := wrap.V2Resource(ns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.CreateOrUpdate(req, wrapper); err != nil {
+			t.Fatal(err)
+		}
+		// Create a resource under the default namespace
+		fixture := fixtureTestResource("foo")
+		fixture.Metadata.Labels["deleteme"] = "yes"
+		req = storev2.NewResourceRequestFromResource(ctx, fixture)
+		wrapper, err = wrap.Resource(fixture)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.CreateOrUpdate(req, wrapper); err != nil {
+			t.Fatal(err)
+		}
+
+		// Merge labels concurrently, none of them should be lost
+		var wg sync.WaitGroup
+		errs := make(chan error, 10)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				value := fmt.Sprintf("%d", i)
+				labels := map[string]*string{fmt.Sprintf("label%d", i): &value}
+				errs <- s.MergeLabels(req, &wrap.Wrapper{}, labels, nil, nil)
+			}(i)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// A nil value deletes the label
+		var w wrap.Wrapper
+		annotation := "bar"
+		if err := s.MergeLabels(req, &w, map[string]*string{"deleteme": nil}, map[string]*string{"foo": &annotation}, nil); err != nil {
+			t.Fatal(err)
+		}
+		var got testResource
+		if err := w.UnwrapInto(&got); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := got.Metadata.Labels["deleteme"]; ok {
+			t.Error("expected label to be deleted")
+		}
+		if got, want := len(got.Metadata.Labels), 10; got != want {
+			t.Errorf("bad number of labels: got %d, want %d", got, want)
+		}
+		if got, want := got.Metadata.Annotations["foo"], "bar"; got != want {
+			t.Errorf("bad annotation: got %q, want %q", got, want)
+		}
+
+		// A mismatching If-Match condition should fail
+		conditions := &store.ETagCondition{IfMatch: `"nope"`}
+		err = s.MergeLabels(req, &wrap.Wrapper{}, map[string]*string{"foo": &annotation}, nil, conditions)
+		if _, ok := err.(*store.ErrPreconditionFailed); !ok {
+			t.Errorf("expected ErrPreconditionFailed, got %v", err)
+		}
+
+		// A resource that doesn't exist can't be merged
+		req.Name = "notfound"
+		err = s.MergeLabels(req, &wrap.Wrapper{}, map[string]*string{"foo": &annotation}, nil, nil)
+		if _, ok := err.(*store.ErrNotFound); !ok {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+}
+
+func TestHistory(t *testing.T) {
+	testWithEtcdStore(t, func(s *etcdstore.Store) {
+		// Create a namespace to work within
+		ns := &corev2.Namespace{Name: "default"}
+		ctx := context.Background()
+		req := storev2.NewResourceRequestFromV2Resource(ctx, ns)
+		wrapper, err := wrap.V2Resource(ns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.CreateOrUpdate(req, wrapper); err != nil {
+			t.Fatal(err)
+		}
+
+		// Write more versions of the resource than the history retains
+		fixture := fixtureTestResource("foo")
+		req = storev2.NewResourceRequestFromResource(ctx, fixture)
+		for i := 0; i < 5; i++ {
+			fixture.Metadata.Labels["version"] = fmt.Sprintf("%d", i)
+			wrapper, err := wrap.Resource(fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := s.CreateOrUpdate(req, wrapper); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		versions, err := s.History(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := len(versions), 3; got != want {
+			t.Fatalf("bad number of versions: got %d, want %d", got, want)
+		}
+		for i, version := range versions {
+			var resource testResource
+			if err := version.Wrapper.UnwrapInto(&resource); err != nil {
+				t.Fatal(err)
+			}
+			if got, want := resource.Metadata.Labels["version"], fmt.Sprintf("%d", 4-i); got != want {
+				t.Errorf("bad version %d: got %s, want %s", i, got, want)
+			}
+		}
+
+		// The history is deleted along with the resource
+		if err := s.Delete(req); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.History(req); err == nil {
+			t.Fatal("expected an error")
+		} else if _, ok := err.(*store.ErrNotFound); !ok {
+			t.Fatalf("expected ErrNotFound, got %T", err)
+		}
+	}, etcdstore.WithHistoryDepth(3))
+}
+
+func TestCheckETags(t *testing.T) {
+	testWithEtcdStore(t, func(s *etcdstore.Store) {
+		ctx := context.Background()
+		ns := &corev2.Namespace{Name: "default"}
+		wrapper, err := wrap.V2Resource(ns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.CreateOrUpdate(storev2.NewResourceRequestFromV2Resource(ctx, ns), wrapper); err != nil {
+			t.Fatal(err)
+		}
+
+		for name, opts := range map[string][]wrap.Option{
+			"foo": {wrap.WithETag},
+			"bar": nil,
+			"baz": {wrap.CompressNone, wrap.WithETag},
+		} {
+			fixture := fixtureTestResource(name)
+			wrapper, err := wrap.Resource(fixture, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if name == "baz" {
+				// Corrupt the value after its etag was computed
+				wrapper.Value = append(wrapper.Value, ' ')
+			}
+			if err := s.CreateOrUpdate(storev2.NewResourceRequestFromResource(ctx, fixture), wrapper); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		report, err := s.CheckETags(ctx, fixtureTestResource("foo").StoreName())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if report.Checked != 2 || report.Skipped != 1 || report.Undecodable != 0 {
+			t.Errorf("bad report: %+v", report)
+		}
+		if len(report.Mismatches) != 1 || !strings.HasSuffix(report.Mismatches[0], "/baz") {
+			t.Errorf("bad mismatches: got %v, want the key of baz", report.Mismatches)
+		}
+	})
+}
+
+func TestCountFormats(t *testing.T) {
+	testWithEtcdStore(t, func(s *etcdstore.Store) {
+		// Create a namespace to work within
+		ns := &corev2.Namespace{Name: "default"}
+		ctx := context.Background()
+		req := storev2.NewResourceRequestFromV2Resource(ctx, ns)
+		wrapper, err := wrap.V2Resource(ns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.CreateOrUpdate(req, wrapper); err != nil {
+			t.Fatal(err)
+		}
+
+		for name, opts := range map[string][]wrap.Option{
+			"foo": nil,
+			"bar": nil,
+			"baz": {wrap.CompressNone},
+		} {
+			fixture := fixtureTestResource(name)
+			req := storev2.NewResourceRequestFromResource(ctx, fixture)
+			wrapper, err := wrap.Resource(fixture, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := s.CreateOrUpdate(req, wrapper); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		counts, err := s.CountFormats(ctx, fixtureTestResource("foo").StoreName())
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := map[etcdstore.WrapperFormat]int{
+			{Encoding: wrap.Encoding_json, Compression: wrap.Compression_snappy}: 2,
+			{Encoding: wrap.Encoding_json, Compression: wrap.Compression_none}:   1,
+		}
+		if !reflect.DeepEqual(counts.Formats, want) {
+			t.Errorf("bad formats: got %v, want %v", counts.Formats, want)
+		}
+		if counts.Undecodable != 0 {
+			t.Errorf("bad undecodable count: got %d, want 0", counts.Undecodable)
+		}
+	})
+}
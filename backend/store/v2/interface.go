@@ -1,6 +1,8 @@
 package v2
 
 import (
+	"time"
+
 	corev3 "github.com/sensu/sensu-go/api/core/v3"
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/backend/store/patch"
@@ -19,6 +21,15 @@ type WrapList interface {
 	Len() int
 }
 
+// Version is a retained version of a stored resource.
+type Version struct {
+	// WrittenAt is the time at which the version was written.
+	WrittenAt time.Time
+
+	// Wrapper is the resource, as it was written at that time.
+	Wrapper Wrapper
+}
+
 // Interface specifies the interface of a v2 store.
 type Interface interface {
 	// CreateOrUpdate creates or updates the wrapped resource.
@@ -35,7 +46,9 @@ type Interface interface {
 	// Get gets a wrapped resource from the store.
 	Get(ResourceRequest) (Wrapper, error)
 
-	// Delete deletes a resource from the store.
+	// Delete deletes a resource from the store. If the context of the
+	// request holds an If-Match condition, see store.ContextWithIfMatch, the
+	// resource is only deleted if its ETag matches.
 	Delete(ResourceRequest) error
 
 	// List lists all resources specified by the resource request, and the
@@ -47,4 +60,13 @@ type Interface interface {
 
 	// Patch patches the resource given in the request
 	Patch(ResourceRequest, Wrapper, patch.Patcher, *store.ETagCondition) error
+
+	// MergeLabels atomically merges the given labels and annotations into the
+	// metadata of the resource given in the request. A nil value deletes the
+	// corresponding key. On success, the wrapper contains the updated resource.
+	MergeLabels(req ResourceRequest, w Wrapper, labels, annotations map[string]*string, cond *store.ETagCondition) error
+
+	// History returns the retained versions of the resource given in the
+	// request, newest first.
+	History(ResourceRequest) ([]Version, error)
 }
@@ -79,3 +79,18 @@ func (p *Proxy) Patch(req ResourceRequest, wrapper Wrapper, patcher patch.Patche
 	defer p.mu.RUnlock()
 	return p.impl.Patch(req, wrapper, patcher, cond)
 }
+
+// MergeLabels atomically merges labels and annotations into the resource
+// given in the request
+func (p *Proxy) MergeLabels(req ResourceRequest, wrapper Wrapper, labels, annotations map[string]*string, cond *store.ETagCondition) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.impl.MergeLabels(req, wrapper, labels, annotations, cond)
+}
+
+// History returns the retained versions of the resource given in the request
+func (p *Proxy) History(req ResourceRequest) ([]Version, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.impl.History(req)
+}
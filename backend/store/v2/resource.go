@@ -18,11 +18,38 @@ const (
 )
 
 // WrapResource is made variable, for the purpose of swapping it out for another
-// implementation.
+// implementation. The annotations that unwrapping a resource exposes are
+// turned back into the wrapper fields they expose, rather than stored as
+// annotations, so that resources that were read from the store can be written
+// back as is. The resource itself is left untouched. Replacements should wrap
+// the resource with the previous WrapResource.
 var WrapResource = func(resource corev3.Resource, opts ...wrap.Option) (Wrapper, error) {
+	meta := resource.GetMetadata()
+	opts = append(wrap.AnnotationOptions(meta), opts...)
+	if stripped := wrap.StripAnnotations(meta); stripped != meta {
+		resource.SetMetadata(stripped)
+		defer resource.SetMetadata(meta)
+	}
 	return wrap.Resource(resource, opts...)
 }
 
+// Durability is the level of durability that a write waits for before it is
+// reported as successful.
+type Durability int
+
+const (
+	// DurabilityDefault is the durability of most writes. With etcd, a write
+	// is acknowledged once a quorum of the cluster has committed it to its
+	// write-ahead log.
+	DurabilityDefault Durability = iota
+
+	// DurabilitySync waits for a write to be persisted by every member of the
+	// cluster, rather than only a quorum of them. It is meant for writes that
+	// must not be lost, such as RBAC rules and silences, and costs at least
+	// one extra round-trip to each member of the cluster.
+	DurabilitySync
+)
+
 // ResourceRequest contains all the information necessary to query a store.
 type ResourceRequest struct {
 	Namespace   string
@@ -31,6 +58,10 @@ type ResourceRequest struct {
 	Context     context.Context
 	SortOrder   SortOrder
 	UsePostgres bool
+
+	// Durability is the durability requested for writes. It is ignored by
+	// reads.
+	Durability Durability
 }
 
 // NewResourceRequestFromResource creates a ResourceRequest from a resource.
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store/v2/wrap"
 )
 
 type testResource struct {
@@ -88,3 +89,28 @@ func TestNewResourceRequestFromResource(t *testing.T) {
 		t.Errorf("bad store name: got %s, want %s", got, want)
 	}
 }
+
+func TestWrapResourceStripsAnnotations(t *testing.T) {
+	resource := fixtureTestResource("foo")
+	resource.Metadata.Annotations[wrap.DisplayNameAnnotation] = "Foo"
+	resource.Metadata.Annotations["foo"] = "bar"
+	w, err := WrapResource(resource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := w.(*wrap.Wrapper).DisplayName, "Foo"; got != want {
+		t.Errorf("bad display name: got %q, want %q", got, want)
+	}
+	unwrapped := &testResource{}
+	if err := w.UnwrapInto(unwrapped); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := unwrapped.Metadata.Annotations, map[string]string{"foo": "bar", wrap.DisplayNameAnnotation: "Foo"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("bad annotations: got %v, want %v", got, want)
+	}
+
+	// The resource is left untouched
+	if got, want := resource.Metadata.Annotations, map[string]string{"foo": "bar", wrap.DisplayNameAnnotation: "Foo"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("bad annotations: got %v, want %v", got, want)
+	}
+}
@@ -53,3 +53,14 @@ func (s *Store) Patch(req storev2.ResourceRequest, w storev2.Wrapper, patcher pa
 	args := s.Called(req, w, patcher, conditions)
 	return args.Error(0)
 }
+
+func (s *Store) MergeLabels(req storev2.ResourceRequest, w storev2.Wrapper, labels, annotations map[string]*string, conditions *store.ETagCondition) error {
+	args := s.Called(req, w, labels, annotations, conditions)
+	return args.Error(0)
+}
+
+func (s *Store) History(req storev2.ResourceRequest) ([]storev2.Version, error) {
+	args := s.Called(req)
+	versions, _ := args.Get(0).([]storev2.Version)
+	return versions, args.Error(1)
+}
@@ -0,0 +1,102 @@
+package wrap
+
+import (
+	"encoding/binary"
+
+	"github.com/golang/snappy"
+)
+
+// IsComplete reports whether the value of the wrapper looks whole, so that
+// recovery tooling can set aside records truncated by an unclean shutdown
+// before attempting to unwrap them. It is a quick check that does not decode
+// the value, and only decompresses values that are neither uncompressed nor
+// snappy compressed:
+//
+//   - uncompressed values must be as long as the recorded uncompressed length;
+//   - snappy compressed values must declare a decoded length equal to the
+//     recorded uncompressed length, and hold exactly the elements needed to
+//     produce that many bytes;
+//   - zstd, gzip and RegisterCompression compressed values must decompress
+//     to the recorded uncompressed length, since their streams can't be
+//     checked without decompressing them.
+//
+// Wrappers written before the uncompressed length was recorded can only be
+// checked against the decoded length of their snappy header, and uncompressed
+// ones are always considered complete. A complete wrapper can still fail to
+// unwrap if its value is otherwise corrupt. An error is returned if the
+// encoding or the compression of the wrapper is unknown.
+func (w *Wrapper) IsComplete() (bool, error) {
+	if err := w.checkFormat(); err != nil {
+		return false, err
+	}
+	switch w.Compression {
+	case Compression_snappy:
+		n, err := snappy.DecodedLen(w.Value)
+		if err != nil {
+			return false, nil
+		}
+		if w.UncompressedLen > 0 && int64(n) != w.UncompressedLen {
+			return false, nil
+		}
+		return snappyBlockLen(w.Value) == int64(n), nil
+	case Compression_none:
+		return w.UncompressedLen == 0 || int64(len(w.Value)) == w.UncompressedLen, nil
+	default:
+		_, err := w.Compression.decompress(w.Value, w.UncompressedLen, DefaultMaxDecompressedSize)
+		return err == nil, nil
+	}
+}
+
+// snappyBlockLen returns the number of bytes that the elements of the snappy
+// block src decode to, without decoding them, or -1 if src ends in the middle
+// of an element. Copy offsets are not checked.
+func snappyBlockLen(src []byte) int64 {
+	_, header := binary.Uvarint(src)
+	if header <= 0 {
+		return -1
+	}
+	var total int64
+	for s := header; s < len(src); {
+		tag := src[s]
+		switch tag & 0x03 {
+		case 0x00: // literal
+			length := int64(tag>>2) + 1
+			s++
+			if extra := int(tag>>2) - 59; extra > 0 {
+				if s+extra > len(src) {
+					return -1
+				}
+				var x uint32
+				for i := 0; i < extra; i++ {
+					x |= uint32(src[s+i]) << (8 * uint(i))
+				}
+				length = int64(x) + 1
+				s += extra
+			}
+			if int64(len(src)-s) < length {
+				return -1
+			}
+			s += int(length)
+			total += length
+		case 0x01: // copy with a 1 byte offset
+			if s+2 > len(src) {
+				return -1
+			}
+			total += 4 + int64(tag>>2&0x07)
+			s += 2
+		case 0x02: // copy with a 2 byte offset
+			if s+3 > len(src) {
+				return -1
+			}
+			total += 1 + int64(tag>>2)
+			s += 3
+		case 0x03: // copy with a 4 byte offset
+			if s+5 > len(src) {
+				return -1
+			}
+			total += 1 + int64(tag>>2)
+			s += 5
+		}
+	}
+	return total
+}
@@ -0,0 +1,54 @@
+package wrap_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+	"github.com/sensu/sensu-go/backend/store/v2/wrap"
+)
+
+func TestWrapperIsComplete(t *testing.T) {
+	entity := corev3.FixtureEntityConfig("foo")
+	// Long, repetitive values make snappy emit both literals and copies
+	entity.Metadata.Annotations["description"] = strings.Repeat("a rather long annotation ", 100)
+
+	for _, compression := range []wrap.Option{wrap.CompressNone, wrap.CompressSnappy, wrap.CompressZstd, wrap.CompressGzip} {
+		w, err := wrap.Resource(entity, compression)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Run(w.Compression.String(), func(t *testing.T) {
+			if ok, err := w.IsComplete(); err != nil || !ok {
+				t.Fatalf("expected a complete wrapper, got %v, %v", ok, err)
+			}
+			legacy := *w
+			legacy.UncompressedLen = 0
+			if ok, err := legacy.IsComplete(); err != nil || !ok {
+				t.Fatalf("expected a complete legacy wrapper, got %v, %v", ok, err)
+			}
+
+			for i := 0; i < len(w.Value); i++ {
+				truncated := *w
+				truncated.Value = w.Value[:i]
+				if ok, err := truncated.IsComplete(); err != nil || ok {
+					t.Fatalf("expected a wrapper truncated to %d bytes to be incomplete, got %v, %v", i, ok, err)
+				}
+				// Truncated snappy values are spotted even without the
+				// uncompressed length
+				if w.Compression == wrap.Compression_snappy {
+					truncated.UncompressedLen = 0
+					if ok, err := truncated.IsComplete(); err != nil || ok {
+						t.Fatalf("expected a legacy wrapper truncated to %d bytes to be incomplete, got %v, %v", i, ok, err)
+					}
+				}
+			}
+		})
+	}
+
+	unknown := &wrap.Wrapper{Compression: wrap.Compression(42)}
+	if _, err := unknown.IsComplete(); !errors.Is(err, wrap.ErrInvalidFormat) {
+		t.Fatalf("expected an invalid format error, got %v", err)
+	}
+}
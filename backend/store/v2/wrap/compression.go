@@ -0,0 +1,157 @@
+package wrap
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sensu/sensu-go/backend/metrics"
+)
+
+const (
+	// CompressDecisions is the name of the prometheus counter vec used to
+	// count the decisions of CompressAuto, by outcome.
+	CompressDecisions = "sensu_go_store_compress_decisions"
+
+	// CompressionSavedBytes is the name of the prometheus counter used to
+	// count the bytes saved by compressing wrapped resources.
+	CompressionSavedBytes = "sensu_go_store_compression_saved_bytes"
+
+	compressDecisionCompressed = "compressed"
+	compressDecisionSkipped    = "skipped"
+)
+
+var (
+	compressDecisionsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: CompressDecisions,
+			Help: "The number of resources CompressAuto decided to compress or to store raw",
+		},
+		[]string{"decision"},
+	)
+
+	compressionSavedBytesCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: CompressionSavedBytes,
+			Help: "The number of bytes saved by compressing wrapped resources",
+		},
+	)
+)
+
+func init() {
+	if err := prometheus.Register(compressDecisionsCounter); err != nil {
+		metrics.LogError(logger, CompressDecisions, err)
+	}
+	if err := prometheus.Register(compressionSavedBytesCounter); err != nil {
+		metrics.LogError(logger, CompressionSavedBytes, err)
+	}
+}
+
+// CompressAuto returns an option that compresses resources with snappy only
+// when their encoding is at least threshold bytes long, since compressing
+// small resources costs more than it saves. Each decision is counted, so that
+// the threshold can be tuned. The decision is made once the resource is
+// encoded, so it overrides any other compression option.
+func CompressAuto(threshold int) Option {
+	return func(w *Wrapper, r interface{}) error {
+		return encodedHook(func(w *Wrapper, r interface{}, message []byte) error {
+			if len(message) < threshold {
+				compressDecisionsCounter.WithLabelValues(compressDecisionSkipped).Inc()
+				return CompressNone(w, r)
+			}
+			compressDecisionsCounter.WithLabelValues(compressDecisionCompressed).Inc()
+			return CompressSnappy(w, r)
+		})
+	}
+}
+
+// compress compresses message with the compression algorithm of the wrapper,
+// at zstdLevel for zstd, counting the bytes saved.
+func (w *Wrapper) compress(message []byte, zstdLevel int) []byte {
+	var value []byte
+	if w.Compression == Compression_zstd {
+		value = zstdCompress(message, zstdLevel)
+	} else {
+		value = w.Compression.Compress(message)
+	}
+	if saved := len(message) - len(value); saved > 0 {
+		compressionSavedBytesCounter.Add(float64(saved))
+	}
+	return value
+}
+
+// compressor compresses and decompresses the values of a Compression
+// registered with RegisterCompression.
+type compressor struct {
+	name       string
+	compress   func([]byte) []byte
+	decompress func([]byte) ([]byte, error)
+}
+
+var (
+	compressorsMu sync.RWMutex
+	compressors   = map[Compression]compressor{}
+)
+
+// RegisterCompression makes the compression algorithm id, named name,
+// available to wrappers, next to the built-in ones. Values it decompresses are
+// still held to their maximum decompressed size and to the uncompressed length
+// recorded by the wrapper, and its decompression errors are reported as a
+// *CorruptValueError. An error wrapping ErrAlreadyRegistered is returned if
+// id or name is already known, built in or not. Like RegisterEncoding, it
+// leaves the generated Compression_name and Compression_value maps untouched,
+// so the names of registered compressions are read with Compression.Name.
+func RegisterCompression(id Compression, name string, compress func([]byte) []byte, decompress func([]byte) ([]byte, error)) error {
+	compressorsMu.Lock()
+	defer compressorsMu.Unlock()
+	if comp, ok := compressors[id]; ok {
+		return fmt.Errorf("compression %d: %w as %s", int32(id), ErrAlreadyRegistered, comp.name)
+	}
+	if id.builtin() {
+		return fmt.Errorf("compression %d: %w as %s", int32(id), ErrAlreadyRegistered, id)
+	}
+	if _, ok := Compression_value[name]; ok {
+		return fmt.Errorf("compression name %s: %w for a built-in compression", name, ErrAlreadyRegistered)
+	}
+	for other, comp := range compressors {
+		if comp.name == name {
+			return fmt.Errorf("compression name %s: %w for compression %d", name, ErrAlreadyRegistered, int32(other))
+		}
+	}
+	compressors[id] = compressor{name: name, compress: compress, decompress: decompress}
+	return nil
+}
+
+// Name returns the name of the compression algorithm, built in or registered
+// with RegisterCompression, or its number if it is unknown.
+func (c Compression) Name() string {
+	if !c.builtin() {
+		if comp, ok := getCompressor(c); ok {
+			return comp.name
+		}
+	}
+	return c.String()
+}
+
+func getCompressor(c Compression) (compressor, bool) {
+	compressorsMu.RLock()
+	defer compressorsMu.RUnlock()
+	comp, ok := compressors[c]
+	return comp, ok
+}
+
+// decompressRegistered is the counterpart of Compression.decompress for
+// registered compression algorithms.
+func decompressRegistered(comp compressor, c Compression, m []byte, size int64, max int) ([]byte, error) {
+	b, err := comp.decompress(m)
+	if err != nil {
+		return nil, &CorruptValueError{Compression: c, Err: err}
+	}
+	if len(b) > max {
+		return nil, fmt.Errorf("%w: %d > %d bytes", ErrDecompressedSizeTooLarge, len(b), max)
+	}
+	if size > 0 && int64(len(b)) != size {
+		return nil, &CorruptValueError{Compression: c, Err: fmt.Errorf("decoded length %d does not match the uncompressed length %d", len(b), size)}
+	}
+	return b, nil
+}
@@ -0,0 +1,138 @@
+package wrap
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+)
+
+func TestCompressAuto(t *testing.T) {
+	small := corev3.FixtureEntityConfig("small")
+	large := corev3.FixtureEntityConfig("large")
+	large.Metadata.Annotations["notes"] = strings.Repeat("compressible ", 100)
+	threshold := small.Size() + 1
+
+	skipped := testutil.ToFloat64(compressDecisionsCounter.WithLabelValues(compressDecisionSkipped))
+	compressed := testutil.ToFloat64(compressDecisionsCounter.WithLabelValues(compressDecisionCompressed))
+	saved := testutil.ToFloat64(compressionSavedBytesCounter)
+
+	w, err := ResourceWithoutValidation(small, CompressAuto(threshold))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Compression != Compression_none {
+		t.Errorf("small resource was compressed with %s", w.Compression)
+	}
+	if got := testutil.ToFloat64(compressDecisionsCounter.WithLabelValues(compressDecisionSkipped)); got != skipped+1 {
+		t.Errorf("bad skipped decisions: got %v, want %v", got, skipped+1)
+	}
+
+	w, err = ResourceWithoutValidation(large, CompressAuto(threshold))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Compression != Compression_snappy {
+		t.Errorf("large resource was compressed with %s", w.Compression)
+	}
+	if got := testutil.ToFloat64(compressDecisionsCounter.WithLabelValues(compressDecisionCompressed)); got != compressed+1 {
+		t.Errorf("bad compressed decisions: got %v, want %v", got, compressed+1)
+	}
+	want := saved + float64(w.UncompressedLen-int64(len(w.Value)))
+	if got := testutil.ToFloat64(compressionSavedBytesCounter); got != want {
+		t.Errorf("bad saved bytes: got %v, want %v", got, want)
+	}
+
+	resource, err := w.Unwrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resource.GetMetadata().Annotations["notes"]; got != large.Metadata.Annotations["notes"] {
+		t.Errorf("bad annotation after unwrapping: %q", got)
+	}
+}
+
+func TestCompressAutoThreshold(t *testing.T) {
+	entity := corev3.FixtureEntityConfig("entity")
+	for _, encoding := range []Option{EncodeProtobuf, EncodeJSON} {
+		w, err := ResourceWithoutValidation(entity, encoding, CompressNone)
+		if err != nil {
+			t.Fatal(err)
+		}
+		size := int(w.UncompressedLen)
+
+		tests := []struct {
+			name      string
+			threshold int
+			want      Compression
+		}{
+			{name: "just under the threshold", threshold: size + 1, want: Compression_none},
+			{name: "at the threshold", threshold: size, want: Compression_snappy},
+			{name: "just over the threshold", threshold: size - 1, want: Compression_snappy},
+		}
+		for _, tt := range tests {
+			t.Run(w.Encoding.String()+"/"+tt.name, func(t *testing.T) {
+				w, err := ResourceWithoutValidation(entity, encoding, CompressAuto(tt.threshold))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if w.Compression != tt.want {
+					t.Errorf("got compression %s, want %s", w.Compression, tt.want)
+				}
+				if w.UncompressedLen != int64(size) {
+					t.Errorf("got uncompressed length %d, want %d", w.UncompressedLen, size)
+				}
+				resource, err := w.Unwrap()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got, want := resource.GetMetadata().Name, "entity"; got != want {
+					t.Errorf("got name %q, want %q", got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestCompressAutoJSON(t *testing.T) {
+	entity := corev3.FixtureEntityConfig("entity")
+	w, err := ResourceWithoutValidation(entity, EncodeJSON, CompressAuto(1<<20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Compression != Compression_none {
+		t.Errorf("resource was compressed with %s", w.Compression)
+	}
+}
+
+func TestCompressAutoEncodesOnce(t *testing.T) {
+	const counting = Encoding(44)
+	var encodes int
+	enc := func(v interface{}) ([]byte, error) {
+		encodes++
+		return encodeJSON(v)
+	}
+	if err := RegisterEncoding(counting, "counting", enc, decodeJSON); err != nil {
+		t.Fatal(err)
+	}
+	ring := NewExemplarRing(1, 1)
+	entity := corev3.FixtureEntityConfig("entity")
+	encoding := func(w *Wrapper, r interface{}) error {
+		w.Encoding = counting
+		return nil
+	}
+	w, err := ResourceWithoutValidation(entity, CompressAuto(1), RecordLargeEncodes(ring), encoding)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if encodes != 1 {
+		t.Errorf("resource was encoded %d times, want 1", encodes)
+	}
+	if w.Compression != Compression_snappy {
+		t.Errorf("resource was compressed with %s", w.Compression)
+	}
+	if got := ring.Exemplars(); len(got) != 1 || got[0].Size != int(w.UncompressedLen) {
+		t.Errorf("bad exemplars: %v", got)
+	}
+}
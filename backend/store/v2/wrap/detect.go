@@ -0,0 +1,37 @@
+package wrap
+
+// DetectEncoding sniffs the decompressed value of the wrapper to determine the
+// encoding it was actually written with, and reports whether it matches the
+// Encoding of the wrapper. It is meant for repair tooling, since some older
+// wrappers were recorded as protobuf encoded while holding JSON values.
+//
+// The value is detected as JSON if it decodes as JSON into the type of the
+// wrapper, and as protobuf otherwise, if it decodes as protobuf. Wrappers with
+// another encoding, such as MessagePack, are first tried with it. If the
+// type of the wrapper can't be resolved, or if its value can't be decompressed
+// or decoded with any encoding, the encoding of the wrapper is returned along
+// with false, as there is nothing to repair it with.
+func (w *Wrapper) DetectEncoding() (Encoding, bool) {
+	if w.TypeMeta == nil || !w.Compression.IsValid() {
+		return w.Encoding, false
+	}
+	value, err := w.decompressedValue()
+	if err != nil {
+		return w.Encoding, false
+	}
+
+	candidates := []Encoding{Encoding_json, Encoding_protobuf}
+	if w.Encoding != Encoding_json && w.Encoding != Encoding_protobuf && w.Encoding.IsValid() {
+		candidates = append([]Encoding{w.Encoding}, candidates...)
+	}
+	for _, encoding := range candidates {
+		resource, err := resolveRaw(w.TypeMeta)
+		if err != nil {
+			return w.Encoding, false
+		}
+		if err := encoding.Decode(value, resource); err == nil {
+			return encoding, encoding == w.Encoding
+		}
+	}
+	return w.Encoding, false
+}
@@ -0,0 +1,87 @@
+package wrap_test
+
+import (
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+	"github.com/sensu/sensu-go/backend/store/v2/wrap"
+)
+
+func TestWrapperDetectEncoding(t *testing.T) {
+	for _, compression := range []wrap.Option{wrap.CompressNone, wrap.CompressSnappy} {
+		for _, encoding := range []wrap.Option{wrap.EncodeJSON, wrap.EncodeProtobuf} {
+			w, err := wrap.Resource(corev3.FixtureEntityConfig("foo"), encoding, compression)
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Run(w.Encoding.String()+"/"+w.Compression.String(), func(t *testing.T) {
+				if got, ok := w.DetectEncoding(); got != w.Encoding || !ok {
+					t.Fatalf("got %v, %v, want %v, true", got, ok, w.Encoding)
+				}
+
+				// Mislabel the wrapper with the other encoding
+				mislabeled := *w
+				mislabeled.Encoding = wrap.Encoding_protobuf
+				if w.Encoding == wrap.Encoding_protobuf {
+					mislabeled.Encoding = wrap.Encoding_json
+				}
+				if _, err := mislabeled.Unwrap(); err == nil {
+					t.Fatal("expected the mislabeled wrapper not to unwrap")
+				}
+				got, ok := mislabeled.DetectEncoding()
+				if got != w.Encoding || ok {
+					t.Fatalf("got %v, %v, want %v, false", got, ok, w.Encoding)
+				}
+
+				// The detected encoding repairs the wrapper
+				mislabeled.Encoding = got
+				resource, err := mislabeled.Unwrap()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got, want := resource.GetMetadata().Name, "foo"; got != want {
+					t.Errorf("got name %q, want %q", got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestWrapperDetectEncodingUndetectable(t *testing.T) {
+	w, err := wrap.Resource(corev3.FixtureEntityConfig("foo"), wrap.EncodeProtobuf, wrap.CompressSnappy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		mangle func(*wrap.Wrapper)
+	}{
+		{
+			name:   "corrupt value",
+			mangle: func(w *wrap.Wrapper) { w.Value = []byte{0xff, 0xff, 0xff} },
+		},
+		{
+			name:   "unknown compression",
+			mangle: func(w *wrap.Wrapper) { w.Compression = wrap.Compression(42) },
+		},
+		{
+			name:   "unknown type",
+			mangle: func(w *wrap.Wrapper) { w.TypeMeta = &corev2.TypeMeta{APIVersion: "core/v3", Type: "Unknown"} },
+		},
+		{
+			name:   "no type",
+			mangle: func(w *wrap.Wrapper) { w.TypeMeta = nil },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mangled := *w
+			tt.mangle(&mangled)
+			if got, ok := mangled.DetectEncoding(); got != wrap.Encoding_protobuf || ok {
+				t.Errorf("got %v, %v, want protobuf, false", got, ok)
+			}
+		})
+	}
+}
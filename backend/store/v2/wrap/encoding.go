@@ -0,0 +1,89 @@
+package wrap
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrAlreadyRegistered is returned when registering an encoding or a
+// compression algorithm with the id or the name of one that is already known.
+var ErrAlreadyRegistered = errors.New("already registered")
+
+// serializer encodes and decodes the values of an Encoding.
+type serializer struct {
+	name   string
+	encode func(interface{}) ([]byte, error)
+	decode func([]byte, interface{}) error
+}
+
+var (
+	serializersMu sync.RWMutex
+	serializers   = map[Encoding]serializer{
+		Encoding_json:     {name: "json", encode: encodeJSON, decode: decodeJSON},
+		Encoding_protobuf: {name: "protobuf", encode: encodeProtobuf, decode: decodeProtobuf},
+		Encoding_msgpack:  {name: "msgpack", encode: encodeMsgpack, decode: decodeMsgpack},
+	}
+)
+
+// RegisterEncoding makes the encoding id, named name, available to wrappers,
+// so that encodings other than the built-in JSON, protobuf and MessagePack ones
+// can be added without patching this package. An error wrapping
+// ErrAlreadyRegistered is returned if id or name is already known, built in
+// or not. The registry is kept apart from the generated Encoding_name and
+// Encoding_value maps, which are left untouched, so the names of registered
+// encodings are read with Encoding.Name rather than Encoding.String.
+func RegisterEncoding(id Encoding, name string, enc func(interface{}) ([]byte, error), dec func([]byte, interface{}) error) error {
+	serializersMu.Lock()
+	defer serializersMu.Unlock()
+	if s, ok := serializers[id]; ok {
+		return fmt.Errorf("encoding %d: %w as %s", int32(id), ErrAlreadyRegistered, s.name)
+	}
+	for other, s := range serializers {
+		if s.name == name {
+			return fmt.Errorf("encoding name %s: %w for encoding %d", name, ErrAlreadyRegistered, int32(other))
+		}
+	}
+	serializers[id] = serializer{name: name, encode: enc, decode: dec}
+	return nil
+}
+
+// Name returns the name of the encoding, built in or registered with
+// RegisterEncoding, or its number if it is unknown.
+func (e Encoding) Name() string {
+	if s, ok := getSerializer(e); ok {
+		return s.name
+	}
+	return e.String()
+}
+
+func getSerializer(e Encoding) (serializer, bool) {
+	serializersMu.RLock()
+	defer serializersMu.RUnlock()
+	s, ok := serializers[e]
+	return s, ok
+}
+
+var (
+	jsonTypesMu sync.RWMutex
+	jsonTypes   = map[string]bool{}
+)
+
+// RegisterJSONType makes EncodeDefault encode the resources whose
+// TypeMeta.Type is typ as JSON even though they are proto messages, so that
+// they remain readable when inspecting etcd directly. It should only be called
+// at init time, and never for types on the hot path.
+func RegisterJSONType(typ string) {
+	jsonTypesMu.Lock()
+	defer jsonTypesMu.Unlock()
+	jsonTypes[typ] = true
+}
+
+func jsonType(w *Wrapper) bool {
+	if w.TypeMeta == nil {
+		return false
+	}
+	jsonTypesMu.RLock()
+	defer jsonTypesMu.RUnlock()
+	return jsonTypes[w.TypeMeta.Type]
+}
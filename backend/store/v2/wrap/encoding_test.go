@@ -0,0 +1,40 @@
+package wrap
+
+import (
+	"testing"
+
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+)
+
+func TestRegisterJSONType(t *testing.T) {
+	RegisterJSONType("EntityConfig")
+	defer func() {
+		jsonTypesMu.Lock()
+		defer jsonTypesMu.Unlock()
+		delete(jsonTypes, "EntityConfig")
+	}()
+
+	w, err := Resource(corev3.FixtureEntityConfig("foo"), CompressNone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := w.Encoding, Encoding_json; got != want {
+		t.Errorf("bad encoding: got %s, want %s", got, want)
+	}
+	var config corev3.EntityConfig
+	if err := w.UnwrapInto(&config); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := config.Metadata.Name, "foo"; got != want {
+		t.Errorf("bad name: got %s, want %s", got, want)
+	}
+
+	// Types that are not registered are still encoded as protobuf
+	w, err = Resource(corev3.FixtureEntityState("foo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := w.Encoding, Encoding_protobuf; got != want {
+		t.Errorf("bad encoding: got %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,81 @@
+package wrap
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// etagPending is the ETag that WithETag records until the value of the
+// wrapper is encoded and compressed, so that wrapWithOptions computes the
+// actual ETag once the value is known.
+const etagPending = "\x00pending"
+
+// WithETag is an option for recording the ETag of the wrapper, as computed by
+// ComputeETag once the resource is encoded and compressed.
+var WithETag Option = func(w *Wrapper, r interface{}) error {
+	w.ETag = etagPending
+	return nil
+}
+
+// ComputeETag sets the ETag of the wrapper to a hash of its type and of its
+// compressed value, so that wrappers holding the same bytes for the same type
+// get the same ETag in any process, on any architecture. The ETag is the first
+// 16 bytes of a SHA-256 digest, hex encoded and quoted like the ETags of
+// store.ETag. An error is returned if the wrapper has no type.
+func (w *Wrapper) ComputeETag() error {
+	etag, err := w.hashETag()
+	if err != nil {
+		return err
+	}
+	w.ETag = etag
+	return nil
+}
+
+// ETagMatches reports whether the ETag of the wrapper is the one ComputeETag
+// computes from its type and value, which it no longer is if either was
+// corrupted. Wrappers whose ETag was not computed by ComputeETag, including
+// those without an ETag, have nothing to check and always match; see
+// HasComputedETag.
+func (w *Wrapper) ETagMatches() (bool, error) {
+	if !w.HasComputedETag() {
+		return true, nil
+	}
+	etag, err := w.hashETag()
+	if err != nil {
+		return false, err
+	}
+	return etag == w.ETag, nil
+}
+
+// HasComputedETag reports whether the ETag of the wrapper has the form of the
+// ETags computed by ComputeETag.
+func (w *Wrapper) HasComputedETag() bool {
+	etag := w.ETag
+	if len(etag) != 2+2*etagHashLen || etag[0] != '"' || etag[len(etag)-1] != '"' {
+		return false
+	}
+	_, err := hex.DecodeString(etag[1 : len(etag)-1])
+	return err == nil
+}
+
+// etagHashLen is the number of bytes of the digest that make up an ETag.
+const etagHashLen = 16
+
+func (w *Wrapper) hashETag() (string, error) {
+	if w.TypeMeta == nil {
+		return "", errors.New("cannot compute the etag of a wrapper without type")
+	}
+	hash := sha256.New()
+	// Fields are prefixed with their length, so that their boundaries are
+	// part of the hash
+	var length [8]byte
+	for _, field := range [][]byte{[]byte(w.TypeMeta.APIVersion), []byte(w.TypeMeta.Type), w.Value} {
+		binary.BigEndian.PutUint64(length[:], uint64(len(field)))
+		_, _ = hash.Write(length[:])
+		_, _ = hash.Write(field)
+	}
+	return fmt.Sprintf("%q", hex.EncodeToString(hash.Sum(nil)[:etagHashLen])), nil
+}
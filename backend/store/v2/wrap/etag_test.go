@@ -0,0 +1,120 @@
+package wrap_test
+
+import (
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+	"github.com/sensu/sensu-go/backend/store/v2/wrap"
+)
+
+func TestWrapperComputeETag(t *testing.T) {
+	w := &wrap.Wrapper{
+		TypeMeta: &corev2.TypeMeta{APIVersion: "core/v3", Type: "EntityConfig"},
+		Value:    []byte(`{"metadata":{"name":"foo"}}`),
+	}
+	if err := w.ComputeETag(); err != nil {
+		t.Fatal(err)
+	}
+	// The ETag must not depend on the process nor the architecture
+	if got, want := w.ETag, `"26ae1c6110edf97313e9f923da6a9ff2"`; got != want {
+		t.Errorf("bad etag: got %s, want %s", got, want)
+	}
+
+	tests := []struct {
+		name   string
+		mangle func(*wrap.Wrapper)
+	}{
+		{
+			name:   "value",
+			mangle: func(w *wrap.Wrapper) { w.Value = []byte(`{"metadata":{"name":"bar"}}`) },
+		},
+		{
+			name:   "type",
+			mangle: func(w *wrap.Wrapper) { w.TypeMeta = &corev2.TypeMeta{APIVersion: "core/v3", Type: "EntityState"} },
+		},
+		{
+			name:   "field boundaries",
+			mangle: func(w *wrap.Wrapper) { w.TypeMeta = &corev2.TypeMeta{APIVersion: "core/v3E", Type: "ntityConfig"} },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed := *w
+			tt.mangle(&changed)
+			if err := changed.ComputeETag(); err != nil {
+				t.Fatal(err)
+			}
+			if changed.ETag == w.ETag {
+				t.Errorf("expected the etag to change, got %s", changed.ETag)
+			}
+		})
+	}
+
+	if err := (&wrap.Wrapper{Value: w.Value}).ComputeETag(); err == nil {
+		t.Error("expected an error for a wrapper without type")
+	}
+}
+
+func TestWrapWithETag(t *testing.T) {
+	w, err := wrap.Resource(corev3.FixtureEntityConfig("foo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.ETag != "" {
+		t.Errorf("unexpected etag: %s", w.ETag)
+	}
+
+	for _, compression := range []wrap.Option{wrap.CompressNone, wrap.CompressSnappy} {
+		w, err := wrap.Resource(corev3.FixtureEntityConfig("foo"), wrap.EncodeJSON, compression, wrap.WithETag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		etag := w.ETag
+		if err := w.ComputeETag(); err != nil {
+			t.Fatal(err)
+		}
+		if etag != w.ETag {
+			t.Errorf("%s: got etag %s, want the etag of the stored value %s", w.Compression, etag, w.ETag)
+		}
+		again, err := wrap.Resource(corev3.FixtureEntityConfig("foo"), wrap.EncodeJSON, compression, wrap.WithETag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if again.ETag != etag {
+			t.Errorf("%s: expected the same resource to get the same etag, got %s and %s", w.Compression, etag, again.ETag)
+		}
+	}
+}
+
+func TestWrapperETagMatches(t *testing.T) {
+	w, err := wrap.Resource(corev3.FixtureEntityConfig("foo"), wrap.WithETag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !w.HasComputedETag() {
+		t.Fatalf("expected a computed etag, got %s", w.ETag)
+	}
+	if ok, err := w.ETagMatches(); err != nil || !ok {
+		t.Errorf("expected the etag to match, got %v, %v", ok, err)
+	}
+
+	corrupt := *w
+	corrupt.Value = append([]byte{}, w.Value...)
+	corrupt.Value[0] ^= 0xff
+	if ok, err := corrupt.ETagMatches(); err != nil || ok {
+		t.Errorf("expected the etag of a corrupt value not to match, got %v, %v", ok, err)
+	}
+
+	// ETags that were not computed by ComputeETag are not checked
+	for _, etag := range []string{"", `"42"`, `W/"26ae1c6110edf97313e9f923da6a9ff2"`, `"zzae1c6110edf97313e9f923da6a9ff2"`} {
+		other := corrupt
+		other.ETag = etag
+		if other.HasComputedETag() {
+			t.Errorf("etag %s: expected no computed etag", etag)
+		}
+		if ok, err := other.ETagMatches(); err != nil || !ok {
+			t.Errorf("etag %s: expected the etag to be skipped, got %v, %v", etag, ok, err)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package wrap
+
+import (
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sensu/sensu-go/backend/metrics"
+)
+
+const (
+	// LargeEncodeBytes is the name of the prometheus histogram used to track
+	// the sizes of the encodes recorded by RecordLargeEncodes. Its
+	// observations carry the type and name of the resource as OpenMetrics
+	// exemplars.
+	LargeEncodeBytes = "sensu_go_store_large_encode_bytes"
+
+	// DefaultExemplarRingSize is the number of exemplars kept by the ring
+	// buffers of the backend.
+	DefaultExemplarRingSize = 100
+)
+
+var largeEncodeBytesHistogram = prometheus.NewHistogram(
+	prometheus.HistogramOpts{
+		Name:    LargeEncodeBytes,
+		Help:    "The sizes of encoded resources over the large encode threshold",
+		Buckets: prometheus.ExponentialBuckets(64*1024, 2, 8),
+	},
+)
+
+func init() {
+	if err := prometheus.Register(largeEncodeBytesHistogram); err != nil {
+		metrics.LogError(logger, LargeEncodeBytes, err)
+	}
+}
+
+// Exemplar describes an encoded resource whose size exceeded the threshold
+// of an ExemplarRing.
+type Exemplar struct {
+	Type       string `json:"type"`
+	APIVersion string `json:"api_version"`
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	Size       int    `json:"size"`
+	Timestamp  int64  `json:"timestamp"`
+}
+
+// ExemplarRing is a bounded ring buffer of the most recent exemplars of large
+// encodes. When it is full, recording an exemplar drops the oldest one. It is
+// safe for concurrent use.
+type ExemplarRing struct {
+	threshold int
+
+	mu        sync.Mutex
+	exemplars []Exemplar
+	next      int
+	full      bool
+}
+
+// NewExemplarRing returns a ring buffer of size exemplars, for the encodes of
+// at least threshold bytes.
+func NewExemplarRing(size, threshold int) *ExemplarRing {
+	if size < 1 {
+		size = 1
+	}
+	return &ExemplarRing{
+		threshold: threshold,
+		exemplars: make([]Exemplar, size),
+	}
+}
+
+// Threshold returns the size, in bytes, from which encodes are recorded.
+func (e *ExemplarRing) Threshold() int {
+	return e.threshold
+}
+
+// Add records an exemplar, dropping the oldest one if the ring is full.
+func (e *ExemplarRing) Add(exemplar Exemplar) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.exemplars[e.next] = exemplar
+	e.next = (e.next + 1) % len(e.exemplars)
+	if e.next == 0 {
+		e.full = true
+	}
+}
+
+// Exemplars returns a copy of the recorded exemplars, oldest first.
+func (e *ExemplarRing) Exemplars() []Exemplar {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.full {
+		return append([]Exemplar{}, e.exemplars[:e.next]...)
+	}
+	result := make([]Exemplar, 0, len(e.exemplars))
+	result = append(result, e.exemplars[e.next:]...)
+	return append(result, e.exemplars[:e.next]...)
+}
+
+// RecordLargeEncodes returns an option that records an exemplar to ring when
+// the encoding of the resource is at least as long as the threshold of ring,
+// and observes its size in the LargeEncodeBytes histogram. Like CompressAuto,
+// it measures the encoding of the resource once it is encoded.
+func RecordLargeEncodes(ring *ExemplarRing) Option {
+	return func(w *Wrapper, r interface{}) error {
+		return encodedHook(func(w *Wrapper, r interface{}, message []byte) error {
+			return recordLargeEncode(ring, w, r, len(message))
+		})
+	}
+}
+
+// recordLargeEncode records an exemplar of the resource r, wrapped in w, to
+// ring if its encoding, size bytes long, is large enough.
+func recordLargeEncode(ring *ExemplarRing, w *Wrapper, r interface{}, size int) error {
+	if size < ring.Threshold() {
+		return nil
+	}
+	exemplar := Exemplar{
+		Size:      size,
+		Timestamp: time.Now().Unix(),
+	}
+	if w.TypeMeta != nil {
+		exemplar.Type = w.TypeMeta.Type
+		exemplar.APIVersion = w.TypeMeta.APIVersion
+	}
+	if meta := objectMeta(r); meta != nil {
+		exemplar.Namespace = meta.Namespace
+		exemplar.Name = meta.Name
+	}
+	ring.Add(exemplar)
+	observeLargeEncode(exemplar)
+	return nil
+}
+
+// observeLargeEncode observes the size of the exemplar, with its type and name
+// as exemplar labels. Names are truncated to fit the limit on the length of
+// exemplar labels.
+func observeLargeEncode(exemplar Exemplar) {
+	labels := prometheus.Labels{"type": exemplar.Type}
+	budget := prometheus.ExemplarMaxRunes - len("type") - utf8.RuneCountInString(exemplar.Type) - len("name")
+	if budget < 0 {
+		largeEncodeBytesHistogram.Observe(float64(exemplar.Size))
+		return
+	}
+	labels["name"] = truncateRunes(exemplar.Name, budget)
+	largeEncodeBytesHistogram.(prometheus.ExemplarObserver).ObserveWithExemplar(float64(exemplar.Size), labels)
+}
+
+// truncateRunes returns the first n runes of s.
+func truncateRunes(s string, n int) string {
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
+}
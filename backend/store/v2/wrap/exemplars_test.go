@@ -0,0 +1,92 @@
+package wrap_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+	"github.com/sensu/sensu-go/backend/store/v2/wrap"
+)
+
+func TestExemplarRing(t *testing.T) {
+	ring := wrap.NewExemplarRing(3, 0)
+	if got := ring.Exemplars(); len(got) != 0 {
+		t.Fatalf("expected no exemplars, got %v", got)
+	}
+	for i := 1; i <= 5; i++ {
+		ring.Add(wrap.Exemplar{Size: i})
+	}
+	got := ring.Exemplars()
+	if len(got) != 3 {
+		t.Fatalf("expected 3 exemplars, got %v", got)
+	}
+	for i, exemplar := range got {
+		if want := i + 3; exemplar.Size != want {
+			t.Errorf("exemplar %d: got size %d, want %d", i, exemplar.Size, want)
+		}
+	}
+}
+
+func TestExemplarRingConcurrency(t *testing.T) {
+	ring := wrap.NewExemplarRing(10, 0)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				ring.Add(wrap.Exemplar{Size: j})
+				_ = ring.Exemplars()
+			}
+		}()
+	}
+	wg.Wait()
+	if got := len(ring.Exemplars()); got != 10 {
+		t.Fatalf("expected 10 exemplars, got %d", got)
+	}
+}
+
+func TestRecordLargeEncodes(t *testing.T) {
+	ring := wrap.NewExemplarRing(10, 1024)
+
+	small := corev3.FixtureEntityConfig("small")
+	if _, err := wrap.Resource(small, wrap.RecordLargeEncodes(ring)); err != nil {
+		t.Fatal(err)
+	}
+	if got := ring.Exemplars(); len(got) != 0 {
+		t.Fatalf("expected no exemplars, got %v", got)
+	}
+
+	// The name is longer than what exemplar labels allow
+	large := corev3.FixtureEntityConfig(strings.Repeat("large", 20))
+	large.Metadata.Annotations["description"] = strings.Repeat("x", 2048)
+	if _, err := wrap.Resource(large, wrap.RecordLargeEncodes(ring)); err != nil {
+		t.Fatal(err)
+	}
+	check := corev2.FixtureCheckConfig("check")
+	check.Command = strings.Repeat("x", 2048)
+	if _, err := wrap.V2Resource(check, wrap.EncodeJSON, wrap.RecordLargeEncodes(ring)); err != nil {
+		t.Fatal(err)
+	}
+
+	got := ring.Exemplars()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 exemplars, got %v", got)
+	}
+	if got[0].Type != "EntityConfig" || got[0].Namespace != "default" || got[0].Name != large.Metadata.Name {
+		t.Errorf("bad exemplar: %v", got[0])
+	}
+	if got[1].Type != "CheckConfig" || got[1].Name != "check" {
+		t.Errorf("bad exemplar: %v", got[1])
+	}
+	for _, exemplar := range got {
+		if exemplar.Size < 2048 {
+			t.Errorf("bad size: %d", exemplar.Size)
+		}
+		if exemplar.Timestamp == 0 {
+			t.Error("missing timestamp")
+		}
+	}
+}
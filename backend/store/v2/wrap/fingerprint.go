@@ -0,0 +1,125 @@
+package wrap
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+	"github.com/sirupsen/logrus"
+)
+
+// fingerprints caches the schema fingerprints of types, keyed by reflect.Type.
+var fingerprints sync.Map
+
+// RecordSchemaFingerprint is an option for recording the schema fingerprint of
+// the resource on the wrapper, so that unwrapping it into a type whose set of
+// fields is different logs a warning naming the resource.
+var RecordSchemaFingerprint Option = func(w *Wrapper, r interface{}) error {
+	w.SchemaFingerprint = SchemaFingerprint(r)
+	return nil
+}
+
+// SchemaFingerprint returns the fingerprint of the set of fields of the type
+// of v. It is a hash of the names, types, and json and protobuf tags of the
+// fields of the type, and of the types they refer to, so that adding,
+// removing, renaming or retyping any of them changes it.
+func SchemaFingerprint(v interface{}) string {
+	if proxy, ok := v.(*corev3.V2ResourceProxy); ok {
+		v = proxy.Resource
+	}
+	t := reflect.TypeOf(v)
+	if fp, ok := fingerprints.Load(t); ok {
+		return fp.(string)
+	}
+	var b strings.Builder
+	writeSchema(&b, t, map[reflect.Type]bool{})
+	sum := sha256.Sum256([]byte(b.String()))
+	fp := hex.EncodeToString(sum[:8])
+	fingerprints.Store(t, fp)
+	return fp
+}
+
+// writeSchema writes a description of the type t to b. Struct types are
+// described by their fields rather than their name, and only once, so that
+// recursive types terminate.
+func writeSchema(b *strings.Builder, t reflect.Type, seen map[reflect.Type]bool) {
+	if t == nil {
+		b.WriteString("nil")
+		return
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		b.WriteString("*")
+		writeSchema(b, t.Elem(), seen)
+	case reflect.Slice:
+		b.WriteString("[]")
+		writeSchema(b, t.Elem(), seen)
+	case reflect.Array:
+		fmt.Fprintf(b, "[%d]", t.Len())
+		writeSchema(b, t.Elem(), seen)
+	case reflect.Map:
+		b.WriteString("map[")
+		writeSchema(b, t.Key(), seen)
+		b.WriteString("]")
+		writeSchema(b, t.Elem(), seen)
+	case reflect.Struct:
+		if seen[t] {
+			b.WriteString(t.String())
+			return
+		}
+		seen[t] = true
+		b.WriteString("struct{")
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" {
+				// unexported fields aren't stored
+				continue
+			}
+			fmt.Fprintf(b, "%s %q %q ", field.Name, field.Tag.Get("json"), field.Tag.Get("protobuf"))
+			writeSchema(b, field.Type, seen)
+			b.WriteString(";")
+		}
+		b.WriteString("}")
+	default:
+		b.WriteString(t.String())
+	}
+}
+
+// checkSchema logs a warning if the wrapper has a schema fingerprint that is
+// not the one of the type of resource, which it was unwrapped into.
+func (w *Wrapper) checkSchema(resource interface{}) {
+	if w.SchemaFingerprint == "" {
+		return
+	}
+	fp := SchemaFingerprint(resource)
+	if fp == w.SchemaFingerprint {
+		return
+	}
+	fields := logrus.Fields{
+		"type":                reflect.Indirect(reflect.ValueOf(resource)).Type().String(),
+		"stored_fingerprint":  w.SchemaFingerprint,
+		"current_fingerprint": fp,
+	}
+	if meta := objectMeta(resource); meta != nil {
+		fields["namespace"] = meta.Namespace
+		fields["name"] = meta.Name
+	}
+	logger.WithFields(fields).Warn("resource was stored with a different schema than the one it was read with")
+}
+
+// objectMeta returns the metadata of resource, or nil if it has none.
+func objectMeta(resource interface{}) *corev2.ObjectMeta {
+	switch r := resource.(type) {
+	case corev3.Resource:
+		return r.GetMetadata()
+	case corev2.Resource:
+		meta := r.GetObjectMeta()
+		return &meta
+	}
+	return nil
+}
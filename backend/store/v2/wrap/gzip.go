@@ -0,0 +1,42 @@
+package wrap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// gzipCompress compresses m as a gzip stream, for consumers of stored values
+// that only have a gzip decoder.
+func gzipCompress(m []byte) []byte {
+	var buf bytes.Buffer
+	// Writes to a bytes.Buffer don't fail
+	zw := gzip.NewWriter(&buf)
+	_, _ = zw.Write(m)
+	_ = zw.Close()
+	return buf.Bytes()
+}
+
+// gzipDecompress is the gzip counterpart of Compression.decompress. Truncated
+// and corrupt streams are reported as a *CorruptValueError.
+func gzipDecompress(m []byte, size int64, max int) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(m))
+	if err != nil {
+		return nil, &CorruptValueError{Compression: Compression_gzip, Err: err}
+	}
+	defer zr.Close()
+	// Read one byte past the maximum, to tell values that exceed it
+	b, err := ioutil.ReadAll(io.LimitReader(zr, int64(max)+1))
+	if err != nil {
+		return nil, &CorruptValueError{Compression: Compression_gzip, Err: err}
+	}
+	if len(b) > max {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrDecompressedSizeTooLarge, max)
+	}
+	if size > 0 && int64(len(b)) != size {
+		return nil, &CorruptValueError{Compression: Compression_gzip, Err: fmt.Errorf("decoded length %d does not match the uncompressed length %d", len(b), size)}
+	}
+	return b, nil
+}
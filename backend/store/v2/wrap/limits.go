@@ -0,0 +1,106 @@
+package wrap
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// TruncatedMarker is appended to the label and annotation values truncated by
+// TruncateMetadataValues.
+const TruncatedMarker = "...(truncated)"
+
+// MetadataValueTooLargeError is returned when a label or annotation value of
+// a resource is larger than the maximum set with LimitMetadataValues.
+type MetadataValueTooLargeError struct {
+	// Kind is either "label" or "annotation".
+	Kind string
+	Key  string
+	Size int
+	Max  int
+}
+
+func (e *MetadataValueTooLargeError) Error() string {
+	return fmt.Sprintf("%s %q is %d bytes long, more than the maximum of %d bytes", e.Kind, e.Key, e.Size, e.Max)
+}
+
+// LimitMetadataValues returns an option that rejects resources with a label or
+// annotation value longer than max bytes, with a *MetadataValueTooLargeError
+// naming the offending key. A single huge annotation can otherwise make a
+// resource larger than etcd accepts, and fail the write opaquely. A max of
+// zero or less disables the limit.
+func LimitMetadataValues(max int) Option {
+	return func(w *Wrapper, r interface{}) error {
+		return limitMetadataValues(r, max, false)
+	}
+}
+
+// TruncateMetadataValues is like LimitMetadataValues, but truncates the label
+// and annotation values longer than max bytes instead, ending them with
+// TruncatedMarker. The values are truncated in the resource itself, so that
+// it matches what is stored.
+func TruncateMetadataValues(max int) Option {
+	return func(w *Wrapper, r interface{}) error {
+		return limitMetadataValues(r, max, true)
+	}
+}
+
+func limitMetadataValues(r interface{}, max int, truncate bool) error {
+	if max <= 0 {
+		return nil
+	}
+	var meta *corev2.ObjectMeta
+	switch r := r.(type) {
+	case interface{ GetMetadata() *corev2.ObjectMeta }:
+		meta = r.GetMetadata()
+	case interface{ GetObjectMeta() corev2.ObjectMeta }:
+		// The maps are shared with the resource
+		m := r.GetObjectMeta()
+		meta = &m
+	}
+	if meta == nil {
+		return nil
+	}
+	if err := limitValues("label", meta.Labels, max, truncate); err != nil {
+		return err
+	}
+	return limitValues("annotation", meta.Annotations, max, truncate)
+}
+
+func limitValues(kind string, values map[string]string, max int, truncate bool) error {
+	// Check the keys in order, so that the same key is always reported
+	keys := make([]string, 0, len(values))
+	for key, value := range values {
+		if len(value) > max {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !truncate {
+			return &MetadataValueTooLargeError{Kind: kind, Key: key, Size: len(values[key]), Max: max}
+		}
+		values[key] = truncateValue(values[key], max)
+	}
+	return nil
+}
+
+// truncateValue truncates value to at most max bytes, marker included, without
+// splitting a UTF-8 encoded character.
+func truncateValue(value string, max int) string {
+	n := max - len(TruncatedMarker)
+	if n < 0 {
+		n = 0
+	}
+	for n > 0 && !utf8.RuneStart(value[n]) {
+		n--
+	}
+	truncated := value[:n] + TruncatedMarker
+	if len(truncated) > max {
+		// The marker itself doesn't fit
+		return TruncatedMarker[:max]
+	}
+	return truncated
+}
@@ -0,0 +1,7 @@
+package wrap
+
+import "github.com/sirupsen/logrus"
+
+var logger = logrus.WithFields(logrus.Fields{
+	"component": "store",
+})
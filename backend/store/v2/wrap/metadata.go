@@ -0,0 +1,182 @@
+package wrap
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+)
+
+var (
+	objectMetaType = reflect.TypeOf(corev2.ObjectMeta{})
+
+	// metadataFields caches the protobuf field number of the metadata of each
+	// resource type, or zero if the type has no metadata field.
+	metadataFields sync.Map
+)
+
+var errMetadataNotFound = errors.New("metadata field not found")
+
+// Metadata returns the metadata of the wrapped resource, without decoding the
+// rest of its value when possible. Protobuf values are scanned for their
+// metadata field, skipping every other field, and JSON values are decoded
+// shallowly. Resources that do not store their metadata in a field of their
+// own, such as core/v2 namespaces, are fully decoded instead.
+//
+// Unlike Unwrap, Metadata returns the metadata as it was stored, without the
+// annotations describing the wrapper itself.
+func (w *Wrapper) Metadata() (*corev2.ObjectMeta, error) {
+	if err := w.checkFormat(); err != nil {
+		return nil, err
+	}
+	message, err := w.decompressedValue()
+	if err != nil {
+		return nil, fmt.Errorf("error unwrapping metadata: %w", err)
+	}
+	var meta *corev2.ObjectMeta
+	switch w.Encoding {
+	case Encoding_protobuf:
+		meta, err = w.protobufMetadata(message)
+	case Encoding_json:
+		meta, err = jsonMetadata(message)
+	default:
+		err = errMetadataNotFound
+	}
+	if err == errMetadataNotFound {
+		return w.decodedMetadata()
+	}
+	return meta, err
+}
+
+// protobufMetadata scans message for the metadata field of the wrapped
+// resource type, and decodes it alone.
+func (w *Wrapper) protobufMetadata(message []byte) (*corev2.ObjectMeta, error) {
+	if w.TypeMeta == nil {
+		return nil, errMetadataNotFound
+	}
+	resource, err := resolveRaw(w.TypeMeta)
+	if err != nil {
+		return nil, err
+	}
+	field := metadataField(reflect.TypeOf(resource))
+	if field == 0 {
+		return nil, errMetadataNotFound
+	}
+
+	meta := new(corev2.ObjectMeta)
+	for len(message) > 0 {
+		key, n := binary.Uvarint(message)
+		if n <= 0 {
+			return nil, errors.New("error unwrapping metadata: malformed protobuf")
+		}
+		message = message[n:]
+		number, wireType := key>>3, key&7
+		size := 0
+		switch wireType {
+		case 0: // varint
+			if _, n = binary.Uvarint(message); n <= 0 {
+				return nil, errors.New("error unwrapping metadata: malformed protobuf")
+			}
+			size = n
+		case 1: // fixed64
+			size = 8
+		case 2: // length-delimited
+			length, n := binary.Uvarint(message)
+			if n <= 0 || length > uint64(len(message)-n) {
+				return nil, errors.New("error unwrapping metadata: malformed protobuf")
+			}
+			message = message[n:]
+			size = int(length)
+		case 5: // fixed32
+			size = 4
+		default:
+			// Groups are not used by any resource, let the full decoder
+			// deal with them
+			return nil, errMetadataNotFound
+		}
+		if size > len(message) {
+			return nil, errors.New("error unwrapping metadata: malformed protobuf")
+		}
+		if number == field && wireType == 2 {
+			// Repeated occurrences of a message field are merged together
+			if err := meta.Unmarshal(message[:size]); err != nil {
+				return nil, fmt.Errorf("error unwrapping metadata: %s", err)
+			}
+		}
+		message = message[size:]
+	}
+	return meta, nil
+}
+
+// metadataField returns the protobuf field number of the metadata of the
+// given resource type, or zero if it has none.
+func metadataField(typ reflect.Type) uint64 {
+	if field, ok := metadataFields.Load(typ); ok {
+		return field.(uint64)
+	}
+	var number uint64
+	elem := typ
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() == reflect.Struct {
+		for i := 0; i < elem.NumField(); i++ {
+			f := elem.Field(i)
+			if f.Type != objectMetaType && f.Type != reflect.PtrTo(objectMetaType) {
+				continue
+			}
+			// The tag looks like "bytes,26,opt,name=metadata,proto3"
+			parts := strings.Split(f.Tag.Get("protobuf"), ",")
+			if len(parts) < 2 || parts[0] != "bytes" {
+				continue
+			}
+			if n, err := strconv.ParseUint(parts[1], 10, 64); err == nil {
+				number = n
+				break
+			}
+		}
+	}
+	metadataFields.Store(typ, number)
+	return number
+}
+
+// jsonMetadata decodes the metadata of a JSON value, leaving the rest of it
+// undecoded.
+func jsonMetadata(message []byte) (*corev2.ObjectMeta, error) {
+	var value struct {
+		Metadata *corev2.ObjectMeta `json:"metadata"`
+	}
+	if err := json.Unmarshal(message, &value); err != nil {
+		return nil, fmt.Errorf("error unwrapping metadata: %s", err)
+	}
+	if value.Metadata == nil {
+		return nil, errMetadataNotFound
+	}
+	return value.Metadata, nil
+}
+
+// decodedMetadata returns the metadata of the fully decoded resource.
+func (w *Wrapper) decodedMetadata() (*corev2.ObjectMeta, error) {
+	resource, err := w.UnwrapRaw()
+	if err != nil {
+		return nil, err
+	}
+	switch resource := resource.(type) {
+	case corev2.Resource:
+		meta := resource.GetObjectMeta()
+		return &meta, nil
+	case corev3.Resource:
+		if meta := resource.GetMetadata(); meta != nil {
+			return meta, nil
+		}
+		return new(corev2.ObjectMeta), nil
+	}
+	return nil, fmt.Errorf("%T has no metadata", resource)
+}
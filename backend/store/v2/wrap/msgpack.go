@@ -0,0 +1,29 @@
+package wrap
+
+import (
+	"bytes"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// msgpackStructTag is the struct tag that MessagePack field names are read
+// from. Using the JSON tags keeps the field names of msgpack encoded values the
+// same as those of JSON encoded ones, and honors their omitempty and "-"
+// options.
+const msgpackStructTag = "json"
+
+func encodeMsgpack(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag(msgpackStructTag)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeMsgpack(m []byte, v interface{}) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(m))
+	dec.SetCustomStructTag(msgpackStructTag)
+	return dec.Decode(v)
+}
@@ -0,0 +1,173 @@
+package wrap
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+)
+
+var (
+	normalizeMu sync.RWMutex
+
+	// setFields are the string slice fields whose order is meaningless, keyed
+	// by struct type and JSON name.
+	setFields = map[reflect.Type]map[string]bool{}
+
+	// defaultFields are the values the server assumes for fields that are not
+	// set, keyed by struct type and JSON name.
+	defaultFields = map[reflect.Type]map[string]interface{}{}
+)
+
+func init() {
+	RegisterSetFields(corev2.CheckConfig{}, "subscriptions", "handlers", "output_metric_handlers")
+	RegisterSetFields(corev2.Entity{}, "subscriptions", "redact")
+	RegisterSetFields(corev3.EntityConfig{}, "subscriptions", "redact")
+	RegisterSetFields(corev2.Handler{}, "handlers", "filters")
+	RegisterSetFields(corev2.Rule{}, "verbs", "resources", "resource_names")
+	RegisterDefaultField(corev2.Entity{}, "redact", corev2.DefaultRedactFields)
+	RegisterDefaultField(corev3.EntityConfig{}, "redact", corev2.DefaultRedactFields)
+}
+
+// RegisterSetFields declares that the named string slice fields of the struct
+// type of v hold sets, so that Normalize sorts them. Fields are named by their
+// JSON name. It should only be called at init time, and panics if a field is
+// not a string slice of the type.
+func RegisterSetFields(v interface{}, fields ...string) {
+	t := reflect.TypeOf(v)
+	normalizeMu.Lock()
+	defer normalizeMu.Unlock()
+	if setFields[t] == nil {
+		setFields[t] = map[string]bool{}
+	}
+	for _, name := range fields {
+		field := normalizeField(t, name)
+		if field.Type != reflect.TypeOf([]string(nil)) {
+			panic(fmt.Sprintf("%s.%s is not a string slice", t, field.Name))
+		}
+		setFields[t][name] = true
+	}
+}
+
+// RegisterDefaultField declares that the server treats the named field of the
+// struct type of v as having value when it is not set, so that Normalize
+// clears it when it is set to value. The field is named by its JSON name. It
+// should only be called at init time, and panics if value can't be assigned to
+// the field.
+func RegisterDefaultField(v interface{}, field string, value interface{}) {
+	t := reflect.TypeOf(v)
+	if f := normalizeField(t, field); !reflect.TypeOf(value).AssignableTo(f.Type) {
+		panic(fmt.Sprintf("cannot use %T as the default value of %s.%s", value, t, f.Name))
+	}
+	normalizeMu.Lock()
+	defer normalizeMu.Unlock()
+	if defaultFields[t] == nil {
+		defaultFields[t] = map[string]interface{}{}
+	}
+	defaultFields[t][field] = value
+}
+
+func normalizeField(t reflect.Type, name string) reflect.StructField {
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("%s is not a struct", t))
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if jsonName(field) == name {
+			return field
+		}
+	}
+	panic(fmt.Sprintf("%s has no field named %q", t, name))
+}
+
+func jsonName(field reflect.StructField) string {
+	return strings.Split(field.Tag.Get("json"), ",")[0]
+}
+
+// Normalize returns a copy of r in canonical form, so that logically equal
+// resources are wrapped into the same bytes, and WouldChange does not report
+// changes that are only differences in representation. In the copy:
+//
+//   - fields registered with RegisterSetFields are sorted;
+//   - nil slices and maps are replaced with empty ones;
+//   - fields registered with RegisterDefaultField that hold their default
+//     value are cleared.
+//
+// r is not modified. Unexported fields are not copied.
+func Normalize(r corev3.Resource) corev3.Resource {
+	if proxy, ok := r.(*corev3.V2ResourceProxy); ok {
+		return &corev3.V2ResourceProxy{
+			Resource: normalize(reflect.ValueOf(proxy.Resource)).Interface().(corev3.Resource),
+		}
+	}
+	return normalize(reflect.ValueOf(r)).Interface().(corev3.Resource)
+}
+
+// NormalizeV2Resource is like Normalize, but works on older core v2 resources.
+func NormalizeV2Resource(r corev2.Resource) corev2.Resource {
+	return normalize(reflect.ValueOf(r)).Interface().(corev2.Resource)
+}
+
+// normalize returns a normalized deep copy of v.
+func normalize(v reflect.Value) reflect.Value {
+	normalizeMu.RLock()
+	defer normalizeMu.RUnlock()
+	return normalizeValue(v)
+}
+
+func normalizeValue(v reflect.Value) reflect.Value {
+	result := reflect.New(v.Type()).Elem()
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			result.Set(normalizeValue(v.Elem()).Addr())
+		}
+	case reflect.Slice:
+		result.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			result.Index(i).Set(normalizeValue(v.Index(i)))
+		}
+	case reflect.Map:
+		result.Set(reflect.MakeMapWithSize(v.Type(), v.Len()))
+		iter := v.MapRange()
+		for iter.Next() {
+			result.SetMapIndex(iter.Key(), normalizeValue(iter.Value()))
+		}
+	case reflect.Struct:
+		normalizeStruct(result, v)
+	default:
+		result.Set(v)
+	}
+	return result
+}
+
+func normalizeStruct(dst, src reflect.Value) {
+	t := src.Type()
+	sets := setFields[t]
+	defaults := defaultFields[t]
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		value := normalizeValue(src.Field(i))
+		name := jsonName(field)
+		if sets[name] {
+			sort.Strings(value.Interface().([]string))
+		}
+		if def, ok := defaults[name]; ok {
+			defValue := normalizeValue(reflect.ValueOf(def))
+			if sets[name] {
+				sort.Strings(defValue.Interface().([]string))
+			}
+			if reflect.DeepEqual(value.Interface(), defValue.Interface()) {
+				value = normalizeValue(reflect.Zero(field.Type))
+			}
+		}
+		dst.Field(i).Set(value)
+	}
+}
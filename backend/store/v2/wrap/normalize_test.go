@@ -0,0 +1,99 @@
+package wrap_test
+
+import (
+	"reflect"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+	"github.com/sensu/sensu-go/backend/store/v2/wrap"
+)
+
+func TestNormalize(t *testing.T) {
+	entity := corev3.FixtureEntityConfig("foo")
+	entity.Subscriptions = []string{"linux", "entity:foo", "database"}
+	entity.Redact = []string{"secret", "password", "passwd", "pass", "api_key", "api_token", "access_key", "secret_key", "private_key"}
+	entity.Metadata.Labels = nil
+
+	normalized := wrap.Normalize(entity).(*corev3.EntityConfig)
+	if got, want := normalized.Subscriptions, []string{"database", "entity:foo", "linux"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("bad subscriptions: got %v, want %v", got, want)
+	}
+	if len(normalized.Redact) != 0 {
+		t.Errorf("expected the default redact fields to be cleared, got %v", normalized.Redact)
+	}
+	if normalized.Metadata.Labels == nil {
+		t.Error("expected nil labels to be replaced with empty ones")
+	}
+
+	// The resource itself is left as is
+	if got, want := entity.Subscriptions[0], "linux"; got != want {
+		t.Errorf("resource was modified: got subscription %q, want %q", got, want)
+	}
+	if entity.Redact == nil || entity.Metadata.Labels != nil {
+		t.Error("resource was modified")
+	}
+	if normalized.Metadata == entity.Metadata {
+		t.Error("expected the metadata to be copied")
+	}
+
+	// Other redact fields are kept
+	entity.Redact = []string{"token"}
+	if got := wrap.Normalize(entity).(*corev3.EntityConfig).Redact; !reflect.DeepEqual(got, entity.Redact) {
+		t.Errorf("bad redact fields: got %v, want %v", got, entity.Redact)
+	}
+}
+
+func TestNormalizeV2Resource(t *testing.T) {
+	role := corev2.FixtureRole("role", "default")
+	role.Rules = []corev2.Rule{{
+		Verbs:     []string{"list", "get"},
+		Resources: []string{"events", "checks"},
+	}}
+	rule := wrap.NormalizeV2Resource(role).(*corev2.Role).Rules[0]
+	if got, want := rule.Verbs, []string{"get", "list"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("bad verbs: got %v, want %v", got, want)
+	}
+	if got, want := rule.Resources, []string{"checks", "events"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("bad resources: got %v, want %v", got, want)
+	}
+	if rule.ResourceNames == nil {
+		t.Error("expected nil resource names to be replaced with an empty slice")
+	}
+	if got, want := role.Rules[0].Verbs[0], "list"; got != want {
+		t.Errorf("role was modified: got verb %q, want %q", got, want)
+	}
+}
+
+func TestNormalizeRoundTrip(t *testing.T) {
+	for _, opt := range []wrap.Option{wrap.EncodeJSON, wrap.EncodeProtobuf} {
+		a := corev3.FixtureEntityConfig("foo")
+		a.Subscriptions = []string{"b", "a"}
+		a.Redact = append([]string(nil), corev2.DefaultRedactFields...)
+		b := corev3.FixtureEntityConfig("foo")
+		b.Subscriptions = []string{"a", "b"}
+		b.Redact = nil
+		b.Metadata.Labels = nil
+		b.Metadata.Annotations = map[string]string{}
+
+		existing, err := wrap.Resource(wrap.Normalize(a), opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		changed, err := wrap.WouldChange(existing, wrap.Normalize(b), opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if changed {
+			t.Error("expected logically equal resources to be wrapped into the same bytes")
+		}
+
+		unwrapped, err := existing.Unwrap()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := wrap.Normalize(unwrapped), wrap.Normalize(a); !reflect.DeepEqual(got, want) {
+			t.Errorf("bad round trip: got %v, want %v", got, want)
+		}
+	}
+}
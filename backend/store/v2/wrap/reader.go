@@ -0,0 +1,154 @@
+package wrap
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// StreamThreshold is the size, in bytes, from which the values of gzip
+// compressed wrappers are decoded as they are decompressed, rather than
+// decompressed to a buffer first, so that unwrapping large values does not
+// hold both their decompressed and decoded forms in memory. It applies to the
+// uncompressed length of the value when the wrapper records it, and to its
+// compressed length otherwise. Only MessagePack values and JSON arrays
+// decoded into slices are streamed: json.Decoder buffers any other JSON value
+// whole, protobuf decoding needs the whole message, snappy values are block
+// compressed rather than framed, and zstd stream decoders allocate a history
+// buffer as large as the value. A threshold of zero or less disables
+// streaming. It should only be modified at init time.
+var StreamThreshold = 1 << 20
+
+// streamable reports whether the value of the wrapper is decoded into p as it
+// is decompressed.
+func (w *Wrapper) streamable(p interface{}) bool {
+	if StreamThreshold <= 0 || w.Compression != Compression_gzip {
+		return false
+	}
+	if _, ok := jsonArrayTarget(w, p); !ok && w.Encoding != Encoding_msgpack {
+		return false
+	}
+	return w.UncompressedLen >= int64(StreamThreshold) || len(w.Value) >= StreamThreshold
+}
+
+// valueReader reads the decompressed value of a wrapper. It enforces its
+// maximum decompressed size and the uncompressed length of the wrapper like
+// Compression.decompress does, and records the first decompression error, so
+// that it can be told apart from decoding errors.
+type valueReader struct {
+	compression Compression
+	r           *gzip.Reader
+	size        int64
+	max         int
+	n           int64
+	err         error
+}
+
+// valueReader returns a reader of the decompressed value of the wrapper. It
+// must be closed once the value is read.
+func (w *Wrapper) valueReader(max int) (*valueReader, error) {
+	if w.Compression != Compression_gzip {
+		return nil, &UnsupportedCompressionError{Compression: w.Compression}
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(w.Value))
+	if err != nil {
+		return nil, &CorruptValueError{Compression: Compression_gzip, Err: err}
+	}
+	return &valueReader{compression: w.Compression, r: zr, size: w.UncompressedLen, max: max}, nil
+}
+
+func (v *valueReader) Read(p []byte) (int, error) {
+	if v.err != nil {
+		return 0, v.err
+	}
+	n, err := v.r.Read(p)
+	v.n += int64(n)
+	switch {
+	case v.n > int64(v.max):
+		err = fmt.Errorf("%w: more than %d bytes", ErrDecompressedSizeTooLarge, v.max)
+	case err == io.EOF:
+		if v.size > 0 && v.n != v.size {
+			err = &CorruptValueError{Compression: v.compression, Err: fmt.Errorf("decoded length %d does not match the uncompressed length %d", v.n, v.size)}
+		}
+	case err != nil:
+		err = &CorruptValueError{Compression: v.compression, Err: err}
+	}
+	if err != nil && err != io.EOF {
+		v.err = err
+	}
+	return n, err
+}
+
+func (v *valueReader) Close() error {
+	return v.r.Close()
+}
+
+// decodeValue decodes the value of the wrapper into p, streaming it if it is
+// large enough.
+func (w *Wrapper) decodeValue(p interface{}, c decodeConfig) error {
+	if w.streamable(p) {
+		return w.decodeStream(p, c)
+	}
+	message, err := w.Compression.decompress(w.Value, w.UncompressedLen, c.maxDecompressedSize)
+	if err != nil {
+		return fmt.Errorf("error unwrapping %T: %w", p, err)
+	}
+	if w.Encoding == Encoding_json && c.useNumber {
+		err = decodeJSONUseNumber(message, p)
+	} else {
+		err = w.Encoding.Decode(message, p)
+	}
+	if err != nil {
+		return err
+	}
+	if w.Encoding == Encoding_protobuf {
+		return w.checkProtobufType(message, p)
+	}
+	return nil
+}
+
+// decodeStream decodes the value of the wrapper into p as it is decompressed.
+func (w *Wrapper) decodeStream(p interface{}, c decodeConfig) error {
+	r, err := w.valueReader(c.maxDecompressedSize)
+	if err != nil {
+		return fmt.Errorf("error unwrapping %T: %w", p, err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if slice, ok := jsonArrayTarget(w, p); ok {
+		err = decodeJSONArray(r, slice, c.useNumber)
+	} else {
+		br := bufio.NewReader(r)
+		dec := msgpack.NewDecoder(br)
+		dec.SetCustomStructTag(msgpackStructTag)
+		if err = dec.Decode(p); err == nil {
+			// Like decodeMsgpack, ignore trailing data, but read it to
+			// verify the length and checksum of the value
+			_, err = io.Copy(ioutil.Discard, br)
+		}
+	}
+	if r.err != nil {
+		return fmt.Errorf("error unwrapping %T: %w", p, r.err)
+	}
+	return err
+}
+
+// checkJSONEOF makes sure that dec holds no data after its top-level value.
+func checkJSONEOF(dec *json.Decoder) error {
+	_, err := dec.Token()
+	if err == io.EOF {
+		return nil
+	}
+	var syntaxErr *json.SyntaxError
+	if err == nil || errors.As(err, &syntaxErr) {
+		return errors.New("invalid character after top-level value")
+	}
+	return err
+}
@@ -0,0 +1,189 @@
+package wrap_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	//nolint:staticcheck // SA1004 Replacing this will take some planning.
+	"github.com/golang/protobuf/proto"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+	"github.com/sensu/sensu-go/backend/store/v2/wrap"
+)
+
+// streamAll makes every streamable value streamed, whatever its size, until
+// the returned function is called.
+func streamAll() func() {
+	threshold := wrap.StreamThreshold
+	wrap.StreamThreshold = 1
+	return func() {
+		wrap.StreamThreshold = threshold
+	}
+}
+
+// compressedWrapper wraps value, JSON or MessagePack encoded, with the
+// compression c.
+func compressedWrapper(value []byte, encoding wrap.Encoding, c wrap.Compression) *wrap.Wrapper {
+	return &wrap.Wrapper{
+		TypeMeta:        &corev2.TypeMeta{Type: "EntityConfig", APIVersion: "core/v3"},
+		Encoding:        encoding,
+		Compression:     c,
+		Value:           c.Compress(value),
+		UncompressedLen: int64(len(value)),
+	}
+}
+
+func TestUnwrapStream(t *testing.T) {
+	defer streamAll()()
+
+	entity := corev3.FixtureEntityConfig("foo")
+	w, err := wrap.Resource(entity, wrap.EncodeMsgpack, wrap.CompressGzip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resource, err := w.UnwrapRaw()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(resource.(proto.Message), entity) {
+		t.Errorf("bad resource: got %v, want %v", resource, entity)
+	}
+
+	// A value that does not match its recorded length
+	corrupt := *w
+	corrupt.UncompressedLen++
+	var config corev3.EntityConfig
+	var corruptErr *wrap.CorruptValueError
+	if err := corrupt.UnwrapInto(&config); !errors.As(err, &corruptErr) {
+		t.Errorf("expected a CorruptValueError, got %v", err)
+	}
+
+	// A truncated value
+	truncated := *w
+	truncated.Value = w.Value[:len(w.Value)-4]
+	if _, err := truncated.UnwrapRaw(); err == nil {
+		t.Error("expected an error for a truncated value")
+	}
+}
+
+func TestUnwrapStreamJSONArray(t *testing.T) {
+	defer streamAll()()
+
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "array",
+			value: `[{"metadata":{"name":"foo"}},{"metadata":{"name":"bar"}}]`,
+			want:  []string{"foo", "bar"},
+		},
+		{
+			name:    "trailing data",
+			value:   `[] []`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid json",
+			value:   `[{"metadata":`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := compressedWrapper([]byte(tt.value), wrap.Encoding_json, wrap.Compression_gzip)
+			var got []*corev3.EntityConfig
+			err := w.UnwrapInto(&got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnwrapInto() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("UnwrapInto() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got, want := got[i].Metadata.Name, tt.want[i]; got != want {
+					t.Errorf("bad name of resource %d: got %s, want %s", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestUnwrapStreamMaxSize(t *testing.T) {
+	defer streamAll()()
+
+	w, err := wrap.Resource(corev3.FixtureEntityConfig("foo"), wrap.EncodeMsgpack, wrap.CompressGzip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.UnwrapWithOptions(wrap.MaxDecompressedSize(16)); !errors.Is(err, wrap.ErrDecompressedSizeTooLarge) {
+		t.Fatalf("expected ErrDecompressedSizeTooLarge, got %v", err)
+	}
+}
+
+// BenchmarkUnwrapStream compares the allocations of unwrapping large gzip
+// compressed values as they are decompressed with those of decompressing them
+// to a buffer first.
+func BenchmarkUnwrapStream(b *testing.B) {
+	resources := make([]*corev3.EntityConfig, 10000)
+	for i := range resources {
+		resources[i] = corev3.FixtureEntityConfig(fmt.Sprintf("entity%d", i))
+	}
+	array, err := json.Marshal(resources)
+	if err != nil {
+		b.Fatal(err)
+	}
+	entity := corev3.FixtureEntityConfig("foo")
+	for i := 0; i < 50000; i++ {
+		entity.Metadata.Labels[fmt.Sprintf("label%d", i)] = fmt.Sprintf("value%d", i)
+	}
+	msgpackEntity, err := wrap.Resource(entity, wrap.EncodeMsgpack, wrap.CompressNone)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	benchmarks := []struct {
+		name  string
+		w     *wrap.Wrapper
+		alloc func() interface{}
+	}{
+		{
+			name:  "json array",
+			w:     compressedWrapper(array, wrap.Encoding_json, wrap.Compression_gzip),
+			alloc: func() interface{} { return &[]*corev3.EntityConfig{} },
+		},
+		{
+			name:  "msgpack",
+			w:     compressedWrapper(msgpackEntity.Value, wrap.Encoding_msgpack, wrap.Compression_gzip),
+			alloc: func() interface{} { return &corev3.EntityConfig{} },
+		},
+	}
+	for _, bm := range benchmarks {
+		for _, threshold := range []int{0, 1} {
+			name := bm.name + "/buffered"
+			if threshold > 0 {
+				name = bm.name + "/streamed"
+			}
+			b.Run(name, func(b *testing.B) {
+				defer func(previous int) {
+					wrap.StreamThreshold = previous
+				}(wrap.StreamThreshold)
+				wrap.StreamThreshold = threshold
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if err := bm.w.UnwrapInto(bm.alloc()); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package wrap
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+	"github.com/sensu/sensu-go/types"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	foldedTypeNamesOnce sync.Once
+
+	// foldedTypeNames maps API versions to the folded names of their types,
+	// and the folded names to the names their resolvers expect.
+	foldedTypeNames map[string]map[string]string
+)
+
+// foldTypeName normalizes the casing of a type name, so that CheckConfig,
+// checkconfig and check_config all fold to the same name.
+func foldTypeName(name string) string {
+	return strings.ToLower(strings.Replace(name, "_", "", -1))
+}
+
+func loadFoldedTypeNames() {
+	v2Names := make(map[string]string)
+	for _, name := range corev2.TypeNames() {
+		if strings.Contains(name, "_") {
+			// Every type is also known by its Go name, which folds the same
+			continue
+		}
+		v2Names[foldTypeName(name)] = name
+	}
+	v3Names := make(map[string]string)
+	for _, resource := range corev3.ListResources() {
+		name := reflect.Indirect(reflect.ValueOf(resource)).Type().Name()
+		v3Names[foldTypeName(name)] = name
+	}
+	foldedTypeNames = map[string]map[string]string{
+		"core/v2": v2Names,
+		"core/v3": v3Names,
+	}
+}
+
+// resolveRaw resolves the raw type of a wrapper. Exact resolution is tried
+// first; if it fails, resolution is retried with the type name matched
+// case-insensitively, for wrappers that were stored with inconsistent casing.
+// The fallback is only available for the core API versions. Types that can't
+// be resolved are reported as an *UnknownTypeError.
+func resolveRaw(tm *corev2.TypeMeta) (interface{}, error) {
+	resource, err := types.ResolveRaw(tm.APIVersion, tm.Type)
+	if err == nil {
+		return resource, nil
+	}
+	unknown := &UnknownTypeError{APIVersion: tm.APIVersion, Type: tm.Type, Err: err}
+	foldedTypeNamesOnce.Do(loadFoldedTypeNames)
+	name, ok := foldedTypeNames[tm.APIVersion][foldTypeName(tm.Type)]
+	if !ok || name == tm.Type {
+		return nil, unknown
+	}
+	resource, foldErr := types.ResolveRaw(tm.APIVersion, name)
+	if foldErr != nil {
+		return nil, unknown
+	}
+	logger.WithFields(logrus.Fields{
+		"api_version":   tm.APIVersion,
+		"type":          tm.Type,
+		"resolved_type": name,
+	}).Warn("resolved wrapper type case-insensitively")
+	return resource, nil
+}
@@ -0,0 +1,198 @@
+package wrap
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	//nolint:staticcheck // SA1004 Replacing this will take some planning.
+	"github.com/golang/protobuf/proto"
+)
+
+// Streams of wrappers are made of frames. Each frame starts with its kind and
+// the uvarint-encoded length of its payload.
+const (
+	// frameWrapper frames hold a single marshaled wrapper.
+	frameWrapper byte = 0
+
+	// frameBlock frames hold a snappy-compressed block of length-prefixed
+	// marshaled wrappers.
+	frameBlock byte = 1
+)
+
+// ErrFrameTooLarge is returned when decoding a frame whose payload would be
+// larger than the maximum decompressed size of the decoder.
+var ErrFrameTooLarge = errors.New("frame size exceeds the maximum")
+
+// EncoderOption is a functional option, for passing to NewEncoder().
+type EncoderOption func(*Encoder)
+
+// BlockCompression returns an encoder option that defers compression to the
+// stream. The values of the wrappers are stored uncompressed, and buffered
+// until they reach threshold bytes, at which point they are compressed
+// together as a single block. This compresses many small wrappers much better
+// than compressing each of them individually.
+func BlockCompression(threshold int) EncoderOption {
+	return func(e *Encoder) {
+		e.threshold = threshold
+	}
+}
+
+// Encoder writes a stream of wrappers, such as a store export.
+type Encoder struct {
+	w         io.Writer
+	threshold int
+	block     []byte
+	header    [binary.MaxVarintLen64 + 1]byte
+}
+
+// NewEncoder returns an encoder that writes to w. By default, each wrapper is
+// written as is, with its own compression.
+func NewEncoder(w io.Writer, opts ...EncoderOption) *Encoder {
+	e := &Encoder{w: w}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Encode writes the wrapper to the stream. With block compression, the
+// wrapper may be buffered until the next block is written; Close must be
+// called once the stream is complete.
+func (e *Encoder) Encode(w *Wrapper) error {
+	if e.threshold <= 0 {
+		b, err := proto.Marshal(w)
+		if err != nil {
+			return err
+		}
+		return e.writeFrame(frameWrapper, b)
+	}
+
+	if w.Compression != Compression_none {
+		value, err := w.decompressedValue()
+		if err != nil {
+			return err
+		}
+		uncompressed := *w
+		uncompressed.Compression = Compression_none
+		uncompressed.Value = value
+		w = &uncompressed
+	}
+	b, err := proto.Marshal(w)
+	if err != nil {
+		return err
+	}
+	n := binary.PutUvarint(e.header[:], uint64(len(b)))
+	e.block = append(e.block, e.header[:n]...)
+	e.block = append(e.block, b...)
+	if len(e.block) >= e.threshold {
+		return e.flush()
+	}
+	return nil
+}
+
+// Close writes any buffered wrappers to the stream. It does not close the
+// underlying writer.
+func (e *Encoder) Close() error {
+	return e.flush()
+}
+
+func (e *Encoder) flush() error {
+	if len(e.block) == 0 {
+		return nil
+	}
+	err := e.writeFrame(frameBlock, Compression_snappy.Compress(e.block))
+	e.block = e.block[:0]
+	return err
+}
+
+func (e *Encoder) writeFrame(kind byte, payload []byte) error {
+	e.header[0] = kind
+	n := binary.PutUvarint(e.header[1:], uint64(len(payload)))
+	if _, err := e.w.Write(e.header[:n+1]); err != nil {
+		return err
+	}
+	_, err := e.w.Write(payload)
+	return err
+}
+
+// Decoder reads a stream of wrappers written by an Encoder, whether or not it
+// was written with block compression.
+type Decoder struct {
+	r     *bufio.Reader
+	block []byte
+	max   int
+}
+
+// NewDecoder returns a decoder that reads from r. Its frames and blocks are
+// held to the maximum decompressed size set by opts.
+func NewDecoder(r io.Reader, opts ...DecodeOption) *Decoder {
+	return &Decoder{r: bufio.NewReader(r), max: newDecodeConfig(opts).maxDecompressedSize}
+}
+
+// Decode returns the next wrapper of the stream, or io.EOF once the stream is
+// exhausted.
+func (d *Decoder) Decode() (*Wrapper, error) {
+	for len(d.block) == 0 {
+		kind, err := d.r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		payload, err := d.readPayload()
+		if err != nil {
+			return nil, err
+		}
+		switch kind {
+		case frameWrapper:
+			return unmarshalWrapper(payload)
+		case frameBlock:
+			if d.block, err = Compression_snappy.decompress(payload, 0, d.max); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("invalid frame kind: %d", kind)
+		}
+	}
+
+	size, n := binary.Uvarint(d.block)
+	if n <= 0 || size > uint64(len(d.block)-n) {
+		d.block = nil
+		return nil, io.ErrUnexpectedEOF
+	}
+	b := d.block[n : n+int(size)]
+	d.block = d.block[n+int(size):]
+	return unmarshalWrapper(b)
+}
+
+func (d *Decoder) readPayload() ([]byte, error) {
+	size, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		return nil, noEOF(err)
+	}
+	if size > uint64(d.max) {
+		return nil, fmt.Errorf("%w: %d > %d bytes", ErrFrameTooLarge, size, d.max)
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(d.r, payload); err != nil {
+		return nil, noEOF(err)
+	}
+	return payload, nil
+}
+
+func unmarshalWrapper(b []byte) (*Wrapper, error) {
+	var w Wrapper
+	if err := proto.Unmarshal(b, &w); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// noEOF reports a stream that ends in the middle of a frame as truncated.
+func noEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,82 @@
+package wrap_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+	"github.com/sensu/sensu-go/backend/store/v2/wrap"
+)
+
+func TestEncoderDecoder(t *testing.T) {
+	resources := make([]corev3.Resource, 100)
+	for i := range resources {
+		resources[i] = corev3.FixtureEntityConfig("entity")
+	}
+	list, err := wrap.Resources(resources)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encode := func(t *testing.T, opts ...wrap.EncoderOption) []byte {
+		t.Helper()
+		var buf bytes.Buffer
+		enc := wrap.NewEncoder(&buf, opts...)
+		for _, w := range list {
+			if err := enc.Encode(w); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := enc.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	perValue := encode(t)
+	block := encode(t, wrap.BlockCompression(4096))
+	if len(block) >= len(perValue) {
+		t.Errorf("block compression did not shrink the stream: %d >= %d bytes", len(block), len(perValue))
+	}
+
+	for name, stream := range map[string][]byte{"per-value": perValue, "block": block} {
+		t.Run(name, func(t *testing.T) {
+			dec := wrap.NewDecoder(bytes.NewReader(stream))
+			for i := range list {
+				w, err := dec.Decode()
+				if err != nil {
+					t.Fatalf("wrapper %d: %s", i, err)
+				}
+				resource, err := w.Unwrap()
+				if err != nil {
+					t.Fatalf("wrapper %d: %s", i, err)
+				}
+				if got, want := resource.GetMetadata().Name, "entity"; got != want {
+					t.Errorf("wrapper %d: bad name: got %q, want %q", i, got, want)
+				}
+			}
+			if _, err := dec.Decode(); err != io.EOF {
+				t.Errorf("expected io.EOF, got %v", err)
+			}
+		})
+	}
+
+	t.Run("truncated", func(t *testing.T) {
+		dec := wrap.NewDecoder(bytes.NewReader(block[:len(block)-1]))
+		var err error
+		for err == nil {
+			_, err = dec.Decode()
+		}
+		if err != io.ErrUnexpectedEOF {
+			t.Errorf("expected io.ErrUnexpectedEOF, got %v", err)
+		}
+	})
+	t.Run("max size", func(t *testing.T) {
+		dec := wrap.NewDecoder(bytes.NewReader(perValue), wrap.MaxDecompressedSize(16))
+		if _, err := dec.Decode(); !errors.Is(err, wrap.ErrFrameTooLarge) {
+			t.Errorf("expected ErrFrameTooLarge, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package wrap
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrTypeMismatch is returned when unwrapping a protobuf value that was not
+// encoded from the type declared by the TypeMeta of its wrapper, as read from
+// a corrupt or mislabeled row.
+var ErrTypeMismatch = errors.New("wrapper value does not match declared type")
+
+// checkProtobufType makes sure that the protobuf message m, decoded into p,
+// was encoded from the type of p. Protobuf decoding does not fail on fields
+// it does not know, it keeps them aside as unrecognized, so that a message of
+// another type silently decodes into a mostly empty value. The value is
+// rejected if none of its fields were recognized, or if some were not and it
+// decoded without a name, since stored resources are named. Named values with
+// a few unrecognized fields are accepted, as newer versions of their type
+// write them.
+func (w *Wrapper) checkProtobufType(m []byte, p interface{}) error {
+	unrecognized := unrecognizedFields(p)
+	if len(unrecognized) == 0 {
+		return nil
+	}
+	if len(unrecognized) < len(m) {
+		if meta := objectMeta(p); meta == nil || meta.Name != "" {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w %q: %d of its %d bytes are unrecognized fields", ErrTypeMismatch, w.TypeMeta.GetType(), len(unrecognized), len(m))
+}
+
+// unrecognizedFields returns the fields that decoding the protobuf message p
+// did not recognize, if it keeps them.
+func unrecognizedFields(p interface{}) []byte {
+	v := reflect.Indirect(reflect.ValueOf(p))
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	field := v.FieldByName("XXX_unrecognized")
+	if !field.IsValid() || field.Type() != reflect.TypeOf([]byte(nil)) {
+		return nil
+	}
+	return field.Bytes()
+}
@@ -0,0 +1,73 @@
+package wrap_test
+
+import (
+	"errors"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+	"github.com/sensu/sensu-go/backend/store/v2/wrap"
+)
+
+func TestUnwrapTypeMismatch(t *testing.T) {
+	entity, err := wrap.V2Resource(corev2.FixtureEntity("foo"), wrap.EncodeProtobuf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	asset, err := wrap.V2Resource(corev2.FixtureAsset("foo"), wrap.EncodeProtobuf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		w       *wrap.Wrapper
+		typ     corev2.TypeMeta
+		wantErr bool
+	}{
+		{
+			name: "entity",
+			w:    entity,
+			typ:  *entity.TypeMeta,
+		},
+		{
+			name:    "entity labeled as a handler",
+			w:       entity,
+			typ:     corev2.TypeMeta{Type: "Handler", APIVersion: "core/v2"},
+			wantErr: true,
+		},
+		{
+			name:    "asset labeled as a role",
+			w:       asset,
+			typ:     corev2.TypeMeta{Type: "Role", APIVersion: "core/v2"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mislabeled := *tt.w
+			mislabeled.TypeMeta = &tt.typ
+			_, err := mislabeled.UnwrapRaw()
+			if got := errors.Is(err, wrap.ErrTypeMismatch); got != tt.wantErr {
+				t.Errorf("UnwrapRaw() error = %v, want a type mismatch: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnwrapUnrecognizedFields(t *testing.T) {
+	w, err := wrap.Resource(corev3.FixtureEntityConfig("foo"), wrap.EncodeProtobuf, wrap.CompressNone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A field added by a newer version of the type: field 99, varint 1
+	w.Value = append(w.Value, 0x98, 0x06, 0x01)
+
+	var config corev3.EntityConfig
+	if err := w.UnwrapInto(&config); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := config.Metadata.Name, "foo"; got != want {
+		t.Errorf("bad name: got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,81 @@
+package wrap
+
+import (
+	"strings"
+	"sync"
+
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+)
+
+// ValidationHook validates a resource before it is wrapped, in addition to
+// the resource's own Validate method. It returns a descriptive error to reject
+// the resource.
+type ValidationHook func(resource interface{}) error
+
+var (
+	validationHooksMu sync.RWMutex
+	validationHooks   = map[string][]ValidationHook{}
+)
+
+// RegisterValidationHook registers hook to be run by Resource and V2Resource
+// on every resource whose TypeMeta.Type is typ, after the resource's own
+// Validate method. This lets policies, such as naming rules, be enforced at
+// the storage boundary without changing the resource types. Hooks are run in
+// the order they were registered. It should only be called at init time.
+func RegisterValidationHook(typ string, hook ValidationHook) {
+	validationHooksMu.Lock()
+	defer validationHooksMu.Unlock()
+	validationHooks[typ] = append(validationHooks[typ], hook)
+}
+
+func getValidationHooks(typ string) []ValidationHook {
+	validationHooksMu.RLock()
+	defer validationHooksMu.RUnlock()
+	return validationHooks[typ]
+}
+
+// ValidationError is returned when a resource fails more than one validation,
+// among its own Validate method and the registered validation hooks. It holds
+// every failure, in the order the validations were run.
+type ValidationError struct {
+	Errors []error
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
+	}
+	return "resource failed validation: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the failures, so that errors.Is and errors.As can match any
+// of them.
+func (e *ValidationError) Unwrap() []error {
+	return e.Errors
+}
+
+// validateWithHooks runs the resource's own Validate method, followed by the
+// validation hooks registered for its type. A single failure is returned as
+// is, and several are returned as a *ValidationError.
+func validateWithHooks(r interface{}) error {
+	var errs []error
+	if err := validate(r); err != nil {
+		errs = append(errs, err)
+	}
+	if proxy, ok := r.(*corev3.V2ResourceProxy); ok {
+		r = proxy.Resource
+	}
+	for _, hook := range getValidationHooks(typeMeta(r).Type) {
+		if err := hook(r); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+	return &ValidationError{Errors: errs}
+}
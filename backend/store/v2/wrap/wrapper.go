@@ -1,9 +1,11 @@
 package wrap
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
 
 	//nolint:staticcheck // SA1004 Replacing this will take some planning.
@@ -33,32 +35,268 @@ type validatable interface {
 
 var ErrValidateMethodMissing = errors.New("resource is missing required Validate() method")
 
+// ErrDecompressedSizeTooLarge is returned when decompressing a value would
+// produce more than its maximum decompressed size, as set by
+// MaxDecompressedSize.
+var ErrDecompressedSizeTooLarge = errors.New("decompressed size exceeds the maximum")
+
+// ErrInvalidFormat is returned when unwrapping a wrapper whose encoding or
+// compression is unknown, as read from corrupt storage.
+var ErrInvalidFormat = errors.New("invalid wrapper format")
+
+// UnsupportedCompressionError is returned when decompressing a value with a
+// compression algorithm that this version of Sensu does not know about, such
+// as one written by a newer backend. It satisfies errors.Is(err,
+// ErrInvalidFormat).
+type UnsupportedCompressionError struct {
+	Compression Compression
+}
+
+func (e *UnsupportedCompressionError) Error() string {
+	return fmt.Sprintf("unsupported compression algorithm %d, the value may have been written by a newer version of sensu-backend; upgrade this backend to read it", int32(e.Compression))
+}
+
+// Is reports whether target is ErrInvalidFormat.
+func (e *UnsupportedCompressionError) Is(target error) bool {
+	return target == ErrInvalidFormat
+}
+
+// CorruptValueError is returned when a value cannot be decompressed with a
+// known compression algorithm, because the compressed data itself is corrupt.
+type CorruptValueError struct {
+	Compression Compression
+	Err         error
+}
+
+func (e *CorruptValueError) Error() string {
+	return fmt.Sprintf("corrupt %s compressed value: %s", e.Compression, e.Err)
+}
+
+func (e *CorruptValueError) Unwrap() error {
+	return e.Err
+}
+
+// UnknownTypeError is returned when unwrapping a wrapper whose TypeMeta does
+// not resolve to a known type, such as one that was removed, so that callers
+// can skip those resources rather than abort.
+type UnknownTypeError struct {
+	APIVersion string
+	Type       string
+	Err        error
+}
+
+func (e *UnknownTypeError) Error() string {
+	return fmt.Sprintf("unknown type %s in API version %s: %s", e.Type, e.APIVersion, e.Err)
+}
+
+func (e *UnknownTypeError) Unwrap() error {
+	return e.Err
+}
+
+const (
+	// ContentTypeJSON is the content type of JSON encoded values.
+	ContentTypeJSON = "application/json"
+
+	// ContentTypeProtobuf is the content type of protobuf encoded values.
+	ContentTypeProtobuf = "application/x-protobuf"
+
+	// ContentTypeMsgpack is the content type of MessagePack encoded values.
+	ContentTypeMsgpack = "application/msgpack"
+
+	// ContentTypeAnnotation is the annotation that Unwrap sets on resources
+	// whose wrapper carries a content type.
+	ContentTypeAnnotation = "sensu.io/content_type"
+
+	// ClassAnnotation is the annotation that Unwrap sets on resources whose
+	// wrapper is not of the durable class.
+	ClassAnnotation = "sensu.io/class"
+
+	// DisplayNameAnnotation is the annotation that Unwrap sets on resources
+	// whose wrapper carries a display name.
+	DisplayNameAnnotation = "sensu.io/display_name"
+
+	// DescriptionAnnotation is the annotation that Unwrap sets on resources
+	// whose wrapper carries a description.
+	DescriptionAnnotation = "sensu.io/description"
+)
+
+// DefaultMaxDecompressedSize is the maximum number of bytes a compressed value
+// is allowed to decompress to, unless the MaxDecompressedSize option says
+// otherwise. It comfortably exceeds anything etcd will store.
+const DefaultMaxDecompressedSize = 64 << 20
+
+// DecodeOption is a functional option for unwrapping, for passing to
+// UnwrapWithOptions, UnwrapIntoWithOptions, Compression.Decompress or
+// NewDecoder.
+type DecodeOption func(*decodeConfig)
+
+// decodeConfig is the configuration of an unwrap, set by its DecodeOptions.
+type decodeConfig struct {
+	useNumber           bool
+	maxDecompressedSize int
+}
+
+func newDecodeConfig(opts []DecodeOption) decodeConfig {
+	c := decodeConfig{maxDecompressedSize: DefaultMaxDecompressedSize}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// UseNumber is a decode option that makes JSON values that are decoded into
+// generic structures, like interface{} or map[string]interface{}, keep their
+// numbers as json.Number rather than float64. This avoids losing precision on
+// large integers. Decoding into typed structures is unaffected.
+var UseNumber DecodeOption = func(c *decodeConfig) {
+	c.useNumber = true
+}
+
+// MaxDecompressedSize is a decode option that sets the maximum number of bytes
+// a compressed value is allowed to decompress to, DefaultMaxDecompressedSize
+// otherwise. The decoded length is read from the value header before any
+// allocation, so crafted values can't make Decompress allocate an arbitrarily
+// large buffer.
+func MaxDecompressedSize(n int) DecodeOption {
+	return func(c *decodeConfig) {
+		c.maxDecompressedSize = n
+	}
+}
+
+// Encode encodes v with the encoding, as registered with RegisterEncoding.
 func (e Encoding) Encode(v interface{}) ([]byte, error) {
-	switch e {
-	case Encoding_json:
-		return json.Marshal(v)
-	case Encoding_protobuf:
-		m, ok := v.(proto.Message)
-		if !ok {
-			return nil, fmt.Errorf("protobuf encoding requested, but %T is not a proto.Message", v)
-		}
-		return proto.Marshal(m)
+	s, ok := getSerializer(e)
+	if !ok {
+		return nil, fmt.Errorf("invalid encoding: %s", e)
 	}
-	return nil, fmt.Errorf("invalid encoding: %s", e)
+	return s.encode(v)
 }
 
+// Decode decodes m into v with the encoding, as registered with
+// RegisterEncoding.
 func (e Encoding) Decode(m []byte, v interface{}) error {
-	switch e {
-	case Encoding_json:
-		return json.Unmarshal(m, v)
-	case Encoding_protobuf:
-		msg, ok := v.(proto.Message)
-		if !ok {
-			return fmt.Errorf("protobuf decoding requested, but %T is not a proto.Message", v)
+	s, ok := getSerializer(e)
+	if !ok {
+		return fmt.Errorf("invalid encoding: %s", e)
+	}
+	return s.decode(m, v)
+}
+
+// IsValid reports whether the encoding is known, either because it is built
+// in or because it was registered with RegisterEncoding.
+func (e Encoding) IsValid() bool {
+	_, ok := getSerializer(e)
+	return ok
+}
+
+func encodeJSON(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func decodeJSON(m []byte, v interface{}) error {
+	return json.Unmarshal(m, v)
+}
+
+func encodeProtobuf(v interface{}) ([]byte, error) {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("protobuf encoding requested, but %T is not a proto.Message", v)
+	}
+	return proto.Marshal(m)
+}
+
+func decodeProtobuf(m []byte, v interface{}) error {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("protobuf decoding requested, but %T is not a proto.Message", v)
+	}
+	return proto.Unmarshal(m, msg)
+}
+
+// decodeJSONUseNumber decodes m into v with json.Decoder.UseNumber set. Like
+// json.Unmarshal, it rejects trailing data after the value.
+func decodeJSONUseNumber(m []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(m))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("invalid character after top-level value")
+	}
+	return nil
+}
+
+// jsonArrayTarget returns the slice pointed to by p, if p points to a slice and
+// the wrapper is JSON encoded, so that it can be decoded with decodeJSONArray.
+func jsonArrayTarget(w *Wrapper, p interface{}) (reflect.Value, bool) {
+	if w.Encoding != Encoding_json {
+		return reflect.Value{}, false
+	}
+	v := reflect.ValueOf(p)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return reflect.Value{}, false
+	}
+	return v.Elem(), true
+}
+
+// decodeJSONArray decodes the JSON array read from r into slice one element at
+// a time, with the tokens of a json.Decoder. Unlike json.Unmarshal, which
+// decodes the whole array at once, it only ever buffers a single element. It
+// is only used for values streamed from their gzip compression, since values
+// that are already decompressed decode with fewer allocations with
+// json.Unmarshal.
+func decodeJSONArray(r io.Reader, slice reflect.Value, useNumber bool) error {
+	dec := json.NewDecoder(r)
+	if useNumber {
+		dec.UseNumber()
+	}
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	result := reflect.Zero(slice.Type())
+	switch tok {
+	case nil:
+		// null decodes to a nil slice, as with json.Unmarshal
+	case json.Delim('['):
+		result = reflect.MakeSlice(slice.Type(), 0, 0)
+		for dec.More() {
+			elem := reflect.New(slice.Type().Elem())
+			if err := dec.Decode(elem.Interface()); err != nil {
+				return err
+			}
+			result = reflect.Append(result, elem.Elem())
+		}
+		if _, err := dec.Token(); err != nil {
+			return err
 		}
-		return proto.Unmarshal(m, msg)
+	default:
+		return fmt.Errorf("cannot decode JSON value %v into %s", tok, slice.Type())
+	}
+	if err := checkJSONEOF(dec); err != nil {
+		return err
 	}
-	return fmt.Errorf("invalid encoding: %s", e)
+	slice.Set(result)
+	return nil
+}
+
+// IsValid reports whether the compression algorithm is known, either because
+// it is built in or because it was registered with RegisterCompression.
+func (c Compression) IsValid() bool {
+	if c.builtin() {
+		return true
+	}
+	_, ok := getCompressor(c)
+	return ok
+}
+
+func (c Compression) builtin() bool {
+	switch c {
+	case Compression_none, Compression_snappy, Compression_zstd, Compression_gzip:
+		return true
+	}
+	return false
 }
 
 func (c Compression) Compress(m []byte) []byte {
@@ -67,18 +305,61 @@ func (c Compression) Compress(m []byte) []byte {
 		return m
 	case Compression_snappy:
 		return snappy.Encode(nil, m)
+	case Compression_zstd:
+		return zstdCompress(m, defaultZstdLevel)
+	case Compression_gzip:
+		return gzipCompress(m)
+	}
+	if comp, ok := getCompressor(c); ok {
+		return comp.compress(m)
 	}
 	return m
 }
 
-func (c Compression) Decompress(m []byte) ([]byte, error) {
+// Decompress decompresses m. It returns an *UnsupportedCompressionError if the
+// compression algorithm is unknown, and a *CorruptValueError if m cannot be
+// decompressed.
+func (c Compression) Decompress(m []byte, opts ...DecodeOption) ([]byte, error) {
+	return c.decompress(m, 0, newDecodeConfig(opts).maxDecompressedSize)
+}
+
+// decompress is like Decompress, but when size, the length of the value before
+// compression, is known, m is decompressed into a buffer of that size, and
+// values that decompress to another length are reported as corrupt. Values
+// that decompress to more than max bytes are refused.
+func (c Compression) decompress(m []byte, size int64, max int) ([]byte, error) {
 	switch c {
 	case Compression_none:
 		return m, nil
 	case Compression_snappy:
-		return snappy.Decode(nil, m)
+		n, err := snappy.DecodedLen(m)
+		if err != nil {
+			return nil, &CorruptValueError{Compression: c, Err: err}
+		}
+		if n > max {
+			return nil, fmt.Errorf("%w: %d > %d bytes", ErrDecompressedSizeTooLarge, n, max)
+		}
+		var dst []byte
+		if size > 0 {
+			if int64(n) != size {
+				return nil, &CorruptValueError{Compression: c, Err: fmt.Errorf("decoded length %d does not match the uncompressed length %d", n, size)}
+			}
+			dst = make([]byte, n)
+		}
+		b, err := snappy.Decode(dst, m)
+		if err != nil {
+			return nil, &CorruptValueError{Compression: c, Err: err}
+		}
+		return b, nil
+	case Compression_zstd:
+		return zstdDecompress(m, size, max)
+	case Compression_gzip:
+		return gzipDecompress(m, size, max)
 	}
-	return nil, fmt.Errorf("invalid compression: %s", c)
+	if comp, ok := getCompressor(c); ok {
+		return decompressRegistered(comp, c, m, size, max)
+	}
+	return nil, &UnsupportedCompressionError{Compression: c}
 }
 
 // Option is a functional option, for passing to wrap.Resource().
@@ -100,11 +381,20 @@ var EncodeJSON Option = func(w *Wrapper, r interface{}) error {
 	return nil
 }
 
+// EncodeMsgpack is an option for setting MessagePack encoding. Field names
+// are those of the JSON encoding of the resource. It is never selected by
+// EncodeDefault.
+var EncodeMsgpack Option = func(w *Wrapper, r interface{}) error {
+	w.Encoding = Encoding_msgpack
+	return nil
+}
+
 // EncodeDefault is the default encoder. It will be protobuf, unless the
-// resource cannot be type asserted to proto.Message.
+// resource cannot be type asserted to proto.Message, or its type was
+// registered with RegisterJSONType.
 var EncodeDefault Option = func(w *Wrapper, r interface{}) error {
 	encoding := Encoding_json
-	if _, ok := r.(proto.Message); ok {
+	if _, ok := r.(proto.Message); ok && !jsonType(w) {
 		encoding = Encoding_protobuf
 	}
 	w.Encoding = encoding
@@ -123,13 +413,113 @@ var CompressSnappy Option = func(w *Wrapper, r interface{}) error {
 	return nil
 }
 
-// CompressDefault is the default compression algorithm.
-var CompressDefault = CompressSnappy
+// CompressZstd is an option for setting zstd compression, at level 3. It
+// compresses large JSON values much better than snappy, at a higher CPU cost.
+var CompressZstd Option = CompressZstdLevel(defaultZstdLevel)
+
+// CompressZstdLevel returns an option for setting zstd compression at level,
+// on the scale of the zstd command line, so that operators can trade CPU for
+// space. Levels are mapped to the closest level the encoder implements.
+func CompressZstdLevel(level int) Option {
+	return func(w *Wrapper, r interface{}) error {
+		w.Compression = Compression_zstd
+		return zstdLevel(level)
+	}
+}
+
+// CompressGzip is an option for setting gzip compression, for values that are
+// read from etcd by external tools that only decode gzip. It is slower than
+// snappy, which remains the default.
+var CompressGzip Option = func(w *Wrapper, r interface{}) error {
+	w.Compression = Compression_gzip
+	return nil
+}
+
+// CompressionHinter is implemented by resources that know how well they
+// compress, to choose their compression algorithm. Small resources that don't
+// compress well can opt out of compression with Compression_none.
+type CompressionHinter interface {
+	CompressionHint() Compression
+}
+
+// CompressDefault is the default compression algorithm. It is the one hinted
+// by the resource if it is a CompressionHinter, and snappy otherwise.
+var CompressDefault Option = func(w *Wrapper, r interface{}) error {
+	hinter, ok := r.(CompressionHinter)
+	if !ok {
+		return CompressSnappy(w, r)
+	}
+	compression := hinter.CompressionHint()
+	if !compression.IsValid() {
+		return &UnsupportedCompressionError{Compression: compression}
+	}
+	w.Compression = compression
+	return nil
+}
+
+// SetContentType returns an option that sets the content type hint of the
+// wrapper. The content type is informational only; the Encoding and
+// Compression of the wrapper still determine how the value is decoded.
+func SetContentType(contentType string) Option {
+	return func(w *Wrapper, r interface{}) error {
+		w.ContentType = contentType
+		return nil
+	}
+}
+
+// SetDisplayName returns an option that sets the display name of the wrapper,
+// a human-readable name for presenting the resource that is stored alongside
+// it rather than in it.
+func SetDisplayName(name string) Option {
+	return func(w *Wrapper, r interface{}) error {
+		w.DisplayName = name
+		return nil
+	}
+}
+
+// SetDescription returns an option that sets the description of the wrapper,
+// which, like its display name, is stored alongside the resource.
+func SetDescription(description string) Option {
+	return func(w *Wrapper, r interface{}) error {
+		w.Description = description
+		return nil
+	}
+}
+
+// Ephemeral returns an option that marks the wrapper as ephemeral, for
+// transient runtime state that is not expected to survive a backend restart,
+// such as agent session tokens.
+func Ephemeral() Option {
+	return func(w *Wrapper, r interface{}) error {
+		w.Class = Class_ephemeral
+		return nil
+	}
+}
+
+// ContentTypeFromEncoding is an option for setting the content type hint of
+// the wrapper according to its encoding. It must be supplied after any
+// encoding option.
+var ContentTypeFromEncoding Option = func(w *Wrapper, r interface{}) error {
+	switch w.Encoding {
+	case Encoding_json:
+		w.ContentType = ContentTypeJSON
+	case Encoding_protobuf:
+		w.ContentType = ContentTypeProtobuf
+	case Encoding_msgpack:
+		w.ContentType = ContentTypeMsgpack
+	default:
+		return fmt.Errorf("no content type for encoding: %s", w.Encoding.Name())
+	}
+	return nil
+}
 
 // Resource wraps the given resource in a wrapper designed for storage.
 // By default, EncodeDefault and CompressDefault options are used. They can
 // be overridden by supplying other options. Typically, protobuf-capable
-// resources will be marshalled to protobuf and then compressed with snappy.
+// resources will be marshalled to protobuf and then compressed with snappy,
+// unless another compression, like CompressZstd or CompressGzip, is supplied.
+// The resource is validated with its Validate method and with the hooks
+// registered with RegisterValidationHook.
 func Resource(r corev3.Resource, opts ...Option) (*Wrapper, error) {
 	return wrap(r, opts...)
 }
@@ -138,6 +528,38 @@ func ResourceWithoutValidation(r corev3.Resource, opts ...Option) (*Wrapper, err
 	return wrapWithoutValidation(r, opts...)
 }
 
+// Resources is like Resource, but wraps a slice of resources with the same
+// options. It fails on the first resource that cannot be wrapped.
+func Resources(rs []corev3.Resource, opts ...Option) (List, error) {
+	opts = withDefaultOptions(opts)
+	list := make(List, len(rs))
+	for i, r := range rs {
+		if err := validate(r); err != nil {
+			return nil, fmt.Errorf("error wrapping resource %d: %w", i, err)
+		}
+		w, err := wrapWithOptions(r, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error wrapping resource %d: %w", i, err)
+		}
+		list[i] = w
+	}
+	return list, nil
+}
+
+// WouldChange reports whether wrapping r with opts would store different bytes
+// than existing, so that callers can skip writes that would change nothing
+// but trigger watches. It requires a deterministic encoding to be meaningful:
+// JSON encoding always is, but protobuf encoding of resources with several
+// labels or annotations may report a change where there is none. It never
+// reports that nothing changed when something did.
+func WouldChange(existing *Wrapper, r corev3.Resource, opts ...Option) (bool, error) {
+	w, err := wrap(r, opts...)
+	if err != nil {
+		return false, err
+	}
+	return !existing.Equal(w), nil
+}
+
 // V2Resource is like Resource, but works on older core v2 resources.
 func V2Resource(r corev2.Resource, opts ...Option) (*Wrapper, error) {
 	return wrap(r, opts...)
@@ -148,25 +570,46 @@ func V2ResourceWithoutValidation(r corev2.Resource, opts ...Option) (*Wrapper, e
 }
 
 func wrapWithoutValidation(r interface{}, opts ...Option) (*Wrapper, error) {
+	return wrapWithOptions(r, withDefaultOptions(opts))
+}
+
+// withDefaultOptions returns opts preceded by the default options, so that they
+// can be overridden.
+func withDefaultOptions(opts []Option) []Option {
+	return append([]Option{EncodeDefault, CompressDefault}, opts...)
+}
+
+// encodedHook is returned, in place of an error, by the options that act on
+// the encoding of the resource, such as CompressAuto, so that wrapWithOptions
+// runs it with the encoding it produces rather than the option encoding the
+// resource a second time.
+type encodedHook func(w *Wrapper, r interface{}, message []byte) error
+
+func (encodedHook) Error() string {
+	return "option requires the encoding of the resource"
+}
+
+// wrapWithOptions wraps r with exactly the given options.
+func wrapWithOptions(r interface{}, opts []Option) (*Wrapper, error) {
 	if proxy, ok := r.(*corev3.V2ResourceProxy); ok {
 		r = proxy.Resource
 	}
-	var tm corev2.TypeMeta
-	if getter, ok := r.(tmGetter); ok {
-		tm = getter.GetTypeMeta()
-	} else {
-		typ := reflect.Indirect(reflect.ValueOf(r)).Type()
-		tm = corev2.TypeMeta{
-			Type:       typ.Name(),
-			APIVersion: types.ApiVersion(typ.PkgPath()),
-		}
-	}
+	tm := typeMeta(r)
 	w := Wrapper{
 		TypeMeta: &tm,
 	}
-	opts = append([]Option{EncodeDefault, CompressDefault}, opts...)
+	var hooks []encodedHook
+	level := zstdLevel(defaultZstdLevel)
 	for _, opt := range opts {
 		if err := opt(&w, r); err != nil {
+			if hook, ok := err.(encodedHook); ok {
+				hooks = append(hooks, hook)
+				continue
+			}
+			if l, ok := err.(zstdLevel); ok {
+				level = l
+				continue
+			}
 			return nil, err
 		}
 	}
@@ -175,28 +618,69 @@ func wrapWithoutValidation(r interface{}, opts ...Option) (*Wrapper, error) {
 	if err != nil {
 		return nil, err
 	}
+	for _, hook := range hooks {
+		if err := hook(&w, r, message); err != nil {
+			return nil, err
+		}
+	}
 
-	w.Value = w.Compression.Compress(message)
+	w.UncompressedLen = int64(len(message))
+	w.Value = w.compress(message, int(level))
+
+	if w.ETag == etagPending {
+		if err := w.ComputeETag(); err != nil {
+			return nil, err
+		}
+	}
 
 	return &w, nil
 }
 
+// typeMeta returns the type of r, as stored in its wrapper.
+func typeMeta(r interface{}) corev2.TypeMeta {
+	if getter, ok := r.(tmGetter); ok {
+		return getter.GetTypeMeta()
+	}
+	typ := reflect.Indirect(reflect.ValueOf(r)).Type()
+	return corev2.TypeMeta{
+		Type:       typ.Name(),
+		APIVersion: types.ApiVersion(typ.PkgPath()),
+	}
+}
+
 func wrap(r interface{}, opts ...Option) (*Wrapper, error) {
-	if v, ok := r.(validatable); ok {
-		if err := v.Validate(); err != nil {
-			return nil, err
-		}
-	} else {
-		return nil, ErrValidateMethodMissing
+	if err := validateWithHooks(r); err != nil {
+		return nil, err
 	}
 	return wrapWithoutValidation(r, opts...)
 }
 
+func validate(r interface{}) error {
+	if v, ok := r.(validatable); ok {
+		return v.Validate()
+	}
+	return ErrValidateMethodMissing
+}
+
 // Unwrap unmarshals the wrapper's value into a resource, according to the
 // configuration of the wrapper. The unwrapped data structure will have
 // its labels and annotations set to non-nil empty slices, if they are nil.
+// If the wrapper has a content type, it is exposed on the resource as the
+// ContentTypeAnnotation annotation. Likewise, the class of wrappers that are
+// not durable is exposed as the ClassAnnotation annotation, and the display
+// name and description of the wrapper as the DisplayNameAnnotation and
+// DescriptionAnnotation annotations.
 func (w *Wrapper) Unwrap() (corev3.Resource, error) {
-	r, err := w.UnwrapRaw()
+	return w.unwrap(newDecodeConfig(nil))
+}
+
+// UnwrapWithOptions is like Unwrap, but unwraps with the given decode options.
+func (w *Wrapper) UnwrapWithOptions(opts ...DecodeOption) (corev3.Resource, error) {
+	return w.unwrap(newDecodeConfig(opts))
+}
+
+func (w *Wrapper) unwrap(c decodeConfig) (corev3.Resource, error) {
+	r, err := w.unwrapRaw(c)
 	if err != nil {
 		return nil, err
 	}
@@ -215,40 +699,229 @@ func (w *Wrapper) Unwrap() (corev3.Resource, error) {
 	if meta.Annotations == nil {
 		meta.Annotations = make(map[string]string)
 	}
+	w.annotate(meta)
 	return resource, nil
 }
 
+// annotate exposes the fields of the wrapper that describe the resource, such
+// as its content type, class, display name and description, as annotations of
+// meta. The annotations are only allocated if there is one to set.
+func (w *Wrapper) annotate(meta *corev2.ObjectMeta) {
+	set := func(key, value string) {
+		if meta.Annotations == nil {
+			meta.Annotations = make(map[string]string)
+		}
+		meta.Annotations[key] = value
+	}
+	if w.ContentType != "" {
+		set(ContentTypeAnnotation, w.ContentType)
+	}
+	if w.Class != Class_durable {
+		set(ClassAnnotation, w.Class.String())
+	}
+	if w.DisplayName != "" {
+		set(DisplayNameAnnotation, w.DisplayName)
+	}
+	if w.Description != "" {
+		set(DescriptionAnnotation, w.Description)
+	}
+}
+
+// annotations are the annotations set by annotate.
+var annotations = []string{
+	ContentTypeAnnotation,
+	ClassAnnotation,
+	DisplayNameAnnotation,
+	DescriptionAnnotation,
+}
+
+// StripAnnotations returns a copy of meta without the annotations that Unwrap
+// sets on resources, so that a resource that was unwrapped can be wrapped
+// again without storing them as its own metadata. meta itself is returned if
+// it has none of them. The fields of the wrapper they expose are kept by
+// wrapping the resource with AnnotationOptions, or with the Options of its
+// wrapper.
+func StripAnnotations(meta *corev2.ObjectMeta) *corev2.ObjectMeta {
+	if meta == nil {
+		return nil
+	}
+	found := false
+	for _, key := range annotations {
+		if _, ok := meta.Annotations[key]; ok {
+			found = true
+		}
+	}
+	if !found {
+		return meta
+	}
+	stripped := *meta
+	stripped.Annotations = make(map[string]string, len(meta.Annotations))
+	for key, value := range meta.Annotations {
+		stripped.Annotations[key] = value
+	}
+	for _, key := range annotations {
+		delete(stripped.Annotations, key)
+	}
+	return &stripped
+}
+
+// AnnotationOptions returns the options that set the fields of a wrapper that
+// Unwrap exposes as annotations of meta, the reverse of Unwrap.
+func AnnotationOptions(meta *corev2.ObjectMeta) []Option {
+	if meta == nil {
+		return nil
+	}
+	var opts []Option
+	if contentType, ok := meta.Annotations[ContentTypeAnnotation]; ok {
+		opts = append(opts, SetContentType(contentType))
+	}
+	if class, ok := Class_value[meta.Annotations[ClassAnnotation]]; ok {
+		opts = append(opts, func(w *Wrapper, r interface{}) error {
+			w.Class = Class(class)
+			return nil
+		})
+	}
+	if name, ok := meta.Annotations[DisplayNameAnnotation]; ok {
+		opts = append(opts, SetDisplayName(name))
+	}
+	if description, ok := meta.Annotations[DescriptionAnnotation]; ok {
+		opts = append(opts, SetDescription(description))
+	}
+	return opts
+}
+
+// Options returns the options that wrap a resource the way w is wrapped: with
+// the encoding, compression, content type, class, display name and
+// description of w, its schema fingerprint if it records one, and an ETag if
+// its ETag was computed. They are meant for writing back a resource that was
+// unwrapped from w.
+func (w *Wrapper) Options() []Option {
+	encoding, compression := w.Encoding, w.Compression
+	opts := []Option{
+		func(wrapper *Wrapper, r interface{}) error {
+			wrapper.Encoding = encoding
+			wrapper.Compression = compression
+			return nil
+		},
+		SetContentType(w.ContentType),
+		SetDisplayName(w.DisplayName),
+		SetDescription(w.Description),
+	}
+	if class := w.Class; class != Class_durable {
+		opts = append(opts, func(wrapper *Wrapper, r interface{}) error {
+			wrapper.Class = class
+			return nil
+		})
+	}
+	if w.SchemaFingerprint != "" {
+		opts = append(opts, RecordSchemaFingerprint)
+	}
+	if w.HasComputedETag() {
+		opts = append(opts, WithETag)
+	}
+	return opts
+}
+
+// checkFormat makes sure that the encoding and compression of the wrapper are
+// known, before dispatching on them.
+func (w *Wrapper) checkFormat() error {
+	if !w.Encoding.IsValid() {
+		return fmt.Errorf("%w: unknown encoding %d", ErrInvalidFormat, w.Encoding)
+	}
+	if !w.Compression.IsValid() {
+		return &UnsupportedCompressionError{Compression: w.Compression}
+	}
+	return nil
+}
+
+// decompressedValue returns the value of the wrapper, decompressed. Wrappers
+// written before the uncompressed length was recorded are decompressed
+// without it.
+func (w *Wrapper) decompressedValue() ([]byte, error) {
+	return w.Compression.decompress(w.Value, w.UncompressedLen, DefaultMaxDecompressedSize)
+}
+
 // UnwrapRaw is like Unwrap, but returns a raw interface{} value.
 func (w *Wrapper) UnwrapRaw() (interface{}, error) {
-	resource, err := types.ResolveRaw(w.TypeMeta.APIVersion, w.TypeMeta.Type)
-	if err != nil {
+	return w.unwrapRaw(newDecodeConfig(nil))
+}
+
+func (w *Wrapper) unwrapRaw(c decodeConfig) (interface{}, error) {
+	if err := w.checkFormat(); err != nil {
 		return nil, err
 	}
-	message, err := w.Compression.Decompress(w.Value)
+	resource, err := resolveRaw(w.TypeMeta)
 	if err != nil {
-		return nil, fmt.Errorf("error unwrapping %T: %s", resource, err)
+		return nil, err
 	}
-	if err := w.Encoding.Decode(message, resource); err != nil {
+	if err := w.decodeValue(resource, c); err != nil {
 		return nil, err
 	}
+	w.checkSchema(resource)
 	return resource, nil
 }
 
+// MarshalJSONValue returns the value of the wrapper encoded as JSON, whatever
+// its storage encoding, for clients that only consume JSON. JSON encoded values
+// are returned as stored, without being decoded.
+func (w *Wrapper) MarshalJSONValue() ([]byte, error) {
+	if err := w.checkFormat(); err != nil {
+		return nil, err
+	}
+	if w.Encoding == Encoding_json {
+		return w.decompressedValue()
+	}
+	resource, err := w.UnwrapRaw()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(resource)
+}
+
 // UnwrapInto unwraps a wrapper into a user-defined data structure. Most users
-// should use Unwrap.
+// should use Unwrap. Resources get the same labels and annotations as with
+// Unwrap.
 func (w *Wrapper) UnwrapInto(p interface{}) error {
+	return w.unwrapInto(p, true, newDecodeConfig(nil))
+}
+
+// UnwrapIntoWithOptions is like UnwrapInto, but unwraps with the given decode
+// options.
+func (w *Wrapper) UnwrapIntoWithOptions(p interface{}, opts ...DecodeOption) error {
+	return w.unwrapInto(p, true, newDecodeConfig(opts))
+}
+
+// UnwrapIntoPreservingNilMaps is like UnwrapInto, but leaves nil labels and
+// annotations nil, for callers that tell unset maps from empty ones. The
+// annotations are still allocated if the wrapper has one to expose.
+func (w *Wrapper) UnwrapIntoPreservingNilMaps(p interface{}) error {
+	return w.unwrapInto(p, false, newDecodeConfig(nil))
+}
+
+func (w *Wrapper) unwrapInto(p interface{}, allocMaps bool, c decodeConfig) error {
+	if err := w.checkFormat(); err != nil {
+		return err
+	}
 	if proxy, ok := p.(*corev3.V2ResourceProxy); ok {
 		p = proxy.Resource
 	}
-	message, err := w.Compression.Decompress(w.Value)
-	if err != nil {
-		return fmt.Errorf("error unwrapping %T: %s", p, err)
-	}
-	if err := w.Encoding.Decode(message, p); err != nil {
+	if err := w.decodeValue(p, c); err != nil {
 		return err
 	}
-	if resource, ok := p.(corev3.Resource); ok {
-		meta := resource.GetMetadata()
+	w.checkSchema(p)
+	resource, ok := p.(corev3.Resource)
+	if !ok {
+		return nil
+	}
+	meta := resource.GetMetadata()
+	if meta == nil {
+		if !allocMaps {
+			return nil
+		}
+		meta = new(corev2.ObjectMeta)
+		resource.SetMetadata(meta)
+	}
+	if allocMaps {
 		if meta.Labels == nil {
 			meta.Labels = make(map[string]string)
 		}
@@ -256,6 +929,7 @@ func (w *Wrapper) UnwrapInto(p interface{}) error {
 			meta.Annotations = make(map[string]string)
 		}
 	}
+	w.annotate(meta)
 	return nil
 }
 
@@ -267,6 +941,28 @@ func (l List) Len() int {
 	return len(l)
 }
 
+// SplitBySize splits the list into consecutive batches whose values total at
+// most maxBytes, for transports that cap the size of their payloads. Wrappers
+// are kept in order and never split, so a wrapper whose value alone exceeds
+// maxBytes gets a batch of its own. The batches share the memory of the list,
+// but appending to one of them does not overwrite the next.
+func (l List) SplitBySize(maxBytes int) []List {
+	var batches []List
+	start, size := 0, 0
+	for i, w := range l {
+		n := len(w.Value)
+		if i > start && size+n > maxBytes {
+			batches = append(batches, l[start:i:i])
+			start, size = i, 0
+		}
+		size += n
+	}
+	if start < len(l) {
+		batches = append(batches, l[start:len(l):len(l)])
+	}
+	return batches
+}
+
 // Unwrap unwraps each item in the list and returns a slice of resources of the
 // same size.
 func (l List) Unwrap() ([]corev3.Resource, error) {
@@ -274,13 +970,16 @@ func (l List) Unwrap() ([]corev3.Resource, error) {
 	for i := range result {
 		p, err := l[i].Unwrap()
 		if err != nil {
-			return nil, fmt.Errorf("wrap list item %d: %s", i, err)
+			return nil, fmt.Errorf("wrap list item %d: %w", i, err)
 		}
 		result[i] = p
 	}
 	return result, nil
 }
 
+// UnwrapInto unwraps each item in the list into the slice pointed to by ptr,
+// reusing its backing array if it is large enough. The slice is left with
+// exactly one element per item.
 func (l List) UnwrapInto(ptr interface{}) error {
 	if len(l) == 0 {
 		// if there are no elements to work on, modify nothing
@@ -302,11 +1001,11 @@ func (l List) UnwrapInto(ptr interface{}) error {
 	if v.Cap() < len(l) {
 		v.Set(reflect.MakeSlice(v.Type(), len(l), len(l)))
 	}
-	if v.Len() < v.Cap() {
-		v.SetLen(v.Cap())
-	}
+	// Reuse the backing array, but only expose as many elements as there are
+	// wrappers, so that no zero elements follow them.
+	v.SetLen(len(l))
 	for i, w := range l {
-		value, err := compression.Decompress(w.Value)
+		value, err := compression.decompress(w.Value, w.UncompressedLen, DefaultMaxDecompressedSize)
 		if err != nil {
 			return err
 		}
@@ -320,6 +1019,7 @@ func (l List) UnwrapInto(ptr interface{}) error {
 		if err := encoding.Decode(value, elt.Interface()); err != nil {
 			return err
 		}
+		w.checkSchema(elt.Interface())
 	}
 	return nil
 }
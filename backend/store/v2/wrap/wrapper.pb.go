@@ -31,16 +31,19 @@ type Encoding int32
 const (
 	Encoding_json     Encoding = 0
 	Encoding_protobuf Encoding = 1
+	Encoding_msgpack  Encoding = 2
 )
 
 var Encoding_name = map[int32]string{
 	0: "json",
 	1: "protobuf",
+	2: "msgpack",
 }
 
 var Encoding_value = map[string]int32{
 	"json":     0,
 	"protobuf": 1,
+	"msgpack":  2,
 }
 
 func (x Encoding) String() string {
@@ -58,16 +61,22 @@ type Compression int32
 const (
 	Compression_none   Compression = 0
 	Compression_snappy Compression = 1
+	Compression_zstd   Compression = 2
+	Compression_gzip   Compression = 3
 )
 
 var Compression_name = map[int32]string{
 	0: "none",
 	1: "snappy",
+	2: "zstd",
+	3: "gzip",
 }
 
 var Compression_value = map[string]int32{
 	"none":   0,
 	"snappy": 1,
+	"zstd":   2,
+	"gzip":   3,
 }
 
 func (x Compression) String() string {
@@ -78,6 +87,32 @@ func (Compression) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_0d211efcc0f41ca5, []int{1}
 }
 
+// Class distinguishes durable configuration from transient runtime state.
+type Class int32
+
+const (
+	Class_durable   Class = 0
+	Class_ephemeral Class = 1
+)
+
+var Class_name = map[int32]string{
+	0: "durable",
+	1: "ephemeral",
+}
+
+var Class_value = map[string]int32{
+	"durable":   0,
+	"ephemeral": 1,
+}
+
+func (x Class) String() string {
+	return proto.EnumName(Class_name, int32(x))
+}
+
+func (Class) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_0d211efcc0f41ca5, []int{2}
+}
+
 // Wrapper represents a serialized resource for storage purposes.
 type Wrapper struct {
 	// TypeMeta contains the type and the API version of the resource.
@@ -87,7 +122,29 @@ type Wrapper struct {
 	// Compression is the type of compression used.
 	Compression Compression `protobuf:"varint,3,opt,name=compression,proto3,enum=backend.store.wrap.Compression" json:"compression,omitempty"`
 	// Value contains the encoded resource value
-	Value                []byte   `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Value []byte `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	// ContentType is an optional MIME type hint describing the encoded value,
+	// for consumers that do not understand the Encoding enum.
+	ContentType string `protobuf:"bytes,5,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// Class is the storage class of the resource. Ephemeral resources are
+	// runtime state that is not expected to survive a backend restart.
+	Class Class `protobuf:"varint,6,opt,name=class,proto3,enum=backend.store.wrap.Class" json:"class,omitempty"`
+	// UncompressedLen is the length of the value before compression. It is zero
+	// for wrappers written before it was recorded.
+	UncompressedLen int64 `protobuf:"varint,7,opt,name=uncompressed_len,json=uncompressedLen,proto3" json:"uncompressed_len,omitempty"`
+	// SchemaFingerprint is the fingerprint of the set of fields of the type of
+	// the resource when it was wrapped. It is only recorded by the
+	// RecordSchemaFingerprint option, and is empty otherwise.
+	SchemaFingerprint string `protobuf:"bytes,8,opt,name=schema_fingerprint,json=schemaFingerprint,proto3" json:"schema_fingerprint,omitempty"`
+	// DisplayName is an optional human-readable name of the resource, for
+	// presentation purposes. It is not part of the resource itself.
+	DisplayName string `protobuf:"bytes,9,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	// Description is an optional human-readable description of the resource,
+	// for presentation purposes. It is not part of the resource itself.
+	Description string `protobuf:"bytes,10,opt,name=description,proto3" json:"description,omitempty"`
+	// ETag identifies the stored version of the resource. It is only recorded
+	// by the WithETag option, see Wrapper.ComputeETag, and is empty otherwise.
+	ETag                 string   `protobuf:"bytes,11,opt,name=etag,proto3" json:"etag,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -154,9 +211,59 @@ func (m *Wrapper) GetValue() []byte {
 	return nil
 }
 
+func (m *Wrapper) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+func (m *Wrapper) GetClass() Class {
+	if m != nil {
+		return m.Class
+	}
+	return Class_durable
+}
+
+func (m *Wrapper) GetUncompressedLen() int64 {
+	if m != nil {
+		return m.UncompressedLen
+	}
+	return 0
+}
+
+func (m *Wrapper) GetSchemaFingerprint() string {
+	if m != nil {
+		return m.SchemaFingerprint
+	}
+	return ""
+}
+
+func (m *Wrapper) GetDisplayName() string {
+	if m != nil {
+		return m.DisplayName
+	}
+	return ""
+}
+
+func (m *Wrapper) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Wrapper) GetETag() string {
+	if m != nil {
+		return m.ETag
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterEnum("backend.store.wrap.Encoding", Encoding_name, Encoding_value)
 	proto.RegisterEnum("backend.store.wrap.Compression", Compression_name, Compression_value)
+	proto.RegisterEnum("backend.store.wrap.Class", Class_name, Class_value)
 	proto.RegisterType((*Wrapper)(nil), "backend.store.wrap.Wrapper")
 }
 
@@ -165,29 +272,40 @@ func init() {
 }
 
 var fileDescriptor_0d211efcc0f41ca5 = []byte{
-	// 344 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x90, 0x41, 0x4b, 0xfb, 0x30,
-	0x18, 0xc6, 0x97, 0xff, 0xbf, 0xce, 0x92, 0x0d, 0x29, 0x41, 0xb0, 0x0c, 0xc9, 0xc6, 0xbc, 0x8c,
-	0x81, 0x89, 0xeb, 0x3c, 0xe8, 0x41, 0xd0, 0x89, 0x47, 0x2f, 0x45, 0x10, 0xbc, 0xa5, 0x5d, 0xac,
-	0x55, 0x97, 0x84, 0xa6, 0xad, 0xec, 0x9b, 0xf8, 0x11, 0xfc, 0x28, 0x3b, 0x7a, 0xf3, 0x26, 0x5a,
-	0xbf, 0x84, 0x47, 0x69, 0xba, 0xcd, 0x81, 0x7a, 0x79, 0x49, 0xde, 0xf7, 0xf7, 0x3c, 0x79, 0xf2,
-	0xc2, 0xa3, 0x28, 0x4e, 0x6f, 0xb2, 0x80, 0x84, 0x72, 0x42, 0x35, 0x17, 0x3a, 0xab, 0xea, 0x6e,
-	0x24, 0x69, 0xc0, 0xc2, 0x3b, 0x2e, 0xc6, 0x54, 0xa7, 0x32, 0xe1, 0x34, 0xf7, 0xe8, 0x43, 0xc2,
-	0x94, 0x29, 0x8a, 0x27, 0x44, 0x25, 0x32, 0x95, 0x08, 0xcd, 0x21, 0x62, 0x20, 0x52, 0x0e, 0x5b,
-	0xfb, 0x2b, 0x96, 0x91, 0x8c, 0x24, 0x35, 0x68, 0x90, 0x5d, 0x1f, 0xe7, 0x03, 0x32, 0x24, 0x03,
-	0xd3, 0x34, 0x3d, 0x73, 0xaa, 0x9c, 0x5a, 0x7b, 0x7f, 0x07, 0x61, 0x2a, 0xa6, 0xe1, 0x3c, 0xc3,
-	0x84, 0xa7, 0xac, 0x52, 0x74, 0x5f, 0x00, 0x5c, 0xbf, 0xac, 0xd2, 0xa0, 0x43, 0x68, 0x5f, 0x4c,
-	0x15, 0x3f, 0xe7, 0x29, 0x73, 0x41, 0x07, 0xf4, 0x1a, 0xde, 0x16, 0x31, 0x7a, 0x52, 0x0a, 0x49,
-	0xee, 0x91, 0xc5, 0x78, 0x64, 0xcd, 0x5e, 0xdb, 0xc0, 0x5f, 0xe2, 0xe8, 0x00, 0xda, 0x5c, 0x84,
-	0x72, 0x1c, 0x8b, 0xc8, 0xfd, 0xd7, 0x01, 0xbd, 0x0d, 0x6f, 0x9b, 0xfc, 0xfc, 0x15, 0x39, 0x9b,
-	0x33, 0xfe, 0x92, 0x46, 0x27, 0xb0, 0x11, 0xca, 0x89, 0x4a, 0xb8, 0xd6, 0xb1, 0x14, 0xee, 0x7f,
-	0x23, 0x6e, 0xff, 0x26, 0x3e, 0xfd, 0xc6, 0xfc, 0x55, 0x0d, 0xda, 0x84, 0x6b, 0x39, 0xbb, 0xcf,
-	0xb8, 0x6b, 0x75, 0x40, 0xaf, 0xe9, 0x57, 0x97, 0x7e, 0x17, 0xda, 0x8b, 0xe7, 0x90, 0x0d, 0xad,
-	0x5b, 0x2d, 0x85, 0x53, 0x43, 0x4d, 0x68, 0x2f, 0x36, 0xe9, 0x80, 0xfe, 0x0e, 0x6c, 0xac, 0xb8,
-	0x96, 0x98, 0x90, 0x82, 0x3b, 0x35, 0x04, 0x61, 0x5d, 0x0b, 0xa6, 0xd4, 0xd4, 0x01, 0x23, 0xfc,
-	0xf9, 0x8e, 0xc1, 0x53, 0x81, 0xc1, 0xac, 0xc0, 0xe0, 0xb9, 0xc0, 0xe0, 0xad, 0xc0, 0xe0, 0xf1,
-	0x03, 0xd7, 0xae, 0xac, 0x32, 0x57, 0x50, 0x37, 0x86, 0xc3, 0xaf, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0x92, 0x2b, 0xae, 0xaf, 0x06, 0x02, 0x00, 0x00,
+	// 525 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xc1, 0x6e, 0xd3, 0x4e,
+	0x10, 0xc6, 0xbb, 0x4d, 0xd2, 0x38, 0xeb, 0xfc, 0xff, 0x98, 0x15, 0x12, 0xa6, 0x42, 0xae, 0x81,
+	0x4b, 0x88, 0xd4, 0x35, 0x4d, 0x91, 0x80, 0x03, 0x12, 0x14, 0xc1, 0x09, 0x38, 0x58, 0x48, 0x48,
+	0x5c, 0xa2, 0x8d, 0x3d, 0x75, 0x4c, 0xed, 0xdd, 0x95, 0x77, 0x1d, 0x94, 0x3e, 0x09, 0x8f, 0xc0,
+	0xa3, 0xf4, 0xc8, 0x13, 0x54, 0x10, 0x5e, 0x82, 0x23, 0xda, 0xb5, 0xd3, 0x44, 0xa2, 0x5c, 0x56,
+	0x93, 0x6f, 0x7e, 0xdf, 0xcc, 0x97, 0x91, 0xf1, 0xf3, 0x2c, 0xd7, 0xf3, 0x7a, 0x46, 0x13, 0x51,
+	0x46, 0x0a, 0xb8, 0xaa, 0x9b, 0xf7, 0x30, 0x13, 0xd1, 0x8c, 0x25, 0x67, 0xc0, 0xd3, 0x48, 0x69,
+	0x51, 0x41, 0xb4, 0x98, 0x44, 0x5f, 0x2a, 0x26, 0xed, 0x23, 0xa1, 0xa2, 0xb2, 0x12, 0x5a, 0x10,
+	0xd2, 0x42, 0xd4, 0x42, 0xd4, 0x34, 0xf7, 0x1f, 0x6f, 0x8d, 0xcc, 0x44, 0x26, 0x22, 0x8b, 0xce,
+	0xea, 0xd3, 0x17, 0x8b, 0x23, 0x7a, 0x4c, 0x8f, 0xac, 0x68, 0x35, 0x5b, 0x35, 0x93, 0xf6, 0x1f,
+	0xfd, 0x3b, 0x08, 0x93, 0x79, 0x94, 0xb4, 0x19, 0x4a, 0xd0, 0xac, 0x71, 0xdc, 0xbf, 0xec, 0xe0,
+	0xfe, 0xc7, 0x26, 0x0d, 0x79, 0x86, 0x9d, 0x0f, 0x4b, 0x09, 0xef, 0x40, 0x33, 0x1f, 0x85, 0x68,
+	0xe4, 0x4e, 0x6e, 0x53, 0xeb, 0xa7, 0xc6, 0x48, 0x17, 0x13, 0xba, 0x6e, 0x9f, 0x74, 0x2f, 0x2e,
+	0x0f, 0x50, 0x7c, 0x85, 0x93, 0xa7, 0xd8, 0x01, 0x9e, 0x88, 0x34, 0xe7, 0x99, 0xbf, 0x1b, 0xa2,
+	0xd1, 0xff, 0x93, 0xbb, 0xf4, 0xef, 0x7f, 0x45, 0x5f, 0xb7, 0x4c, 0x7c, 0x45, 0x93, 0x97, 0xd8,
+	0x4d, 0x44, 0x29, 0x2b, 0x50, 0x2a, 0x17, 0xdc, 0xef, 0x58, 0xf3, 0xc1, 0x75, 0xe6, 0x57, 0x1b,
+	0x2c, 0xde, 0xf6, 0x90, 0x5b, 0xb8, 0xb7, 0x60, 0x45, 0x0d, 0x7e, 0x37, 0x44, 0xa3, 0x61, 0xdc,
+	0xfc, 0x20, 0xf7, 0xf0, 0x30, 0x11, 0x5c, 0x03, 0xd7, 0x53, 0xbd, 0x94, 0xe0, 0xf7, 0x42, 0x34,
+	0x1a, 0xc4, 0x6e, 0xab, 0x99, 0xe4, 0x24, 0xc2, 0xbd, 0xa4, 0x60, 0x4a, 0xf9, 0x7b, 0x76, 0xeb,
+	0x9d, 0x6b, 0xb7, 0x1a, 0x20, 0x6e, 0x38, 0xf2, 0x10, 0x7b, 0x35, 0x5f, 0xaf, 0x86, 0x74, 0x5a,
+	0x00, 0xf7, 0xfb, 0x21, 0x1a, 0x75, 0xe2, 0x1b, 0xdb, 0xfa, 0x5b, 0xe0, 0xe4, 0x10, 0x13, 0x95,
+	0xcc, 0xa1, 0x64, 0xd3, 0xd3, 0x9c, 0x67, 0x50, 0xc9, 0x2a, 0xe7, 0xda, 0x77, 0x6c, 0x88, 0x9b,
+	0x4d, 0xe7, 0xcd, 0xa6, 0x61, 0xd2, 0xa6, 0xb9, 0x92, 0x05, 0x5b, 0x4e, 0x39, 0x2b, 0xc1, 0x1f,
+	0x34, 0x69, 0x5b, 0xed, 0x3d, 0x2b, 0x81, 0x84, 0xd8, 0x4d, 0x41, 0x25, 0x55, 0x2e, 0xb5, 0xb9,
+	0x14, 0x6e, 0x89, 0x8d, 0x44, 0x08, 0xee, 0x82, 0x66, 0x99, 0xef, 0xda, 0x96, 0xad, 0xc7, 0x11,
+	0x76, 0xd6, 0x57, 0x27, 0x0e, 0xee, 0x7e, 0x56, 0x82, 0x7b, 0x3b, 0x64, 0x88, 0x9d, 0xf5, 0x07,
+	0xe5, 0x21, 0xe2, 0xe2, 0x7e, 0xa9, 0x32, 0xc9, 0x92, 0x33, 0x6f, 0x77, 0xfc, 0x04, 0xbb, 0x5b,
+	0x97, 0x36, 0x1e, 0x2e, 0x38, 0x78, 0x3b, 0x04, 0xe3, 0x3d, 0xc5, 0x99, 0x94, 0x4b, 0x0f, 0x19,
+	0xf5, 0x5c, 0xe9, 0xd4, 0xdb, 0x35, 0x55, 0x76, 0x9e, 0x4b, 0xaf, 0x33, 0x7e, 0x80, 0x7b, 0xf6,
+	0x58, 0x66, 0x5c, 0x5a, 0x57, 0x6c, 0x56, 0x18, 0xd7, 0x7f, 0x78, 0x00, 0x72, 0x0e, 0x25, 0x54,
+	0xac, 0xf0, 0xd0, 0x49, 0xf0, 0xfb, 0x67, 0x80, 0xbe, 0xad, 0x02, 0x74, 0xb1, 0x0a, 0xd0, 0xf7,
+	0x55, 0x80, 0x7e, 0xac, 0x02, 0xf4, 0xf5, 0x57, 0xb0, 0xf3, 0xa9, 0x6b, 0xce, 0x3d, 0xdb, 0xb3,
+	0xb1, 0x8e, 0xff, 0x0c, 0x00, 0x66, 0xf4, 0x8d, 0x73, 0x53, 0x03, 0x00, 0x00,
 }
 
 func (this *Wrapper) Equal(that interface{}) bool {
@@ -221,6 +339,27 @@ func (this *Wrapper) Equal(that interface{}) bool {
 	if !bytes.Equal(this.Value, that1.Value) {
 		return false
 	}
+	if this.ContentType != that1.ContentType {
+		return false
+	}
+	if this.Class != that1.Class {
+		return false
+	}
+	if this.UncompressedLen != that1.UncompressedLen {
+		return false
+	}
+	if this.SchemaFingerprint != that1.SchemaFingerprint {
+		return false
+	}
+	if this.DisplayName != that1.DisplayName {
+		return false
+	}
+	if this.Description != that1.Description {
+		return false
+	}
+	if this.ETag != that1.ETag {
+		return false
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -250,6 +389,51 @@ func (m *Wrapper) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.ETag) > 0 {
+		i -= len(m.ETag)
+		copy(dAtA[i:], m.ETag)
+		i = encodeVarintWrapper(dAtA, i, uint64(len(m.ETag)))
+		i--
+		dAtA[i] = 0x5a
+	}
+	if len(m.Description) > 0 {
+		i -= len(m.Description)
+		copy(dAtA[i:], m.Description)
+		i = encodeVarintWrapper(dAtA, i, uint64(len(m.Description)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if len(m.DisplayName) > 0 {
+		i -= len(m.DisplayName)
+		copy(dAtA[i:], m.DisplayName)
+		i = encodeVarintWrapper(dAtA, i, uint64(len(m.DisplayName)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.SchemaFingerprint) > 0 {
+		i -= len(m.SchemaFingerprint)
+		copy(dAtA[i:], m.SchemaFingerprint)
+		i = encodeVarintWrapper(dAtA, i, uint64(len(m.SchemaFingerprint)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.UncompressedLen != 0 {
+		i = encodeVarintWrapper(dAtA, i, uint64(m.UncompressedLen))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Class != 0 {
+		i = encodeVarintWrapper(dAtA, i, uint64(m.Class))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.ContentType) > 0 {
+		i -= len(m.ContentType)
+		copy(dAtA[i:], m.ContentType)
+		i = encodeVarintWrapper(dAtA, i, uint64(len(m.ContentType)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if len(m.Value) > 0 {
 		i -= len(m.Value)
 		copy(dAtA[i:], m.Value)
@@ -305,8 +489,18 @@ func NewPopulatedWrapper(r randyWrapper, easy bool) *Wrapper {
 	for i := 0; i < v1; i++ {
 		this.Value[i] = byte(r.Intn(256))
 	}
+	this.ContentType = string(randStringWrapper(r))
+	this.Class = Class([]int32{0, 1}[r.Intn(2)])
+	this.UncompressedLen = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.UncompressedLen *= -1
+	}
+	this.SchemaFingerprint = string(randStringWrapper(r))
+	this.DisplayName = string(randStringWrapper(r))
+	this.Description = string(randStringWrapper(r))
+	this.ETag = string(randStringWrapper(r))
 	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedWrapper(r, 5)
+		this.XXX_unrecognized = randUnrecognizedWrapper(r, 12)
 	}
 	return this
 }
@@ -403,6 +597,32 @@ func (m *Wrapper) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovWrapper(uint64(l))
 	}
+	l = len(m.ContentType)
+	if l > 0 {
+		n += 1 + l + sovWrapper(uint64(l))
+	}
+	if m.Class != 0 {
+		n += 1 + sovWrapper(uint64(m.Class))
+	}
+	if m.UncompressedLen != 0 {
+		n += 1 + sovWrapper(uint64(m.UncompressedLen))
+	}
+	l = len(m.SchemaFingerprint)
+	if l > 0 {
+		n += 1 + l + sovWrapper(uint64(l))
+	}
+	l = len(m.DisplayName)
+	if l > 0 {
+		n += 1 + l + sovWrapper(uint64(l))
+	}
+	l = len(m.Description)
+	if l > 0 {
+		n += 1 + l + sovWrapper(uint64(l))
+	}
+	l = len(m.ETag)
+	if l > 0 {
+		n += 1 + l + sovWrapper(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -552,16 +772,211 @@ func (m *Wrapper) Unmarshal(dAtA []byte) error {
 				m.Value = []byte{}
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContentType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWrapper
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWrapper
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWrapper
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContentType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Class", wireType)
+			}
+			m.Class = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWrapper
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Class |= Class(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UncompressedLen", wireType)
+			}
+			m.UncompressedLen = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWrapper
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UncompressedLen |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SchemaFingerprint", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWrapper
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWrapper
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWrapper
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SchemaFingerprint = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DisplayName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWrapper
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWrapper
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWrapper
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DisplayName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWrapper
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWrapper
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWrapper
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Description = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ETag", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWrapper
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWrapper
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWrapper
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ETag = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWrapper(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthWrapper
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthWrapper
 			}
 			if (iNdEx + skippy) > l {
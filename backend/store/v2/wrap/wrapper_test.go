@@ -1,9 +1,17 @@
 package wrap_test
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
 	"encoding/json"
+	"errors"
 	fmt "fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
 	"testing"
+	"unicode/utf8"
 
 	//nolint:staticcheck // SA1004 Replacing this will take some planning.
 	"github.com/golang/protobuf/proto"
@@ -12,6 +20,8 @@ import (
 	corev3 "github.com/sensu/sensu-go/api/core/v3"
 	"github.com/sensu/sensu-go/backend/store/v2/wrap"
 	"github.com/sensu/sensu-go/types"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
 )
 
 func init() {
@@ -218,3 +228,1552 @@ func fixtureTestResource2(name string) *testResource2 {
 		},
 	}
 }
+
+func TestUnwrapIntoAnnotations(t *testing.T) {
+	config := corev3.FixtureEntityConfig("foo")
+	w, err := wrap.Resource(config,
+		wrap.ContentTypeFromEncoding,
+		wrap.Ephemeral(),
+		wrap.SetDisplayName("Foo"),
+		wrap.SetDescription("The foo entity"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource, err := w.Unwrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := resource.GetMetadata().Annotations
+	if len(want) != 4 {
+		t.Fatalf("expected 4 annotations from Unwrap, got %v", want)
+	}
+	var into corev3.EntityConfig
+	if err := w.UnwrapInto(&into); err != nil {
+		t.Fatal(err)
+	}
+	if got := into.Metadata.Annotations; !reflect.DeepEqual(got, want) {
+		t.Errorf("UnwrapInto annotations = %v, Unwrap annotations = %v", got, want)
+	}
+	var preserved corev3.EntityConfig
+	if err := w.UnwrapIntoPreservingNilMaps(&preserved); err != nil {
+		t.Fatal(err)
+	}
+	if got := preserved.Metadata.Annotations; !reflect.DeepEqual(got, want) {
+		t.Errorf("UnwrapIntoPreservingNilMaps annotations = %v, Unwrap annotations = %v", got, want)
+	}
+}
+
+func TestUnwrapIntoPreservingNilMaps(t *testing.T) {
+	resource := &testResource{
+		Metadata: &corev2.ObjectMeta{
+			Name:      "foo",
+			Namespace: "default",
+		},
+	}
+	w, err := wrap.Resource(resource)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var preserved testResource
+	if err := w.UnwrapIntoPreservingNilMaps(&preserved); err != nil {
+		t.Fatal(err)
+	}
+	if preserved.Metadata.Labels != nil || preserved.Metadata.Annotations != nil {
+		t.Errorf("expected nil maps, got labels %#v and annotations %#v", preserved.Metadata.Labels, preserved.Metadata.Annotations)
+	}
+
+	var allocated testResource
+	if err := w.UnwrapInto(&allocated); err != nil {
+		t.Fatal(err)
+	}
+	if allocated.Metadata.Labels == nil || allocated.Metadata.Annotations == nil {
+		t.Error("expected UnwrapInto to allocate empty maps")
+	}
+}
+
+func TestUnwrapRawCaseInsensitiveType(t *testing.T) {
+	check := corev2.FixtureCheckConfig("foo")
+	for _, typename := range []string{"CheckConfig", "checkconfig", "CHECK_CONFIG"} {
+		t.Run(typename, func(t *testing.T) {
+			w, err := wrap.V2Resource(check)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w.TypeMeta.Type = typename
+			resource, err := w.UnwrapRaw()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, ok := resource.(*corev2.CheckConfig)
+			if !ok {
+				t.Fatalf("expected *CheckConfig, got %T", resource)
+			}
+			if got.Name != "foo" {
+				t.Errorf("bad name: got %q, want %q", got.Name, "foo")
+			}
+		})
+	}
+
+	w, err := wrap.V2Resource(check)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.TypeMeta.Type = "checkconfiguration"
+	if _, err := w.UnwrapRaw(); err == nil {
+		t.Error("expected an error for an unknown type")
+	}
+}
+
+func TestWouldChange(t *testing.T) {
+	resource := &testResource{
+		Metadata: &corev2.ObjectMeta{
+			Name:      "foo",
+			Namespace: "default",
+			Labels:    map[string]string{"a": "b"},
+		},
+	}
+	existing, err := wrap.Resource(resource)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changed, err := wrap.WouldChange(existing, resource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed {
+		t.Error("expected no change when re-wrapping the same resource")
+	}
+
+	changed, err = wrap.WouldChange(existing, resource, wrap.CompressNone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Error("expected a change when re-wrapping with other options")
+	}
+
+	resource.Metadata.Labels["a"] = "c"
+	changed, err = wrap.WouldChange(existing, resource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Error("expected a change when the resource was modified")
+	}
+}
+
+func TestUnwrapIntoUseNumber(t *testing.T) {
+	w := &wrap.Wrapper{
+		Encoding:    wrap.Encoding_json,
+		Compression: wrap.Compression_none,
+		Value:       []byte(`{"count":9007199254740993}`),
+	}
+
+	var lossy map[string]interface{}
+	if err := w.UnwrapInto(&lossy); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := lossy["count"].(float64); !ok {
+		t.Fatalf("expected float64, got %T", lossy["count"])
+	}
+
+	var precise map[string]interface{}
+	if err := w.UnwrapIntoWithOptions(&precise, wrap.UseNumber); err != nil {
+		t.Fatal(err)
+	}
+	n, ok := precise["count"].(json.Number)
+	if !ok {
+		t.Fatalf("expected json.Number, got %T", precise["count"])
+	}
+	if got, want := n.String(), "9007199254740993"; got != want {
+		t.Errorf("bad number: got %s, want %s", got, want)
+	}
+
+	w.Value = []byte(`{"count":1} {}`)
+	if err := w.UnwrapInto(&precise); err == nil {
+		t.Error("expected error for trailing data")
+	}
+}
+
+func TestWrapContentType(t *testing.T) {
+	tests := []struct {
+		Name    string
+		Options []wrap.Option
+		Exp     string
+	}{
+		{
+			Name: "no content type by default",
+		},
+		{
+			Name:    "explicit content type",
+			Options: []wrap.Option{wrap.SetContentType("application/vnd.example")},
+			Exp:     "application/vnd.example",
+		},
+		{
+			Name:    "content type from default encoding",
+			Options: []wrap.Option{wrap.ContentTypeFromEncoding},
+			Exp:     wrap.ContentTypeProtobuf,
+		},
+		{
+			Name:    "content type from json encoding",
+			Options: []wrap.Option{wrap.EncodeJSON, wrap.ContentTypeFromEncoding},
+			Exp:     wrap.ContentTypeJSON,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			wrapper, err := wrap.Resource(corev3.FixtureEntityState("estate"), test.Options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := wrapper.ContentType, test.Exp; got != want {
+				t.Errorf("bad content type: got %q, want %q", got, want)
+			}
+			b, err := proto.Marshal(wrapper)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var decoded wrap.Wrapper
+			if err := proto.Unmarshal(b, &decoded); err != nil {
+				t.Fatal(err)
+			}
+			resource, err := decoded.Unwrap()
+			if err != nil {
+				t.Fatal(err)
+			}
+			annotation, ok := resource.GetMetadata().Annotations[wrap.ContentTypeAnnotation]
+			if ok != (test.Exp != "") || annotation != test.Exp {
+				t.Errorf("bad content type annotation: got %q, want %q", annotation, test.Exp)
+			}
+		})
+	}
+}
+
+func TestWrapEphemeral(t *testing.T) {
+	wrapper, err := wrap.Resource(corev3.FixtureEntityState("estate"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := wrapper.Class, wrap.Class_durable; got != want {
+		t.Errorf("bad class: got %v, want %v", got, want)
+	}
+	resource, err := wrapper.Unwrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := resource.GetMetadata().Annotations[wrap.ClassAnnotation]; ok {
+		t.Error("durable resource should not have a class annotation")
+	}
+
+	wrapper, err = wrap.Resource(corev3.FixtureEntityState("estate"), wrap.Ephemeral())
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := proto.Marshal(wrapper)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded wrap.Wrapper
+	if err := proto.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := decoded.Class, wrap.Class_ephemeral; got != want {
+		t.Errorf("bad class: got %v, want %v", got, want)
+	}
+	resource, err = decoded.Unwrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := resource.GetMetadata().Annotations[wrap.ClassAnnotation], "ephemeral"; got != want {
+		t.Errorf("bad class annotation: got %q, want %q", got, want)
+	}
+}
+
+func TestWrapDisplayName(t *testing.T) {
+	wrapper, err := wrap.Resource(corev3.FixtureEntityConfig("foo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resource, err := wrapper.Unwrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotations := resource.GetMetadata().Annotations
+	if _, ok := annotations[wrap.DisplayNameAnnotation]; ok {
+		t.Error("resource without a display name should not have a display name annotation")
+	}
+	if _, ok := annotations[wrap.DescriptionAnnotation]; ok {
+		t.Error("resource without a description should not have a description annotation")
+	}
+
+	wrapper, err = wrap.Resource(corev3.FixtureEntityConfig("foo"), wrap.SetDisplayName("Foo"), wrap.SetDescription("The foo entity"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := proto.Marshal(wrapper)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded wrap.Wrapper
+	if err := proto.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := decoded.DisplayName, "Foo"; got != want {
+		t.Errorf("bad display name: got %q, want %q", got, want)
+	}
+	if got, want := decoded.Description, "The foo entity"; got != want {
+		t.Errorf("bad description: got %q, want %q", got, want)
+	}
+	resource, err = decoded.Unwrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotations = resource.GetMetadata().Annotations
+	if got, want := annotations[wrap.DisplayNameAnnotation], "Foo"; got != want {
+		t.Errorf("bad display name annotation: got %q, want %q", got, want)
+	}
+	if got, want := annotations[wrap.DescriptionAnnotation], "The foo entity"; got != want {
+		t.Errorf("bad description annotation: got %q, want %q", got, want)
+	}
+}
+
+func TestWrapperOptions(t *testing.T) {
+	wrapper, err := wrap.Resource(corev3.FixtureEntityConfig("foo"),
+		wrap.EncodeJSON,
+		wrap.CompressZstd,
+		wrap.ContentTypeFromEncoding,
+		wrap.Ephemeral(),
+		wrap.SetDisplayName("Foo"),
+		wrap.SetDescription("The foo entity"),
+		wrap.RecordSchemaFingerprint,
+		wrap.WithETag,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resource, err := wrapper.Unwrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resource.GetMetadata().Labels["foo"] = "bar"
+	unwrapped := resource.GetMetadata()
+	resource.SetMetadata(wrap.StripAnnotations(unwrapped))
+	if got := resource.GetMetadata().Annotations; len(got) != 0 {
+		t.Errorf("expected the annotations of the wrapper to be stripped, got %v", got)
+	}
+	if got := unwrapped.Annotations[wrap.DisplayNameAnnotation]; got != "Foo" {
+		t.Errorf("expected the unwrapped metadata to be left untouched, got %v", unwrapped.Annotations)
+	}
+
+	rewrapped, err := wrap.Resource(resource, wrapper.Options()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rewrapped.Encoding != wrapper.Encoding || rewrapped.Compression != wrapper.Compression {
+		t.Errorf("bad format: got %s/%s, want %s/%s", rewrapped.Encoding, rewrapped.Compression, wrapper.Encoding, wrapper.Compression)
+	}
+	if rewrapped.ContentType != wrapper.ContentType || rewrapped.Class != wrapper.Class {
+		t.Errorf("bad content type or class: got %q/%s, want %q/%s", rewrapped.ContentType, rewrapped.Class, wrapper.ContentType, wrapper.Class)
+	}
+	if rewrapped.DisplayName != wrapper.DisplayName || rewrapped.Description != wrapper.Description {
+		t.Errorf("bad display name or description: got %q/%q", rewrapped.DisplayName, rewrapped.Description)
+	}
+	if rewrapped.SchemaFingerprint != wrapper.SchemaFingerprint {
+		t.Errorf("bad schema fingerprint: got %q, want %q", rewrapped.SchemaFingerprint, wrapper.SchemaFingerprint)
+	}
+	if !rewrapped.HasComputedETag() || rewrapped.ETag == wrapper.ETag {
+		t.Errorf("expected a new etag, got %q", rewrapped.ETag)
+	}
+
+	// The annotations are set by unwrapping only, not stored
+	value, err := rewrapped.MarshalJSONValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stored corev3.EntityConfig
+	if err := json.Unmarshal(value, &stored); err != nil {
+		t.Fatal(err)
+	}
+	if len(stored.Metadata.Annotations) != 0 {
+		t.Errorf("expected no stored annotations, got %v", stored.Metadata.Annotations)
+	}
+}
+
+func TestAnnotationOptions(t *testing.T) {
+	wrapper, err := wrap.Resource(corev3.FixtureEntityConfig("foo"),
+		wrap.ContentTypeFromEncoding,
+		wrap.Ephemeral(),
+		wrap.SetDisplayName("Foo"),
+		wrap.SetDescription("The foo entity"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resource, err := wrapper.Unwrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The annotations are all a client sends back
+	meta := resource.GetMetadata()
+	rewrapped, err := wrap.Resource(resource, wrap.AnnotationOptions(meta)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rewrapped.ContentType != wrapper.ContentType || rewrapped.Class != wrapper.Class {
+		t.Errorf("bad content type or class: got %q/%s, want %q/%s", rewrapped.ContentType, rewrapped.Class, wrapper.ContentType, wrapper.Class)
+	}
+	if rewrapped.DisplayName != "Foo" || rewrapped.Description != "The foo entity" {
+		t.Errorf("bad display name or description: got %q/%q", rewrapped.DisplayName, rewrapped.Description)
+	}
+
+	if opts := wrap.AnnotationOptions(corev2.NewObjectMetaP("foo", "default")); len(opts) != 0 {
+		t.Errorf("expected no options without annotations, got %d", len(opts))
+	}
+}
+
+func TestWrapResources(t *testing.T) {
+	resources := []corev3.Resource{
+		corev3.FixtureEntityConfig("foo"),
+		corev3.FixtureEntityConfig("bar"),
+	}
+	list, err := wrap.Resources(resources, wrap.EncodeJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := list.Len(), len(resources); got != want {
+		t.Fatalf("bad length: got %d, want %d", got, want)
+	}
+	for i, w := range list {
+		if got, want := w.Encoding, wrap.Encoding_json; got != want {
+			t.Errorf("wrapper %d: bad encoding: got %v, want %v", i, got, want)
+		}
+	}
+	unwrapped, err := list.Unwrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range unwrapped {
+		if got, want := unwrapped[i].GetMetadata().Name, resources[i].GetMetadata().Name; got != want {
+			t.Errorf("resource %d: bad name: got %q, want %q", i, got, want)
+		}
+	}
+
+	invalid := corev3.FixtureEntityConfig("")
+	if _, err := wrap.Resources(append(resources, invalid)); err == nil {
+		t.Fatal("expected error for invalid resource")
+	}
+}
+
+func TestWrapResourcesMatchesResource(t *testing.T) {
+	resources := []corev3.Resource{
+		corev3.FixtureEntityConfig("foo"),
+		corev3.FixtureEntityConfig("bar"),
+		corev3.FixtureEntityConfig("baz"),
+	}
+	opts := []wrap.Option{wrap.EncodeProtobuf, wrap.CompressSnappy, wrap.SetDisplayName("Entity")}
+	list, err := wrap.Resources(resources, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range resources {
+		w, err := wrap.Resource(r, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(list[i], w) {
+			t.Errorf("wrapper %d: Resources() = %v, Resource() = %v", i, list[i], w)
+		}
+	}
+
+	invalid := append(resources[:1:1], corev3.FixtureEntityConfig(""), resources[2])
+	_, err = wrap.Resources(invalid, opts...)
+	if err == nil {
+		t.Fatal("expected error for invalid resource")
+	}
+	if !strings.Contains(err.Error(), "resource 1") {
+		t.Errorf("error does not name the invalid resource: %v", err)
+	}
+}
+
+// BenchmarkWrapResources compares the allocations of wrapping a slice of
+// resources with Resources to those of calling Resource for each of them.
+func BenchmarkWrapResources(b *testing.B) {
+	resources := make([]corev3.Resource, 1000)
+	for i := range resources {
+		resources[i] = corev3.FixtureEntityConfig(fmt.Sprintf("entity%d", i))
+	}
+	opts := []wrap.Option{wrap.EncodeProtobuf, wrap.CompressNone}
+
+	b.Run("Resources", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := wrap.Resources(resources, opts...); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Resource", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			list := make(wrap.List, len(resources))
+			for j, r := range resources {
+				w, err := wrap.Resource(r, opts...)
+				if err != nil {
+					b.Fatal(err)
+				}
+				list[j] = w
+			}
+		}
+	})
+}
+
+func TestListUnwrapIntoLength(t *testing.T) {
+	list, err := wrap.Resources([]corev3.Resource{
+		corev3.FixtureEntityConfig("foo"),
+		corev3.FixtureEntityConfig("bar"),
+		corev3.FixtureEntityConfig("baz"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		configs []*corev3.EntityConfig
+	}{
+		{
+			name: "nil slice",
+		},
+		{
+			name:    "oversized backing array",
+			configs: make([]*corev3.EntityConfig, 0, 100),
+		},
+		{
+			name:    "longer slice",
+			configs: make([]*corev3.EntityConfig, 10),
+		},
+		{
+			name:    "shorter slice",
+			configs: make([]*corev3.EntityConfig, 1, 2),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configs := tt.configs
+			if err := list.UnwrapInto(&configs); err != nil {
+				t.Fatal(err)
+			}
+			if got, want := len(configs), len(list); got != want {
+				t.Fatalf("bad length: got %d, want %d", got, want)
+			}
+			for i, name := range []string{"foo", "bar", "baz"} {
+				if got := configs[i].Metadata.Name; got != name {
+					t.Errorf("resource %d: bad name: got %q, want %q", i, got, name)
+				}
+			}
+			if cap(tt.configs) >= len(list) && &configs[0] != &tt.configs[:1][0] {
+				t.Error("expected the backing array to be reused")
+			}
+		})
+	}
+}
+
+func TestDecompressMaxSize(t *testing.T) {
+	value := wrap.Compression_snappy.Compress(make([]byte, 1024))
+	if _, err := wrap.Compression_snappy.Decompress(value); err != nil {
+		t.Fatal(err)
+	}
+
+	// A crafted value whose length prefix claims 1 GiB
+	bomb := make([]byte, binary.MaxVarintLen64+1)
+	bomb = bomb[:binary.PutUvarint(bomb, 1<<30)+1]
+	if _, err := wrap.Compression_snappy.Decompress(bomb); !errors.Is(err, wrap.ErrDecompressedSizeTooLarge) {
+		t.Fatalf("expected ErrDecompressedSizeTooLarge, got %v", err)
+	}
+
+	if _, err := wrap.Compression_snappy.Decompress(value, wrap.MaxDecompressedSize(512)); !errors.Is(err, wrap.ErrDecompressedSizeTooLarge) {
+		t.Fatalf("expected ErrDecompressedSizeTooLarge, got %v", err)
+	}
+}
+
+func TestWrapZstd(t *testing.T) {
+	if got, want := wrap.Compression_zstd.String(), "zstd"; got != want {
+		t.Errorf("bad name: got %q, want %q", got, want)
+	}
+	if got := wrap.Compression(wrap.Compression_value["zstd"]); got != wrap.Compression_zstd {
+		t.Errorf("bad value: got %v, want %v", got, wrap.Compression_zstd)
+	}
+
+	// A large, repetitive JSON payload
+	entity := corev3.FixtureEntityConfig("foo")
+	for i := 0; i < 200; i++ {
+		entity.Metadata.Labels[fmt.Sprintf("label%d", i)] = strings.Repeat("value", i%10)
+	}
+	encoded, err := json.Marshal(entity)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sizes := make(map[wrap.Compression]int)
+	for _, compression := range []wrap.Option{wrap.CompressSnappy, wrap.CompressZstd} {
+		w, err := wrap.Resource(entity, wrap.EncodeJSON, compression)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sizes[w.Compression] = len(w.Value)
+
+		// Wrappers are stored and read back as protobuf
+		b, err := proto.Marshal(w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded wrap.Wrapper
+		if err := proto.Unmarshal(b, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		resource, err := decoded.UnwrapRaw()
+		if err != nil {
+			t.Fatalf("%s: %s", w.Compression, err)
+		}
+		got, err := json.Marshal(resource)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, encoded) {
+			t.Errorf("%s: unwrapped resource differs from the wrapped one", w.Compression)
+		}
+	}
+	if sizes[wrap.Compression_zstd] >= sizes[wrap.Compression_snappy] {
+		t.Errorf("expected zstd to compress better than snappy: %v", sizes)
+	}
+}
+
+func TestCompressZstdLevel(t *testing.T) {
+	entity := corev3.FixtureEntityConfig("foo")
+	for i := 0; i < 200; i++ {
+		entity.Metadata.Labels[fmt.Sprintf("label%d", i)] = strings.Repeat("value", i%10)
+	}
+
+	sizes := make(map[int]int)
+	for _, level := range []int{1, 19} {
+		w, err := wrap.Resource(entity, wrap.EncodeJSON, wrap.CompressZstdLevel(level))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := w.Compression, wrap.Compression_zstd; got != want {
+			t.Errorf("level %d: bad compression: got %s, want %s", level, got, want)
+		}
+		var config corev3.EntityConfig
+		if err := w.UnwrapInto(&config); err != nil {
+			t.Fatalf("level %d: %s", level, err)
+		}
+		if got, want := len(config.Metadata.Labels), len(entity.Metadata.Labels); got != want {
+			t.Errorf("level %d: bad labels: got %d, want %d", level, got, want)
+		}
+		sizes[level] = len(w.Value)
+	}
+	if sizes[19] > sizes[1] {
+		t.Errorf("expected level 19 to compress at least as well as level 1: %v", sizes)
+	}
+}
+
+func TestZstdDecompressMaxSize(t *testing.T) {
+	value := wrap.Compression_zstd.Compress(make([]byte, 1024))
+	if _, err := wrap.Compression_zstd.Decompress(value); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := wrap.Compression_zstd.Decompress(value, wrap.MaxDecompressedSize(512)); !errors.Is(err, wrap.ErrDecompressedSizeTooLarge) {
+		t.Fatalf("expected ErrDecompressedSizeTooLarge, got %v", err)
+	}
+
+	_, err := wrap.Compression_zstd.Decompress([]byte("not zstd"))
+	var corrupt *wrap.CorruptValueError
+	if !errors.As(err, &corrupt) {
+		t.Fatalf("expected a CorruptValueError, got %v", err)
+	}
+}
+
+func TestWrapGzip(t *testing.T) {
+	w, err := wrap.Resource(corev3.FixtureEntityConfig("foo"), wrap.EncodeJSON, wrap.CompressGzip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := w.Compression, wrap.Compression_gzip; got != want {
+		t.Fatalf("bad compression: got %v, want %v", got, want)
+	}
+	resource, err := w.Unwrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := resource.GetMetadata().Name, "foo"; got != want {
+		t.Errorf("bad name: got %q, want %q", got, want)
+	}
+
+	// The value is a plain gzip stream
+	zr, err := gzip.NewReader(bytes.NewReader(w.Value))
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := ioutil.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !json.Valid(value) {
+		t.Errorf("expected a JSON value, got %q", value)
+	}
+
+	// Truncated and corrupt streams are reported as corrupt values
+	var corrupt *wrap.CorruptValueError
+	for _, mangled := range [][]byte{w.Value[:len(w.Value)/2], w.Value[:5], []byte("not gzip")} {
+		if _, err := wrap.Compression_gzip.Decompress(mangled); !errors.As(err, &corrupt) {
+			t.Errorf("expected a CorruptValueError, got %v", err)
+		}
+	}
+
+	if _, err := w.UnwrapWithOptions(wrap.MaxDecompressedSize(16)); !errors.Is(err, wrap.ErrDecompressedSizeTooLarge) {
+		t.Fatalf("expected ErrDecompressedSizeTooLarge, got %v", err)
+	}
+}
+
+func TestDecompressErrors(t *testing.T) {
+	_, err := wrap.Compression(1000).Decompress([]byte("foo"))
+	var unsupported *wrap.UnsupportedCompressionError
+	if !errors.As(err, &unsupported) {
+		t.Fatalf("expected an UnsupportedCompressionError, got %v", err)
+	}
+	if got, want := unsupported.Compression, wrap.Compression(1000); got != want {
+		t.Errorf("bad compression: got %d, want %d", got, want)
+	}
+	if !strings.Contains(err.Error(), "1000") {
+		t.Errorf("expected the error to name the algorithm: %s", err)
+	}
+	if !errors.Is(err, wrap.ErrInvalidFormat) {
+		t.Error("expected the error to be an ErrInvalidFormat")
+	}
+
+	value := wrap.Compression_snappy.Compress([]byte("hello, world"))
+	_, err = wrap.Compression_snappy.Decompress(value[:len(value)-2])
+	var corrupt *wrap.CorruptValueError
+	if !errors.As(err, &corrupt) {
+		t.Fatalf("expected a CorruptValueError, got %v", err)
+	}
+	if errors.As(err, &unsupported) {
+		t.Error("corrupt data reported as an unsupported compression")
+	}
+
+	// Unwrapping preserves the error types
+	w, err := wrap.Resource(corev3.FixtureEntityConfig("foo"), wrap.CompressSnappy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Value = w.Value[:len(w.Value)-2]
+	if _, err := w.Unwrap(); !errors.As(err, &corrupt) {
+		t.Errorf("expected a CorruptValueError, got %v", err)
+	}
+	w.Compression = wrap.Compression(1000)
+	if _, err := w.Unwrap(); !errors.As(err, &unsupported) {
+		t.Errorf("expected an UnsupportedCompressionError, got %v", err)
+	}
+}
+
+func TestWrapMsgpack(t *testing.T) {
+	config := corev3.FixtureEntityConfig("foo")
+	config.Subscriptions = []string{"linux", "entity:foo"}
+	config.Metadata.Labels = map[string]string{"region": "us-west-2"}
+	w, err := wrap.Resource(config, wrap.EncodeMsgpack, wrap.CompressSnappy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := w.Encoding, wrap.Encoding_msgpack; got != want {
+		t.Errorf("bad encoding: got %s, want %s", got, want)
+	}
+	resource, err := w.UnwrapRaw()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := resource, config; !proto.Equal(got.(proto.Message), want) {
+		t.Errorf("bad resource: got %v, want %v", got, want)
+	}
+
+	// The encoding is read from the stored wrapper
+	b, err := proto.Marshal(w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stored wrap.Wrapper
+	if err := proto.Unmarshal(b, &stored); err != nil {
+		t.Fatal(err)
+	}
+	var unwrapped corev3.EntityConfig
+	if err := stored.UnwrapInto(&unwrapped); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(&unwrapped, config) {
+		t.Errorf("bad resource: got %v, want %v", &unwrapped, config)
+	}
+
+	// The default encoding is unchanged
+	w, err = wrap.Resource(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := w.Encoding, wrap.Encoding_protobuf; got != want {
+		t.Errorf("bad default encoding: got %s, want %s", got, want)
+	}
+}
+
+func TestRegisterEncoding(t *testing.T) {
+	// A JSON encoding variant that prefixes values, so that its use is visible
+	const prefix = "custom:"
+	custom := wrap.Encoding(42)
+	var encoded, decoded int
+	enc := func(v interface{}) ([]byte, error) {
+		encoded++
+		b, err := json.Marshal(v)
+		return append([]byte(prefix), b...), err
+	}
+	dec := func(m []byte, v interface{}) error {
+		decoded++
+		if !bytes.HasPrefix(m, []byte(prefix)) {
+			return errors.New("missing prefix")
+		}
+		return json.Unmarshal(bytes.TrimPrefix(m, []byte(prefix)), v)
+	}
+	if err := wrap.RegisterEncoding(custom, "custom", enc, dec); err != nil {
+		t.Fatal(err)
+	}
+
+	// Known ids, built in or not, can't be registered again
+	for _, id := range []wrap.Encoding{custom, wrap.Encoding_json} {
+		if err := wrap.RegisterEncoding(id, "other", enc, dec); !errors.Is(err, wrap.ErrAlreadyRegistered) {
+			t.Errorf("expected ErrAlreadyRegistered for encoding %d, got %v", id, err)
+		}
+	}
+
+	// Known names can't be registered again either, nor override built-in ones
+	for _, name := range []string{"custom", "json"} {
+		if err := wrap.RegisterEncoding(wrap.Encoding(43), name, enc, dec); !errors.Is(err, wrap.ErrAlreadyRegistered) {
+			t.Errorf("expected ErrAlreadyRegistered for encoding name %s, got %v", name, err)
+		}
+	}
+	if got, want := wrap.Encoding(wrap.Encoding_value["json"]), wrap.Encoding_json; got != want {
+		t.Errorf("bad json encoding: got %v, want %v", got, want)
+	}
+
+	if got, want := custom.Name(), "custom"; got != want {
+		t.Errorf("bad encoding name: got %q, want %q", got, want)
+	}
+	if got, want := wrap.Encoding_json.Name(), "json"; got != want {
+		t.Errorf("bad encoding name: got %q, want %q", got, want)
+	}
+	encodeCustom := func(w *wrap.Wrapper, r interface{}) error {
+		w.Encoding = custom
+		return nil
+	}
+	wrapper, err := wrap.Resource(corev3.FixtureEntityConfig("entity"), encodeCustom)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resource, err := wrapper.Unwrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := resource.GetMetadata().Name, "entity"; got != want {
+		t.Errorf("bad name: got %q, want %q", got, want)
+	}
+	if encoded != 1 || decoded != 1 {
+		t.Errorf("custom encoding not used: %d encodes, %d decodes", encoded, decoded)
+	}
+
+	if _, err := wrap.Encoding(43).Encode(resource); err == nil {
+		t.Error("expected an error for an unregistered encoding")
+	}
+}
+
+func TestRegisterCompression(t *testing.T) {
+	// A trivial "compression" that XORs every byte, so that its use is
+	// visible
+	xor := func(m []byte) []byte {
+		b := make([]byte, len(m))
+		for i := range m {
+			b[i] = m[i] ^ 0x5a
+		}
+		return b
+	}
+	custom := wrap.Compression(42)
+	decompress := func(m []byte) ([]byte, error) {
+		return xor(m), nil
+	}
+	if err := wrap.RegisterCompression(custom, "xor", xor, decompress); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []wrap.Compression{custom, wrap.Compression_snappy} {
+		if err := wrap.RegisterCompression(id, "other", xor, decompress); !errors.Is(err, wrap.ErrAlreadyRegistered) {
+			t.Errorf("expected ErrAlreadyRegistered for compression %d, got %v", id, err)
+		}
+	}
+	for _, name := range []string{"xor", "snappy"} {
+		if err := wrap.RegisterCompression(wrap.Compression(43), name, xor, decompress); !errors.Is(err, wrap.ErrAlreadyRegistered) {
+			t.Errorf("expected ErrAlreadyRegistered for compression name %s, got %v", name, err)
+		}
+	}
+	if got, want := custom.Name(), "xor"; got != want {
+		t.Errorf("bad compression name: got %q, want %q", got, want)
+	}
+	if got, want := wrap.Compression_snappy.Name(), "snappy"; got != want {
+		t.Errorf("bad compression name: got %q, want %q", got, want)
+	}
+
+	config := corev3.FixtureEntityConfig("entity")
+	compressXOR := func(w *wrap.Wrapper, r interface{}) error {
+		w.Compression = custom
+		return nil
+	}
+	w, err := wrap.Resource(config, wrap.EncodeProtobuf, compressXOR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := proto.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := w.Value, xor(encoded); !bytes.Equal(got, want) {
+		t.Errorf("bad value: got %x, want %x", got, want)
+	}
+	if complete, err := w.IsComplete(); err != nil || !complete {
+		t.Errorf("expected a complete wrapper, got %v, %v", complete, err)
+	}
+	resource, err := w.Unwrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(resource.(proto.Message), config) {
+		t.Errorf("bad resource: got %v, want %v", resource, config)
+	}
+
+	// Registered compressions are held to the recorded uncompressed length
+	w.UncompressedLen++
+	var corrupt *wrap.CorruptValueError
+	if _, err := w.Unwrap(); !errors.As(err, &corrupt) {
+		t.Errorf("expected a CorruptValueError, got %v", err)
+	}
+}
+
+func TestUnwrapUnknownType(t *testing.T) {
+	// A resolver whose only type can be removed
+	removed := false
+	types.RegisterResolver("v2/wrap_unknown_test", func(name string) (interface{}, error) {
+		if removed || name != "testResource" {
+			return nil, fmt.Errorf("invalid resource: %s", name)
+		}
+		return &testResource{}, nil
+	})
+
+	resource := fixtureTestResource("foo")
+	w, err := wrap.Resource(resource, wrap.EncodeJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.TypeMeta = &corev2.TypeMeta{Type: "testResource", APIVersion: "v2/wrap_unknown_test"}
+	if _, err := w.UnwrapRaw(); err != nil {
+		t.Fatal(err)
+	}
+
+	removed = true
+	var unknown *wrap.UnknownTypeError
+	if _, err := w.UnwrapRaw(); !errors.As(err, &unknown) {
+		t.Fatalf("expected an UnknownTypeError, got %v", err)
+	}
+	if unknown.APIVersion != "v2/wrap_unknown_test" || unknown.Type != "testResource" {
+		t.Errorf("bad unknown type: %s %s", unknown.APIVersion, unknown.Type)
+	}
+
+	// Lists propagate the error
+	if _, err := (wrap.List{w}).Unwrap(); !errors.As(err, &unknown) {
+		t.Errorf("expected an UnknownTypeError, got %v", err)
+	}
+
+	// Unknown API versions are unknown types too
+	w.TypeMeta = &corev2.TypeMeta{Type: "testResource", APIVersion: "v2/wrap_missing_test"}
+	if _, err := w.UnwrapRaw(); !errors.As(err, &unknown) {
+		t.Errorf("expected an UnknownTypeError, got %v", err)
+	}
+}
+
+func TestWrapperMetadata(t *testing.T) {
+	check := corev2.FixtureCheckConfig("check")
+	check.Labels = map[string]string{"region": "us-west-1"}
+	check.Annotations = map[string]string{"team": "ops"}
+	entity := corev3.FixtureEntityConfig("entity")
+	entity.Metadata.Labels["region"] = "us-west-2"
+	namespace := corev2.FixtureNamespace("ns")
+	jsonResource := &testResource{
+		Metadata: &corev2.ObjectMeta{
+			Name:      "json",
+			Namespace: "default",
+			Labels:    map[string]string{"region": "eu-west-1"},
+		},
+	}
+
+	tests := []struct {
+		Name     string
+		Resource interface{}
+		Want     corev2.ObjectMeta
+	}{
+		{
+			Name:     "core/v2 protobuf",
+			Resource: check,
+			Want:     check.ObjectMeta,
+		},
+		{
+			Name:     "core/v3 protobuf",
+			Resource: entity,
+			Want:     *entity.Metadata,
+		},
+		{
+			Name:     "json",
+			Resource: jsonResource,
+			Want:     *jsonResource.Metadata,
+		},
+		{
+			Name:     "no metadata field",
+			Resource: namespace,
+			Want:     namespace.GetObjectMeta(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var w *wrap.Wrapper
+			var err error
+			switch r := test.Resource.(type) {
+			case corev3.Resource:
+				w, err = wrap.Resource(r)
+			case corev2.Resource:
+				w, err = wrap.V2Resource(r)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			meta, err := w.Metadata()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !meta.Equal(&test.Want) {
+				t.Errorf("bad metadata: got %#v, want %#v", meta, test.Want)
+			}
+		})
+	}
+}
+
+func TestUnwrapInvalidFormat(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Modify func(*wrap.Wrapper)
+	}{
+		{
+			Name: "unknown encoding",
+			Modify: func(w *wrap.Wrapper) {
+				w.Encoding = wrap.Encoding(1000)
+			},
+		},
+		{
+			Name: "unknown compression",
+			Modify: func(w *wrap.Wrapper) {
+				w.Compression = wrap.Compression(1000)
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			w, err := wrap.Resource(corev3.FixtureEntityConfig("foo"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.Modify(w)
+
+			// Out-of-range values survive a round-trip through storage
+			b, err := proto.Marshal(w)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var stored wrap.Wrapper
+			if err := proto.Unmarshal(b, &stored); err != nil {
+				t.Fatal(err)
+			}
+			if stored.Encoding.IsValid() && stored.Compression.IsValid() {
+				t.Fatal("expected an invalid encoding or compression")
+			}
+
+			if _, err := stored.Unwrap(); !errors.Is(err, wrap.ErrInvalidFormat) {
+				t.Errorf("Unwrap: expected ErrInvalidFormat, got %v", err)
+			}
+			var entity corev3.EntityConfig
+			if err := stored.UnwrapInto(&entity); !errors.Is(err, wrap.ErrInvalidFormat) {
+				t.Errorf("UnwrapInto: expected ErrInvalidFormat, got %v", err)
+			}
+			if _, err := stored.Metadata(); !errors.Is(err, wrap.ErrInvalidFormat) {
+				t.Errorf("Metadata: expected ErrInvalidFormat, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRegisterValidationHook(t *testing.T) {
+	errPolicy := errors.New("entity names must not start with forbidden-")
+	wrap.RegisterValidationHook("EntityConfig", func(r interface{}) error {
+		entity, ok := r.(*corev3.EntityConfig)
+		if !ok {
+			return fmt.Errorf("unexpected resource %T", r)
+		}
+		if strings.HasPrefix(entity.Metadata.Name, "forbidden-") {
+			return errPolicy
+		}
+		return nil
+	})
+
+	if _, err := wrap.Resource(corev3.FixtureEntityConfig("allowed")); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := wrap.Resource(corev3.FixtureEntityConfig("forbidden-foo"))
+	if err != errPolicy {
+		t.Fatalf("expected the hook error, got %v", err)
+	}
+
+	// Both the built-in validation and the hook fail
+	entity := corev3.FixtureEntityConfig("forbidden-foo")
+	entity.Metadata.Labels = nil
+	_, err = wrap.Resource(entity)
+	var verr *wrap.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected a ValidationError, got %v", err)
+	}
+	if got, want := len(verr.Errors), 2; got != want {
+		t.Fatalf("bad number of errors: got %d, want %d", got, want)
+	}
+	if verr.Errors[1] != errPolicy {
+		t.Errorf("expected the hook error to come last, got %v", verr.Errors[1])
+	}
+
+	// Hooks are not run without validation
+	if _, err := wrap.V2ResourceWithoutValidation(corev2.FixtureEntity("forbidden-foo")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWrapUncompressedLen(t *testing.T) {
+	entity := corev3.FixtureEntityConfig("foo")
+	w, err := wrap.Resource(entity, wrap.CompressSnappy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, err := w.Encoding.Encode(entity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := w.UncompressedLen, int64(len(message)); got != want {
+		t.Fatalf("bad uncompressed length: got %d, want %d", got, want)
+	}
+	if _, err := w.Unwrap(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Legacy wrappers don't record the uncompressed length
+	legacy := *w
+	legacy.UncompressedLen = 0
+	if _, err := legacy.Unwrap(); err != nil {
+		t.Fatal(err)
+	}
+
+	mismatch := *w
+	mismatch.UncompressedLen++
+	var corrupt *wrap.CorruptValueError
+	if _, err := mismatch.Unwrap(); !errors.As(err, &corrupt) {
+		t.Fatalf("expected a CorruptValueError, got %v", err)
+	}
+}
+
+func TestLimitMetadataValues(t *testing.T) {
+	entity := corev3.FixtureEntityConfig("foo")
+	entity.Metadata.Labels["region"] = "us-west-2"
+	entity.Metadata.Annotations["huge"] = strings.Repeat("a", 100)
+
+	if _, err := wrap.Resource(entity, wrap.LimitMetadataValues(100)); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := wrap.Resource(entity, wrap.LimitMetadataValues(64))
+	var tooLarge *wrap.MetadataValueTooLargeError
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("expected a MetadataValueTooLargeError, got %v", err)
+	}
+	if got, want := tooLarge.Kind, "annotation"; got != want {
+		t.Errorf("bad kind: got %q, want %q", got, want)
+	}
+	if got, want := tooLarge.Key, "huge"; got != want {
+		t.Errorf("bad key: got %q, want %q", got, want)
+	}
+	if got := entity.Metadata.Annotations["huge"]; len(got) != 100 {
+		t.Errorf("the annotation was modified: %q", got)
+	}
+
+	// v2 resources are supported too
+	check := corev2.FixtureCheckConfig("check")
+	check.Labels = map[string]string{"huge": strings.Repeat("a", 100)}
+	if _, err := wrap.V2Resource(check, wrap.LimitMetadataValues(64)); !errors.As(err, &tooLarge) {
+		t.Fatalf("expected a MetadataValueTooLargeError, got %v", err)
+	}
+}
+
+func TestTruncateMetadataValues(t *testing.T) {
+	entity := corev3.FixtureEntityConfig("foo")
+	entity.Metadata.Labels["region"] = "us-west-2"
+	entity.Metadata.Annotations["huge"] = strings.Repeat("é", 50)
+
+	w, err := wrap.Resource(entity, wrap.TruncateMetadataValues(32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err := w.Unwrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := stored.GetMetadata()
+	if got, want := meta.Labels["region"], "us-west-2"; got != want {
+		t.Errorf("bad label: got %q, want %q", got, want)
+	}
+	got := meta.Annotations["huge"]
+	if len(got) > 32 {
+		t.Errorf("annotation too long: %d bytes", len(got))
+	}
+	if !strings.HasSuffix(got, wrap.TruncatedMarker) {
+		t.Errorf("expected the truncation marker: %q", got)
+	}
+	if !utf8.ValidString(got) {
+		t.Errorf("invalid UTF-8: %q", got)
+	}
+	if got != entity.Metadata.Annotations["huge"] {
+		t.Error("expected the resource to be truncated too")
+	}
+}
+
+type hintedTestResource struct {
+	*testResource
+	hint wrap.Compression
+}
+
+func (h hintedTestResource) CompressionHint() wrap.Compression {
+	return h.hint
+}
+
+func TestWrapCompressionHint(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource corev3.Resource
+		opts     []wrap.Option
+		want     wrap.Compression
+		wantErr  bool
+	}{
+		{
+			name:     "no hint",
+			resource: fixtureTestResource("foo"),
+			want:     wrap.Compression_snappy,
+		},
+		{
+			name:     "hinted no compression",
+			resource: hintedTestResource{testResource: fixtureTestResource("foo"), hint: wrap.Compression_none},
+			want:     wrap.Compression_none,
+		},
+		{
+			name:     "hinted snappy",
+			resource: hintedTestResource{testResource: fixtureTestResource("foo"), hint: wrap.Compression_snappy},
+			want:     wrap.Compression_snappy,
+		},
+		{
+			name:     "explicit option overrides the hint",
+			resource: hintedTestResource{testResource: fixtureTestResource("foo"), hint: wrap.Compression_none},
+			opts:     []wrap.Option{wrap.CompressSnappy},
+			want:     wrap.Compression_snappy,
+		},
+		{
+			name:     "unsupported hint",
+			resource: hintedTestResource{testResource: fixtureTestResource("foo"), hint: wrap.Compression(1000)},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := wrap.Resource(tt.resource, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, wrap.ErrInvalidFormat) {
+					t.Errorf("expected an unsupported compression error, got %v", err)
+				}
+				return
+			}
+			if got := w.Compression; got != tt.want {
+				t.Errorf("bad compression: got %s, want %s", got, tt.want)
+			}
+			var got testResource
+			if err := w.UnwrapInto(&got); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestMarshalJSONValue(t *testing.T) {
+	for _, encoding := range []wrap.Option{wrap.EncodeProtobuf, wrap.EncodeJSON} {
+		w, err := wrap.Resource(corev3.FixtureEntityConfig("foo"), encoding)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Run(w.Encoding.String(), func(t *testing.T) {
+			b, err := w.MarshalJSONValue()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var config corev3.EntityConfig
+			if err := json.Unmarshal(b, &config); err != nil {
+				t.Fatalf("invalid JSON %s: %s", string(b), err)
+			}
+			if got, want := config.Metadata.Name, "foo"; got != want {
+				t.Errorf("bad name: got %s, want %s", got, want)
+			}
+		})
+	}
+
+	w, err := wrap.Resource(corev3.FixtureEntityConfig("foo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Encoding = wrap.Encoding(1000)
+	if _, err := w.MarshalJSONValue(); !errors.Is(err, wrap.ErrInvalidFormat) {
+		t.Errorf("expected an invalid format error, got %v", err)
+	}
+}
+
+func jsonArrayWrapper(value string) *wrap.Wrapper {
+	return &wrap.Wrapper{
+		TypeMeta:    &corev2.TypeMeta{Type: "testResource", APIVersion: "v2/wrap_test"},
+		Encoding:    wrap.Encoding_json,
+		Compression: wrap.Compression_none,
+		Value:       []byte(value),
+	}
+}
+
+func TestUnwrapIntoJSONArray(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []*testResource
+		wantErr bool
+	}{
+		{
+			name:  "array",
+			value: `[{"Metadata":{"name":"foo"}},{"Metadata":{"name":"bar"}}]`,
+			want: []*testResource{
+				{Metadata: &corev2.ObjectMeta{Name: "foo"}},
+				{Metadata: &corev2.ObjectMeta{Name: "bar"}},
+			},
+		},
+		{
+			name:  "empty array",
+			value: `[]`,
+			want:  []*testResource{},
+		},
+		{
+			name:  "null",
+			value: `null`,
+		},
+		{
+			name:    "object",
+			value:   `{"Metadata":{"name":"foo"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "trailing data",
+			value:   `[] []`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid element",
+			value:   `[{"Metadata":{"name":"foo"}},42]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []*testResource
+			err := jsonArrayWrapper(tt.value).UnwrapInto(&got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnwrapInto() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if (got == nil) != (tt.want == nil) || len(got) != len(tt.want) {
+				t.Fatalf("UnwrapInto() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got, want := got[i].Metadata.Name, tt.want[i].Metadata.Name; got != want {
+					t.Errorf("bad name of resource %d: got %s, want %s", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestUnwrapIntoJSONArrayUseNumber(t *testing.T) {
+	var got []interface{}
+	if err := jsonArrayWrapper(`[9007199254740993]`).UnwrapIntoWithOptions(&got, wrap.UseNumber); err != nil {
+		t.Fatal(err)
+	}
+	if n, ok := got[0].(json.Number); !ok || n.String() != "9007199254740993" {
+		t.Errorf("bad number: %#v", got[0])
+	}
+}
+
+func TestRecordSchemaFingerprint(t *testing.T) {
+	hook := test.NewLocal(logrus.StandardLogger())
+	defer logrus.StandardLogger().ReplaceHooks(make(logrus.LevelHooks))
+
+	w, err := wrap.Resource(fixtureTestResource("foo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.SchemaFingerprint != "" {
+		t.Errorf("unexpected schema fingerprint: %q", w.SchemaFingerprint)
+	}
+
+	w, err = wrap.Resource(fixtureTestResource("foo"), wrap.RecordSchemaFingerprint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := w.SchemaFingerprint, wrap.SchemaFingerprint(&testResource{}); got != want {
+		t.Errorf("bad schema fingerprint: got %q, want %q", got, want)
+	}
+	if wrap.SchemaFingerprint(&testResource{}) != wrap.SchemaFingerprint(&testResource2{}) {
+		t.Error("expected types with the same fields to have the same fingerprint")
+	}
+	if wrap.SchemaFingerprint(&testResource{}) == wrap.SchemaFingerprint(&corev2.CheckConfig{}) {
+		t.Error("expected types with different fields to have different fingerprints")
+	}
+	if _, err := w.Unwrap(); err != nil {
+		t.Fatal(err)
+	}
+	if len(hook.AllEntries()) != 0 {
+		t.Errorf("unexpected log entries: %v", hook.AllEntries())
+	}
+
+	// A resource written with another schema is reported when unwrapped
+	w.SchemaFingerprint = "0123456789abcdef"
+	var got testResource
+	if err := w.UnwrapInto(&got); err != nil {
+		t.Fatal(err)
+	}
+	entry := hook.LastEntry()
+	if entry == nil || entry.Level != logrus.WarnLevel {
+		t.Fatalf("expected a warning, got %v", entry)
+	}
+	if got, want := entry.Data["name"], "foo"; got != want {
+		t.Errorf("bad resource name in warning: got %v, want %v", got, want)
+	}
+	if got, want := entry.Data["stored_fingerprint"], "0123456789abcdef"; got != want {
+		t.Errorf("bad stored fingerprint in warning: got %v, want %v", got, want)
+	}
+}
+
+func TestListSplitBySize(t *testing.T) {
+	sized := func(sizes ...int) wrap.List {
+		list := make(wrap.List, len(sizes))
+		for i, size := range sizes {
+			list[i] = &wrap.Wrapper{Value: make([]byte, size)}
+		}
+		return list
+	}
+	sizesOf := func(batches []wrap.List) [][]int {
+		var result [][]int
+		for _, batch := range batches {
+			var sizes []int
+			for _, w := range batch {
+				sizes = append(sizes, len(w.Value))
+			}
+			result = append(result, sizes)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		list     wrap.List
+		maxBytes int
+		want     [][]int
+	}{
+		{
+			name:     "empty list",
+			list:     wrap.List{},
+			maxBytes: 10,
+			want:     nil,
+		},
+		{
+			name:     "single batch",
+			list:     sized(3, 3, 4),
+			maxBytes: 10,
+			want:     [][]int{{3, 3, 4}},
+		},
+		{
+			name:     "mixed sizes",
+			list:     sized(4, 5, 2, 9, 1, 1),
+			maxBytes: 10,
+			want:     [][]int{{4, 5}, {2}, {9, 1}, {1}},
+		},
+		{
+			name:     "oversized wrappers go alone",
+			list:     sized(2, 15, 3, 12, 11),
+			maxBytes: 10,
+			want:     [][]int{{2}, {15}, {3}, {12}, {11}},
+		},
+		{
+			name:     "empty values",
+			list:     sized(0, 10, 0, 0),
+			maxBytes: 10,
+			want:     [][]int{{0, 10, 0, 0}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batches := tt.list.SplitBySize(tt.maxBytes)
+			if got := sizesOf(batches); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got batches of sizes %v, want %v", got, tt.want)
+			}
+			// The wrappers must be kept in order
+			var joined wrap.List
+			for _, batch := range batches {
+				joined = append(joined, batch...)
+			}
+			for i := range joined {
+				if joined[i] != tt.list[i] {
+					t.Fatalf("wrapper %d is out of order", i)
+				}
+			}
+		})
+	}
+
+	// Appending to a batch must not clobber the next one
+	list := sized(5, 5, 5)
+	batches := list.SplitBySize(5)
+	_ = append(batches[0], &wrap.Wrapper{})
+	if batches[1][0] != list[1] {
+		t.Fatal("appending to a batch overwrote the next one")
+	}
+}
@@ -0,0 +1,91 @@
+package wrap
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// defaultZstdLevel is the level at which CompressZstd compresses values.
+const defaultZstdLevel = 3
+
+var (
+	zstdMu       sync.Mutex
+	zstdEncoders = make(map[zstd.EncoderLevel]*zstd.Encoder)
+
+	// zstdDecoders are the decoders of zstd values, by the maximum number
+	// of bytes they decode.
+	zstdDecoders = make(map[int]*zstd.Decoder)
+)
+
+// zstdEncoder returns the encoder for level, on the scale of the zstd command
+// line. Encoders can be used concurrently, so they are shared.
+func zstdEncoder(zstdLevel int) *zstd.Encoder {
+	level := zstd.EncoderLevelFromZstd(zstdLevel)
+	zstdMu.Lock()
+	defer zstdMu.Unlock()
+	encoder, ok := zstdEncoders[level]
+	if !ok {
+		// The level is always a valid one, so this does not fail
+		encoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(level))
+		zstdEncoders[level] = encoder
+	}
+	return encoder
+}
+
+// getZstdDecoder returns the decoder of zstd values which refuses to decode
+// more than max bytes. Decoders can be used concurrently, so they are shared.
+func getZstdDecoder(max int) (*zstd.Decoder, error) {
+	if max <= 0 {
+		return nil, fmt.Errorf("invalid maximum decompressed size: %d", max)
+	}
+	zstdMu.Lock()
+	defer zstdMu.Unlock()
+	if decoder, ok := zstdDecoders[max]; ok {
+		return decoder, nil
+	}
+	decoder, err := zstd.NewReader(nil, zstd.WithDecoderMaxMemory(uint64(max)))
+	if err != nil {
+		return nil, err
+	}
+	zstdDecoders[max] = decoder
+	return decoder, nil
+}
+
+// zstdCompress compresses m as a single zstd frame, at level.
+func zstdCompress(m []byte, level int) []byte {
+	return zstdEncoder(level).EncodeAll(m, nil)
+}
+
+// zstdLevel is returned, in place of an error, by CompressZstdLevel, so that
+// wrapWithOptions compresses the value at that level.
+type zstdLevel int
+
+func (zstdLevel) Error() string {
+	return "option sets the zstd compression level"
+}
+
+// zstdDecompress is the zstd counterpart of Compression.decompress.
+func zstdDecompress(m []byte, size int64, max int) ([]byte, error) {
+	decoder, err := getZstdDecoder(max)
+	if err != nil {
+		return nil, err
+	}
+	var dst []byte
+	if size > 0 && size <= int64(max) {
+		dst = make([]byte, 0, size)
+	}
+	b, err := decoder.DecodeAll(m, dst)
+	// The window size of the decoder is capped to its maximum decoded size
+	if err == zstd.ErrDecoderSizeExceeded || err == zstd.ErrWindowSizeExceeded {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrDecompressedSizeTooLarge, max)
+	}
+	if err != nil {
+		return nil, &CorruptValueError{Compression: Compression_zstd, Err: err}
+	}
+	if size > 0 && int64(len(b)) != size {
+		return nil, &CorruptValueError{Compression: Compression_zstd, Err: fmt.Errorf("decoded length %d does not match the uncompressed length %d", len(b), size)}
+	}
+	return b, nil
+}
@@ -26,6 +26,25 @@ func (client *RestClient) Delete(path string) error {
 	return nil
 }
 
+// DeleteMatching sends a DELETE request to the collection at the given path,
+// which deletes the resources whose labels match the label selector
+func (client *RestClient) DeleteMatching(path, labelSelector string) (*corev2.DeleteMatchingResult, error) {
+	res, err := client.R().SetQueryParam("labelSelector", labelSelector).Delete(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode() >= 400 {
+		return nil, UnmarshalError(res)
+	}
+
+	var result corev2.DeleteMatchingResult
+	if err := json.Unmarshal(res.Body(), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // Get sends a GET request for an object at the given path
 func (client *RestClient) Get(path string, obj interface{}) error {
 	res, err := client.R().SetResult(obj).Get(path)
@@ -164,6 +183,26 @@ func (client *RestClient) Put(path string, obj interface{}) error {
 	return nil
 }
 
+// TouchResource bumps the version of the resource at the given path without
+// changing its content, and returns its new ETag. Unless ifMatch is empty, the
+// resource is only touched if its current ETag matches ifMatch.
+func (client *RestClient) TouchResource(path, ifMatch string) (string, error) {
+	request := client.R()
+	if ifMatch != "" {
+		request.SetHeader("If-Match", ifMatch)
+	}
+	res, err := request.Put(path + "/touch")
+	if err != nil {
+		return "", err
+	}
+
+	if res.StatusCode() >= 400 {
+		return "", UnmarshalError(res)
+	}
+
+	return res.Header().Get("ETag"), nil
+}
+
 // PutResource ...
 func (client *RestClient) PutResource(r types.Wrapper) error {
 	var path string
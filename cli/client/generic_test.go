@@ -0,0 +1,69 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/cli/client/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTouchResource(t *testing.T) {
+	testHandler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/core/v2/namespaces/default/checks/foo/touch", r.URL.Path)
+		if r.Header.Get("If-Match") != `"abc"` {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			_, _ = w.Write([]byte(`{"message":"precondition failed","code":9}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"def"`)
+		_, _ = w.Write([]byte(`{}`))
+	}
+	server := httptest.NewServer(http.HandlerFunc(testHandler))
+	defer server.Close()
+
+	mockConfig := &config.MockConfig{}
+	restyInst := resty.New()
+	client := &RestClient{resty: restyInst, config: mockConfig}
+
+	mockConfig.On("APIUrl").Return(server.URL)
+	mockConfig.On("Tokens").Return(&corev2.Tokens{Access: "foo"})
+	mockConfig.On("APIKey").Return("")
+
+	path := corev2.FixtureCheckConfig("foo").URIPath()
+	etag, err := client.TouchResource(path, `"abc"`)
+	assert.NoError(t, err)
+	assert.Equal(t, `"def"`, etag)
+
+	_, err = client.TouchResource(path, `"xyz"`)
+	assert.Error(t, err)
+}
+
+func TestDeleteMatching(t *testing.T) {
+	testHandler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/api/core/v2/namespaces/default/checks", r.URL.Path)
+		assert.Equal(t, "region=us-west-2", r.URL.Query().Get("labelSelector"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"deleted":1,"errors":{"check-cpu":"unauthorized"}}`))
+	}
+	server := httptest.NewServer(http.HandlerFunc(testHandler))
+	defer server.Close()
+
+	mockConfig := &config.MockConfig{}
+	restyInst := resty.New()
+	client := &RestClient{resty: restyInst, config: mockConfig}
+
+	mockConfig.On("APIUrl").Return(server.URL)
+	mockConfig.On("Tokens").Return(&corev2.Tokens{Access: "foo"})
+	mockConfig.On("APIKey").Return("")
+
+	result, err := client.DeleteMatching(ChecksPath("default"), "region=us-west-2")
+	assert.NoError(t, err)
+	assert.Equal(t, &corev2.DeleteMatchingResult{Deleted: 1, Errors: map[string]string{"check-cpu": "unauthorized"}}, result)
+}
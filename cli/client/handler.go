@@ -70,3 +70,26 @@ func (client *RestClient) UpdateHandler(handler *corev2.Handler) (err error) {
 
 	return nil
 }
+
+// TestHandler runs the given handler against a synthetic event on the
+// configured Sensu instance, and returns the result of its execution.
+func (client *RestClient) TestHandler(name string, event *corev2.Event) (corev2.HandlerTestResult, error) {
+	var result corev2.HandlerTestResult
+	bytes, err := json.Marshal(event)
+	if err != nil {
+		return result, err
+	}
+
+	path := HandlersPath(client.config.Namespace(), name, "test")
+	res, err := client.R().SetBody(bytes).Post(path)
+	if err != nil {
+		return result, err
+	}
+
+	if res.StatusCode() >= 400 {
+		return result, UnmarshalError(res)
+	}
+
+	err = json.Unmarshal(res.Body(), &result)
+	return result, err
+}
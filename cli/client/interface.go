@@ -43,6 +43,7 @@ type APIClient interface {
 	PipelineAPIClient
 	RoleAPIClient
 	RoleBindingAPIClient
+	SearchAPIClient
 	UserAPIClient
 	SilencedAPIClient
 	GenericClient
@@ -60,6 +61,9 @@ type APIKeyClient interface {
 type GenericClient interface {
 	// Delete deletes the key with the given path
 	Delete(path string) error
+	// DeleteMatching deletes the resources of the collection at the given
+	// path whose labels match the label selector
+	DeleteMatching(path, labelSelector string) (*corev2.DeleteMatchingResult, error)
 	// Get retrieves the key at the given path and stores it into obj
 	Get(path string, obj interface{}) error
 	// List retrieves all keys with the given path prefix and stores them into objs
@@ -73,6 +77,10 @@ type GenericClient interface {
 
 	// PutResource puts a resource according to its URIPath.
 	PutResource(types.Wrapper) error
+
+	// TouchResource bumps the version of the resource at the given path
+	// without changing its content, and returns its new ETag.
+	TouchResource(path, ifMatch string) (string, error)
 }
 
 // AuthenticationAPIClient client methods for authenticating
@@ -148,6 +156,7 @@ type HandlerAPIClient interface {
 	DeleteHandler(string, string) error
 	FetchHandler(string) (*corev2.Handler, error)
 	UpdateHandler(*corev2.Handler) error
+	TestHandler(string, *corev2.Event) (corev2.HandlerTestResult, error)
 }
 
 // HealthAPIClient client methods for health api
@@ -174,9 +183,11 @@ type MutatorAPIClient interface {
 // NamespaceAPIClient client methods for namespaces
 type NamespaceAPIClient interface {
 	CreateNamespace(*corev2.Namespace) error
-	UpdateNamespace(*corev2.Namespace) error
-	DeleteNamespace(string) error
+	UpdateNamespace(namespace *corev2.Namespace, ifMatch string) error
+	DeleteNamespace(namespace, ifMatch string) error
 	FetchNamespace(string) (*corev2.Namespace, error)
+	FetchNamespaces(names []string) (*corev2.NamespaceFetchResult, error)
+	ListNamespacesWithCounts() ([]corev2.NamespaceWithCounts, error)
 }
 
 // PipelineAPIClient client methods for pipelines
@@ -213,14 +224,28 @@ type RoleBindingAPIClient interface {
 	FetchRoleBinding(string) (*corev2.RoleBinding, error)
 }
 
+// SearchAPIClient client methods for searching resources
+type SearchAPIClient interface {
+	Search(namespace, query string, types []string) ([]corev2.SearchResult, error)
+}
+
 // SilencedAPIClient client methods for silenced
 type SilencedAPIClient interface {
 	// CreateSilenced creates a new silenced entry from its input.
 	CreateSilenced(*corev2.Silenced) error
 
+	// CreateSilencedBatch creates the given silenced entries in a namespace,
+	// and returns the result of the creation of each of them. If strict is
+	// true, entries that overlap with other entries are not created.
+	CreateSilencedBatch(namespace string, entries []*corev2.Silenced, strict bool) ([]corev2.SilencedBatchResult, error)
+
 	// DeleteSilenced deletes an existing silenced entry given its ID.
 	DeleteSilenced(namespace string, name string) error
 
+	// DeleteSilencedByFilter deletes the silenced entries matching the given
+	// subscription and check, and returns how many were deleted.
+	DeleteSilencedByFilter(namespace, subscription, checkName string) (int, error)
+
 	// ListSilenceds lists all silenced entries, optionally constraining by
 	// subscription or check.
 	ListSilenceds(namespace, subscription, check string, options *ListOptions, header *http.Header) ([]types.Silenced, error)
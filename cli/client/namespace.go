@@ -2,6 +2,7 @@ package client
 
 import (
 	"encoding/json"
+	"strings"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 )
@@ -30,15 +31,21 @@ func (client *RestClient) CreateNamespace(namespace *corev2.Namespace) error {
 	return nil
 }
 
-// UpdateNamespace updates given namespace on a configured Sensu instance
-func (client *RestClient) UpdateNamespace(namespace *corev2.Namespace) error {
+// UpdateNamespace updates given namespace on a configured Sensu instance. If
+// ifMatch is not empty, the namespace is only updated if its current etag
+// matches it.
+func (client *RestClient) UpdateNamespace(namespace *corev2.Namespace, ifMatch string) error {
 	bytes, err := json.Marshal(namespace)
 	if err != nil {
 		return err
 	}
 
 	path := NamespacesPath(namespace.Name)
-	res, err := client.R().SetBody(bytes).Put(path)
+	req := client.R().SetBody(bytes)
+	if ifMatch != "" {
+		req.SetHeader("If-Match", ifMatch)
+	}
+	res, err := req.Put(path)
 	if err != nil {
 		return err
 	}
@@ -50,9 +57,24 @@ func (client *RestClient) UpdateNamespace(namespace *corev2.Namespace) error {
 	return nil
 }
 
-// DeleteNamespace deletes an namespace on configured Sensu instance
-func (client *RestClient) DeleteNamespace(namespace string) error {
-	return client.Delete(NamespacesPath(namespace))
+// DeleteNamespace deletes an namespace on configured Sensu instance. If
+// ifMatch is not empty, the namespace is only deleted if its current etag
+// matches it.
+func (client *RestClient) DeleteNamespace(namespace, ifMatch string) error {
+	req := client.R()
+	if ifMatch != "" {
+		req.SetHeader("If-Match", ifMatch)
+	}
+	res, err := req.Delete(NamespacesPath(namespace))
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode() >= 400 {
+		return UnmarshalError(res)
+	}
+
+	return nil
 }
 
 // FetchNamespace fetches an namespace by name
@@ -72,3 +94,55 @@ func (client *RestClient) FetchNamespace(namespaceName string) (*corev2.Namespac
 	err = json.Unmarshal(res.Body(), &namespace)
 	return namespace, err
 }
+
+// FetchNamespaces fetches several namespaces by name in a single request. The
+// result also lists the names of the namespaces that were not found.
+func (client *RestClient) FetchNamespaces(names []string) (*corev2.NamespaceFetchResult, error) {
+	var result *corev2.NamespaceFetchResult
+
+	path := NamespacesPath()
+	res, err := client.R().SetQueryParam("names", strings.Join(names, ",")).Get(path)
+	if err != nil {
+		return result, err
+	}
+
+	if res.StatusCode() >= 400 {
+		return result, UnmarshalError(res)
+	}
+
+	err = json.Unmarshal(res.Body(), &result)
+	return result, err
+}
+
+// ListNamespacesWithCounts lists the namespaces along with the number of
+// resources of each type they contain, counted by the backend.
+func (client *RestClient) ListNamespacesWithCounts() ([]corev2.NamespaceWithCounts, error) {
+	path := NamespacesPath()
+	results := []corev2.NamespaceWithCounts{}
+	continueToken := ""
+	for {
+		request := client.R().SetQueryParam("counts", "true")
+		if continueToken != "" {
+			request.SetQueryParam("continue", continueToken)
+		}
+
+		res, err := request.Get(path)
+		if err != nil {
+			return nil, err
+		}
+		if res.StatusCode() >= 400 {
+			return nil, UnmarshalError(res)
+		}
+
+		var page []corev2.NamespaceWithCounts
+		if err := json.Unmarshal(res.Body(), &page); err != nil {
+			return nil, err
+		}
+		results = append(results, page...)
+
+		continueToken = res.Header().Get(corev2.PaginationContinueHeader)
+		if continueToken == "" {
+			return results, nil
+		}
+	}
+}
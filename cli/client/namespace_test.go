@@ -0,0 +1,72 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/cli/client/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchNamespaces(t *testing.T) {
+	testHandler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/core/v2/namespaces", r.URL.Path)
+		assert.Equal(t, "dev,missing", r.URL.Query().Get("names"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"namespaces":[{"name":"dev"}],"missing":["missing"]}`))
+	}
+	server := httptest.NewServer(http.HandlerFunc(testHandler))
+	defer server.Close()
+
+	mockConfig := &config.MockConfig{}
+	restyInst := resty.New()
+	client := &RestClient{resty: restyInst, config: mockConfig}
+
+	mockConfig.On("APIUrl").Return(server.URL)
+	mockConfig.On("Tokens").Return(&corev2.Tokens{Access: "foo"})
+	mockConfig.On("APIKey").Return("")
+
+	result, err := client.FetchNamespaces([]string{"dev", "missing"})
+	assert.NoError(t, err)
+	assert.Equal(t, &corev2.NamespaceFetchResult{
+		Namespaces: []*corev2.Namespace{{Name: "dev"}},
+		Missing:    []string{"missing"},
+	}, result)
+}
+
+func TestListNamespacesWithCounts(t *testing.T) {
+	testHandler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/core/v2/namespaces", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("counts"))
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("continue") == "" {
+			w.Header().Set(corev2.PaginationContinueHeader, "prod")
+			_, _ = w.Write([]byte(`[{"namespace":{"name":"dev"},"counts":{"checks":3}}]`))
+			return
+		}
+		assert.Equal(t, "prod", r.URL.Query().Get("continue"))
+		_, _ = w.Write([]byte(`[{"namespace":{"name":"prod"},"counts":{"checks":5}}]`))
+	}
+	server := httptest.NewServer(http.HandlerFunc(testHandler))
+	defer server.Close()
+
+	mockConfig := &config.MockConfig{}
+	restyInst := resty.New()
+	client := &RestClient{resty: restyInst, config: mockConfig}
+
+	mockConfig.On("APIUrl").Return(server.URL)
+	mockConfig.On("Tokens").Return(&corev2.Tokens{Access: "foo"})
+	mockConfig.On("APIKey").Return("")
+
+	result, err := client.ListNamespacesWithCounts()
+	assert.NoError(t, err)
+	assert.Equal(t, []corev2.NamespaceWithCounts{
+		{Namespace: &corev2.Namespace{Name: "dev"}, Counts: map[string]int64{"checks": 3}},
+		{Namespace: &corev2.Namespace{Name: "prod"}, Counts: map[string]int64{"checks": 5}},
+	}, result)
+}
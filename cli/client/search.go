@@ -0,0 +1,48 @@
+package client
+
+import (
+	"encoding/json"
+	"strings"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// SearchPath is the api path for searching resources.
+var SearchPath = createNSBasePath(coreAPIGroup, coreAPIVersion, "search")
+
+// Search returns the resources of the namespace whose name contains the query,
+// among the given types of resources, or all of the searchable types if none
+// is given. The results are fetched a page at a time.
+func (client *RestClient) Search(namespace, query string, types []string) ([]corev2.SearchResult, error) {
+	path := SearchPath(namespace)
+	results := []corev2.SearchResult{}
+	continueToken := ""
+	for {
+		request := client.R().SetQueryParam("q", query)
+		if len(types) > 0 {
+			request.SetQueryParam("types", strings.Join(types, ","))
+		}
+		if continueToken != "" {
+			request.SetQueryParam("continue", continueToken)
+		}
+
+		res, err := request.Get(path)
+		if err != nil {
+			return nil, err
+		}
+		if res.StatusCode() >= 400 {
+			return nil, UnmarshalError(res)
+		}
+
+		var page []corev2.SearchResult
+		if err := json.Unmarshal(res.Body(), &page); err != nil {
+			return nil, err
+		}
+		results = append(results, page...)
+
+		continueToken = res.Header().Get(corev2.PaginationContinueHeader)
+		if continueToken == "" {
+			return results, nil
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/cli/client/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearch(t *testing.T) {
+	testHandler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/core/v2/namespaces/default/search", r.URL.Path)
+		assert.Equal(t, "cpu", r.URL.Query().Get("q"))
+		assert.Equal(t, "checks,entities", r.URL.Query().Get("types"))
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("continue") == "" {
+			w.Header().Set(corev2.PaginationContinueHeader, base64.RawURLEncoding.EncodeToString([]byte("checks/check-cpu")))
+			_, _ = w.Write([]byte(`[{"type":"checks","name":"check-cpu","namespace":"default"}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"type":"entities","name":"cpu-host","namespace":"default"}]`))
+	}
+	server := httptest.NewServer(http.HandlerFunc(testHandler))
+	defer server.Close()
+
+	mockConfig := &config.MockConfig{}
+	restyInst := resty.New()
+	client := &RestClient{resty: restyInst, config: mockConfig}
+
+	mockConfig.On("APIUrl").Return(server.URL)
+	mockConfig.On("Tokens").Return(&corev2.Tokens{Access: "foo"})
+	mockConfig.On("APIKey").Return("")
+
+	results, err := client.Search("default", "cpu", []string{"checks", "entities"})
+	assert.NoError(t, err)
+	assert.Equal(t, []corev2.SearchResult{
+		{Type: "checks", Name: "check-cpu", Namespace: "default"},
+		{Type: "entities", Name: "cpu-host", Namespace: "default"},
+	}, results)
+}
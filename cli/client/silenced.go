@@ -29,11 +29,62 @@ func (client *RestClient) CreateSilenced(silenced *corev2.Silenced) error {
 	return nil
 }
 
+// CreateSilencedBatch creates many silenced entries at once.
+func (client *RestClient) CreateSilencedBatch(namespace string, entries []*corev2.Silenced, strict bool) ([]corev2.SilencedBatchResult, error) {
+	b, err := json.Marshal(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	path := silencedPath(namespace, "batch")
+	request := client.R().SetBody(b)
+	if strict {
+		request.SetQueryParam("strict", "true")
+	}
+	res, err := request.Post(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode() >= 400 {
+		return nil, UnmarshalError(res)
+	}
+
+	var results []corev2.SilencedBatchResult
+	err = json.Unmarshal(res.Body(), &results)
+	return results, err
+}
+
 // DeleteSilenced deletes a silenced entry.
 func (client *RestClient) DeleteSilenced(namespace, name string) error {
 	return client.Delete(silencedPath(namespace, name))
 }
 
+// DeleteSilencedByFilter deletes the silenced entries of a namespace that
+// match a subscription and a check, and returns how many were deleted.
+// Wildcard entries that match the filter are deleted as well.
+func (client *RestClient) DeleteSilencedByFilter(namespace, subscription, checkName string) (int, error) {
+	request := client.R()
+	if subscription != "" {
+		request.SetQueryParam("subscription", subscription)
+	}
+	if checkName != "" {
+		request.SetQueryParam("check", checkName)
+	}
+	res, err := request.Delete(silencedPath(namespace))
+	if err != nil {
+		return 0, err
+	}
+
+	if res.StatusCode() >= 400 {
+		return 0, UnmarshalError(res)
+	}
+
+	var result corev2.SilencedDeleteResult
+	err = json.Unmarshal(res.Body(), &result)
+	return result.Deleted, err
+}
+
 // ListSilenceds fetches all silenced entries from configured Sensu instance
 func (client *RestClient) ListSilenceds(namespace, sub, check string, options *ListOptions, header *http.Header) ([]corev2.Silenced, error) {
 	if sub != "" && check != "" {
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/go-resty/resty/v2"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/cli/client"
 	"github.com/sensu/sensu-go/types"
 )
@@ -14,6 +15,13 @@ func (c *MockClient) Delete(path string) error {
 	return args.Error(0)
 }
 
+// DeleteMatching ...
+func (c *MockClient) DeleteMatching(path, labelSelector string) (*corev2.DeleteMatchingResult, error) {
+	args := c.Called(path, labelSelector)
+	result, _ := args.Get(0).(*corev2.DeleteMatchingResult)
+	return result, args.Error(1)
+}
+
 // Get ...
 func (c *MockClient) Get(path string, obj interface{}) error {
 	args := c.Called(path, obj)
@@ -49,3 +57,9 @@ func (c *MockClient) PutResource(r types.Wrapper) error {
 	args := c.Called(r)
 	return args.Error(0)
 }
+
+// TouchResource ...
+func (c *MockClient) TouchResource(path, ifMatch string) (string, error) {
+	args := c.Called(path, ifMatch)
+	return args.String(0), args.Error(1)
+}
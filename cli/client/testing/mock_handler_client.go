@@ -27,3 +27,9 @@ func (c *MockClient) UpdateHandler(h *corev2.Handler) error {
 	args := c.Called(h)
 	return args.Error(0)
 }
+
+// TestHandler for use with mock lib
+func (c *MockClient) TestHandler(name string, event *corev2.Event) (corev2.HandlerTestResult, error) {
+	args := c.Called(name, event)
+	return args.Get(0).(corev2.HandlerTestResult), args.Error(1)
+}
@@ -11,14 +11,14 @@ func (c *MockClient) CreateNamespace(namespace *corev2.Namespace) error {
 }
 
 // UpdateNamespace for use with mock lib
-func (c *MockClient) UpdateNamespace(namespace *corev2.Namespace) error {
-	args := c.Called(namespace)
+func (c *MockClient) UpdateNamespace(namespace *corev2.Namespace, ifMatch string) error {
+	args := c.Called(namespace, ifMatch)
 	return args.Error(0)
 }
 
 // DeleteNamespace for use with mock lib
-func (c *MockClient) DeleteNamespace(namespace string) error {
-	args := c.Called(namespace)
+func (c *MockClient) DeleteNamespace(namespace, ifMatch string) error {
+	args := c.Called(namespace, ifMatch)
 	return args.Error(0)
 }
 
@@ -27,3 +27,15 @@ func (c *MockClient) FetchNamespace(namespace string) (*corev2.Namespace, error)
 	args := c.Called(namespace)
 	return args.Get(0).(*corev2.Namespace), args.Error(1)
 }
+
+// FetchNamespaces for use with mock lib
+func (c *MockClient) FetchNamespaces(names []string) (*corev2.NamespaceFetchResult, error) {
+	args := c.Called(names)
+	return args.Get(0).(*corev2.NamespaceFetchResult), args.Error(1)
+}
+
+// ListNamespacesWithCounts for use with mock lib
+func (c *MockClient) ListNamespacesWithCounts() ([]corev2.NamespaceWithCounts, error) {
+	args := c.Called()
+	return args.Get(0).([]corev2.NamespaceWithCounts), args.Error(1)
+}
@@ -0,0 +1,9 @@
+package testing
+
+import corev2 "github.com/sensu/sensu-go/api/core/v2"
+
+// Search for use with mock lib
+func (c *MockClient) Search(namespace, query string, types []string) ([]corev2.SearchResult, error) {
+	args := c.Called(namespace, query, types)
+	return args.Get(0).([]corev2.SearchResult), args.Error(1)
+}
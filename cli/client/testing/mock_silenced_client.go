@@ -15,6 +15,12 @@ func (c *MockClient) CreateSilenced(silenced *types.Silenced) error {
 	return args.Error(0)
 }
 
+// CreateSilencedBatch for use with mock lib
+func (c *MockClient) CreateSilencedBatch(namespace string, entries []*corev2.Silenced, strict bool) ([]corev2.SilencedBatchResult, error) {
+	args := c.Called(namespace, entries, strict)
+	return args.Get(0).([]corev2.SilencedBatchResult), args.Error(1)
+}
+
 // UpdateSilenced for use with mock lib
 func (c *MockClient) UpdateSilenced(silenced *types.Silenced) error {
 	args := c.Called(silenced)
@@ -27,6 +33,12 @@ func (c *MockClient) DeleteSilenced(namespace, name string) error {
 	return args.Error(0)
 }
 
+// DeleteSilencedByFilter for use with mock lib
+func (c *MockClient) DeleteSilencedByFilter(namespace, subscription, checkName string) (int, error) {
+	args := c.Called(namespace, subscription, checkName)
+	return args.Int(0), args.Error(1)
+}
+
 // FetchSilenced for use with mock lib
 func (c *MockClient) FetchSilenced(id string) (*types.Silenced, error) {
 	args := c.Called(id)
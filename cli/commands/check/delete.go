@@ -3,8 +3,10 @@ package check
 import (
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/client"
 	"github.com/sensu/sensu-go/cli/commands/helpers"
 	"github.com/spf13/cobra"
 )
@@ -13,9 +15,13 @@ import (
 func DeleteCommand(cli *cli.SensuCli) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:          "delete [NAME]",
-		Short:        "delete checks given name",
+		Short:        "delete checks given name, or matching labels",
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if labels, _ := cmd.Flags().GetString("labels"); labels != "" {
+				return deleteMatching(cli, cmd, args, labels)
+			}
+
 			// If no name is present print out usage
 			if len(args) != 1 {
 				_ = cmd.Help()
@@ -43,6 +49,44 @@ func DeleteCommand(cli *cli.SensuCli) *cobra.Command {
 	}
 
 	cmd.Flags().Bool("skip-confirm", false, "skip interactive confirmation prompt")
+	cmd.Flags().String("labels", "", "delete all the checks whose labels match the selector, as comma-separated key=value pairs")
 
 	return cmd
 }
+
+// deleteMatching deletes all the checks whose labels match the label
+// selector.
+func deleteMatching(cli *cli.SensuCli, cmd *cobra.Command, args []string, labels string) error {
+	if len(args) > 0 {
+		_ = cmd.Help()
+		return errors.New("a name cannot be given along with --labels")
+	}
+	namespace := cli.Config.Namespace()
+
+	if skipConfirm, _ := cmd.Flags().GetBool("skip-confirm"); !skipConfirm {
+		confirm := &helpers.ConfirmDestructiveOp{Type: "checks matching", Op: "delete"}
+		if confirmed, _ := confirm.Ask(labels); !confirmed {
+			fmt.Fprintln(cmd.OutOrStdout(), "Canceled")
+			return nil
+		}
+	}
+
+	result, err := cli.Client.DeleteMatching(client.ChecksPath(namespace), labels)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Deleted %d checks\n", result.Deleted)
+	if len(result.Errors) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(result.Errors))
+	for name := range result.Errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(cmd.OutOrStdout(), "Could not delete check %s: %s\n", name, result.Errors[name])
+	}
+	return fmt.Errorf("%d checks could not be deleted", len(names))
+}
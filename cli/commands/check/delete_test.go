@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	client "github.com/sensu/sensu-go/cli/client/testing"
 	test "github.com/sensu/sensu-go/cli/commands/testing"
 	"github.com/stretchr/testify/assert"
@@ -78,3 +79,48 @@ func TestDeleteCommandRunEFailConfirm(t *testing.T) {
 
 	assert.Contains(out, "Canceled")
 }
+
+func TestDeleteCommandRunEClosureWithLabels(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := test.NewMockCLI()
+	client := cli.Client.(*client.MockClient)
+	result := &corev2.DeleteMatchingResult{Deleted: 2}
+	client.On("DeleteMatching", "/api/core/v2/namespaces/default/checks", "region=us-west-2").Return(result, nil)
+
+	cmd := DeleteCommand(cli)
+	require.NoError(t, cmd.Flags().Set("skip-confirm", "t"))
+	require.NoError(t, cmd.Flags().Set("labels", "region=us-west-2"))
+	out, err := test.RunCmd(cmd, []string{})
+
+	assert.NoError(err)
+	assert.Contains(out, "Deleted 2 checks")
+}
+
+func TestDeleteCommandRunEClosureWithLabelsErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := test.NewMockCLI()
+	client := cli.Client.(*client.MockClient)
+	result := &corev2.DeleteMatchingResult{Deleted: 1, Errors: map[string]string{"check-cpu": "unauthorized"}}
+	client.On("DeleteMatching", mock.Anything, "region=us-west-2").Return(result, nil)
+
+	cmd := DeleteCommand(cli)
+	require.NoError(t, cmd.Flags().Set("skip-confirm", "t"))
+	require.NoError(t, cmd.Flags().Set("labels", "region=us-west-2"))
+	out, err := test.RunCmd(cmd, []string{})
+
+	assert.Error(err)
+	assert.Contains(out, "Deleted 1 checks")
+	assert.Contains(out, "Could not delete check check-cpu: unauthorized")
+}
+
+func TestDeleteCommandRunEClosureWithLabelsAndName(t *testing.T) {
+	cli := test.NewMockCLI()
+	cmd := DeleteCommand(cli)
+	require.NoError(t, cmd.Flags().Set("skip-confirm", "t"))
+	require.NoError(t, cmd.Flags().Set("labels", "region=us-west-2"))
+	_, err := test.RunCmd(cmd, []string{"my-check"})
+
+	assert.Error(t, err)
+}
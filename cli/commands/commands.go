@@ -13,6 +13,7 @@ import (
 	"github.com/sensu/sensu-go/cli/commands/config"
 	"github.com/sensu/sensu-go/cli/commands/configure"
 	"github.com/sensu/sensu-go/cli/commands/create"
+	"github.com/sensu/sensu-go/cli/commands/debug"
 	"github.com/sensu/sensu-go/cli/commands/delete"
 	"github.com/sensu/sensu-go/cli/commands/describetype"
 	"github.com/sensu/sensu-go/cli/commands/dump"
@@ -32,6 +33,7 @@ import (
 	"github.com/sensu/sensu-go/cli/commands/silenced"
 	"github.com/sensu/sensu-go/cli/commands/tessen"
 	"github.com/sensu/sensu-go/cli/commands/user"
+	"github.com/sensu/sensu-go/cli/commands/validate"
 	"github.com/spf13/cobra"
 )
 
@@ -68,8 +70,10 @@ func AddCommands(rootCmd *cobra.Command, cli *cli.SensuCli) {
 		edit.Command(cli),
 		tessen.HelpCommand(cli),
 		dump.Command(cli),
+		debug.HelpCommand(cli),
 		command.HelpCommand(cli),
 		describetype.Command(cli),
+		validate.Command(cli),
 	)
 
 	for _, cmd := range rootCmd.Commands() {
@@ -0,0 +1,23 @@
+package debug
+
+import (
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/commands/helpers"
+	"github.com/spf13/cobra"
+)
+
+// HelpCommand defines new parent
+func HelpCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Inspect the internals of the backend for debugging",
+		RunE:  helpers.DefaultSubCommandRunE,
+	}
+
+	// Add sub-commands
+	cmd.AddCommand(
+		WrapperCommand(cli),
+	)
+
+	return cmd
+}
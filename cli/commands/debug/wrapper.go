@@ -0,0 +1,116 @@
+package debug
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/backend/store/v2/wrap"
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/elements/list"
+	"github.com/spf13/cobra"
+)
+
+// wrappersPath is the path of the API returning raw store wrappers
+const wrappersPath = "/api/core/v2/wrappers"
+
+// WrapperCommand prints the raw store wrapper of a key, and the resource it
+// wraps
+func WrapperCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "wrapper [KEY]",
+		Short:        "show the raw store wrapper of a key, e.g. /sensu.io/entity_configs/default/foo, and the resource it wraps",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				_ = cmd.Help()
+				return errors.New("a store key is required")
+			}
+			key := args[0]
+
+			var wrapper wrap.Wrapper
+			if err := cli.Client.Get(wrappersPath+"?key="+url.QueryEscape(key), &wrapper); err != nil {
+				return err
+			}
+			return printWrapper(key, &wrapper, cmd.OutOrStdout())
+		},
+	}
+
+	return cmd
+}
+
+// printWrapper prints the envelope of the wrapper, and as much of the wrapped
+// resource as can be decoded, so that corrupt wrappers can still be inspected.
+func printWrapper(key string, w *wrap.Wrapper, out io.Writer) error {
+	var typeMeta corev2.TypeMeta
+	if w.TypeMeta != nil {
+		typeMeta = *w.TypeMeta
+	}
+	rows := []*list.Row{
+		{Label: "Key", Value: key},
+		{Label: "Type", Value: typeMeta.Type},
+		{Label: "API Version", Value: typeMeta.APIVersion},
+		{Label: "Encoding", Value: w.Encoding.String()},
+		{Label: "Compression", Value: w.Compression.String()},
+		{Label: "Content Type", Value: w.ContentType},
+		{Label: "Class", Value: w.Class.String()},
+		{Label: "Stored Size", Value: strconv.Itoa(len(w.Value))},
+		{Label: "Uncompressed Size", Value: strconv.FormatInt(w.UncompressedLen, 10)},
+		{Label: "Schema Fingerprint", Value: w.SchemaFingerprint},
+		{Label: "Display Name", Value: w.DisplayName},
+		{Label: "Description", Value: w.Description},
+		{Label: "ETag", Value: w.ETag},
+	}
+
+	var errs []string
+	if complete, err := w.IsComplete(); err != nil {
+		errs = append(errs, fmt.Sprintf("checking completeness: %s", err))
+	} else {
+		rows = append(rows, &list.Row{Label: "Complete", Value: strconv.FormatBool(complete)})
+	}
+	if meta, err := w.Metadata(); err != nil {
+		errs = append(errs, fmt.Sprintf("decoding metadata: %s", err))
+	} else if meta != nil {
+		rows = append(rows,
+			&list.Row{Label: "Created By", Value: meta.CreatedBy},
+			&list.Row{Label: "Updated At", Value: meta.Annotations[corev2.UpdatedAtAnnotation]},
+		)
+	}
+	if resource, err := w.Unwrap(); err != nil {
+		errs = append(errs, fmt.Sprintf("unwrapping: %s", err))
+	} else if etag, err := store.ETag(resource); err != nil {
+		errs = append(errs, fmt.Sprintf("computing the ETag: %s", err))
+	} else {
+		rows = append(rows, &list.Row{Label: "ETag", Value: etag})
+	}
+
+	var resource bytes.Buffer
+	if value, err := w.MarshalJSONValue(); err != nil {
+		errs = append(errs, fmt.Sprintf("decoding value: %s", err))
+	} else if err := json.Indent(&resource, value, "", "  "); err != nil {
+		// Print the value as is, it may still be useful
+		resource.Write(value)
+	}
+
+	for _, err := range errs {
+		rows = append(rows, &list.Row{Label: "Error", Value: err})
+	}
+	cfg := &list.Config{
+		Title: "Store Wrapper",
+		Rows:  rows,
+	}
+	if err := list.Print(out, cfg); err != nil {
+		return err
+	}
+	if resource.Len() > 0 {
+		_, err := fmt.Fprintf(out, "\nResource:\n%s\n", resource.String())
+		return err
+	}
+	return nil
+}
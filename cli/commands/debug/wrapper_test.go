@@ -0,0 +1,79 @@
+package debug
+
+import (
+	"testing"
+
+	corev3 "github.com/sensu/sensu-go/api/core/v3"
+	"github.com/sensu/sensu-go/backend/store/v2/wrap"
+	client "github.com/sensu/sensu-go/cli/client/testing"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapperCommand(t *testing.T) {
+	cli := test.NewCLI()
+	cmd := WrapperCommand(cli)
+
+	assert.NotNil(t, cmd, "cmd should be returned")
+	assert.NotNil(t, cmd.RunE, "cmd should be able to be executed")
+	assert.Regexp(t, "wrapper", cmd.Use)
+}
+
+func TestWrapperCommandWithoutKey(t *testing.T) {
+	cli := test.NewCLI()
+	cmd := WrapperCommand(cli)
+	out, err := test.RunCmd(cmd, []string{})
+	require.Error(t, err)
+	assert.Contains(t, out, "Usage")
+}
+
+func TestWrapperCommandRunEClosure(t *testing.T) {
+	entity := corev3.FixtureEntityConfig("foo")
+	complete, err := wrap.Resource(entity, wrap.EncodeJSON)
+	require.NoError(t, err)
+	truncated, err := wrap.Resource(entity, wrap.EncodeProtobuf, wrap.CompressSnappy)
+	require.NoError(t, err)
+	truncated.Value = truncated.Value[:len(truncated.Value)/2]
+
+	tests := []struct {
+		name     string
+		wrapper  *wrap.Wrapper
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "complete wrapper",
+			wrapper:  complete,
+			contains: []string{"EntityConfig", "json", "ETag", "Resource:", `"entity_class": "agent"`},
+			excludes: []string{"Error"},
+		},
+		{
+			name:     "truncated wrapper",
+			wrapper:  truncated,
+			contains: []string{"EntityConfig", "snappy", "Complete", "false", "Error", "unwrapping"},
+			excludes: []string{"Resource:"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := test.NewCLI()
+			client := cli.Client.(*client.MockClient)
+			client.On("Get", "/api/core/v2/wrappers?key=%2Fsensu.io%2Fentity_configs%2Fdefault%2Ffoo", mock.Anything).
+				Run(func(args mock.Arguments) {
+					*args.Get(1).(*wrap.Wrapper) = *tt.wrapper
+				}).Return(nil)
+
+			cmd := WrapperCommand(cli)
+			out, err := test.RunCmd(cmd, []string{"/sensu.io/entity_configs/default/foo"})
+			require.NoError(t, err)
+			for _, s := range tt.contains {
+				assert.Contains(t, out, s)
+			}
+			for _, s := range tt.excludes {
+				assert.NotContains(t, out, s)
+			}
+		})
+	}
+}
@@ -8,6 +8,12 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// askUpdateQuestions asks the user for the new definition of the handler being
+// updated. It is replaced in tests, which have no terminal to answer it.
+var askUpdateQuestions = func(opts *handlerOpts) error {
+	return opts.administerQuestionnaire(true)
+}
+
 // UpdateCommand allows the user to update handlers
 func UpdateCommand(cli *cli.SensuCli) *cobra.Command {
 	cmd := &cobra.Command{
@@ -28,10 +34,14 @@ func UpdateCommand(cli *cli.SensuCli) *cobra.Command {
 				return err
 			}
 
+			// Copy only replaces the fields of the handler, so a shallow copy
+			// keeps the current definition intact
+			current := *handler
+
 			opts := newHandlerOpts()
 			opts.withHandler(handler)
 
-			if err := opts.administerQuestionnaire(true); err != nil {
+			if err := askUpdateQuestions(opts); err != nil {
 				return err
 			}
 
@@ -41,6 +51,13 @@ func UpdateCommand(cli *cli.SensuCli) *cobra.Command {
 				return err
 			}
 
+			// Skip the update when nothing changed, so that its UpdatedAt is
+			// not bumped for nothing
+			if ifChanged, _ := cmd.Flags().GetBool("if-changed"); ifChanged && handler.Equal(&current) {
+				fmt.Fprintln(cmd.OutOrStdout(), "No changes")
+				return nil
+			}
+
 			if err := cli.Client.UpdateHandler(handler); err != nil {
 				return err
 			}
@@ -50,5 +67,7 @@ func UpdateCommand(cli *cli.SensuCli) *cobra.Command {
 		},
 	}
 
+	cmd.Flags().Bool("if-changed", false, "only update the handler if its definition changed")
+
 	return cmd
 }
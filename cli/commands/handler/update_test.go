@@ -9,6 +9,7 @@ import (
 	"github.com/sensu/sensu-go/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUpdateCommand(t *testing.T) {
@@ -61,3 +62,36 @@ func TestUpdateCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateCommandIfChangedFlag(t *testing.T) {
+	cmd := UpdateCommand(test.NewMockCLI())
+	flag := cmd.Flag("if-changed")
+	assert.NotNil(t, flag)
+	assert.Equal(t, "false", flag.DefValue)
+}
+
+func TestUpdateCommandIfChangedUnchanged(t *testing.T) {
+	ask := askUpdateQuestions
+	defer func() { askUpdateQuestions = ask }()
+	// Keep every answer as is
+	askUpdateQuestions = func(*handlerOpts) error { return nil }
+
+	cli := test.NewMockCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("FetchHandler", "foo").Return(types.FixtureHandler("foo"), nil)
+	client.On("UpdateHandler", mock.Anything).Return(nil)
+
+	cmd := UpdateCommand(cli)
+	require.NoError(t, cmd.Flags().Set("if-changed", "true"))
+	out, err := test.RunCmd(cmd, []string{"foo"})
+	require.NoError(t, err)
+	assert.Contains(t, out, "No changes")
+	client.AssertNotCalled(t, "UpdateHandler", mock.Anything)
+
+	// Without the flag, the unchanged handler is still updated
+	cmd = UpdateCommand(cli)
+	out, err = test.RunCmd(cmd, []string{"foo"})
+	require.NoError(t, err)
+	assert.Contains(t, out, "Updated")
+	client.AssertCalled(t, "UpdateHandler", mock.Anything)
+}
@@ -31,7 +31,8 @@ func DeleteCommand(cli *cli.SensuCli) *cobra.Command {
 				}
 			}
 
-			err := cli.Client.DeleteNamespace(namespace)
+			ifMatch, _ := cmd.Flags().GetString("if-match")
+			err := cli.Client.DeleteNamespace(namespace, ifMatch)
 			if err != nil {
 				return err
 			}
@@ -42,6 +43,7 @@ func DeleteCommand(cli *cli.SensuCli) *cobra.Command {
 	}
 
 	_ = cmd.Flags().Bool("skip-confirm", false, "skip interactive confirmation prompt")
+	_ = cmd.Flags().String("if-match", "", "only delete the namespace if its current etag matches this one")
 
 	return cmd
 }
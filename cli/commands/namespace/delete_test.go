@@ -40,7 +40,7 @@ func TestDeleteCommandRunEClosureWithFlags(t *testing.T) {
 
 	cli := test.NewMockCLI()
 	client := cli.Client.(*client.MockClient)
-	client.On("DeleteNamespace", "foo").Return(nil)
+	client.On("DeleteNamespace", "foo", "").Return(nil)
 
 	cmd := DeleteCommand(cli)
 	require.NoError(t, cmd.Flags().Set("skip-confirm", "t"))
@@ -50,12 +50,28 @@ func TestDeleteCommandRunEClosureWithFlags(t *testing.T) {
 	assert.Nil(err)
 }
 
+func TestDeleteCommandRunEClosureWithIfMatch(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := test.NewMockCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("DeleteNamespace", "foo", `"abc"`).Return(nil)
+
+	cmd := DeleteCommand(cli)
+	require.NoError(t, cmd.Flags().Set("skip-confirm", "t"))
+	require.NoError(t, cmd.Flags().Set("if-match", `"abc"`))
+	out, err := test.RunCmd(cmd, []string{"foo"})
+
+	assert.Regexp("Deleted", out)
+	assert.Nil(err)
+}
+
 func TestDeleteCommandRunEClosureWithServerErr(t *testing.T) {
 	assert := assert.New(t)
 
 	cli := test.NewMockCLI()
 	client := cli.Client.(*client.MockClient)
-	client.On("DeleteNamespace", "bar").Return(errors.New("oh noes"))
+	client.On("DeleteNamespace", "bar", "").Return(errors.New("oh noes"))
 
 	cmd := DeleteCommand(cli)
 	require.NoError(t, cmd.Flags().Set("skip-confirm", "t"))
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/cli"
 	"github.com/sensu/sensu-go/cli/commands/helpers"
 	"github.com/spf13/cobra"
@@ -21,6 +22,9 @@ func DeleteCommand(cli *cli.SensuCli) *cobra.Command {
 				_ = cmd.Help()
 				return errors.New("invalid argument(s) received")
 			}
+			if all, _ := cmd.Flags().GetBool("all"); all {
+				return deleteAll(cli, cmd, args)
+			}
 			name, err := getName(cmd, args)
 			if err != nil {
 				return err
@@ -44,9 +48,42 @@ func DeleteCommand(cli *cli.SensuCli) *cobra.Command {
 		},
 	}
 
+	cmd.Flags().Bool("all", false, "delete all the silences matching the subscription and check flags, including wildcard silences")
 	cmd.Flags().Bool("skip-confirm", false, "skip interactive confirmation prompt")
 	cmd.Flags().StringP("subscription", "s", "", "silenced subscription")
 	cmd.Flags().StringP("check", "c", "", "silenced check")
 
 	return cmd
 }
+
+// deleteAll deletes all the silences matching the subscription and check
+// flags.
+func deleteAll(cli *cli.SensuCli, cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		_ = cmd.Help()
+		return errors.New("a name cannot be given along with --all")
+	}
+	sub, _ := cmd.Flags().GetString("subscription")
+	check, _ := cmd.Flags().GetString("check")
+	if sub == "" && check == "" {
+		return errors.New("specify subscription, check, or both")
+	}
+	name, _ := corev2.SilencedName(sub, check)
+	namespace := cli.Config.Namespace()
+
+	if skipConfirm, _ := cmd.Flags().GetBool("skip-confirm"); !skipConfirm {
+		confirm := &helpers.ConfirmDestructiveOp{Type: "silences matching", Op: "delete"}
+		if confirmed, _ := confirm.Ask(name); !confirmed {
+			fmt.Fprintln(cmd.OutOrStdout(), "Canceled")
+			return nil
+		}
+	}
+
+	deleted, err := cli.Client.DeleteSilencedByFilter(namespace, sub, check)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(cmd.OutOrStdout(), "Deleted %d silences\n", deleted)
+	return err
+}
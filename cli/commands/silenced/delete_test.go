@@ -82,3 +82,43 @@ func TestDeleteCommandRunEFailConfirm(t *testing.T) {
 	require.NoError(t, err)
 	assert.Contains(out, "Canceled")
 }
+
+func TestDeleteCommandAll(t *testing.T) {
+	cli := test.NewMockCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("DeleteSilencedByFilter", "default", "app", "").Return(3, nil)
+
+	cmd := DeleteCommand(cli)
+	require.NoError(t, cmd.Flags().Set("skip-confirm", "t"))
+	require.NoError(t, cmd.Flags().Set("all", "t"))
+	require.NoError(t, cmd.Flags().Set("subscription", "app"))
+	out, err := test.RunCmd(cmd, []string{})
+
+	require.NoError(t, err)
+	assert.Regexp(t, "Deleted 3 silences", out)
+}
+
+func TestDeleteCommandAllWithoutFilter(t *testing.T) {
+	cli := test.NewMockCLI()
+	cmd := DeleteCommand(cli)
+	require.NoError(t, cmd.Flags().Set("skip-confirm", "t"))
+	require.NoError(t, cmd.Flags().Set("all", "t"))
+	_, err := test.RunCmd(cmd, []string{})
+
+	require.Error(t, err)
+}
+
+func TestDeleteCommandAllWithServerErr(t *testing.T) {
+	cli := test.NewMockCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("DeleteSilencedByFilter", "default", "", "check-cpu").Return(0, errors.New("oh noes"))
+
+	cmd := DeleteCommand(cli)
+	require.NoError(t, cmd.Flags().Set("skip-confirm", "t"))
+	require.NoError(t, cmd.Flags().Set("all", "t"))
+	require.NoError(t, cmd.Flags().Set("check", "check-cpu"))
+	_, err := test.RunCmd(cmd, []string{})
+
+	require.Error(t, err)
+	assert.Equal(t, "oh noes", err.Error())
+}
@@ -0,0 +1,55 @@
+package validate
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/commands/hooks"
+	"github.com/sensu/sensu-go/cli/resource"
+	"github.com/spf13/cobra"
+)
+
+// Command validates resource files locally, without contacting the API.
+func Command(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate [-r] [[-f URL] ... ]",
+		Short: "Validate resources from file or URL (path, file://, http[s]://), or STDIN otherwise, without contacting the Sensu API.",
+		RunE:  execute(cli),
+		Annotations: map[string]string{
+			// Resources are validated locally, so we want to be able to run
+			// this command regardless of whether the CLI has been configured.
+			hooks.ConfigurationRequirement: hooks.ConfigurationNotRequired,
+		},
+	}
+
+	_ = cmd.Flags().StringSliceP("file", "f", nil, "Files, directories, or URLs to validate resources from")
+	_ = cmd.Flags().BoolP("recursive", "r", false, "Follow subdirectories")
+
+	return cmd
+}
+
+func execute(cli *cli.SensuCli) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) > 1 {
+			_ = cmd.Help()
+			return errors.New("invalid argument(s) received")
+		}
+		t := &http.Transport{}
+		t.RegisterProtocol("file", http.NewFileTransport(http.Dir("/")))
+		client := &http.Client{Transport: t}
+		inputs, err := cmd.Flags().GetStringSlice("file")
+		if err != nil {
+			return err
+		}
+		processor := resource.NewValidator(cmd.OutOrStderr())
+		if len(inputs) == 0 {
+			return resource.ProcessStdin(cli, client, processor)
+		}
+		recurse, err := cmd.Flags().GetBool("recursive")
+		if err != nil {
+			return err
+		}
+		return resource.Process(cli, client, inputs, recurse, processor)
+	}
+}
@@ -0,0 +1,90 @@
+package validate
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	mockclient "github.com/sensu/sensu-go/cli/client/testing"
+	cmdtesting "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validResources = `
+type: CheckConfig
+api_version: core/v2
+metadata:
+  name: foo
+spec:
+  command: "true"
+  interval: 60
+  subscriptions:
+  - linux
+---
+type: Handler
+api_version: core/v2
+metadata:
+  name: bar
+spec:
+  type: pipe
+  command: cat
+`
+
+const invalidResources = `
+type: CheckConfig
+api_version: core/v2
+metadata:
+  name: foo bar
+spec:
+  command: "true"
+  interval: 60
+---
+type: Handler
+api_version: core/v2
+metadata:
+  name: bar
+spec:
+  type: pipe
+  command: cat
+`
+
+func writeInput(t *testing.T, dir, content string) string {
+	t.Helper()
+	fp := filepath.Join(dir, "input.yaml")
+	require.NoError(t, ioutil.WriteFile(fp, []byte(content), 0644))
+	return fp
+}
+
+func TestValidateCommand(t *testing.T) {
+	cli := cmdtesting.NewMockCLI()
+	client := cli.Client.(*mockclient.MockClient)
+
+	td, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(td)
+
+	cmd := Command(cli)
+	require.NoError(t, cmd.Flags().Set("file", writeInput(t, td, validResources)))
+	_, err = cmdtesting.RunCmd(cmd, nil)
+	require.NoError(t, err)
+
+	// Validation never contacts the API
+	client.AssertNotCalled(t, "PutResource")
+}
+
+func TestValidateCommandInvalid(t *testing.T) {
+	cli := cmdtesting.NewMockCLI()
+
+	td, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(td)
+
+	cmd := Command(cli)
+	require.NoError(t, cmd.Flags().Set("file", writeInput(t, td, invalidResources)))
+	out, err := cmdtesting.RunCmd(cmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, out, `resource #0 with name "foo bar"`)
+	assert.NotContains(t, out, `"bar"`)
+}
@@ -0,0 +1,59 @@
+package resource
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/sensu/sensu-go/backend/store/v2/wrap"
+	"github.com/sensu/sensu-go/cli/client"
+	"github.com/sensu/sensu-go/types"
+)
+
+type validatable interface {
+	Validate() error
+}
+
+// Validator is a Processor that validates resources locally, without ever
+// contacting the API.
+type Validator struct {
+	// Out is where validation errors are reported.
+	Out io.Writer
+}
+
+// NewValidator instantiates a new Validator Processor, that reports
+// validation errors to out.
+func NewValidator(out io.Writer) *Validator {
+	return &Validator{Out: out}
+}
+
+// Process validates every resource, and reports the ones that are invalid. An
+// error is returned if any resource is invalid.
+func (v *Validator) Process(_ client.GenericClient, resources []*types.Wrapper) error {
+	invalid := 0
+	for i, resource := range resources {
+		if err := validateResource(resource); err != nil {
+			invalid++
+			fmt.Fprintf(
+				v.Out,
+				"error validating resource #%d with name %q and namespace %q (%s): %s\n",
+				i, resource.ObjectMeta.Name, resource.ObjectMeta.Namespace, resource.Type, err,
+			)
+		}
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d resources are invalid", invalid, len(resources))
+	}
+	return nil
+}
+
+func validateResource(resource *types.Wrapper) error {
+	if resource.Value == nil {
+		return errors.New("resource is nil")
+	}
+	value, ok := resource.Value.(validatable)
+	if !ok {
+		return wrap.ErrValidateMethodMissing
+	}
+	return value.Validate()
+}
@@ -25,6 +25,8 @@ func main() {
 	rootCmd.AddCommand(cmd.VersionCommand())
 	rootCmd.AddCommand(cmd.InitCommand())
 	rootCmd.AddCommand(cmd.UpgradeCommand())
+	rootCmd.AddCommand(cmd.FormatsCommand())
+	rootCmd.AddCommand(cmd.ETagsCommand())
 
 	if err := rootCmd.Execute(); err != nil {
 		if err == seeds.ErrAlreadyInitialized {
@@ -29,3 +29,10 @@ func (m *HandlerAdapter) Handle(ctx context.Context, ref *corev2.ResourceReferen
 	args := m.Called(ctx, ref, event, data)
 	return args.Error(0)
 }
+
+// TestHandle ...
+func (m *HandlerAdapter) TestHandle(ctx context.Context, ref *corev2.ResourceReference, event *corev2.Event, data []byte) (*corev2.HandlerTestResult, error) {
+	args := m.Called(ctx, ref, event, data)
+	result, _ := args.Get(0).(*corev2.HandlerTestResult)
+	return result, args.Error(1)
+}
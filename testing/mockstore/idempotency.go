@@ -0,0 +1,24 @@
+package mockstore
+
+import (
+	"context"
+	"time"
+)
+
+// ReserveIdempotencyKey ...
+func (s *MockStore) ReserveIdempotencyKey(ctx context.Context, key, fingerprint string, ttl time.Duration) (string, error) {
+	args := s.Called(ctx, key, fingerprint, ttl)
+	return args.String(0), args.Error(1)
+}
+
+// ReleaseIdempotencyKey ...
+func (s *MockStore) ReleaseIdempotencyKey(ctx context.Context, key, fingerprint string) error {
+	args := s.Called(ctx, key, fingerprint)
+	return args.Error(0)
+}
+
+// GetIdempotencyKey ...
+func (s *MockStore) GetIdempotencyKey(ctx context.Context, key string) (string, error) {
+	args := s.Called(ctx, key)
+	return args.String(0), args.Error(1)
+}
@@ -31,6 +31,18 @@ func (s *MockStore) GetNamespace(ctx context.Context, name string) (*types.Names
 	return args.Get(0).(*types.Namespace), args.Error(1)
 }
 
+// GetNamespaces ...
+func (s *MockStore) GetNamespaces(ctx context.Context, names []string) ([]*types.Namespace, error) {
+	args := s.Called(ctx, names)
+	return args.Get(0).([]*types.Namespace), args.Error(1)
+}
+
+// CountNamespaceResources ...
+func (s *MockStore) CountNamespaceResources(ctx context.Context, namespaces, prefixes []string) (map[string]map[string]int64, error) {
+	args := s.Called(ctx, namespaces, prefixes)
+	return args.Get(0).(map[string]map[string]int64), args.Error(1)
+}
+
 // UpdateNamespace ...
 func (s *MockStore) UpdateNamespace(ctx context.Context, org *types.Namespace) error {
 	args := s.Called(ctx, org)
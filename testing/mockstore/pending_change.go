@@ -0,0 +1,26 @@
+package mockstore
+
+import (
+	"context"
+
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+// PutPendingChange ...
+func (s *MockStore) PutPendingChange(ctx context.Context, change *store.PendingChange) error {
+	args := s.Called(ctx, change)
+	return args.Error(0)
+}
+
+// GetPendingChange ...
+func (s *MockStore) GetPendingChange(ctx context.Context, resourcePrefix, name string) (*store.PendingChange, error) {
+	args := s.Called(ctx, resourcePrefix, name)
+	change, _ := args.Get(0).(*store.PendingChange)
+	return change, args.Error(1)
+}
+
+// DeletePendingChange ...
+func (s *MockStore) DeletePendingChange(ctx context.Context, resourcePrefix, name, id string) error {
+	args := s.Called(ctx, resourcePrefix, name, id)
+	return args.Error(0)
+}
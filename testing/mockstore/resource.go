@@ -26,6 +26,12 @@ func (s *MockStore) DeleteResource(ctx context.Context, kind, name string) error
 	return args.Error(0)
 }
 
+// DeleteResourceIfMatch ...
+func (s *MockStore) DeleteResourceIfMatch(ctx context.Context, resource corev2.Resource, name, ifMatch string) error {
+	args := s.Called(ctx, resource, name, ifMatch)
+	return args.Error(0)
+}
+
 // GetResource ...
 func (s *MockStore) GetResource(ctx context.Context, name string, resource corev2.Resource) error {
 	args := s.Called(ctx, name, resource)
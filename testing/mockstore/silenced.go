@@ -3,6 +3,7 @@ package mockstore
 import (
 	"context"
 
+	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/types"
 )
 
@@ -46,3 +47,9 @@ func (s *MockStore) UpdateSilencedEntry(ctx context.Context, silenced *types.Sil
 	args := s.Called(ctx, silenced)
 	return args.Error(0)
 }
+
+// GetSilencedWatcher ...
+func (s *MockStore) GetSilencedWatcher(ctx context.Context) <-chan store.WatchEventSilenced {
+	args := s.Called(ctx)
+	return args.Get(0).(<-chan store.WatchEventSilenced)
+}
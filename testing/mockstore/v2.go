@@ -48,3 +48,13 @@ func (v *V2MockStore) Exists(req storev2.ResourceRequest) (bool, error) {
 func (v *V2MockStore) Patch(req storev2.ResourceRequest, w storev2.Wrapper, patcher patch.Patcher, cond *store.ETagCondition) error {
 	return v.Called(req, w, patcher, cond).Error(0)
 }
+
+func (v *V2MockStore) MergeLabels(req storev2.ResourceRequest, w storev2.Wrapper, labels, annotations map[string]*string, cond *store.ETagCondition) error {
+	return v.Called(req, w, labels, annotations, cond).Error(0)
+}
+
+func (v *V2MockStore) History(req storev2.ResourceRequest) ([]storev2.Version, error) {
+	args := v.Called(req)
+	versions, _ := args.Get(0).([]storev2.Version)
+	return versions, args.Error(1)
+}
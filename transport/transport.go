@@ -27,6 +27,11 @@ const (
 	// MessageTypeEntityConfig is the message type sent for entity config updates
 	MessageTypeEntityConfig = "entity_config"
 
+	// MessageTypeBackpressure is the message type sent by backends to ask an
+	// agent to slow down its event submissions. Its payload is the number of
+	// milliseconds the agent should wait before sending its next event.
+	MessageTypeBackpressure = "backpressure"
+
 	// HeaderKeyAgentName is the HTTP request header specifying the Agent name
 	HeaderKeyAgentName = "Sensu-AgentName"
 